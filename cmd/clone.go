@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/joescharf/wt/internal/ui"
+)
+
+var cloneBare bool
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <url> [dir]",
+	Short: "Clone a repo and set up the worktrees layout",
+	Long: `Clone a repository and prepare wt's layout: the clone goes in dir (default:
+the repo name from the URL) and worktrees in the sibling dir.worktrees.
+
+If no config file exists yet, one is created with base_branch set to the
+clone's default branch. If one exists and its base_branch differs, wt warns.
+
+--bare clones a bare repository into dir/.bare with dir/.git pointing at it,
+so every branch lives in a worktree and dir has no checkout of its own.
+Local merges need a checkout of the base branch, so use 'wt merge --pr' there.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := ""
+		if len(args) == 2 {
+			dir = args[1]
+		}
+		return cloneRun(args[0], dir)
+	},
+}
+
+func init() {
+	cloneCmd.Flags().BoolVar(&cloneBare, "bare", false, "Clone bare into <dir>/.bare so all branches live in worktrees")
+	rootCmd.AddCommand(cloneCmd)
+}
+
+func cloneRun(url, dir string) error {
+	if dir == "" {
+		dir = cloneDirName(url)
+		if dir == "" {
+			return fmt.Errorf("cannot derive a directory name from '%s' — pass one", url)
+		}
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("destination '%s' already exists and is not empty", dir)
+	}
+	wtDir := dir + ".worktrees"
+
+	cfgPath, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	_, statErr := os.Stat(cfgPath)
+	configExists := statErr == nil
+
+	if dryRun {
+		if cloneBare {
+			output.DryRunMsg("Would clone %s bare into %s", url, filepath.Join(dir, ".bare"))
+		} else {
+			output.DryRunMsg("Would clone %s into %s", url, dir)
+		}
+		output.DryRunMsg("Would create worktrees directory: %s", wtDir)
+		if !configExists {
+			output.DryRunMsg("Would create config file: %s", cfgPath)
+		}
+		return nil
+	}
+
+	spinner := output.StartSpinner("Cloning")
+	err = gitClient.Clone(url, dir, cloneBare)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+	output.Success("Cloned %s into %s", url, ui.Cyan(dir))
+
+	if err := os.MkdirAll(wtDir, 0755); err != nil {
+		return fmt.Errorf("failed to create worktrees directory: %w", err)
+	}
+	output.Success("Worktrees directory: %s", wtDir)
+
+	defaultBranch, err := gitClient.CurrentBranch(dir)
+	if err != nil {
+		output.VerboseLog("Could not read default branch: %v", err)
+	}
+	if !configExists {
+		data := currentConfigData()
+		if defaultBranch != "" {
+			data.BaseBranch = defaultBranch
+		}
+		content, err := renderConfig(data)
+		if err != nil {
+			return err
+		}
+		if err := writeConfigFile(cfgPath, content); err != nil {
+			return err
+		}
+		output.Success("Config file created: %s (base_branch: %s)", cfgPath, data.BaseBranch)
+	} else if base := viper.GetString("base_branch"); defaultBranch != "" && defaultBranch != base {
+		output.Warning("Default branch is '%s' but base_branch is '%s' — use --base or update 'wt config edit'", defaultBranch, base)
+	}
+
+	_, _ = fmt.Fprintln(output.ErrOut)
+	output.Info("Next: cd %s && wt create <branch>", dir)
+	return nil
+}
+
+// cloneDirName derives the clone directory from a repo URL the way git does:
+// the last path component without a trailing ".git".
+func cloneDirName(url string) string {
+	name := strings.TrimRight(url, "/")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, ".git")
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
@@ -19,6 +21,7 @@ import (
 	"github.com/joescharf/wt/pkg/iterm"
 	itermmocks "github.com/joescharf/wt/pkg/iterm/mocks"
 	"github.com/joescharf/wt/pkg/lifecycle"
+	"github.com/joescharf/wt/pkg/ops"
 	state "github.com/joescharf/wt/pkg/wtstate"
 	"github.com/joescharf/wt/internal/ui"
 )
@@ -33,6 +36,8 @@ type testEnv struct {
 	out   *bytes.Buffer
 	err   *bytes.Buffer
 	dir   string
+
+	noStashes *mock.Call // default StashCount expectation; see setStashCount
 }
 
 func setupTest(t *testing.T) *testEnv {
@@ -42,6 +47,10 @@ func setupTest(t *testing.T) *testEnv {
 
 	mockGit := gitmocks.NewMockClient(t)
 	mockIterm := itermmocks.NewMockClient(t)
+	// The configured base branch exists unless a test says otherwise.
+	mockGit.EXPECT().BranchExists(mock.Anything, "main").Return(true, nil).Maybe()
+	// Worktrees have no stashes unless a test calls setStashCount.
+	noStashes := mockGit.EXPECT().StashCount(mock.Anything).Return(0, nil).Maybe()
 
 	statePath := filepath.Join(dir, "state.json")
 	mgr := state.NewManager(statePath)
@@ -67,15 +76,51 @@ func setupTest(t *testing.T) *testEnv {
 	lcMgr = lifecycle.NewManager(mockGit, mockIterm, mgr, trust, &uiLogger{u: u})
 
 	// Reset flags
-	verbose = false
+	verbose = 0
 	dryRun = false
+	noLock = false
 	openNoClaude = false
+	openClaude = false
+	openWait = false
+	openName = ""
+	openProfile = ""
+	openAdoptWindow = false
+	openBackground = false
+	openAll = false
+	openCreate = false
+	listStaleWindows = false
+	listReopen = false
+	listClear = false
+	listFetch = false
+	listJSON = false
+	listOrphans = false
+	listClean = false
+	listLong = false
+	listNumber = false
+	worktreesPorcelain = false
+	worktreesJSON = false
+	reflogJSON = false
+	restoreNoClaude = false
+	restoreNoWindow = false
 	createBase = ""
 	createNoClaude = false
+	createNoWindow = false
+	createLatest = false
 	createExisting = false
+	createNoTrust = false
+	createForce = false
+	createPrintPath = false
+	createTemplate = ""
+	createFrom = ""
+	createProfile = ""
+	createDesc = ""
+	createWtDir = ""
+	createGitConfig = nil
 	deleteForce = false
 	deleteBranchFlag = false
+	deleteKeepBranch = false
 	deleteAll = false
+	deleteExcept = nil
 	mergePR = false
 	mergeNoCleanup = false
 	mergeBase = ""
@@ -88,18 +133,63 @@ func setupTest(t *testing.T) *testEnv {
 	syncAll = false
 	syncRebase = false
 	syncMerge = false
+	syncFFOnly = false
+	syncContinue = false
+	syncOnlyBehind = false
+	syncRepoAll = false
+	syncAbort = false
+	mergeResetOnPush = false
+	mergeStratOpts = nil
+	mergeBodyFile = ""
+	mergeIsolated = false
+	mergeKeepWindow = false
+	mergeAmendBase = false
+	mergeSign = ""
+	syncStratOpts = nil
+	syncRebaseMerges = false
+	syncFailFast = false
+	syncJSON = false
+	cloneBare = false
+	undoSyncForce = false
 	mergeRebase = false
 	mergeMerge = false
+	mergeSquash = false
+	mergeNoFF = false
+	mergeNoPull = false
+	mergeNoGHCheck = false
+	ghAuthCheckFunc = func() error { return nil }
+	mergeContinue = false
+	mergeThenCheckout = ""
 	discoverAdopt = false
+	pruneWindows = false
+	pruneJSON = false
+	pruneStateOnly = false
+	pruneTrustOnly = false
+	switchNext = false
+	switchPrev = false
+	switchOpenIfStale = false
+	currentDirFunc = os.Getwd
 	configForce = false
 	configDirFunc = defaultConfigDir
 	promptFunc = func(msg string) bool { return false } // default deny in tests
+	promptDefaultYes = defaultPromptYes
 
 	// Set viper defaults for tests
 	viper.Reset()
 	viper.SetDefault("base_branch", "main")
 	viper.SetDefault("no_claude", false)
 	viper.SetDefault("rebase", false)
+	viper.SetDefault("create.fetch_base", false)
+	viper.SetDefault("merge.pull", true)
+	viper.SetDefault("merge.sign", false)
+	viper.SetDefault("list.age_source", "accessed")
+	viper.SetDefault("port.enabled", false)
+	viper.SetDefault("port.start", 4000)
+	viper.SetDefault("port.end", 4999)
+	viper.SetDefault("trust.enabled", true)
+	viper.SetDefault("iterm.max_concurrent", 1)
+	viper.SetDefault("iterm.spawn_delay", 200*time.Millisecond)
+	viper.SetDefault("iterm.profile", "")
 
 	return &testEnv{
 		git:    mockGit,
@@ -110,9 +200,18 @@ func setupTest(t *testing.T) *testEnv {
 		out:    outBuf,
 		err:    errBuf,
 		dir:    dir,
+
+		noStashes: noStashes,
 	}
 }
 
+// setStashCount replaces the default of no stashes with n stashes for wtPath.
+func setStashCount(env *testEnv, wtPath string, n int) {
+	env.noStashes.Unset()
+	env.git.EXPECT().StashCount(wtPath).Return(n, nil)
+	env.git.EXPECT().StashCount(mock.Anything).Return(0, nil).Maybe()
+}
+
 // ─── Create Tests ────────────────────────────────────────────────────────────
 
 func TestCreate_NewBranch(t *testing.T) {
@@ -123,12 +222,12 @@ func TestCreate_NewBranch(t *testing.T) {
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
 	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
 	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
-	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true).
-		Run(func(repoPath, path, branch, base string, newBranch bool) {
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
 			_ = os.MkdirAll(path, 0755) // simulate worktree creation
 		}).Return(nil)
 
-	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
 
 	err := createRun("feature/auth")
@@ -142,7 +241,100 @@ func TestCreate_NewBranch(t *testing.T) {
 	assert.Equal(t, "c-123", ws.ClaudeSessionID)
 	assert.Equal(t, "s-456", ws.ShellSessionID)
 
-	assert.Contains(t, env.out.String(), "Worktree ready")
+	assert.Contains(t, env.err.String(), "Worktree ready")
+}
+
+func TestCreate_Profile(t *testing.T) {
+	env := setupTest(t)
+	createProfile = "Prod"
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).Return(nil)
+
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{Profile: "Prod"}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
+
+	require.NoError(t, createRun("feature/auth"))
+}
+
+func TestCreate_WorktreesDir(t *testing.T) {
+	env := setupTest(t)
+	createWtDir = filepath.Join(env.dir, "elsewhere")
+	wtPath := filepath.Join(createWtDir, "auth")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+			_ = os.MkdirAll(path, 0755)
+		}).Return(nil)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
+
+	require.NoError(t, createRun("feature/auth"))
+
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "feature/auth", ws.Branch)
+}
+
+func TestCreate_WorktreesDirNotADirectory(t *testing.T) {
+	env := setupTest(t)
+	file := filepath.Join(env.dir, "not-a-dir")
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+	createWtDir = file
+
+	err := createRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--worktrees-dir")
+}
+
+func TestCreate_Desc(t *testing.T) {
+	env := setupTest(t)
+	createDesc = "Try new caching layer!"
+	createNoWindow = true
+	viper.Set("create.desc_prefix", "exp/")
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "try-new-caching-layer-3")
+
+	// The first two names are taken, so a counter is appended
+	env.git.EXPECT().BranchExists(mock.Anything, "exp/try-new-caching-layer").Return(true, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "exp/try-new-caching-layer-2").Return(true, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "exp/try-new-caching-layer-3").Return(false, nil)
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "exp/try-new-caching-layer-3", "main", true, false).Return(nil)
+
+	require.NoError(t, createCmd.RunE(createCmd, nil))
+
+	assert.Contains(t, env.err.String(), "Branch name: exp/try-new-caching-layer-3")
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "exp/try-new-caching-layer-3", ws.Branch)
+}
+
+func TestCreate_DescErrors(t *testing.T) {
+	env := setupTest(t)
+
+	createDesc = "caching"
+	assert.EqualError(t, createCmd.RunE(createCmd, []string{"feature/cache"}), "--desc names the branch; don't pass one too")
+
+	createExisting = true
+	assert.EqualError(t, createCmd.RunE(createCmd, nil), "--desc cannot be used with --existing")
+	createExisting = false
+
+	createDesc = "?!"
+	assert.EqualError(t, createCmd.RunE(createCmd, nil), "can't make a branch name from '?!': it has no letters or digits")
+
+	createDesc = ""
+	assert.EqualError(t, createCmd.RunE(createCmd, nil), "branch name required (or use --desc)")
+	assert.Empty(t, env.out.String())
 }
 
 func TestCreate_ExistingWorktree(t *testing.T) {
@@ -158,13 +350,32 @@ func TestCreate_ExistingWorktree(t *testing.T) {
 	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
 
 	// open will be called since worktree exists
-	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
 
 	err := createRun("feature/auth")
 	require.NoError(t, err)
 
-	assert.Contains(t, env.out.String(), "Worktree already exists")
+	assert.Contains(t, env.err.String(), "Worktree already exists")
+	assert.Contains(t, env.err.String(), "(already existed)")
+}
+
+func TestCreate_ExistingWorktreePrintPath(t *testing.T) {
+	env := setupTest(t)
+	createNoWindow = true
+	createPrintPath = true
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+
+	require.NoError(t, createRun("feature/auth"))
+	require.NoError(t, createRun("feature/auth"))
+
+	assert.Equal(t, wtPath+"\n"+wtPath+"\n", env.out.String())
 }
 
 func TestCreate_ExistingBranch(t *testing.T) {
@@ -175,253 +386,354 @@ func TestCreate_ExistingBranch(t *testing.T) {
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
 	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
 	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(true, nil)
-	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "", false).
-		Run(func(repoPath, path, branch, base string, newBranch bool) {
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "", false, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
 			_ = os.MkdirAll(path, 0755)
 		}).Return(nil)
 
-	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
 
 	err := createRun("feature/auth")
 	require.NoError(t, err)
 
-	assert.Contains(t, env.out.String(), "already exists, using it")
+	assert.Contains(t, env.err.String(), "already exists, using it")
 }
 
-// ─── List Tests ──────────────────────────────────────────────────────────────
-
-func TestList_WithWorktrees(t *testing.T) {
+func TestCreate_BranchPatternRejects(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	viper.Set("branch_pattern", `^(feat|fix)/[a-z0-9-]+$`)
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
 
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
-	}, nil)
-
-	// Set up state so we can check window status
-	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:            "myrepo",
-		Branch:          "feature/auth",
-		ClaudeSessionID: "c-123",
-		CreatedAt:       state.FlexTime{Time: time.Now().UTC().Add(-2 * time.Hour)},
-	}))
-
-	env.iterm.EXPECT().IsRunning().Return(true)
-	env.iterm.EXPECT().SessionExists("c-123").Return(true)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/Auth").Return(false, nil)
+	// No WorktreeAdd
 
-	// Git status checks
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(2, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
+	err := createRun("feature/Auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't match branch_pattern ^(feat|fix)/[a-z0-9-]+$")
+}
 
-	err := listRun()
-	require.NoError(t, err)
+func TestCreate_BranchPatternInvalid(t *testing.T) {
+	setupTest(t)
+	viper.Set("branch_pattern", `feat/(`)
 
-	out := env.out.String()
-	assert.Contains(t, out, "feature/auth")
-	assert.Contains(t, out, "open")
-	assert.Contains(t, out, "↑2")
-	assert.Contains(t, out, "2h")
+	err := createRun("feat/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid branch_pattern")
 }
 
-func TestList_StatusDirty(t *testing.T) {
+func TestCreate_ForceRecoversLeftoverDir(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	createForce = true
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
-	}, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{{Path: env.dir, Branch: "main"}}, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(true, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "", false, true).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+			_ = os.MkdirAll(path, 0755)
+		}).Return(nil)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
 
-	err := listRun()
+	err := createRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "dirty")
+
+	out := env.err.String()
+	assert.Contains(t, out, "Removed leftover directory")
+	assert.NotContains(t, out, "Worktree already exists")
+	assert.Contains(t, out, "Worktree ready")
 }
 
-func TestList_StatusDirtyAndBehind(t *testing.T) {
+func TestCreate_UnknownBase(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	createBase = "no-such-branch"
 
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
-	}, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(4, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "no-such-branch").Return(false, nil)
 
-	err := listRun()
-	require.NoError(t, err)
-	out := env.out.String()
-	assert.Contains(t, out, "dirty")
-	assert.Contains(t, out, "↓4")
+	err := createRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown ref 'no-such-branch'")
+	env.git.AssertNotCalled(t, "WorktreeAdd", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestList_StatusClean(t *testing.T) {
+func TestRestore_ExistingBranch(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
 
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(true, nil)
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
-	}, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "", false, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+			_ = os.MkdirAll(path, 0755)
+		}).Return(nil)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
 
-	err := listRun()
+	err := restoreRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "clean")
+
+	ws, _ := env.state.GetWorktree(wtPath)
+	require.NotNil(t, ws)
+	assert.Equal(t, "feature/auth", ws.Branch)
+	assert.Contains(t, env.err.String(), "Worktree restored")
 }
 
-func TestList_StatusBehind(t *testing.T) {
+func TestRestore_Dirname(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	restoreNoWindow = true
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
 
+	env.git.EXPECT().BranchExists(mock.Anything, "auth").Return(false, nil)
+	env.git.EXPECT().BranchList(mock.Anything).Return([]string{"main", "feature/auth"}, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(true, nil)
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
-	}, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(5, nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "", false, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+			_ = os.MkdirAll(path, 0755)
+		}).Return(nil)
 
-	err := listRun()
+	err := restoreRun("auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "↓5")
+	assert.DirExists(t, wtPath)
 }
 
-func TestList_StatusAheadAndBehind(t *testing.T) {
+func TestRestore_MissingBranch(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
-	}, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(2, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(3, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/gone").Return(false, nil)
+	env.git.EXPECT().BranchList(mock.Anything).Return([]string{"main", "feature/auth"}, nil)
 
-	err := listRun()
-	require.NoError(t, err)
-	out := env.out.String()
-	assert.Contains(t, out, "↑2")
-	assert.Contains(t, out, "↓3")
+	err := restoreRun("feature/gone")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+	env.git.AssertNotCalled(t, "WorktreeAdd", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestList_PrunesStaleState(t *testing.T) {
+func TestCreate_FromTemplate(t *testing.T) {
 	env := setupTest(t)
+	createTemplate = "template/service"
+	createNoWindow = true
+	viper.Set("create.fetch_base", true) // never fetches for a template
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "my-service")
 
-	// Add state for a non-existent path
-	require.NoError(t, env.state.SetWorktree("/nonexistent/path", &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "stale",
-	}))
-
+	env.git.EXPECT().RefExists(mock.Anything, "template/service").Return(true, nil)
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-	}, nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "my-service").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "my-service", "template/service", true, false).Return(nil)
 
-	err := listRun()
+	err := createRun("my-service")
 	require.NoError(t, err)
 
-	// Verify stale entry was pruned
-	ws, _ := env.state.GetWorktree("/nonexistent/path")
-	assert.Nil(t, ws)
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "template/service", ws.FromTemplate)
+	assert.Contains(t, env.err.String(), "new branch 'my-service' from 'template/service'")
+}
+
+func TestCreate_TemplateNotFound(t *testing.T) {
+	env := setupTest(t)
+	createTemplate = "template/nope"
 
-	assert.Contains(t, env.out.String(), "Pruned 1 stale")
+	env.git.EXPECT().RefExists(mock.Anything, "template/nope").Return(false, nil)
+
+	err := createRun("my-service")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "template 'template/nope' not found")
+	env.git.AssertNotCalled(t, "WorktreeAdd", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestList_StatusRebasing(t *testing.T) {
+func TestCreate_TemplateRejectsBase(t *testing.T) {
+	setupTest(t)
+	createTemplate = "template/service"
+	createBase = "develop"
+
+	err := createCmd.RunE(createCmd, []string{"my-service"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--template cannot be used with --base")
+}
+
+func TestCreate_FromWorktreeHead(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	createFrom = "feature/auth"
+	createNoWindow = true
+	viper.Set("create.fetch_base", true) // never fetches when branching off a worktree
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	srcPath := filepath.Join(wtDir, "auth")
+	wtPath := filepath.Join(wtDir, "auth-v2")
 
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(srcPath, nil)
+	env.git.EXPECT().HeadSHA(srcPath).Return("abc1234def5678", nil)
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
-	}, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(1, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth-v2").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth-v2", "abc1234def5678", true, false).Return(nil)
 
-	err := listRun()
+	err := createRun("feature/auth-v2")
 	require.NoError(t, err)
-	out := env.out.String()
-	assert.Contains(t, out, "rebasing")
-	assert.Contains(t, out, "dirty")
-	assert.Contains(t, out, "↑1")
-	assert.Contains(t, out, "↓2")
+
+	assert.Contains(t, env.err.String(), "Branching from 'feature/auth' at abc1234")
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Empty(t, ws.FromTemplate, "--from records no relationship")
+	assert.Empty(t, ws.BaseBranch)
 }
 
-func TestList_StatusMerging(t *testing.T) {
+func TestCreate_FromMissingWorktree(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	createFrom = "nope"
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "nope").Return("", fmt.Errorf("worktree not found: nope"))
+
+	err := createRun("feature/new")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "worktree not found: nope")
+	env.git.AssertNotCalled(t, "WorktreeAdd", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreate_FromRejectsTemplate(t *testing.T) {
+	setupTest(t)
+	createFrom = "feature/auth"
+	createTemplate = "template/service"
+
+	err := createCmd.RunE(createCmd, []string{"my-service"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--from cannot be used with --template")
+}
+
+func TestCreate_GitConfig(t *testing.T) {
+	env := setupTest(t)
+	createNoWindow = true
+	viper.Set("create.git_config", []string{"user.email=me@home.com", "commit.gpgsign=true"})
+	createGitConfig = []string{"user.email=me@work.com"}
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
 
 	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main", HEAD: "abc123"},
-		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
-	}, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(true, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(3, nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).Return(nil)
+	// Runs inside the new worktree; the flag overrides the config value
+	env.git.EXPECT().ConfigValue(wtPath, "extensions.worktreeConfig").Return("", nil)
+	env.git.EXPECT().SetLocalConfig(wtPath, "commit.gpgsign", "true").Return(nil)
+	env.git.EXPECT().SetLocalConfig(wtPath, "user.email", "me@work.com").Return(nil)
 
-	err := listRun()
+	err := createRun("feature/auth")
 	require.NoError(t, err)
-	out := env.out.String()
-	assert.Contains(t, out, "merging")
-	assert.Contains(t, out, "dirty")
-	assert.Contains(t, out, "↓3")
+
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, map[string]string{"commit.gpgsign": "true", "user.email": "me@work.com"}, ws.GitConfig)
+	assert.Contains(t, env.err.String(), "Enabling extensions.worktreeConfig")
 }
 
-func TestList_StatusRebasingOnly(t *testing.T) {
+func TestCreate_GitConfigInvalid(t *testing.T) {
+	env := setupTest(t)
+	createGitConfig = []string{"user.email"}
+
+	err := createRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid git config 'user.email' (want key=value")
+	env.git.AssertNotCalled(t, "WorktreeAdd", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreate_NoWindow(t *testing.T) {
+	env := setupTest(t)
+	createNoWindow = true
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).Return(nil)
+	// No CreateWorktreeWindow expectation — mock fails the test if called
+
+	err := createRun("feature/auth")
+	require.NoError(t, err)
+
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Empty(t, ws.ClaudeSessionID)
+	assert.Contains(t, env.err.String(), "wt open feature/auth")
+}
+
+func TestCreate_PrintPath(t *testing.T) {
+	env := setupTest(t)
+	createNoWindow = true
+	createPrintPath = true
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).Return(nil)
+
+	err := createRun("feature/auth")
+	require.NoError(t, err)
+
+	assert.Equal(t, wtPath+"\n", env.out.String())
+	assert.Contains(t, env.err.String(), "Worktree ready")
+}
+
+func TestCreate_BaseLatest(t *testing.T) {
+	env := setupTest(t)
+	createNoWindow = true
+	createLatest = true
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().Fetch(mock.Anything).Return(nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "origin/main", true, false).Return(nil)
+
+	err := createRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "from 'origin/main'")
+}
+
+func TestCreate_FetchBaseConfig(t *testing.T) {
+	env := setupTest(t)
+	createNoWindow = true
+	viper.Set("create.fetch_base", true)
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().Fetch(mock.Anything).Return(nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "origin/main", true, false).Return(nil)
+
+	err := createRun("feature/auth")
+	require.NoError(t, err)
+}
+
+// ─── List Tests ──────────────────────────────────────────────────────────────
+
+func TestList_WithWorktrees(t *testing.T) {
 	env := setupTest(t)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
@@ -432,572 +744,3949 @@ func TestList_StatusRebasingOnly(t *testing.T) {
 		{Path: env.dir, Branch: "main", HEAD: "abc123"},
 		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
 	}, nil)
+
+	// Set up state so we can check window status
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+		CreatedAt:       state.FlexTime{Time: time.Now().UTC().Add(-2 * time.Hour)},
+	}))
+
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-123").Return(true)
+	env.iterm.EXPECT().SessionBusy("c-123").Return(false)
+
+	// Git status checks
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(true, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(2, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
 
 	err := listRun()
 	require.NoError(t, err)
+
 	out := env.out.String()
-	assert.Contains(t, out, "rebasing")
-	assert.NotContains(t, out, "clean")
+	assert.Contains(t, out, "feature/auth")
+	assert.Contains(t, out, "open")
+	assert.Contains(t, out, "↑2")
+	assert.Contains(t, out, "2h")
 }
 
-// ─── Switch Tests ────────────────────────────────────────────────────────────
-
-func TestSwitch_FocusesWindow(t *testing.T) {
-	env := setupTest(t)
+// expectListOpenWindow records a worktree whose window is open and whose
+// shell pane reports busy as given.
+func expectListOpenWindow(t *testing.T, env *testEnv, busy bool) string {
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
-
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
-	env.iterm.EXPECT().EnsureRunning().Return(nil)
-	env.iterm.EXPECT().SessionExists("c-123").Return(true)
-	env.iterm.EXPECT().FocusWindow("c-123").Return(nil)
-
 	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
 		Repo:            "myrepo",
 		Branch:          "feature/auth",
 		ClaudeSessionID: "c-123",
+		ShellSessionID:  "s-456",
 	}))
 
-	err := switchRun("feature/auth")
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-123").Return(true)
+	env.iterm.EXPECT().SessionBusy("c-123").Return(false)
+	env.iterm.EXPECT().SessionBusy("s-456").Return(busy)
+	expectListOneWorktree(env, wtPath, "main", 0)
+	return wtPath
+}
+
+func TestList_BusyWindow(t *testing.T) {
+	env := setupTest(t)
+	expectListOpenWindow(t, env, true)
+
+	err := listRun()
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Focused")
+
+	assert.Contains(t, env.out.String(), "busy")
+	assert.NotContains(t, env.out.String(), "open")
 }
 
-func TestSwitch_StaleSession(t *testing.T) {
+func TestList_IdleWindow(t *testing.T) {
+	env := setupTest(t)
+	expectListOpenWindow(t, env, false)
+
+	err := listRun()
+	require.NoError(t, err)
+
+	assert.Contains(t, env.out.String(), "open")
+	assert.NotContains(t, env.out.String(), "busy")
+}
+
+func TestList_JSONBusy(t *testing.T) {
+	env := setupTest(t)
+	listJSON = true
+	expectListOpenWindow(t, env, true)
+
+	err := listRun()
+	require.NoError(t, err)
+
+	var got struct {
+		Worktrees []listEntry `json:"worktrees"`
+	}
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &got))
+	require.Len(t, got.Worktrees, 1)
+	assert.Equal(t, "open", got.Worktrees[0].WindowStatus)
+	assert.True(t, got.Worktrees[0].Busy)
+}
+
+
+func TestList_Long(t *testing.T) {
 	env := setupTest(t)
+	listLong = true
+
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local)
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:      "myrepo",
+		Branch:    "feature/auth",
+		CreatedAt: state.FlexTime{Time: created},
+	}))
+	expectListOneWorktree(env, wtPath, "main", 0)
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
-	env.iterm.EXPECT().EnsureRunning().Return(nil)
-	env.iterm.EXPECT().SessionExists("c-123").Return(false)
+	err := listRun()
+	require.NoError(t, err)
+
+	out := env.out.String()
+	for _, col := range []string{"PATH", "REPO", "BASE", "CREATED", "LAST ACCESSED"} {
+		assert.Contains(t, out, col)
+	}
+	assert.Contains(t, out, wtPath, "full path, not truncated")
+	assert.Contains(t, out, "2026-03-01 09:30")
+	assert.Regexp(t, `myrepo\s.*wt\s.*main\s`, out)
+}
+
+func TestList_NumberRecordsOrder(t *testing.T) {
+	env := setupTest(t)
+	listNumber = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	expectListOneWorktree(env, wtPath, "main", 0)
+
+	require.NoError(t, listRun())
+
+	assert.Regexp(t, `#\s.*BRANCH`, env.out.String())
+	assert.Regexp(t, `1\s.*feature/auth`, env.out.String())
 
+	listed, err := env.state.LastListed(env.dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{wtPath}, listed)
+}
+
+func TestList_JSONIncludesLastAccessed(t *testing.T) {
+	env := setupTest(t)
+	listJSON = true
+	listLong = true // --json always has every field
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	accessed := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
 	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:            "myrepo",
-		Branch:          "feature/auth",
-		ClaudeSessionID: "c-123",
+		Repo:           "myrepo",
+		Branch:         "feature/auth",
+		CreatedAt:      state.FlexTime{Time: accessed.Add(-24 * time.Hour)},
+		LastAccessedAt: state.FlexTime{Time: accessed},
 	}))
+	expectListOneWorktree(env, wtPath, "main", 0)
 
-	err := switchRun("feature/auth")
+	err := listRun()
 	require.NoError(t, err)
-	assert.Contains(t, env.err.String(), "no longer exists")
+
+	var got struct {
+		Worktrees []listEntry `json:"worktrees"`
+	}
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &got))
+	require.Len(t, got.Worktrees, 1)
+	assert.True(t, accessed.Equal(got.Worktrees[0].AccessedAt))
+	assert.Contains(t, env.out.String(), `"last_accessed_at"`)
 }
 
-// ─── Open Tests ──────────────────────────────────────────────────────────────
+func TestList_AgeFromLastAccessed(t *testing.T) {
+	env := setupTest(t)
 
-func TestOpen_AlreadyOpen(t *testing.T) {
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:           "myrepo",
+		Branch:         "feature/auth",
+		CreatedAt:      state.FlexTime{Time: time.Now().Add(-10 * 24 * time.Hour)},
+		LastAccessedAt: state.FlexTime{Time: time.Now().Add(-3 * time.Hour)},
+	}))
+	expectListOneWorktree(env, wtPath, "main", 0)
+
+	require.NoError(t, listRun())
+
+	assert.Regexp(t, `feature/auth\s.*\s3h\s`, env.out.String())
+	assert.NotContains(t, env.out.String(), "10d")
+}
+
+func TestList_AgeFallsBackToCreated(t *testing.T) {
 	env := setupTest(t)
+
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:      "myrepo",
+		Branch:    "feature/auth",
+		CreatedAt: state.FlexTime{Time: time.Now().Add(-10 * 24 * time.Hour)},
+	}))
+	expectListOneWorktree(env, wtPath, "main", 0)
 
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
-	env.iterm.EXPECT().IsRunning().Return(true)
-	env.iterm.EXPECT().SessionExists("c-123").Return(true)
-	env.iterm.EXPECT().FocusWindow("c-123").Return(nil)
+	require.NoError(t, listRun())
+
+	assert.Regexp(t, `feature/auth\s.*\s10d\s`, env.out.String())
+}
 
+func TestList_AgeSourceCreated(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("list.age_source", "created")
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
 	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:            "myrepo",
-		Branch:          "feature/auth",
-		ClaudeSessionID: "c-123",
+		Repo:           "myrepo",
+		Branch:         "feature/auth",
+		CreatedAt:      state.FlexTime{Time: time.Now().Add(-10 * 24 * time.Hour)},
+		LastAccessedAt: state.FlexTime{Time: time.Now().Add(-3 * time.Hour)},
 	}))
+	expectListOneWorktree(env, wtPath, "main", 0)
+
+	require.NoError(t, listRun())
+
+	assert.Regexp(t, `feature/auth\s.*\s10d\s`, env.out.String())
+	assert.NotContains(t, env.out.String(), "3h")
+}
+
+func TestList_AgeSourceInvalid(t *testing.T) {
+	setupTest(t)
+	viper.Set("list.age_source", "modified")
+
+	err := listRun()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid list.age_source "modified"`)
+}
+// setupStaleWindows records three worktrees: one with a live window, one with a
+// dead session, and one that never had a window.
+func setupStaleWindows(t *testing.T, env *testEnv) (openPath, stalePath string) {
+	t.Helper()
+	openPath = filepath.Join(env.dir, "repo.worktrees", "auth")
+	stalePath = filepath.Join(env.dir, "repo.worktrees", "api")
+	closedPath := filepath.Join(env.dir, "repo.worktrees", "docs")
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: openPath, Branch: "feature/auth"},
+		{Path: stalePath, Branch: "feature/api"},
+		{Path: closedPath, Branch: "feature/docs"},
+	}, nil)
+	require.NoError(t, env.state.SetWorktree(openPath, &state.WorktreeState{
+		Branch: "feature/auth", ClaudeSessionID: "c-open", ShellSessionID: "s-open",
+	}))
+	require.NoError(t, env.state.SetWorktree(stalePath, &state.WorktreeState{
+		Branch: "feature/api", ClaudeSessionID: "c-dead", ShellSessionID: "s-dead",
+	}))
+
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-open").Return(true)
+	env.iterm.EXPECT().SessionExists("c-dead").Return(false)
+	return openPath, stalePath
+}
+
+func TestList_StaleWindows(t *testing.T) {
+	env := setupTest(t)
+	listStaleWindows = true
+	setupStaleWindows(t, env)
+
+	err := listCmd.RunE(listCmd, nil)
+	require.NoError(t, err)
+
+	out := env.out.String()
+	assert.Contains(t, out, "feature/api")
+	assert.NotContains(t, out, "feature/auth")
+	assert.NotContains(t, out, "feature/docs")
+	assert.Contains(t, env.err.String(), "1 stale window(s)")
+}
+
+func TestList_StaleWindowsClear(t *testing.T) {
+	env := setupTest(t)
+	listClear = true
+	openPath, stalePath := setupStaleWindows(t, env)
+
+	err := listCmd.RunE(listCmd, nil)
+	require.NoError(t, err)
+
+	ws, _ := env.state.GetWorktree(stalePath)
+	require.NotNil(t, ws)
+	assert.Empty(t, ws.ClaudeSessionID)
+	assert.Empty(t, ws.ShellSessionID)
+	assert.Equal(t, "feature/api", ws.Branch)
+
+	ws, _ = env.state.GetWorktree(openPath)
+	require.NotNil(t, ws)
+	assert.Equal(t, "c-open", ws.ClaudeSessionID)
+	assert.Contains(t, env.err.String(), "Cleared 1 stale window(s)")
+}
+
+func TestList_StaleWindowsReopenAndClearConflict(t *testing.T) {
+	setupTest(t)
+	listReopen = true
+	listClear = true
+
+	err := listCmd.RunE(listCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+}
+
+// setupOrphans creates a registered worktree plus three stray directories in
+// the worktrees dir: an empty one, one holding only a dead .git file, and one
+// with files.
+func setupOrphans(t *testing.T, env *testEnv) (wtDir string) {
+	t.Helper()
+	wtDir = filepath.Join(env.dir, "repo.worktrees")
+	for _, name := range []string{"auth", "empty", "dead", "work"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(wtDir, name), 0755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(wtDir, "dead", ".git"), []byte("gitdir: /gone"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(wtDir, "work", "notes.txt"), []byte("keep me"), 0644))
+
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: filepath.Join(wtDir, "auth"), Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	return wtDir
+}
+
+func TestList_Orphans(t *testing.T) {
+	env := setupTest(t)
+	wtDir := setupOrphans(t, env)
+	listOrphans = true
+
+	err := listCmd.RunE(listCmd, nil)
+	require.NoError(t, err)
+
+	out := env.out.String()
+	assert.Contains(t, out, filepath.Join(wtDir, "empty")+"  empty")
+	assert.Contains(t, out, filepath.Join(wtDir, "dead")+"  dead")
+	assert.Contains(t, out, filepath.Join(wtDir, "work")+"  ")
+	assert.Contains(t, out, "has files")
+	assert.NotContains(t, out, filepath.Join(wtDir, "auth"))
+	assert.Contains(t, env.err.String(), "3 orphaned director(ies), 2 removable")
+	assert.DirExists(t, filepath.Join(wtDir, "empty"), "listing never removes")
+}
+
+func TestList_OrphansClean(t *testing.T) {
+	env := setupTest(t)
+	wtDir := setupOrphans(t, env)
+	listOrphans = true
+	listClean = true
+
+	err := listCmd.RunE(listCmd, nil)
+	require.NoError(t, err)
+
+	assert.NoDirExists(t, filepath.Join(wtDir, "empty"))
+	assert.NoDirExists(t, filepath.Join(wtDir, "dead"))
+	assert.FileExists(t, filepath.Join(wtDir, "work", "notes.txt"))
+	assert.DirExists(t, filepath.Join(wtDir, "auth"))
+	assert.Contains(t, env.err.String(), "Removed 2 orphaned director(ies)")
+	assert.Contains(t, env.err.String(), "Left 1 with files in place")
+}
+
+func TestList_OrphansCleanDryRun(t *testing.T) {
+	env := setupTest(t)
+	wtDir := setupOrphans(t, env)
+	listOrphans = true
+	listClean = true
+	dryRun = true
+	env.ui.DryRun = true
+
+	err := listCmd.RunE(listCmd, nil)
+	require.NoError(t, err)
+
+	assert.DirExists(t, filepath.Join(wtDir, "empty"))
+	assert.Contains(t, env.err.String(), "Would remove "+filepath.Join(wtDir, "dead"))
+}
+
+func TestList_CleanRequiresOrphans(t *testing.T) {
+	setupTest(t)
+	listClean = true
+
+	err := listCmd.RunE(listCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--clean requires --orphans")
+}
+
+func TestList_StatusDirty(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	assert.Contains(t, env.out.String(), "dirty")
+}
+
+func TestList_StashIndicator(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	setStashCount(env, wtPath, 2)
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	assert.Contains(t, env.out.String(), "clean ⚑2")
+}
+
+func TestList_StatusDirtyAndBehind(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(4, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	out := env.out.String()
+	assert.Contains(t, out, "dirty")
+	assert.Contains(t, out, "↓4")
+}
+
+func TestList_StatusClean(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	assert.Contains(t, env.out.String(), "clean")
+}
+
+func TestList_StatusBehind(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(5, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	assert.Contains(t, env.out.String(), "↓5")
+}
+
+func TestList_RecordedBases(t *testing.T) {
+	env := setupTest(t)
+	listJSON = true
+	authPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	fixPath := filepath.Join(env.dir, "repo.worktrees", "fix")
+	require.NoError(t, os.MkdirAll(authPath, 0755))
+	require.NoError(t, os.MkdirAll(fixPath, 0755))
+	require.NoError(t, env.state.SetWorktree(fixPath, &state.WorktreeState{Branch: "hotfix/login", BaseBranch: "release"}))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: authPath, Branch: "feature/auth", HEAD: "def456"},
+		{Path: fixPath, Branch: "hotfix/login", HEAD: "987fed"},
+	}, nil)
+	for _, p := range []string{authPath, fixPath} {
+		env.git.EXPECT().IsWorktreeDirty(p).Return(false, nil)
+		env.git.EXPECT().IsRebaseInProgress(p).Return(false, nil)
+		env.git.EXPECT().IsMergeInProgress(p).Return(false, nil)
+	}
+	env.git.EXPECT().CommitsAhead(authPath, "main").Return(1, nil)
+	env.git.EXPECT().CommitsBehind(authPath, "main").Return(4, nil)
+	env.git.EXPECT().CommitsAhead(fixPath, "release").Return(2, nil)
+	env.git.EXPECT().CommitsBehind(fixPath, "release").Return(0, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+
+	var got struct {
+		Worktrees []listEntry `json:"worktrees"`
+	}
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &got))
+	require.Len(t, got.Worktrees, 2)
+	assert.Equal(t, "main", got.Worktrees[0].Base)
+	assert.Equal(t, "↑1 ↓4", got.Worktrees[0].GitStatus)
+	assert.Equal(t, "release", got.Worktrees[1].Base)
+	assert.Equal(t, "↑2", got.Worktrees[1].GitStatus)
+	env.git.AssertNotCalled(t, "CommitsBehind", fixPath, "main")
+}
+
+func TestList_BaseColumnOnlyWhenNonDefault(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	expectListOneWorktree(env, wtPath, "main", 0)
+	require.NoError(t, listRun())
+	assert.NotContains(t, env.out.String(), "BASE")
+}
+
+func TestList_FetchUsesRecordedBaseRemote(t *testing.T) {
+	env := setupTest(t)
+	listFetch = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth", BaseBranch: "develop"}))
+
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().Fetch(env.dir).Return(nil)
+	expectListOneWorktree(env, wtPath, "origin/develop", 3)
+
+	require.NoError(t, listRun())
+	out := env.out.String()
+	assert.Contains(t, out, "BASE")
+	assert.Contains(t, out, "develop")
+	assert.Contains(t, out, "↓3")
+}
+
+// expectListOneWorktree sets up a list run over a single clean worktree whose
+// ahead/behind is computed against ref.
+func expectListOneWorktree(env *testEnv, wtPath, ref string, behind int) {
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, ref).Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, ref).Return(behind, nil)
+}
+
+func TestList_JSONOnlyDataOnStdout(t *testing.T) {
+	env := setupTest(t)
+	listJSON = true
+	listFetch = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	// A state entry for a missing worktree makes list print a "Pruned" message
+	require.NoError(t, env.state.SetWorktree(filepath.Join(env.dir, "gone"), &state.WorktreeState{Branch: "gone"}))
+
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	expectListOneWorktree(env, wtPath, "main", 2)
+
+	err := listCmd.RunE(listCmd, nil)
+	require.NoError(t, err)
+
+	var got struct {
+		Repo      string      `json:"repo"`
+		Worktrees []listEntry `json:"worktrees"`
+	}
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &got), "stdout must be pure JSON: %s", env.out.String())
+	assert.Equal(t, "myrepo", got.Repo)
+	require.Len(t, got.Worktrees, 1)
+	assert.Equal(t, listEntry{
+		Branch:       "feature/auth",
+		Path:         wtPath,
+		Source:       "wt",
+		Base:         "main",
+		WindowStatus: "closed",
+		GitStatus:    "↓2",
+	}, got.Worktrees[0])
+
+	assert.Contains(t, env.err.String(), "Pruned 1 stale state entries")
+	assert.Contains(t, env.err.String(), "No remote configured")
+}
+
+func TestList_DuplicateBranches(t *testing.T) {
+	env := setupTest(t)
+	listJSON = true
+	first := filepath.Join(env.dir, "repo.worktrees", "auth")
+	second := filepath.Join(env.dir, "repo.worktrees", "auth-copy")
+	for _, p := range []string{first, second} {
+		require.NoError(t, os.MkdirAll(p, 0755))
+		env.git.EXPECT().IsWorktreeDirty(p).Return(false, nil)
+		env.git.EXPECT().IsRebaseInProgress(p).Return(false, nil)
+		env.git.EXPECT().IsMergeInProgress(p).Return(false, nil)
+		env.git.EXPECT().CommitsAhead(p, "main").Return(0, nil)
+		env.git.EXPECT().CommitsBehind(p, "main").Return(0, nil)
+	}
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: first, Branch: "feature/auth", HEAD: "def456"},
+		{Path: second, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+
+	require.NoError(t, listRun())
+
+	errOut := env.err.String()
+	assert.Contains(t, errOut, "Branch 'feature/auth' is checked out in 2 worktrees")
+	assert.Contains(t, errOut, first+", "+second)
+
+	var got struct {
+		Worktrees []listEntry `json:"worktrees"`
+	}
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &got))
+	require.Len(t, got.Worktrees, 2)
+	assert.True(t, got.Worktrees[0].Duplicate)
+	assert.True(t, got.Worktrees[1].Duplicate)
+}
+
+func expectRawWorktrees(env *testEnv) []gitops.WorktreeInfo {
+	worktrees := []gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "aaa111"},
+		{Path: filepath.Join(env.dir, "repo.worktrees", "auth"), Branch: "feature/auth", HEAD: "bbb222", Locked: true},
+		{Path: filepath.Join(env.dir, "repo.worktrees", "gone"), HEAD: "ccc333", Prunable: true},
+	}
+	env.git.EXPECT().WorktreeList(mock.Anything).Return(worktrees, nil)
+	return worktrees
+}
+
+func TestWorktrees_Lines(t *testing.T) {
+	env := setupTest(t)
+	wts := expectRawWorktrees(env)
+
+	err := worktreesCmd.RunE(worktreesCmd, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, wts[0].Path+"\tmain\taaa111\t-\n"+
+		wts[1].Path+"\tfeature/auth\tbbb222\tlocked\n"+
+		wts[2].Path+"\t(detached)\tccc333\tprunable\n", env.out.String())
+	assert.Empty(t, env.err.String())
+}
+
+func TestWorktrees_Porcelain(t *testing.T) {
+	env := setupTest(t)
+	worktreesPorcelain = true
+	wts := expectRawWorktrees(env)
+
+	err := worktreesCmd.RunE(worktreesCmd, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "worktree "+wts[0].Path+"\nHEAD aaa111\nbranch main\n\n"+
+		"worktree "+wts[1].Path+"\nHEAD bbb222\nbranch feature/auth\nlocked\n\n"+
+		"worktree "+wts[2].Path+"\nHEAD ccc333\ndetached\nprunable\n\n", env.out.String())
+}
+
+func TestWorktrees_JSON(t *testing.T) {
+	env := setupTest(t)
+	worktreesJSON = true
+	wts := expectRawWorktrees(env)
+
+	err := worktreesCmd.RunE(worktreesCmd, nil)
+	require.NoError(t, err)
+
+	var got []worktreeEntry
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &got), "stdout must be pure JSON: %s", env.out.String())
+	require.Len(t, got, 3)
+	assert.Equal(t, worktreeEntry{Path: wts[1].Path, Branch: "feature/auth", HEAD: "bbb222", Locked: true}, got[1])
+	assert.Equal(t, worktreeEntry{Path: wts[2].Path, HEAD: "ccc333", Prunable: true}, got[2])
+}
+
+func TestWorktrees_PorcelainAndJSONConflict(t *testing.T) {
+	setupTest(t)
+	worktreesPorcelain = true
+	worktreesJSON = true
+
+	err := worktreesCmd.RunE(worktreesCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+}
+
+func reflogFixture() []gitops.ReflogEntry {
+	return []gitops.ReflogEntry{
+		{SHA: "1111111aaaaaaa", Selector: "feature/auth@{0}", Action: "reset", Message: "moving to HEAD~1"},
+		{SHA: "2222222bbbbbbb", Selector: "feature/auth@{1}", Action: "commit", Message: "add login"},
+	}
+}
+
+func TestReflog_Worktree(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	env.git.EXPECT().Reflog(wtPath, "feature/auth").Return(reflogFixture(), nil)
+
+	require.NoError(t, reflogRun("auth"))
+	assert.Equal(t, "1111111 feature/auth@{0} reset: moving to HEAD~1\n2222222 feature/auth@{1} commit: add login\n", env.out.String())
+}
+
+func TestReflog_NotAWorktree(t *testing.T) {
+	env := setupTest(t)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "old-branch").Return("", fmt.Errorf("worktree not found: old-branch"))
+	env.git.EXPECT().Reflog(env.dir, "old-branch").Return(nil, nil)
+
+	require.NoError(t, reflogRun("old-branch"))
+	assert.Contains(t, env.err.String(), "No reflog entries for 'old-branch'")
+}
+
+func TestReflog_JSON(t *testing.T) {
+	env := setupTest(t)
+	reflogJSON = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	env.git.EXPECT().Reflog(wtPath, "feature/auth").Return(reflogFixture(), nil)
+
+	require.NoError(t, reflogRun("auth"))
+
+	var got []reflogEntry
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, reflogEntry{SHA: "2222222bbbbbbb", Selector: "feature/auth@{1}", Action: "commit", Message: "add login"}, got[1])
+}
+
+func TestList_JSONRejectsStaleWindows(t *testing.T) {
+	setupTest(t)
+	listJSON = true
+	listStaleWindows = true
+
+	err := listCmd.RunE(listCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--json cannot be used")
+}
+
+func TestList_FetchUsesRemote(t *testing.T) {
+	env := setupTest(t)
+	listFetch = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().Fetch(env.dir).Return(nil)
+	expectListOneWorktree(env, wtPath, "origin/main", 3)
+
+	err := listRun()
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Refreshed from origin")
+	assert.Contains(t, env.out.String(), "↓3")
+}
+
+func TestList_FetchWithoutRemote(t *testing.T) {
+	env := setupTest(t)
+	listFetch = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	expectListOneWorktree(env, wtPath, "main", 0)
+
+	err := listRun()
+	require.NoError(t, err)
+	env.git.AssertNotCalled(t, "Fetch", mock.Anything)
+	assert.Contains(t, env.err.String(), "No remote configured")
+}
+
+func TestList_FetchDryRun(t *testing.T) {
+	env := setupTest(t)
+	listFetch = true
+	dryRun = true
+	env.ui.DryRun = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	expectListOneWorktree(env, wtPath, "main", 0)
+
+	err := listRun()
+	require.NoError(t, err)
+	env.git.AssertNotCalled(t, "Fetch", mock.Anything)
+	assert.Contains(t, env.err.String(), "Would fetch from origin")
+}
+
+func TestList_NoFetchByDefault(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	expectListOneWorktree(env, wtPath, "main", 0)
+
+	err := listRun()
+	require.NoError(t, err)
+	env.git.AssertNotCalled(t, "HasRemote", mock.Anything)
+	env.git.AssertNotCalled(t, "Fetch", mock.Anything)
+}
+
+func TestList_StatusAheadAndBehind(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(2, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(3, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	out := env.out.String()
+	assert.Contains(t, out, "↑2")
+	assert.Contains(t, out, "↓3")
+}
+
+func TestList_PrunesStaleState(t *testing.T) {
+	env := setupTest(t)
+
+	// Add state for a non-existent path
+	require.NoError(t, env.state.SetWorktree("/nonexistent/path", &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "stale",
+	}))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+	}, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+
+	// Verify stale entry was pruned
+	ws, _ := env.state.GetWorktree("/nonexistent/path")
+	assert.Nil(t, ws)
+
+	assert.Contains(t, env.err.String(), "Pruned 1 stale")
+}
+
+func TestList_StatusRebasing(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(true, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(1, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	out := env.out.String()
+	assert.Contains(t, out, "rebasing")
+	assert.Contains(t, out, "dirty")
+	assert.Contains(t, out, "↑1")
+	assert.Contains(t, out, "↓2")
+}
+
+func TestList_StatusMerging(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(true, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(3, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	out := env.out.String()
+	assert.Contains(t, out, "merging")
+	assert.Contains(t, out, "dirty")
+	assert.Contains(t, out, "↓3")
+}
+
+func TestList_StatusRebasingOnly(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main", HEAD: "abc123"},
+		{Path: wtPath, Branch: "feature/auth", HEAD: "def456"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(true, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
+
+	err := listRun()
+	require.NoError(t, err)
+	out := env.out.String()
+	assert.Contains(t, out, "rebasing")
+	assert.NotContains(t, out, "clean")
+}
+
+// ─── Switch Tests ────────────────────────────────────────────────────────────
+
+func TestSwitch_FocusesWindow(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.iterm.EXPECT().EnsureRunning().Return(nil)
+	env.iterm.EXPECT().SessionExists("c-123").Return(true)
+	env.iterm.EXPECT().FocusWindow("c-123").Return(nil)
+	env.iterm.EXPECT().ActiveSession().Return("c-123", nil)
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+	}))
+
+	err := switchRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Focused")
+
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ws.LastAccessedAt.Time, time.Minute, "switching counts as an access")
+}
+
+func TestSwitch_FocusRetriesUntilWindowInFront(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.iterm.EXPECT().EnsureRunning().Return(nil)
+	env.iterm.EXPECT().SessionExists("c-123").Return(true)
+	env.iterm.EXPECT().FocusWindow("c-123").Return(nil).Times(2)
+	env.iterm.EXPECT().ActiveSession().Return("c-other", nil).Once()
+	env.iterm.EXPECT().ActiveSession().Return("c-123", nil).Once()
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+	}))
+
+	require.NoError(t, switchRun("feature/auth"))
+	assert.Contains(t, env.err.String(), "Focused")
+	assert.NotContains(t, env.err.String(), "didn't come to the front")
+}
+
+func TestSwitch_StaleSession(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.iterm.EXPECT().EnsureRunning().Return(nil)
+	env.iterm.EXPECT().SessionExists("c-123").Return(false)
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+	}))
+
+	err := switchRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "no longer exists")
+}
+
+func TestSwitch_StaleSessionOpensWindow(t *testing.T) {
+	env := setupTest(t)
+	switchOpenIfStale = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil).Maybe()
+	env.iterm.EXPECT().EnsureRunning().Return(nil)
+	env.iterm.EXPECT().IsRunning().Return(true).Maybe()
+	env.iterm.EXPECT().SessionExists("c-123").Return(false)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+	}))
+
+	err := switchRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "no longer exists")
+	assert.Contains(t, env.err.String(), "window opened")
+
+	ws, _ := env.state.GetWorktree(wtPath)
+	require.NotNil(t, ws)
+	assert.Equal(t, "c-new", ws.ClaudeSessionID)
+}
+
+func TestSwitch_StaleSessionPromptsToOpen(t *testing.T) {
+	env := setupTest(t)
+	var asked string
+	promptFunc = func(msg string) bool { asked = msg; return true }
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil).Maybe()
+	env.iterm.EXPECT().EnsureRunning().Return(nil)
+	env.iterm.EXPECT().IsRunning().Return(true).Maybe()
+	env.iterm.EXPECT().SessionExists("c-123").Return(false)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+	}))
+
+	require.NoError(t, switchRun("feature/auth"))
+	assert.Equal(t, "Open a new window for 'feature/auth'?", asked)
+	assert.Contains(t, env.err.String(), "window opened")
+}
+
+func TestAdjacentWorktree(t *testing.T) {
+	wts := []gitops.WorktreeInfo{{Path: "/a"}, {Path: "/b"}, {Path: "/c"}}
+	tests := []struct {
+		name    string
+		current string
+		step    int
+		want    string
+	}{
+		{"next", "/a", 1, "/b"},
+		{"prev", "/b", -1, "/a"},
+		{"next wraps", "/c", 1, "/a"},
+		{"prev wraps", "/a", -1, "/c"},
+		{"next from unknown", "", 1, "/a"},
+		{"prev from unknown", "", -1, "/c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, adjacentWorktree(wts, tt.current, tt.step).Path)
+		})
+	}
+}
+
+// setupSwitchCycle records three worktrees, listed out of branch order, each
+// with a live window. It returns their paths in branch order: api, auth, zeta.
+func setupSwitchCycle(t *testing.T, env *testEnv) []string {
+	t.Setenv("ITERM_SESSION_ID", "")
+	var paths []string
+	for _, name := range []string{"api", "auth", "zeta"} {
+		wtPath := filepath.Join(env.dir, "repo.worktrees", name)
+		require.NoError(t, os.MkdirAll(wtPath, 0755))
+		require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+			Branch:          "feature/" + name,
+			ClaudeSessionID: "c-" + name,
+			ShellSessionID:  "s-" + name,
+		}))
+		paths = append(paths, wtPath)
+	}
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: paths[2], Branch: "feature/zeta"},
+		{Path: paths[0], Branch: "feature/api"},
+		{Path: paths[1], Branch: "feature/auth"},
+	}, nil)
+	env.iterm.EXPECT().EnsureRunning().Return(nil).Maybe()
+	return paths
+}
+
+func TestSwitch_NextFromCwd(t *testing.T) {
+	env := setupTest(t)
+	switchNext = true
+	paths := setupSwitchCycle(t, env)
+	currentDirFunc = func() (string, error) { return filepath.Join(paths[1], "pkg"), nil }
+
+	env.iterm.EXPECT().SessionExists("c-zeta").Return(true)
+	env.iterm.EXPECT().FocusWindow("c-zeta").Return(nil)
+	env.iterm.EXPECT().ActiveSession().Return("c-zeta", nil)
+
+	err := switchCmd.RunE(switchCmd, nil)
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Focused iTerm2 window for 'zeta'")
+
+	ws, err := env.state.GetWorktree(paths[2])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ws.LastAccessedAt.Time, time.Minute)
+}
+
+func TestSwitch_NextWrapsAround(t *testing.T) {
+	env := setupTest(t)
+	switchNext = true
+	paths := setupSwitchCycle(t, env)
+	currentDirFunc = func() (string, error) { return paths[2], nil }
+
+	env.iterm.EXPECT().SessionExists("c-api").Return(true)
+	env.iterm.EXPECT().FocusWindow("c-api").Return(nil)
+	env.iterm.EXPECT().ActiveSession().Return("c-api", nil)
+
+	err := switchCmd.RunE(switchCmd, nil)
+	require.NoError(t, err)
+}
+
+func TestSwitch_PrevFromITermSession(t *testing.T) {
+	env := setupTest(t)
+	switchPrev = true
+	setupSwitchCycle(t, env)
+	// The main repo isn't part of the cycle, so the session decides
+	currentDirFunc = func() (string, error) { return env.dir, nil }
+	t.Setenv("ITERM_SESSION_ID", "w0t0p1:s-api")
+
+	env.iterm.EXPECT().SessionExists("c-zeta").Return(true)
+	env.iterm.EXPECT().FocusWindow("c-zeta").Return(nil)
+	env.iterm.EXPECT().ActiveSession().Return("c-zeta", nil)
+
+	err := switchCmd.RunE(switchCmd, nil)
+	require.NoError(t, err)
+}
+
+func TestSwitch_NextAndPrevConflict(t *testing.T) {
+	setupTest(t)
+	switchNext = true
+	switchPrev = true
+
+	err := switchCmd.RunE(switchCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+}
+
+func TestSwitch_BranchWithNext(t *testing.T) {
+	setupTest(t)
+	switchNext = true
+
+	err := switchCmd.RunE(switchCmd, []string{"auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined")
+}
+
+func TestSwitch_ByListIndex(t *testing.T) {
+	env := setupTest(t)
+	authPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	apiPath := filepath.Join(env.dir, "repo.worktrees", "api")
+	require.NoError(t, os.MkdirAll(authPath, 0755))
+	require.NoError(t, os.MkdirAll(apiPath, 0755))
+	require.NoError(t, env.state.SetWorktree(apiPath, &state.WorktreeState{Branch: "feature/api", ClaudeSessionID: "c-api"}))
+	require.NoError(t, env.state.SetLastListed(env.dir, []string{authPath, apiPath}))
+
+	env.iterm.EXPECT().EnsureRunning().Return(nil)
+	env.iterm.EXPECT().SessionExists("c-api").Return(true)
+	env.iterm.EXPECT().FocusWindow("c-api").Return(nil)
+	env.iterm.EXPECT().ActiveSession().Return("c-api", nil)
+
+	require.NoError(t, switchRun("2"))
+	assert.Contains(t, env.err.String(), "Focused iTerm2 window for 'api'")
+}
+
+func TestSwitch_ListIndexOutOfRange(t *testing.T) {
+	env := setupTest(t)
+	authPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, env.state.SetLastListed(env.dir, []string{authPath}))
+
+	err := switchRun("3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no worktree #3 in the last 'wt list' (it listed 1)")
+}
+
+func TestSwitch_ListIndexGone(t *testing.T) {
+	env := setupTest(t)
+	authPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, env.state.SetLastListed(env.dir, []string{authPath}))
+
+	err := switchRun("1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "worktree #1 (auth) no longer exists")
+}
+
+func TestSwitch_NumberWithoutListingIsBranch(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "2")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{Branch: "2", ClaudeSessionID: "c-2"}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "2").Return(wtPath, nil)
+	env.iterm.EXPECT().EnsureRunning().Return(nil)
+	env.iterm.EXPECT().SessionExists("c-2").Return(true)
+	env.iterm.EXPECT().FocusWindow("c-2").Return(nil)
+	env.iterm.EXPECT().ActiveSession().Return("c-2", nil)
+
+	require.NoError(t, switchRun("2"))
+}
+
+// ─── Open Tests ──────────────────────────────────────────────────────────────
+
+func TestOpen_AlreadyOpen(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-123").Return(true)
+	env.iterm.EXPECT().FocusWindow("c-123").Return(nil)
+	env.iterm.EXPECT().ActiveSession().Return("c-123", nil)
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+	}))
+
+	err := openRun("feature/auth", true)
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "already open")
+}
+
+func TestOpen_NewWindow(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+
+	err := openRun("feature/auth", true)
+	require.NoError(t, err)
+
+	ws, _ := env.state.GetWorktree(wtPath)
+	require.NotNil(t, ws)
+	assert.Equal(t, "c-new", ws.ClaudeSessionID)
+	assert.Contains(t, env.err.String(), "window opened")
+}
+
+func TestOpen_ReopenKeepsCreateNoClaude(t *testing.T) {
+	env := setupTest(t)
+	createNoClaude = true
+	createNoWindow = true
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+			_ = os.MkdirAll(path, 0755)
+		}).Return(nil)
+	require.NoError(t, createRun("feature/auth"))
+
+	ws, _ := env.state.GetWorktree(wtPath)
+	require.NotNil(t, ws)
+	assert.True(t, ws.NoClaude)
+
+	// A later plain 'wt open' keeps claude off
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{NoClaude: true}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-1", ShellSessionID: "s-1"}, nil).Once()
+	require.NoError(t, openRun("feature/auth", true))
+
+	// --claude turns it back on
+	openClaude = true
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-1").Return(false)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-2", ShellSessionID: "s-2"}, nil).Once()
+	require.NoError(t, openRun("feature/auth", true))
+}
+
+func TestOpen_ClaudeAndNoClaudeConflict(t *testing.T) {
+	setupTest(t)
+	openClaude = true
+	openNoClaude = true
+
+	assert.EqualError(t, openCmd.RunE(openCmd, []string{"feature/auth"}), "--claude and --no-claude cannot be used together")
+}
+
+func TestOpen_Background(t *testing.T) {
+	env := setupTest(t)
+	openBackground = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{NoFocus: true}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+
+	err := openRun("feature/auth", true)
+	require.NoError(t, err)
+
+	ws, _ := env.state.GetWorktree(wtPath)
+	require.NotNil(t, ws)
+	assert.Equal(t, "c-new", ws.ClaudeSessionID)
+}
+
+func TestOpen_Profile(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		config  string
+		profile string
+	}{
+		{name: "flag", flag: "Prod", profile: "Prod"},
+		{name: "config default", config: "Work", profile: "Work"},
+		{name: "flag overrides config", flag: "Prod", config: "Work", profile: "Prod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupTest(t)
+			openProfile = tt.flag
+			viper.Set("iterm.profile", tt.config)
+			wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+			require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+			env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+			env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+			env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+
+			env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{Profile: tt.profile}).
+				Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+
+			require.NoError(t, openRun("feature/auth", true))
+		})
+	}
+}
+
+// setupOpenAll records three managed worktrees — auth with a live window, api
+// with a stale one, fix never opened — plus docs, which wt doesn't manage.
+func setupOpenAll(t *testing.T, env *testEnv) (wtDir string) {
+	t.Helper()
+	wtDir = filepath.Join(env.dir, "repo.worktrees")
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: filepath.Join(wtDir, "auth"), Branch: "feature/auth"},
+		{Path: filepath.Join(wtDir, "api"), Branch: "feature/api"},
+		{Path: filepath.Join(wtDir, "fix"), Branch: "bugfix/fix"},
+		{Path: filepath.Join(wtDir, "docs"), Branch: "feature/docs"},
+	}, nil)
+	for name, session := range map[string]string{"auth": "c-auth", "api": "c-stale", "fix": ""} {
+		require.NoError(t, env.state.SetWorktree(filepath.Join(wtDir, name), &state.WorktreeState{
+			Repo:            "myrepo",
+			Branch:          "feature/" + name,
+			ClaudeSessionID: session,
+		}))
+	}
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-auth").Return(true)
+	env.iterm.EXPECT().SessionExists("c-stale").Return(false)
+	return wtDir
+}
+
+func TestOpen_All(t *testing.T) {
+	env := setupTest(t)
+	openAll = true
+	wtDir := setupOpenAll(t, env)
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	// Only the closed worktrees get windows, in the background; auth is
+	// already open and docs isn't managed
+	for _, name := range []string{"api", "fix"} {
+		env.iterm.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, name), "wt:myrepo:"+name, iterm.WindowOptions{NoFocus: true}).
+			Return(&iterm.SessionIDs{ClaudeSessionID: "c-" + name, ShellSessionID: "s-" + name}, nil)
+	}
+
+	err := openCmd.RunE(openCmd, nil)
+	require.NoError(t, err)
+
+	ws, _ := env.state.GetWorktree(filepath.Join(wtDir, "fix"))
+	require.NotNil(t, ws)
+	assert.Equal(t, "c-fix", ws.ClaudeSessionID)
+	assert.Contains(t, env.err.String(), "Opened 2 window(s), 1 already open")
+}
+
+func TestOpen_AllDryRun(t *testing.T) {
+	env := setupTest(t)
+	openAll = true
+	dryRun = true
+	env.ui.DryRun = true
+	setupOpenAll(t, env)
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	// No CreateWorktreeWindow expectation — mock fails the test if called
+
+	err := openCmd.RunE(openCmd, nil)
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Would open 2 window(s), 1 already open")
+}
+
+func TestOpen_AllNoTerminalStillSummarizes(t *testing.T) {
+	env := setupTest(t)
+	openAll = true
+	wtDir := setupOpenAll(t, env)
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.iterm.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "api"), "wt:myrepo:api", iterm.WindowOptions{NoFocus: true}).
+		Return(nil, fmt.Errorf("AppleScript error"))
+	env.iterm.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "fix"), "wt:myrepo:fix", iterm.WindowOptions{NoFocus: true}).
+		Return(nil, iterm.ErrNoTerminal)
+
+	err := openCmd.RunE(openCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to open 1 window(s)")
+	assert.Contains(t, env.err.String(), "No terminal available — opened 0 window(s), 1 already open")
+}
+
+func TestOpen_AllRejectsBranch(t *testing.T) {
+	setupTest(t)
+	openAll = true
+
+	err := openCmd.RunE(openCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "don't pass a branch")
+}
+
+func TestOpen_AdoptWindow(t *testing.T) {
+	env := setupTest(t)
+	openAdoptWindow = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().ListWindows().Return([]iterm.WindowInfo{
+		{Path: wtPath, SessionIDs: []string{"c-manual"}},
+	}, nil)
+	env.iterm.EXPECT().FocusWindow("c-manual").Return(nil)
+	env.iterm.EXPECT().ActiveSession().Return("c-manual", nil)
+
+	err := openRun("feature/auth", true)
+	require.NoError(t, err)
+
+	ws, _ := env.state.GetWorktree(wtPath)
+	require.NotNil(t, ws)
+	assert.Equal(t, "c-manual", ws.ClaudeSessionID)
+	assert.Empty(t, ws.ShellSessionID)
+	assert.Contains(t, env.err.String(), "Adopted the open iTerm2 window")
+}
+
+func TestOpen_CustomName(t *testing.T) {
+	env := setupTest(t)
+	openName = "Auth bug #123"
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{Title: "Auth bug #123"}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+
+	err := openRun("auth", true)
+	require.NoError(t, err)
+
+	ws, _ := env.state.GetWorktree(wtPath)
+	require.NotNil(t, ws)
+	assert.Equal(t, "Auth bug #123", ws.Title)
+}
+
+func TestOpen_Wait(t *testing.T) {
+	env := setupTest(t)
+	openWait = true
+	openWaitInterval = time.Millisecond
+	t.Cleanup(func() { openWaitInterval = time.Second })
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+	env.iterm.EXPECT().SessionExists("c-new").Return(true).Once()
+	env.iterm.EXPECT().SessionExists("c-new").Return(false).Once()
+
+	err := openRun("feature/auth", true)
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Window closed")
+}
+
+func TestOpen_WaitDryRun(t *testing.T) {
+	env := setupTest(t)
+	openWait = true
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+
+	err := openRun("feature/auth", true)
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Would wait for the iTerm2 window to close")
+}
+
+func TestOpen_WaitNoTerminal(t *testing.T) {
+	env := setupTest(t)
+	openWait = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).Return(nil, iterm.ErrNoTerminal)
+	// No SessionExists polling
+
+	err := openRun("feature/auth", true)
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "No terminal available")
+	assert.NotContains(t, env.err.String(), "Waiting for the iTerm2 window")
+}
+
+func TestPort_Recorded(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth", Port: 4242}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+
+	err := portRun("auth")
+	require.NoError(t, err)
+	assert.Equal(t, "4242\n", env.out.String())
+}
+
+func TestPort_AssignsWhenEnabled(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("port.enabled", true)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{Repo: "myrepo", Branch: "auth"}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil).Times(2)
+
+	require.NoError(t, portRun("auth"))
+	first := env.out.String()
+	env.out.Reset()
+	require.NoError(t, portRun("auth"))
+
+	assert.Equal(t, first, env.out.String(), "port should be stable")
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%d\n", ws.Port), first)
+}
+
+func TestPort_UntrackedWorktreeNotSaved(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("port.enabled", true)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+
+	require.NoError(t, portRun("auth"))
+	assert.NotEmpty(t, env.out.String())
+
+	// wt doesn't manage this worktree, so no blank entry is left behind
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+}
+
+func TestPort_Disabled(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+
+	err := portRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "port.enabled")
+}
+
+// ─── Env Tests ───────────────────────────────────────────────────────────────
+
+func TestEnv_ExportLines(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+		Port:   4123,
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+
+	err := envRun("auth")
+	require.NoError(t, err)
+	assert.Equal(t, "export WT_PATH="+wtPath+"\n"+
+		"export WT_BRANCH=feature/auth\n"+
+		"export WT_REPO=myrepo\n"+
+		"export WT_PORT=4123\n", env.out.String())
+	assert.Empty(t, env.err.String())
+}
+
+func TestEnv_QuotesAndFallsBackToGit(t *testing.T) {
+	env := setupTest(t)
+	wtPath := "/tmp/my repo.worktrees/it's"
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "its").Return(wtPath, nil)
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/its", nil)
+
+	err := envRun("its")
+	require.NoError(t, err)
+	assert.Equal(t, "export WT_PATH='/tmp/my repo.worktrees/it'\\''s'\n"+
+		"export WT_BRANCH=feature/its\n"+
+		"export WT_REPO=myrepo\n", env.out.String(), "no WT_PORT without an assigned port")
+}
+
+func TestOpen_NotFoundPromptAccepted(t *testing.T) {
+	env := setupTest(t)
+	promptDefaultYes = func(msg string) bool { return true }
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "feat-mkdocs")
+
+	// openRun calls ResolveWorktree (returns error), then createRun -> lcMgr.Create calls RepoName + WorktreesDir
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feat-mkdocs").Return("", fmt.Errorf("worktree not found: feat-mkdocs"))
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feat-mkdocs").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feat-mkdocs", "main", true, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+			_ = os.MkdirAll(path, 0755)
+		}).Return(nil)
+
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:feat-mkdocs", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
+
+	err := openRun("feat-mkdocs", true)
+	require.NoError(t, err)
+
+	assert.Contains(t, env.err.String(), "not found")
+	assert.Contains(t, env.err.String(), "Worktree ready")
+}
+
+func TestOpen_NotFoundPromptDenied(t *testing.T) {
+	env := setupTest(t)
+	promptDefaultYes = func(msg string) bool { return false }
+
+	// openRun calls ResolveWorktree (fails), warns, prompts (denied), returns nil
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feat-mkdocs").Return("", fmt.Errorf("worktree not found: feat-mkdocs"))
+
+	err := openRun("feat-mkdocs", true)
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "not found")
+}
+
+func TestOpen_BareShorthand(t *testing.T) {
+	// wt <branch> delegates to openRun
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+
+	err := rootCmd.RunE(rootCmd, []string{"auth"})
+	require.NoError(t, err)
+}
+
+func TestOpen_BareShorthandTypoDoesNotCreate(t *testing.T) {
+	env := setupTest(t)
+	promptDefaultYes = func(msg string) bool {
+		t.Fatalf("unexpected prompt: %s", msg)
+		return true
+	}
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feat-mkdcos").Return("", fmt.Errorf("worktree not found: feat-mkdcos"))
+	// No BranchExists or WorktreeAdd
+
+	err := rootCmd.RunE(rootCmd, []string{"feat-mkdcos"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "worktree not found: feat-mkdcos")
+	assert.Contains(t, err.Error(), "wt create feat-mkdcos")
+}
+
+func TestOpen_BareShorthandCreateConfig(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("shorthand_create", true)
+	var prompted string
+	promptDefaultYes = func(msg string) bool { prompted = msg; return false }
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feat-mkdocs").Return("", fmt.Errorf("worktree not found: feat-mkdocs"))
+
+	err := rootCmd.RunE(rootCmd, []string{"feat-mkdocs"})
+	require.NoError(t, err)
+	assert.Equal(t, "Create worktree 'feat-mkdocs'?", prompted)
+}
+
+func TestOpen_CreateFlagSkipsPrompt(t *testing.T) {
+	env := setupTest(t)
+	openCreate = true
+	promptDefaultYes = func(msg string) bool {
+		t.Fatalf("unexpected prompt: %s", msg)
+		return false
+	}
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "feat-mkdocs")
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feat-mkdocs").Return("", fmt.Errorf("worktree not found: feat-mkdocs"))
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feat-mkdocs").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feat-mkdocs", "main", true, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+			_ = os.MkdirAll(path, 0755)
+		}).Return(nil)
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:feat-mkdocs", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
+
+	err := openCmd.RunE(openCmd, []string{"feat-mkdocs"})
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Worktree ready")
+}
+
+// ─── Delete Tests ────────────────────────────────────────────────────────────
+
+func TestDelete_FullCleanup(t *testing.T) {
+	env := setupTest(t)
+	deleteBranchFlag = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) {
+			_ = os.RemoveAll(path)
+		}).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-123").Return(true)
+	env.iterm.EXPECT().CloseWindow("c-123").Return(nil)
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+	}))
+
+	err := deleteRun("feature/auth")
+	require.NoError(t, err)
+
+	// Verify state was removed
+	ws, _ := env.state.GetWorktree(wtPath)
+	assert.Nil(t, ws)
+
+	assert.Contains(t, env.err.String(), "removed")
+	assert.Contains(t, env.err.String(), "recover it with 'git branch feature/auth abc1234def5678'")
+}
+
+func TestDelete_Force(t *testing.T) {
+	env := setupTest(t)
+	deleteForce = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	// --force skips safety checks (no IsWorktreeDirty/HasUnpushedCommits calls)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) {
+			_ = os.RemoveAll(path)
+		}).Return(nil)
+
+	err := deleteRun("auth")
+	require.NoError(t, err)
+}
+
+func TestRepoLock_Held(t *testing.T) {
+	env := setupTest(t)
+	require.NoError(t, os.Mkdir(filepath.Join(env.dir, ".git"), 0755))
+	lock, err := gitops.LockRepo(env.dir, "wt merge")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lock.Unlock() })
+
+	// No git calls are made while another operation holds the lock
+	for name, run := range map[string]func() error{
+		"merge":      func() error { return mergeRun("feature/auth") },
+		"sync":       func() error { return syncRun("feature/auth") },
+		"sync --all": syncAllRun,
+		"delete":     func() error { return deleteRun("feature/auth") },
+		"delete all": deleteAllRun,
+	} {
+		err := run()
+		require.ErrorIs(t, err, gitops.ErrRepoLocked, name)
+		assert.Contains(t, err.Error(), "wt merge", name)
+		assert.Contains(t, err.Error(), "--no-lock", name)
+	}
+
+	// --no-lock and --dry-run go ahead
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return("", fmt.Errorf("worktree not found: feature/auth"))
+	noLock = true
+	assert.EqualError(t, syncRun("feature/auth"), "worktree not found: feature/auth")
+	noLock = false
+	dryRun = true
+	assert.EqualError(t, mergeRun("feature/auth"), "worktree not found: feature/auth")
+}
+
+func TestRepoLock_ReleasedAfterRun(t *testing.T) {
+	env := setupTest(t)
+	require.NoError(t, os.Mkdir(filepath.Join(env.dir, ".git"), 0755))
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return("", fmt.Errorf("worktree not found: feature/auth"))
+
+	require.Error(t, mergeRun("feature/auth"))
+
+	lock, err := gitops.LockRepo(env.dir, "wt sync")
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+}
+
+func TestDelete_SafeCleanWorktree(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+
+	err := deleteRun("auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "removed")
+}
+
+func TestDelete_DirtyWorktreePromptDenied(t *testing.T) {
+	env := setupTest(t)
+	promptFunc = func(msg string) bool { return false }
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+
+	err := deleteRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aborted")
+}
+
+func TestDelete_DirtyWorktreePromptAccepted(t *testing.T) {
+	env := setupTest(t)
+	promptFunc = func(msg string) bool { return true }
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+
+	err := deleteRun("auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "removed")
+}
+
+func TestDelete_StashesPromptDenied(t *testing.T) {
+	env := setupTest(t)
+	var asked []string
+	promptFunc = func(msg string) bool { asked = append(asked, msg); return false }
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	setStashCount(env, wtPath, 1)
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+
+	err := deleteRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aborted")
+	assert.Equal(t, []string{"Delete 'auth' with 1 stash(es)?"}, asked)
+	assert.Contains(t, env.err.String(), "'auth' has 1 stash(es)")
+	assert.DirExists(t, wtPath)
+}
+
+func TestDelete_StashesPromptAccepted(t *testing.T) {
+	env := setupTest(t)
+	var asked []string
+	promptFunc = func(msg string) bool { asked = append(asked, msg); return true }
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	setStashCount(env, wtPath, 3)
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+
+	err := deleteRun("auth")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Delete 'auth' with 3 stash(es)?",
+		"Delete 'auth' with uncommitted changes?",
+	}, asked, "stashes are an extra confirmation on top of the usual checks")
+}
+
+func TestDelete_UnpushedCommitsPromptDenied(t *testing.T) {
+	env := setupTest(t)
+	promptFunc = func(msg string) bool { return false }
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+
+	err := deleteRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aborted")
+}
+
+func TestDelete_All(t *testing.T) {
+	env := setupTest(t)
+	deleteAll = true
+	deleteForce = true // skip prompts for simplicity
+
+	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
+	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "api")
+	require.NoError(t, os.MkdirAll(wtPath1, 0755))
+	require.NoError(t, os.MkdirAll(wtPath2, 0755))
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath1, Branch: "feature/auth"},
+		{Path: wtPath2, Branch: "feature/api"},
+	}, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath1, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath2, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+
+	err := deleteAllRun()
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Deleted 2 worktrees")
+}
+
+func TestDelete_KeepsBranchByDefault(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+
+	err := deleteRun("feature/auth")
+	require.NoError(t, err)
+	env.git.AssertNotCalled(t, "BranchDelete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDelete_UnmergedBranchPromptDenied(t *testing.T) {
+	env := setupTest(t)
+	deleteBranchFlag = true
+	var prompted string
+	promptFunc = func(msg string) bool { prompted = msg; return false }
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(2, nil)
+
+	err := deleteRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aborted")
+	assert.Contains(t, prompted, "unmerged commits")
+	assert.Contains(t, env.err.String(), "2 commit(s) not merged into main")
+	assert.DirExists(t, wtPath)
+}
+
+func TestDelete_UnmergedBranchPromptAccepted(t *testing.T) {
+	env := setupTest(t)
+	deleteBranchFlag = true
+	promptFunc = func(msg string) bool { return true }
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(2, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	err := deleteRun("feature/auth")
+	require.NoError(t, err)
+}
+
+func TestDelete_UsesRecordedBase(t *testing.T) {
+	env := setupTest(t)
+	deleteBranchFlag = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:       "myrepo",
+		Branch:     "feature/auth",
+		BaseBranch: "develop",
+	}))
+
+	// Checked against develop, where the branch is merged, not main
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "develop").Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "develop").Return(0, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	require.NoError(t, deleteRun("feature/auth"))
+}
+
+func TestDelete_UnmergedBranchForceSkipsPrompt(t *testing.T) {
+	env := setupTest(t)
+	deleteBranchFlag = true
+	deleteForce = true
+	promptFunc = func(msg string) bool {
+		t.Fatalf("unexpected prompt: %s", msg)
+		return false
+	}
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	err := deleteRun("feature/auth")
+	require.NoError(t, err)
+	env.git.AssertNotCalled(t, "CommitsAhead", mock.Anything, mock.Anything)
+}
+
+func TestDelete_BranchAndKeepBranchConflict(t *testing.T) {
+	setupTest(t)
+	deleteBranchFlag = true
+	deleteKeepBranch = true
+
+	err := deleteCmd.RunE(deleteCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+}
+
+func TestDelete_All_KeepsBranchByDefault(t *testing.T) {
+	env := setupTest(t)
+	deleteAll = true
+	deleteForce = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth"},
+	}, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+
+	err := deleteAllRun()
+	require.NoError(t, err)
+	env.git.AssertNotCalled(t, "BranchDelete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDelete_All_DeleteBranch(t *testing.T) {
+	env := setupTest(t)
+	deleteAll = true
+	deleteForce = true
+	deleteBranchFlag = true
+
+	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
+	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "api")
+	require.NoError(t, os.MkdirAll(wtPath1, 0755))
+	require.NoError(t, os.MkdirAll(wtPath2, 0755))
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath1, Branch: "feature/auth"},
+		{Path: wtPath2, Branch: "feature/api"},
+	}, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath1, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath2, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/api", false).Return(nil)
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+
+	err := deleteAllRun()
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Deleted 2 worktrees")
+}
+
+func TestDelete_All_Except(t *testing.T) {
+	env := setupTest(t)
+	deleteAll = true
+	deleteForce = true
+	deleteExcept = []string{"feature/auth"}
+
+	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
+	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "api")
+	require.NoError(t, os.MkdirAll(wtPath1, 0755))
+	require.NoError(t, os.MkdirAll(wtPath2, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath1, nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath1, Branch: "feature/auth"},
+		{Path: wtPath2, Branch: "feature/api"},
+	}, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath2, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+
+	err := deleteAllRun()
+	require.NoError(t, err)
+	assert.DirExists(t, wtPath1)
+	assert.NoDirExists(t, wtPath2)
+	assert.Contains(t, env.err.String(), "Keeping 1 worktree(s): auth")
+}
+
+func TestDelete_All_ExceptUnknownFailsFirst(t *testing.T) {
+	env := setupTest(t)
+	deleteAll = true
+	deleteForce = true
+	deleteExcept = []string{"nope"}
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "nope").Return("", fmt.Errorf("no worktree found for 'nope'"))
+	// No WorktreeList or WorktreeRemove
+
+	err := deleteAllRun()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--except nope")
+}
+
+func TestDelete_ExceptRequiresAll(t *testing.T) {
+	setupTest(t)
+	deleteExcept = []string{"feature/auth"}
+
+	err := deleteCmd.RunE(deleteCmd, []string{"feature/api"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--except requires --all")
+}
+
+func TestDelete_All_NoneFound(t *testing.T) {
+	env := setupTest(t)
+	deleteAll = true
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+	}, nil)
+
+	err := deleteAllRun()
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "No worktrees")
+}
+
+func TestDelete_All_DryRunShowsPlan(t *testing.T) {
+	env := setupTest(t)
+	deleteAll = true
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	clean := filepath.Join(wtDir, "auth")
+	dirty := filepath.Join(wtDir, "api")
+	unpushed := filepath.Join(wtDir, "docs")
+	for _, p := range []string{clean, dirty, unpushed} {
+		require.NoError(t, os.MkdirAll(p, 0755))
+	}
+	require.NoError(t, env.state.SetWorktree(clean, &state.WorktreeState{Branch: "feature/auth", ClaudeSessionID: "c-1"}))
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: clean, Branch: "feature/auth"},
+		{Path: dirty, Branch: "feature/api"},
+		{Path: unpushed, Branch: "feature/docs"},
+	}, nil)
+	env.git.EXPECT().IsWorktreeDirty(clean).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(clean, "main").Return(false, nil)
+	env.git.EXPECT().IsWorktreeDirty(dirty).Return(true, nil)
+	env.git.EXPECT().HasUnpushedCommits(dirty, "main").Return(false, nil)
+	env.git.EXPECT().IsWorktreeDirty(unpushed).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(unpushed, "main").Return(true, nil)
+	// No WorktreeRemove, WorktreePrune, or CloseWindow
+
+	err := deleteCmd.RunE(deleteCmd, nil)
+	require.NoError(t, err)
+
+	out := env.out.String()
+	assert.Regexp(t, `auth\s.*feature/auth\s.*clean\s.*delete`, out)
+	assert.Regexp(t, `api\s.*feature/api\s.*dirty\s.*confirm`, out)
+	assert.Regexp(t, `docs\s.*feature/docs\s.*unpushed\s.*confirm`, out)
+	assert.Contains(t, env.err.String(), "Would delete 1, confirm 2, skip 0")
+
+	for _, p := range []string{clean, dirty, unpushed} {
+		assert.DirExists(t, p)
+	}
+	ws, _ := env.state.GetWorktree(clean)
+	require.NotNil(t, ws)
+	env.iterm.AssertNotCalled(t, "CloseWindow", mock.Anything)
+}
+
+func TestNewGitClient_TracesCommandsAtVV(t *testing.T) {
+	env := setupTest(t)
+	env.ui.Verbosity = 2
+
+	_, _ = newGitClient().RepoRoot(env.dir)
+
+	assert.Contains(t, env.err.String(), "git -C "+env.dir+" rev-parse --git-common-dir (")
+}
+
+func TestNewGitClient_NoTracingAtV(t *testing.T) {
+	env := setupTest(t)
+	env.ui.Verbosity = 1
+
+	_, _ = newGitClient().RepoRoot(env.dir)
+
+	assert.NotContains(t, env.err.String(), "rev-parse")
+	assert.NotContains(t, env.out.String(), "rev-parse")
+}
+
+// ─── Dry-Run Tests ───────────────────────────────────────────────────────────
+
+func TestDryRun_Create(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/dry").Return(false, nil)
+	env.iterm.EXPECT().PreviewCommand(filepath.Join(wtDir, "dry"), "wt:myrepo:dry", iterm.WindowOptions{}).
+		Return("window: wt:myrepo:dry\ntop pane (wt:myrepo:dry:claude): cd '/x' && claude")
+
+	err := createRun("feature/dry")
+	require.NoError(t, err)
+
+	// Verify no side effects
+	assert.NoDirExists(t, filepath.Join(wtDir, "dry"))
+	ws, _ := env.state.GetWorktree(filepath.Join(wtDir, "dry"))
+	assert.Nil(t, ws)
+
+	assert.Contains(t, env.err.String(), "Would create worktree")
+	assert.Contains(t, env.err.String(), "(claude: on)")
+	assert.Contains(t, env.err.String(), "top pane (wt:myrepo:dry:claude): cd '/x' && claude")
+}
+
+func TestDryRun_Open(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+
+	err := openRun("auth", true)
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Would open iTerm2 window")
+}
+
+func TestDryRun_Switch(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		ClaudeSessionID: "c-123",
+	}))
+
+	err := switchRun("auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "DRY-RUN")
+}
+
+// ─── Prune Tests ─────────────────────────────────────────────────────────────
+
+func TestPrune_CleansStaleState(t *testing.T) {
+	env := setupTest(t)
+
+	// Add state for a non-existent path
+	require.NoError(t, env.state.SetWorktree("/nonexistent/path", &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "stale",
+	}))
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+
+	err := pruneRun()
+	require.NoError(t, err)
+
+	// Verify stale entry was pruned
+	ws, _ := env.state.GetWorktree("/nonexistent/path")
+	assert.Nil(t, ws)
+	assert.Contains(t, env.err.String(), "Pruned 1 stale")
+}
+
+func TestPrune_NothingToClean(t *testing.T) {
+	env := setupTest(t)
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+
+	err := pruneRun()
+	require.NoError(t, err)
+
+	assert.Contains(t, env.err.String(), "clean")
+}
+
+// setupRemovedWorktreeWindows records two worktrees whose directories are gone,
+// one with a live window (shell pane only) and one whose window was closed,
+// plus a worktree that still exists. No git calls are expected.
+func setupRemovedWorktreeWindows(t *testing.T, env *testEnv) (live, closed, kept string) {
+	live = filepath.Join(env.dir, "repo.worktrees", "gone-live")
+	closed = filepath.Join(env.dir, "repo.worktrees", "gone-closed")
+	kept = filepath.Join(env.dir, "repo.worktrees", "kept")
+	require.NoError(t, os.MkdirAll(kept, 0755))
+
+	require.NoError(t, env.state.SetWorktree(live, &state.WorktreeState{Branch: "gone-live", ClaudeSessionID: "c-1", ShellSessionID: "s-1"}))
+	require.NoError(t, env.state.SetWorktree(closed, &state.WorktreeState{Branch: "gone-closed", ClaudeSessionID: "c-2"}))
+	require.NoError(t, env.state.SetWorktree(kept, &state.WorktreeState{Branch: "kept", ClaudeSessionID: "c-3"}))
+
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-1").Return(false)
+	env.iterm.EXPECT().SessionExists("s-1").Return(true)
+	env.iterm.EXPECT().SessionExists("c-2").Return(false)
+	return live, closed, kept
+}
+
+func TestPrune_Windows(t *testing.T) {
+	env := setupTest(t)
+	pruneWindows = true
+	live, closed, kept := setupRemovedWorktreeWindows(t, env)
+	env.iterm.EXPECT().CloseWindow("s-1").Return(nil)
+	// No WorktreePrune or trust expectations — mocks fail the test if called
+
+	err := pruneCmd.RunE(pruneCmd, nil)
+	require.NoError(t, err)
+
+	for _, path := range []string{live, closed} {
+		ws, _ := env.state.GetWorktree(path)
+		assert.Nil(t, ws, path)
+	}
+	ws, _ := env.state.GetWorktree(kept)
+	assert.NotNil(t, ws)
+	assert.Contains(t, env.err.String(), "Closed iTerm2 window for removed worktree 'gone-live'")
+	assert.Contains(t, env.err.String(), "Closed 1 window(s), cleared 2 state entries")
+}
+
+func TestPrune_WindowsDryRun(t *testing.T) {
+	env := setupTest(t)
+	pruneWindows = true
+	dryRun = true
+	env.ui.DryRun = true
+	live, _, _ := setupRemovedWorktreeWindows(t, env)
+	// Should NOT call CloseWindow
+
+	err := pruneCmd.RunE(pruneCmd, nil)
+	require.NoError(t, err)
+
+	ws, _ := env.state.GetWorktree(live)
+	assert.NotNil(t, ws)
+	assert.Contains(t, env.err.String(), "Would close iTerm2 window for removed worktree 'gone-live'")
+}
+
+func TestRepair_ReportsBrokenInDryRun(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, ".git"), []byte("gitdir: /moved/away/.git/worktrees/auth\n"), 0644))
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth"},
+	}, nil)
+	// Should NOT call WorktreeRepair
+
+	err := repairRun()
+	require.NoError(t, err)
+
+	assert.Contains(t, env.err.String(), "Broken gitdir link: 'auth'")
+	assert.Contains(t, env.err.String(), "Would run git worktree repair")
+}
+
+func TestPrune_DryRun(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
+	require.NoError(t, env.state.SetWorktree("/nonexistent/path", &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "stale",
+	}))
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+
+	// WorktreePrune should NOT be called in dry-run
+
+	err := pruneRun()
+	require.NoError(t, err)
+
+	assert.Contains(t, env.err.String(), "Would run git worktree prune")
+	assert.Contains(t, env.err.String(), "Would prune 1 stale state entries")
+	ws, _ := env.state.GetWorktree("/nonexistent/path")
+	assert.NotNil(t, ws, "dry run must keep the entry")
+}
+
+func TestPrune_DryRunJSON(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+	pruneJSON = true
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	require.NoError(t, env.state.SetWorktree(filepath.Join(wtDir, "gone"), &state.WorktreeState{
+		Repo: "myrepo", Branch: "feature/gone", ClaudeSessionID: "c-gone",
+	}))
+	require.NoError(t, env.state.SetWorktree(filepath.Join(wtDir, "closed"), &state.WorktreeState{
+		Repo: "myrepo", Branch: "feature/closed", ClaudeSessionID: "c-closed",
+	}))
+	_, err := env.claude.TrustProject(filepath.Join(wtDir, "gone"))
+	require.NoError(t, err)
+
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-gone").Return(true)
+	env.iterm.EXPECT().SessionExists("c-closed").Return(false)
+
+	require.NoError(t, pruneRun())
+
+	var report struct {
+		DryRun   bool     `json:"dry_run"`
+		State    []string `json:"state"`
+		Trust    []string `json:"trust"`
+		Windows  []string `json:"windows"`
+		GitPrune bool     `json:"git_prune"`
+	}
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &report))
+	assert.True(t, report.DryRun)
+	assert.Equal(t, []string{filepath.Join(wtDir, "closed"), filepath.Join(wtDir, "gone")}, report.State)
+	assert.Equal(t, []string{filepath.Join(wtDir, "gone")}, report.Trust)
+	assert.Equal(t, []string{filepath.Join(wtDir, "gone")}, report.Windows)
+	assert.True(t, report.GitPrune)
+
+	// Nothing was pruned
+	ws, _ := env.state.GetWorktree(filepath.Join(wtDir, "gone"))
+	assert.NotNil(t, ws)
+}
+
+func TestPrune_JSONWithWindows(t *testing.T) {
+	setupTest(t)
+	pruneJSON = true
+	pruneWindows = true
+
+	err := pruneCmd.RunE(pruneCmd, nil)
+	assert.EqualError(t, err, "--json cannot be used with --windows")
+}
+
+func TestDryRun_Delete(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+
+	// Should not call WorktreeRemove
+	_ = mock.Anything
+
+	err := deleteRun("auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Would remove git worktree")
+	assert.DirExists(t, wtPath) // dir not removed
+}
+
+// --- Claude Trust Tests ---
+
+func TestCreate_SetsClaudeTrust(t *testing.T) {
+	env := setupTest(t)
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	wtPath := filepath.Join(wtDir, "auth")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).
+		Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+			_ = os.MkdirAll(path, 0755)
+		}).Return(nil)
+
+	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
+
+	err := createRun("feature/auth")
+	require.NoError(t, err)
+
+	// Verify Claude trust was set
+	added, err := env.claude.TrustProject(wtPath)
+	require.NoError(t, err)
+	assert.False(t, added, "should already be trusted")
+}
+
+func TestCreate_DryRun_DoesNotSetTrust(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "feature/dry").Return(false, nil)
+
+	env.iterm.EXPECT().PreviewCommand(mock.Anything, mock.Anything, mock.Anything).Return("")
+	env.iterm.EXPECT().PreviewCommand(mock.Anything, mock.Anything, mock.Anything).Return("")
+	err := createRun("feature/dry")
+	require.NoError(t, err)
+
+	// Verify no Claude trust was set (file should not exist)
+	_, statErr := os.Stat(env.claude.Path())
+	assert.True(t, os.IsNotExist(statErr), "claude.json should not be created in dry-run")
+}
+
+func TestDelete_RemovesClaudeTrust(t *testing.T) {
+	env := setupTest(t)
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	// Pre-set Claude trust
+	added, err := env.claude.TrustProject(wtPath)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+
+	err = deleteRun("auth")
+	require.NoError(t, err)
+
+	// Verify Claude trust was removed - re-trusting should return true (added)
+	added, err = env.claude.TrustProject(wtPath)
+	require.NoError(t, err)
+	assert.True(t, added, "trust should have been removed by delete")
+}
+
+func TestPrune_CleansOrphanedTrust(t *testing.T) {
+	env := setupTest(t)
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	existingPath := filepath.Join(wtDir, "auth")
+	stalePath := filepath.Join(wtDir, "stale-branch")
+
+	// Create only the existing path
+	require.NoError(t, os.MkdirAll(existingPath, 0755))
+
+	// Trust both
+	_, err := env.claude.TrustProject(existingPath)
+	require.NoError(t, err)
+	_, err = env.claude.TrustProject(stalePath)
+	require.NoError(t, err)
+
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+
+	err = pruneRun()
+	require.NoError(t, err)
+
+	// Verify stale entry was pruned - re-trusting should return true (added)
+	added, err := env.claude.TrustProject(stalePath)
+	require.NoError(t, err)
+	assert.True(t, added, "stale trust should have been pruned")
+
+	// Verify existing entry preserved
+	added, err = env.claude.TrustProject(existingPath)
+	require.NoError(t, err)
+	assert.False(t, added, "existing trust should be preserved")
+
+	assert.Contains(t, env.err.String(), "Pruned 1 stale trust entries")
+}
+
+func TestCreate_NoTrust(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		setup func()
+	}{
+		{"flag", func() { createNoTrust = true }},
+		{"config", func() { viper.Set("trust.enabled", false) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupTest(t)
+			tc.setup()
+			wtDir := filepath.Join(env.dir, "repo.worktrees")
+			wtPath := filepath.Join(wtDir, "auth")
+
+			env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+			env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+			env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
+			env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true, false).
+				Run(func(repoPath, path, branch, base string, newBranch, force bool) {
+					_ = os.MkdirAll(path, 0755)
+				}).Return(nil)
+			env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+				Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
+
+			err := createRun("feature/auth")
+			require.NoError(t, err)
+
+			_, statErr := os.Stat(env.claude.Path())
+			assert.True(t, os.IsNotExist(statErr), "claude.json should not be written when trust is disabled")
+		})
+	}
+}
+
+func TestDelete_TrustDisabledKeepsEntry(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("trust.enabled", false)
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	_, err := env.claude.TrustProject(wtPath)
+	require.NoError(t, err)
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+
+	err = deleteRun("auth")
+	require.NoError(t, err)
+
+	added, err := env.claude.TrustProject(wtPath)
+	require.NoError(t, err)
+	assert.False(t, added, "trust entry should be left alone when trust is disabled")
+}
+
+func TestPrune_TrustDisabledSkipsTrust(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("trust.enabled", false)
+
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	stalePath := filepath.Join(wtDir, "stale-branch")
+	_, err := env.claude.TrustProject(stalePath)
+	require.NoError(t, err)
+
+	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+
+	err = pruneRun()
+	require.NoError(t, err)
+
+	added, err := env.claude.TrustProject(stalePath)
+	require.NoError(t, err)
+	assert.False(t, added, "stale trust should not be pruned when trust is disabled")
+}
+
+// setupScopedPrune records a stale state entry and a stale trust entry and
+// returns their paths.
+func setupScopedPrune(t *testing.T, env *testEnv) (statePath, trustPath string) {
+	t.Helper()
+	statePath = filepath.Join(env.dir, "repo.worktrees", "gone")
+	trustPath = filepath.Join(env.dir, "repo.worktrees", "stale-branch")
+	require.NoError(t, env.state.SetWorktree(statePath, &state.WorktreeState{Repo: "myrepo", Branch: "gone"}))
+	_, err := env.claude.TrustProject(trustPath)
+	require.NoError(t, err)
+	return statePath, trustPath
+}
+
+func TestPrune_StateOnly(t *testing.T) {
+	env := setupTest(t)
+	pruneStateOnly = true
+	statePath, trustPath := setupScopedPrune(t, env)
+	// No WorktreesDir (trust) or WorktreePrune (git) expectations
+
+	require.NoError(t, pruneRun())
+
+	ws, _ := env.state.GetWorktree(statePath)
+	assert.Nil(t, ws, "stale state should be pruned")
+	added, err := env.claude.TrustProject(trustPath)
+	require.NoError(t, err)
+	assert.False(t, added, "trust should be left alone")
+}
+
+func TestPrune_TrustOnly(t *testing.T) {
+	env := setupTest(t)
+	pruneTrustOnly = true
+	statePath, trustPath := setupScopedPrune(t, env)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil)
+
+	require.NoError(t, pruneRun())
+
+	ws, _ := env.state.GetWorktree(statePath)
+	assert.NotNil(t, ws, "state should be left alone")
+	added, err := env.claude.TrustProject(trustPath)
+	require.NoError(t, err)
+	assert.True(t, added, "stale trust should be pruned")
+	assert.Contains(t, env.err.String(), "Pruned 1 stale trust entries")
+}
+
+func TestPrune_ScopedDryRun(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		scope *bool
+		want  string
+	}{
+		{"state-only", &pruneStateOnly, "Would prune 1 stale state entries"},
+		{"trust-only", &pruneTrustOnly, "Would prune 1 stale trust entries"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupTest(t)
+			dryRun = true
+			env.ui.DryRun = true
+			*tc.scope = true
+			statePath, trustPath := setupScopedPrune(t, env)
+			env.git.EXPECT().WorktreesDir(mock.Anything).Return(filepath.Join(env.dir, "repo.worktrees"), nil).Maybe()
+
+			require.NoError(t, pruneRun())
+
+			out := env.err.String()
+			assert.Contains(t, out, tc.want)
+			assert.NotContains(t, out, "git worktree prune")
+			ws, _ := env.state.GetWorktree(statePath)
+			assert.NotNil(t, ws, "dry run must keep state")
+			added, err := env.claude.TrustProject(trustPath)
+			require.NoError(t, err)
+			assert.False(t, added, "dry run must keep trust")
+		})
+	}
+}
+
+func TestPrune_ScopeConflicts(t *testing.T) {
+	setupTest(t)
+	pruneStateOnly, pruneTrustOnly = true, true
+	assert.EqualError(t, pruneCmd.RunE(pruneCmd, nil), "--state-only and --trust-only cannot be used together")
+
+	pruneTrustOnly, pruneWindows = false, true
+	assert.EqualError(t, pruneCmd.RunE(pruneCmd, nil), "--windows cannot be used with --state-only or --trust-only")
+}
+
+func TestPrune_TrustOnlyWithTrustDisabled(t *testing.T) {
+	setupTest(t)
+	viper.Set("trust.enabled", false)
+	pruneTrustOnly = true
+
+	err := pruneRun()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trust management is disabled")
+}
+
+// ─── Merge Tests ─────────────────────────────────────────────────────────────
+
+func TestMerge_LocalSuccess_WithRemote(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil).Times(2) // once in merge, once in finish
+	env.git.EXPECT().Pull(env.dir).Return(nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().Push(env.dir, "main", false).Return(nil)
+
+	// Cleanup expectations: remove worktree + delete branch
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	err := mergeRun("feature/auth")
+	require.NoError(t, err)
+
+	out := env.err.String()
+	assert.Contains(t, out, "Merged")
+	assert.Contains(t, out, "Merge complete")
+}
+
+// expectMergeThenPushFails sets up a local merge with a remote whose push of
+// the base branch fails.
+func expectMergeThenPushFails(env *testEnv, wtPath string) {
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil).Times(2)
+	env.git.EXPECT().Pull(env.dir).Return(nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().Push(env.dir, "main", false).Return(fmt.Errorf("rejected"))
+}
+
+func TestMerge_PushFailureKeepsWorktree(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	expectMergeThenPushFails(env, wtPath)
+	// No WorktreeRemove/BranchDelete/ResetHard expectations — the prompt is declined
+
+	err := mergeRun("feature/auth")
+	require.NoError(t, err)
+
+	assert.DirExists(t, wtPath)
+	out := env.err.String()
+	assert.Contains(t, out, "Keeping worktree 'auth' because the push failed")
+	assert.Contains(t, out, "isn't pushed")
+}
+
+func TestMerge_ResetOnPushFailure(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	expectMergeThenPushFails(env, wtPath)
+	env.git.EXPECT().IsWorktreeDirty(env.dir).Return(false, nil)
+	env.git.EXPECT().ResetHard(env.dir, "ORIG_HEAD").Return(nil)
+	mergeResetOnPush = true
+
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the merge was undone")
+	assert.DirExists(t, wtPath)
+}
+
+// expectMergeWithoutPull sets up a local merge with a remote in which Pull
+// must not be called but the base branch is still pushed.
+func expectMergeWithoutPull(env *testEnv, wtPath string) {
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil).Times(2)
+	// No Pull expectation — mock fails the test if called
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().Push(env.dir, "main", false).Return(nil)
+}
+
+func TestMerge_NoPull(t *testing.T) {
+	env := setupTest(t)
+	mergeNoPull = true
+	mergeNoCleanup = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	expectMergeWithoutPull(env, wtPath)
+
+	err := mergeRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Skipping pull of 'main'")
+	assert.Contains(t, env.err.String(), "Pushed 'main'")
+}
+
+func TestMerge_ConfigPullDisabled(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("merge.pull", false)
+	mergeNoCleanup = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	expectMergeWithoutPull(env, wtPath)
+
+	err := mergeRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Skipping pull of 'main'")
+}
+
+func TestMerge_LocalSuccess_NoRemote(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2) // once in merge, once in finish
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+
+	// Cleanup: no push, but still remove worktree + delete branch
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	err := mergeRun("feature/auth")
+	require.NoError(t, err)
+
+	out := env.err.String()
+	assert.Contains(t, out, "Merged")
+	assert.Contains(t, out, "Merge complete")
+}
+
+func TestMerge_KeepWindow(t *testing.T) {
+	env := setupTest(t)
+	mergeKeepWindow = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	err := mergeRun("feature/auth")
+	require.NoError(t, err)
+
+	env.iterm.AssertNotCalled(t, "CloseWindow", mock.Anything)
+	assert.Contains(t, env.err.String(), "Leaving iTerm2 window open")
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Nil(t, ws, "session cleared from state")
+}
+
+func TestMerge_KeepWindowRejectsNoCleanup(t *testing.T) {
+	setupTest(t)
+	mergeKeepWindow = true
+	mergeNoCleanup = true
+
+	err := mergeCmd.RunE(mergeCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--keep-window")
+}
+
+func TestMerge_AmendBaseForceDeletesPickedBranch(t *testing.T) {
+	env := setupTest(t)
+	mergeAmendBase = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(1, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("abc1234def5678", nil)
+	env.git.EXPECT().CherryPick(env.dir, "abc1234def5678", gitops.CherryPickRunOptions{}).Return(nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	// The picked commit has a new hash, so 'git branch -d' refuses
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(fmt.Errorf("not fully merged"))
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", true).Return(nil)
+	promptFunc = func(string) bool {
+		t.Fatal("should not prompt to force-delete a cherry-picked branch")
+		return false
+	}
+
+	require.NoError(t, mergeRun("feature/auth"))
+	assert.Contains(t, env.err.String(), "Cherry-picked 'feature/auth' onto 'main'")
+}
+
+func TestMerge_AmendBaseRejectsPR(t *testing.T) {
+	setupTest(t)
+	mergeAmendBase = true
+	mergePR = true
+
+	err := mergeCmd.RunE(mergeCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--amend-base only applies to local merges")
+}
+
+func TestMerge_UsesRecordedBase(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:       "myrepo",
+		Branch:     "feature/auth",
+		BaseBranch: "develop",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "develop").Return(true, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "develop").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+
+	// The main repo must be on the recorded base, not the configured one
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "main repo is on 'main', expected 'develop'")
+}
+
+func TestMerge_NothingToMerge(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(true, nil)
+
+	err := mergeRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "No commits to merge")
+}
+
+func TestMerge_DirtyWorktree(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+
+	err := mergeRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "uncommitted changes")
+}
+
+func TestMerge_DirtyWorktree_Force(t *testing.T) {
+	env := setupTest(t)
+	mergeForce = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
+
+	// --force skips IsWorktreeDirty
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
-	err := openRun("feature/auth")
+	err := mergeRun("auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "already open")
+	assert.Contains(t, env.err.String(), "Merge complete")
 }
 
-func TestOpen_NewWindow(t *testing.T) {
+func TestMerge_MergeConflict_NoCleanup(t *testing.T) {
 	env := setupTest(t)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
+
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
-	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(assert.AnError)
 
-	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
-		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "merge conflict")
 
-	err := openRun("feature/auth")
-	require.NoError(t, err)
+	// Worktree should NOT be cleaned up
+	assert.DirExists(t, wtPath)
+}
 
-	ws, _ := env.state.GetWorktree(wtPath)
-	require.NotNil(t, ws)
-	assert.Equal(t, "c-new", ws.ClaudeSessionID)
-	assert.Contains(t, env.out.String(), "window opened")
+// expectQueuedMerge sets up one branch of a multi-branch local merge against a
+// repo with a remote; mergeErr, if set, is the merge's conflict.
+func expectQueuedMerge(env *testEnv, name string, mergeErr error, merged *[]string) string {
+	branch := "feature/" + name
+	wtPath := filepath.Join(env.dir, "repo.worktrees", name)
+	_ = os.MkdirAll(wtPath, 0755)
+	_ = env.state.SetWorktree(wtPath, &state.WorktreeState{Repo: "myrepo", Branch: branch})
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, branch).Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().Merge(env.dir, branch, gitops.MergeRunOptions{}).
+		Run(func(string, string, gitops.MergeRunOptions) { *merged = append(*merged, branch) }).Return(mergeErr)
+	if mergeErr == nil {
+		env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+			Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+		env.git.EXPECT().BranchDelete(mock.Anything, branch, false).Return(nil)
+	}
+	return wtPath
 }
 
-func TestOpen_NotFoundPromptAccepted(t *testing.T) {
+func TestMerge_MultipleBranchesInSequence(t *testing.T) {
 	env := setupTest(t)
-	promptDefaultYes = func(msg string) bool { return true }
 
-	wtDir := filepath.Join(env.dir, "repo.worktrees")
-	wtPath := filepath.Join(wtDir, "feat-mkdocs")
+	var merged []string
+	authPath := expectQueuedMerge(env, "auth", nil, &merged)
+	apiPath := expectQueuedMerge(env, "api", nil, &merged)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().Pull(env.dir).Return(nil).Once()
+	env.git.EXPECT().Push(env.dir, "main", false).Return(nil).Times(2)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+
+	require.NoError(t, mergeRunAll([]string{"feature/auth", "feature/api"}))
+
+	assert.Equal(t, []string{"feature/auth", "feature/api"}, merged)
+	assert.NoDirExists(t, authPath)
+	assert.NoDirExists(t, apiPath)
+	out := env.err.String()
+	assert.Contains(t, out, "Skipping pull of 'main'", "base is pulled only before the first merge")
+	assert.Contains(t, out, "Merged 2 branch(es): feature/auth, feature/api")
+}
 
-	// openRun calls ResolveWorktree (returns error), then createRun -> lcMgr.Create calls RepoName + WorktreesDir
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "feat-mkdocs").Return("", fmt.Errorf("worktree not found: feat-mkdocs"))
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
-	env.git.EXPECT().BranchExists(mock.Anything, "feat-mkdocs").Return(false, nil)
-	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feat-mkdocs", "main", true).
-		Run(func(repoPath, path, branch, base string, newBranch bool) {
-			_ = os.MkdirAll(path, 0755)
-		}).Return(nil)
+func TestMerge_MultipleBranchesStopAtConflict(t *testing.T) {
+	env := setupTest(t)
 
-	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:feat-mkdocs", false).
-		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
+	var merged []string
+	authPath := expectQueuedMerge(env, "auth", nil, &merged)
+	apiPath := expectQueuedMerge(env, "api", assert.AnError, &merged)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().Pull(env.dir).Return(nil).Once()
+	env.git.EXPECT().Push(env.dir, "main", false).Return(nil).Once()
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 
-	err := openRun("feat-mkdocs")
-	require.NoError(t, err)
+	err := mergeRunAll([]string{"feature/auth", "feature/api", "feature/ui"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "merge conflict")
 
-	assert.Contains(t, env.err.String(), "not found")
-	assert.Contains(t, env.out.String(), "Worktree ready")
+	assert.Equal(t, []string{"feature/auth", "feature/api"}, merged, "feature/ui is never attempted")
+	assert.NoDirExists(t, authPath)
+	assert.DirExists(t, apiPath)
+	out := env.err.String()
+	assert.Contains(t, out, "Merged 1 branch(es): feature/auth")
+	assert.Contains(t, out, "Failed: feature/api")
+	assert.Contains(t, out, "Not merged: feature/ui")
+	assert.Contains(t, out, "wt merge feature/api --continue")
+	assert.Contains(t, out, "run 'wt merge feature/ui' for the rest")
 }
 
-func TestOpen_NotFoundPromptDenied(t *testing.T) {
-	env := setupTest(t)
-	promptDefaultYes = func(msg string) bool { return false }
+func TestMerge_MultipleBranchesFlagConflicts(t *testing.T) {
+	setupTest(t)
+	args := []string{"feature/auth", "feature/api"}
 
-	// openRun calls ResolveWorktree (fails), warns, prompts (denied), returns nil
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "feat-mkdocs").Return("", fmt.Errorf("worktree not found: feat-mkdocs"))
+	mergePR = true
+	err := mergeCmd.RunE(mergeCmd, args)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--pr takes a single branch")
 
-	err := openRun("feat-mkdocs")
-	require.NoError(t, err)
-	assert.Contains(t, env.err.String(), "not found")
+	mergePR = false
+	mergeContinue = true
+	err = mergeCmd.RunE(mergeCmd, args)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--continue takes a single branch")
 }
 
-func TestOpen_BareShorthand(t *testing.T) {
-	// wt <branch> delegates to openRun
+func TestMerge_ThenCheckoutAfterSuccess(t *testing.T) {
 	env := setupTest(t)
+	mergeThenCheckout = "develop"
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
 
-	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
-		Return(&iterm.SessionIDs{ClaudeSessionID: "c-new", ShellSessionID: "s-new"}, nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().BranchExists(env.dir, "develop").Return(true, nil)
+	env.git.EXPECT().IsWorktreeDirty(env.dir).Return(false, nil) // checked before merging
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	// The checkout runs last, once the merge and cleanup are done
+	env.git.EXPECT().Checkout(env.dir, "develop").Return(nil)
 
-	// This simulates what root RunE does
-	err := openRun("auth")
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Checked out 'develop' in the main repo")
 }
 
-// ─── Delete Tests ────────────────────────────────────────────────────────────
-
-func TestDelete_FullCleanup(t *testing.T) {
+func TestMerge_ThenCheckoutSkippedOnConflict(t *testing.T) {
 	env := setupTest(t)
-	deleteBranchFlag = true
-
+	mergeThenCheckout = "develop"
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().BranchExists(env.dir, "develop").Return(true, nil)
+	env.git.EXPECT().IsWorktreeDirty(env.dir).Return(false, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
-		Run(func(repoPath, path string, force bool) {
-			_ = os.RemoveAll(path)
-		}).Return(nil)
-	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
-
-	env.iterm.EXPECT().IsRunning().Return(true)
-	env.iterm.EXPECT().SessionExists("c-123").Return(true)
-	env.iterm.EXPECT().CloseWindow("c-123").Return(nil)
-
-	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:            "myrepo",
-		Branch:          "feature/auth",
-		ClaudeSessionID: "c-123",
-	}))
-
-	err := deleteRun("feature/auth")
-	require.NoError(t, err)
-
-	// Verify state was removed
-	ws, _ := env.state.GetWorktree(wtPath)
-	assert.Nil(t, ws)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(assert.AnError)
 
-	assert.Contains(t, env.out.String(), "removed")
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "merge conflict")
+	env.git.AssertNotCalled(t, "Checkout", mock.Anything, mock.Anything)
 }
 
-func TestDelete_Force(t *testing.T) {
+func TestMerge_ThenCheckoutDirtyMainRepo(t *testing.T) {
 	env := setupTest(t)
-	deleteForce = true
-
+	mergeThenCheckout = "develop"
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	// --force skips safety checks (no IsWorktreeDirty/HasUnpushedCommits calls)
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
-		Run(func(repoPath, path string, force bool) {
-			_ = os.RemoveAll(path)
-		}).Return(nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().BranchExists(env.dir, "develop").Return(true, nil)
+	env.git.EXPECT().IsWorktreeDirty(env.dir).Return(true, nil)
 
-	err := deleteRun("auth")
-	require.NoError(t, err)
+	// Refused before merging, rather than leaving a merge that can't be followed up
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "main repo has uncommitted changes")
+	env.git.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+	env.git.AssertNotCalled(t, "Checkout", mock.Anything, mock.Anything)
 }
 
-func TestDelete_SafeCleanWorktree(t *testing.T) {
+func TestMerge_ThenCheckoutSkippedOnPushFailure(t *testing.T) {
 	env := setupTest(t)
+	mergeThenCheckout = "develop"
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	env.git.EXPECT().BranchExists(env.dir, "develop").Return(true, nil)
+	env.git.EXPECT().IsWorktreeDirty(env.dir).Return(false, nil)
+	expectMergeThenPushFails(env, wtPath)
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
-
-	err := deleteRun("auth")
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "removed")
+	assert.Contains(t, env.err.String(), "Not checking out 'develop' — the base branch isn't pushed")
+	env.git.AssertNotCalled(t, "Checkout", mock.Anything, mock.Anything)
 }
 
-func TestDelete_DirtyWorktreePromptDenied(t *testing.T) {
+func TestMerge_ThenCheckoutMissingBranch(t *testing.T) {
 	env := setupTest(t)
-	promptFunc = func(msg string) bool { return false }
-
+	mergeThenCheckout = "nope"
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().BranchExists(env.dir, "nope").Return(false, nil)
 
-	err := deleteRun("auth")
+	err := mergeRun("feature/auth")
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "aborted")
+	assert.Contains(t, err.Error(), "--then-checkout branch 'nope' does not exist")
+	env.git.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestDelete_DirtyWorktreePromptAccepted(t *testing.T) {
+func TestMerge_ThenCheckoutRejectsPR(t *testing.T) {
+	setupTest(t)
+	mergeThenCheckout = "develop"
+	mergePR = true
+
+	err := mergeCmd.RunE(mergeCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--then-checkout only applies to local merges")
+}
+
+func TestMerge_NoCleanup(t *testing.T) {
 	env := setupTest(t)
-	promptFunc = func(msg string) bool { return true }
+	mergeNoCleanup = true
 
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
 
-	err := deleteRun("auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+
+	// No WorktreeRemove or BranchDelete expected
+
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "removed")
+	assert.DirExists(t, wtPath) // worktree preserved
+	assert.Contains(t, env.err.String(), "Merge complete")
 }
 
-func TestDelete_UnpushedCommitsPromptDenied(t *testing.T) {
+func TestMerge_KeepPushesAndPreservesWindow(t *testing.T) {
 	env := setupTest(t)
-	promptFunc = func(msg string) bool { return false }
+	require.NoError(t, mergeCmd.ParseFlags([]string{"--keep"}))
+	t.Cleanup(func() { _ = mergeCmd.Flags().Set("keep", "false") })
+	require.True(t, mergeNoCleanup, "--keep should set no-cleanup")
 
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
+		ShellSessionID:  "s-456",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil).Times(2)
+	env.git.EXPECT().Pull(env.dir).Return(nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().Push(env.dir, "main", false).Return(nil)
 
-	err := deleteRun("auth")
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "aborted")
+	// No WorktreeRemove, BranchDelete, or CloseWindow expected
+
+	err := mergeRun("feature/auth")
+	require.NoError(t, err)
+
+	assert.DirExists(t, wtPath)
+	ws, _ := env.state.GetWorktree(wtPath)
+	require.NotNil(t, ws, "state (and window session) should be kept")
+	assert.Equal(t, "c-123", ws.ClaudeSessionID)
+	env.iterm.AssertNotCalled(t, "CloseWindow", mock.Anything)
+	assert.Contains(t, env.err.String(), "Pushed 'main'")
+	assert.Contains(t, env.err.String(), "Keeping worktree 'auth' and its iTerm2 window")
 }
 
-func TestDelete_All(t *testing.T) {
+func TestMerge_MainRepoNotOnBaseBranch(t *testing.T) {
 	env := setupTest(t)
-	deleteAll = true
-	deleteForce = true // skip prompts for simplicity
-
-	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
-	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "api")
-	require.NoError(t, os.MkdirAll(wtPath1, 0755))
-	require.NoError(t, os.MkdirAll(wtPath2, 0755))
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main"},
-		{Path: wtPath1, Branch: "feature/auth"},
-		{Path: wtPath2, Branch: "feature/api"},
-	}, nil)
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath1, true).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath2, true).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
-	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("develop", nil)
 
-	err := deleteAllRun()
-	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Deleted 2 worktrees")
+	err := mergeRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected 'main'")
 }
 
-func TestDelete_All_NoneFound(t *testing.T) {
+func TestMerge_PR_Success(t *testing.T) {
 	env := setupTest(t)
-	deleteAll = true
-
-	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
-		{Path: env.dir, Branch: "main"},
-	}, nil)
+	mergePR = true
 
-	err := deleteAllRun()
-	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "No worktrees")
-}
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-// ─── Dry-Run Tests ───────────────────────────────────────────────────────────
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
 
-func TestDryRun_Create(t *testing.T) {
-	env := setupTest(t)
-	dryRun = true
-	env.ui.DryRun = true
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().Push(wtPath, "feature/auth", true).Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("abc1234def", nil)
+	env.git.EXPECT().RemoteBranchSHA(wtPath, "origin", "feature/auth").Return("abc1234def", nil)
 
-	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	// Mock gh pr create
+	ghPRCreateFunc = func(args []string) (string, error) {
+		assert.Contains(t, args, "--base")
+		assert.Contains(t, args, "--fill")
+		return "https://github.com/owner/repo/pull/42", nil
+	}
 
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
-	env.git.EXPECT().BranchExists(mock.Anything, "feature/dry").Return(false, nil)
+	checked := false
+	ghAuthCheckFunc = func() error { checked = true; return nil }
 
-	err := createRun("feature/dry")
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
 
-	// Verify no side effects
-	assert.NoDirExists(t, filepath.Join(wtDir, "dry"))
-	ws, _ := env.state.GetWorktree(filepath.Join(wtDir, "dry"))
-	assert.Nil(t, ws)
-
-	assert.Contains(t, env.out.String(), "Would create worktree")
+	assert.True(t, checked, "gh auth should be checked before pushing")
+	assert.Contains(t, env.err.String(), "Pull request created")
+	assert.Equal(t, "https://github.com/owner/repo/pull/42\n", env.out.String())
 }
 
-func TestDryRun_Open(t *testing.T) {
+func TestMerge_PR_GHNotAuthenticated(t *testing.T) {
 	env := setupTest(t)
-	dryRun = true
-	env.ui.DryRun = true
+	mergePR = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	ghAuthCheckFunc = func() error { return fmt.Errorf("gh is not authenticated — run 'gh auth login'") }
+	ghPRCreateFunc = func(args []string) (string, error) {
+		t.Fatal("gh pr create should not run")
+		return "", nil
+	}
+	// No Push expectation — nothing is pushed without gh auth
+
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gh auth login")
+}
 
+func TestMerge_PR_NoGHCheck(t *testing.T) {
+	env := setupTest(t)
+	mergePR = true
+	mergeNoGHCheck = true
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().Push(wtPath, "feature/auth", true).Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("abc1234def", nil)
+	env.git.EXPECT().RemoteBranchSHA(wtPath, "origin", "feature/auth").Return("abc1234def", nil)
+	ghAuthCheckFunc = func() error {
+		t.Fatal("gh auth check should be skipped")
+		return nil
+	}
+	ghPRCreateFunc = func(args []string) (string, error) { return "https://github.com/owner/repo/pull/43", nil }
 
-	err := openRun("auth")
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Would open iTerm2 window")
+	assert.Contains(t, env.err.String(), "Pull request created")
 }
 
-func TestDryRun_Switch(t *testing.T) {
+func TestMerge_PR_Draft(t *testing.T) {
 	env := setupTest(t)
-	dryRun = true
-	env.ui.DryRun = true
+	mergePR = true
+	mergeDraft = true
+	mergeTitle = "My PR"
 
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-
 	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		ClaudeSessionID: "c-123",
+		Repo:   "myrepo",
+		Branch: "feature/auth",
 	}))
 
-	err := switchRun("auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().Push(wtPath, "feature/auth", true).Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("abc1234def", nil)
+	env.git.EXPECT().RemoteBranchSHA(wtPath, "origin", "feature/auth").Return("abc1234def", nil)
+
+	ghPRCreateFunc = func(args []string) (string, error) {
+		assert.Contains(t, args, "--draft")
+		assert.Contains(t, args, "--title")
+		assert.Contains(t, args, "My PR")
+		return "https://github.com/owner/repo/pull/43", nil
+	}
+
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.err.String(), "DRY-RUN")
+	assert.Contains(t, env.err.String(), "Pull request created")
 }
 
-// ─── Prune Tests ─────────────────────────────────────────────────────────────
+// expectPRPush sets up the push and push check of a --pr merge of feature/auth.
+func expectPRPush(env *testEnv, wtPath string) {
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().Push(wtPath, "feature/auth", true).Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("abc1234def", nil)
+	env.git.EXPECT().RemoteBranchSHA(wtPath, "origin", "feature/auth").Return("abc1234def", nil)
+}
 
-func TestPrune_CleansStaleState(t *testing.T) {
+func TestMerge_PR_BodyFile(t *testing.T) {
 	env := setupTest(t)
+	mergePR = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(filepath.Join(wtPath, ".github"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, ".github", "pull_request_template.md"), []byte("## Template"), 0644))
+	bodyFile := filepath.Join(env.dir, "body.md")
+	require.NoError(t, os.WriteFile(bodyFile, []byte("## Summary"), 0644))
+	mergeBodyFile = bodyFile
+	expectPRPush(env, wtPath)
+
+	var got []string
+	ghPRCreateFunc = func(args []string) (string, error) {
+		got = args
+		return "https://github.com/owner/repo/pull/44", nil
+	}
 
-	// Add state for a non-existent path
-	require.NoError(t, env.state.SetWorktree("/nonexistent/path", &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "stale",
-	}))
+	require.NoError(t, mergeRun("feature/auth"))
+	assert.Equal(t, []string{"pr", "create", "--base", "main", "--head", "feature/auth", "--body-file", bodyFile, "--fill"}, got,
+		"--body-file wins over the repo template")
+}
 
-	wtDir := filepath.Join(env.dir, "repo.worktrees")
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
-	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+func TestMerge_PR_Template(t *testing.T) {
+	env := setupTest(t)
+	mergePR = true
+	mergeTitle = "Add auth"
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	template := filepath.Join(wtPath, ".github", "PULL_REQUEST_TEMPLATE.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(template), 0755))
+	require.NoError(t, os.WriteFile(template, []byte("## Summary"), 0644))
+	expectPRPush(env, wtPath)
 
-	err := pruneRun()
-	require.NoError(t, err)
+	var got []string
+	ghPRCreateFunc = func(args []string) (string, error) {
+		got = args
+		return "https://github.com/owner/repo/pull/45", nil
+	}
 
-	// Verify stale entry was pruned
-	ws, _ := env.state.GetWorktree("/nonexistent/path")
-	assert.Nil(t, ws)
-	assert.Contains(t, env.out.String(), "Pruned 1 stale")
+	require.NoError(t, mergeRun("feature/auth"))
+	assert.Equal(t, []string{"pr", "create", "--base", "main", "--head", "feature/auth", "--title", "Add auth", "--body-file", template}, got)
 }
 
-func TestPrune_NothingToClean(t *testing.T) {
+func TestMerge_PR_BodySkipsTemplate(t *testing.T) {
 	env := setupTest(t)
+	mergePR = true
+	mergeBody = "inline body"
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "pull_request_template.md"), []byte("## Template"), 0644))
+	expectPRPush(env, wtPath)
 
-	wtDir := filepath.Join(env.dir, "repo.worktrees")
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
-	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+	ghPRCreateFunc = func(args []string) (string, error) {
+		assert.NotContains(t, args, "--body-file")
+		assert.Contains(t, args, "inline body")
+		return "https://github.com/owner/repo/pull/46", nil
+	}
 
-	err := pruneRun()
-	require.NoError(t, err)
+	require.NoError(t, mergeRun("feature/auth"))
+}
 
-	assert.Contains(t, env.out.String(), "clean")
+func TestMerge_PR_BodyFileMissing(t *testing.T) {
+	env := setupTest(t)
+	mergePR = true
+	mergeBodyFile = filepath.Join(env.dir, "nope.md")
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--body-file")
+	env.git.AssertNotCalled(t, "Push", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestPrune_DryRun(t *testing.T) {
+func TestMerge_BodyAndBodyFile(t *testing.T) {
+	setupTest(t)
+	mergeBody = "x"
+	mergeBodyFile = "body.md"
+
+	err := mergeCmd.RunE(mergeCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--body and --body-file cannot be used together")
+}
+
+func TestMerge_IsolatedFlagConflicts(t *testing.T) {
+	setupTest(t)
+	mergeIsolated = true
+	mergePR = true
+	err := mergeCmd.RunE(mergeCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--isolated only applies to local merges")
+
+	mergePR = false
+	mergeContinue = true
+	err = mergeCmd.RunE(mergeCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--isolated and --continue cannot be used together")
+}
+
+func TestMerge_DryRun_Local(t *testing.T) {
 	env := setupTest(t)
 	dryRun = true
 	env.ui.DryRun = true
 
-	require.NoError(t, env.state.SetWorktree("/nonexistent/path", &state.WorktreeState{
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
 		Repo:   "myrepo",
-		Branch: "stale",
+		Branch: "feature/auth",
 	}))
 
-	wtDir := filepath.Join(env.dir, "repo.worktrees")
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
-
-	// WorktreePrune should NOT be called in dry-run
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil).Times(2)
 
-	err := pruneRun()
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
 
-	assert.Contains(t, env.out.String(), "Would run git worktree prune")
+	out := env.err.String()
+	assert.Contains(t, out, "Would merge")
+	assert.Contains(t, out, "Merge branch 'feature/auth'")
+	assert.Contains(t, out, "Would pull")
+	assert.Contains(t, out, "Would push")
+	assert.DirExists(t, wtPath) // not removed in dry run
 }
 
-func TestDryRun_Delete(t *testing.T) {
+func TestMerge_DryRun_Squash(t *testing.T) {
 	env := setupTest(t)
 	dryRun = true
 	env.ui.DryRun = true
+	mergeSquash = true
 
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
 
-	// Should not call WorktreeRemove
-	_ = mock.Anything
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().CommitSubjects(env.dir, "main", "feature/auth").Return([]string{"Add login form", "Validate password"}, nil)
 
-	err := deleteRun("auth")
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Would remove git worktree")
-	assert.DirExists(t, wtPath) // dir not removed
+
+	out := env.err.String()
+	assert.Contains(t, out, "Would squash merge")
+	assert.Contains(t, out, "Squash merge branch 'feature/auth'")
+	assert.Contains(t, out, "* Add login form")
+	assert.Contains(t, out, "* Validate password")
 }
 
-// --- Claude Trust Tests ---
+// expectSquashMergeCleanup sets up a successful local squash merge of
+// feature/auth whose cleanup finds the branch unmerged by 'git branch -d'.
+func expectSquashMergeCleanup(env *testEnv, wtPath string, checkDirty bool) {
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	if checkDirty {
+		env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	}
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().CommitSubjects(env.dir, "main", "feature/auth").Return([]string{"Add login form"}, nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", mock.MatchedBy(func(o gitops.MergeRunOptions) bool { return o.Squash })).Return(nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	// The squashed commits aren't ancestors of main, so -d refuses
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(fmt.Errorf("not fully merged"))
+}
 
-func TestCreate_SetsClaudeTrust(t *testing.T) {
+func TestMerge_Squash_ForceDeletesBranchWithConsent(t *testing.T) {
 	env := setupTest(t)
-	wtDir := filepath.Join(env.dir, "repo.worktrees")
-	wtPath := filepath.Join(wtDir, "auth")
-
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
-	env.git.EXPECT().BranchExists(mock.Anything, "feature/auth").Return(false, nil)
-	env.git.EXPECT().WorktreeAdd(mock.Anything, wtPath, "feature/auth", "main", true).
-		Run(func(repoPath, path, branch, base string, newBranch bool) {
-			_ = os.MkdirAll(path, 0755)
-		}).Return(nil)
+	mergeSquash = true
+	var prompted string
+	promptFunc = func(msg string) bool { prompted = msg; return true }
 
-	env.iterm.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
-		Return(&iterm.SessionIDs{ClaudeSessionID: "c-123", ShellSessionID: "s-456"}, nil)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	expectSquashMergeCleanup(env, wtPath, true)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", true).Return(nil)
 
-	err := createRun("feature/auth")
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
 
-	// Verify Claude trust was set
-	added, err := env.claude.TrustProject(wtPath)
-	require.NoError(t, err)
-	assert.False(t, added, "should already be trusted")
+	assert.Contains(t, prompted, "Branch 'feature/auth'")
+	assert.Contains(t, env.err.String(), "Force-deleted branch 'feature/auth'")
+	assert.Contains(t, env.err.String(), "Merge complete")
 }
 
-func TestCreate_DryRun_DoesNotSetTrust(t *testing.T) {
+func TestMerge_Squash_KeepsBranchWithoutConsent(t *testing.T) {
 	env := setupTest(t)
-	dryRun = true
-	env.ui.DryRun = true
-
-	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	mergeSquash = true
+	promptFunc = func(msg string) bool { return false }
 
-	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
-	env.git.EXPECT().BranchExists(mock.Anything, "feature/dry").Return(false, nil)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	expectSquashMergeCleanup(env, wtPath, true)
+	// No BranchDelete(..., true) expectation — mock fails the test if called
 
-	err := createRun("feature/dry")
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
 
-	// Verify no Claude trust was set (file should not exist)
-	_, statErr := os.Stat(env.claude.Path())
-	assert.True(t, os.IsNotExist(statErr), "claude.json should not be created in dry-run")
+	out := env.err.String()
+	assert.Contains(t, out, "Kept branch 'feature/auth'")
+	assert.Contains(t, out, "git branch -D feature/auth")
+	assert.Contains(t, out, "Merge complete")
+	assert.NoDirExists(t, wtPath)
 }
 
-func TestDelete_RemovesClaudeTrust(t *testing.T) {
+func TestMerge_Squash_ForceFlagSkipsPrompt(t *testing.T) {
 	env := setupTest(t)
+	mergeSquash = true
+	mergeForce = true
+	promptFunc = func(msg string) bool {
+		t.Fatalf("unexpected prompt: %s", msg)
+		return false
+	}
 
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	expectSquashMergeCleanup(env, wtPath, false) // --force skips the dirty check
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", true).Return(nil)
 
-	// Pre-set Claude trust
-	added, err := env.claude.TrustProject(wtPath)
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	require.True(t, added)
+	assert.Contains(t, env.err.String(), "Force-deleted branch 'feature/auth'")
+}
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+func TestMerge_SquashWithRebase(t *testing.T) {
+	setupTest(t)
+	mergeSquash = true
+	mergeRebase = true
 
-	err = deleteRun("auth")
-	require.NoError(t, err)
+	err := mergeCmd.RunE(mergeCmd, []string{"auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+}
 
-	// Verify Claude trust was removed - re-trusting should return true (added)
-	added, err = env.claude.TrustProject(wtPath)
-	require.NoError(t, err)
-	assert.True(t, added, "trust should have been removed by delete")
+func TestMerge_NoFFWithRebase(t *testing.T) {
+	setupTest(t)
+	mergeNoFF = true
+	mergeRebase = true
+
+	err := mergeCmd.RunE(mergeCmd, []string{"auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+}
+
+func TestMerge_NoFFWithSquash(t *testing.T) {
+	setupTest(t)
+	mergeNoFF = true
+	mergeSquash = true
+
+	err := mergeCmd.RunE(mergeCmd, []string{"auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
 }
 
-func TestPrune_CleansOrphanedTrust(t *testing.T) {
+func TestMerge_DryRun_PR(t *testing.T) {
 	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+	mergePR = true
 
-	wtDir := filepath.Join(env.dir, "repo.worktrees")
-	existingPath := filepath.Join(wtDir, "auth")
-	stalePath := filepath.Join(wtDir, "stale-branch")
-
-	// Create only the existing path
-	require.NoError(t, os.MkdirAll(existingPath, 0755))
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	// Trust both
-	_, err := env.claude.TrustProject(existingPath)
-	require.NoError(t, err)
-	_, err = env.claude.TrustProject(stalePath)
-	require.NoError(t, err)
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
 
-	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
-	env.git.EXPECT().WorktreePrune(mock.Anything).Return(nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
 
-	err = pruneRun()
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
 
-	// Verify stale entry was pruned - re-trusting should return true (added)
-	added, err := env.claude.TrustProject(stalePath)
-	require.NoError(t, err)
-	assert.True(t, added, "stale trust should have been pruned")
+	out := env.err.String()
+	assert.Contains(t, out, "Would push")
+	assert.Contains(t, out, "Would run: gh")
+}
 
-	// Verify existing entry preserved
-	added, err = env.claude.TrustProject(existingPath)
-	require.NoError(t, err)
-	assert.False(t, added, "existing trust should be preserved")
+func TestMerge_WorktreeNotFound(t *testing.T) {
+	env := setupTest(t)
+	_ = env
 
-	assert.Contains(t, env.out.String(), "Pruned 1 stale trust entries")
-}
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "nonexistent").Return("", fmt.Errorf("worktree not found: nonexistent"))
 
-// ─── Merge Tests ─────────────────────────────────────────────────────────────
+	err := mergeRun("nonexistent")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "worktree not found")
+}
 
-func TestMerge_LocalSuccess_WithRemote(t *testing.T) {
+func TestMerge_CustomBase(t *testing.T) {
 	env := setupTest(t)
+	mergeBase = "develop"
+
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
@@ -1007,30 +4696,26 @@ func TestMerge_LocalSuccess_WithRemote(t *testing.T) {
 	}))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "develop").Return(true, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	// Note: uses "develop" as base branch
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "develop").Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil).Times(2) // once in merge, once in finish
-	env.git.EXPECT().Pull(env.dir).Return(nil)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil)
-	env.git.EXPECT().Push(env.dir, "main", false).Return(nil)
-
-	// Cleanup expectations: remove worktree + delete branch
+	env.git.EXPECT().CurrentBranch(env.dir).Return("develop", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
 	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
 		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
 	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-
-	out := env.out.String()
-	assert.Contains(t, out, "Merged")
-	assert.Contains(t, out, "Merge complete")
+	assert.Contains(t, env.err.String(), "Merge complete")
 }
 
-func TestMerge_LocalSuccess_NoRemote(t *testing.T) {
+func TestMerge_Continue_Success(t *testing.T) {
 	env := setupTest(t)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
@@ -1042,57 +4727,75 @@ func TestMerge_LocalSuccess_NoRemote(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2) // once in merge, once in finish
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(true, nil)
+	env.git.EXPECT().HasConflicts(env.dir).Return(false, nil)
+	env.git.EXPECT().MergeContinue(env.dir).Return(nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 
-	// Cleanup: no push, but still remove worktree + delete branch
+	// Cleanup
 	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
 		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
 	err := mergeRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
-	assert.Contains(t, out, "Merged")
+	out := env.err.String()
+	assert.Contains(t, out, "Merge in progress")
 	assert.Contains(t, out, "Merge complete")
 }
 
-func TestMerge_NothingToMerge(t *testing.T) {
+func TestMerge_ContinueFlag_NothingInProgress(t *testing.T) {
 	env := setupTest(t)
+	mergeContinue = true
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(false, nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 
-	err := mergeRun("feature/auth")
+	err := mergeRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to continue")
+	assert.DirExists(t, wtPath)
+}
+
+func TestSync_ContinueFlag_MergeInProgress(t *testing.T) {
+	env := setupTest(t)
+	syncContinue = true
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(true, nil)
+	env.git.EXPECT().HasConflicts(wtPath).Return(false, nil)
+	env.git.EXPECT().MergeContinue(wtPath).Return(nil)
+
+	err := syncRun("auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "No commits to merge")
+	assert.Contains(t, env.err.String(), "Sync continued")
 }
 
-func TestMerge_DirtyWorktree(t *testing.T) {
+func TestSync_ContinueFlag_NothingInProgress(t *testing.T) {
 	env := setupTest(t)
+	syncContinue = true
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 
-	err := mergeRun("auth")
+	err := syncRun("auth")
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "uncommitted changes")
+	assert.Contains(t, err.Error(), "nothing to continue")
 }
 
-func TestMerge_DirtyWorktree_Force(t *testing.T) {
+func TestMerge_Continue_WithRemote(t *testing.T) {
 	env := setupTest(t)
-	mergeForce = true
-
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
@@ -1101,25 +4804,46 @@ func TestMerge_DirtyWorktree_Force(t *testing.T) {
 		Branch: "feature/auth",
 	}))
 
-	// --force skips IsWorktreeDirty
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(true, nil)
+	env.git.EXPECT().HasConflicts(env.dir).Return(false, nil)
+	env.git.EXPECT().MergeContinue(env.dir).Return(nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().Push(env.dir, "main", false).Return(nil)
+
 	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
 		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
-	err := mergeRun("auth")
+	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Merge complete")
+	assert.Contains(t, env.err.String(), "Merge complete")
 }
 
-func TestMerge_MergeConflict_NoCleanup(t *testing.T) {
+func TestMerge_Continue_UnresolvedConflicts(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(true, nil)
+	env.git.EXPECT().HasConflicts(env.dir).Return(true, nil)
+
+	err := mergeRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unresolved conflicts")
+}
+
+func TestMerge_Continue_DryRun(t *testing.T) {
 	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
@@ -1130,71 +4854,125 @@ func TestMerge_MergeConflict_NoCleanup(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(true, nil)
+	env.git.EXPECT().HasConflicts(env.dir).Return(false, nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(assert.AnError)
 
 	err := mergeRun("feature/auth")
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "merge conflict")
+	require.NoError(t, err)
 
-	// Worktree should NOT be cleaned up
-	assert.DirExists(t, wtPath)
+	out := env.err.String()
+	assert.Contains(t, out, "Would run: git merge --continue")
 }
 
-func TestMerge_NoCleanup(t *testing.T) {
-	env := setupTest(t)
-	mergeNoCleanup = true
+// ─── Lifecycle Delete Tests ──────────────────────────────────────────────────
 
+func TestLifecycleDelete_FullCleanup(t *testing.T) {
+	env := setupTest(t)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
 	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "c-123",
 	}))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil)
+	// Trust the project first
+	_, err := env.claude.TrustProject(wtPath)
+	require.NoError(t, err)
 
-	// No WorktreeRemove or BranchDelete expected
+	env.iterm.EXPECT().IsRunning().Return(true)
+	env.iterm.EXPECT().SessionExists("c-123").Return(true)
+	env.iterm.EXPECT().CloseWindow("c-123").Return(nil)
 
-	err := mergeRun("feature/auth")
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
+	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+
+	err = lcMgr.Delete(lifecycle.DeleteOptions{
+		RepoPath:     env.dir,
+		WtPath:       wtPath,
+		Branch:       "feature/auth",
+		Force:        false,
+		DeleteBranch: true,
+	})
 	require.NoError(t, err)
-	assert.DirExists(t, wtPath) // worktree preserved
-	assert.Contains(t, env.out.String(), "Merge complete")
+
+	// Verify state removed
+	ws, _ := env.state.GetWorktree(wtPath)
+	assert.Nil(t, ws)
+
+	// Verify trust removed
+	added, err := env.claude.TrustProject(wtPath)
+	require.NoError(t, err)
+	assert.True(t, added, "trust should have been removed by cleanup")
+
+	assert.Contains(t, env.err.String(), "Closed iTerm2 window")
+	assert.Contains(t, env.err.String(), "Removed git worktree")
+	assert.Contains(t, env.err.String(), "removed")
 }
 
-func TestMerge_MainRepoNotOnBaseBranch(t *testing.T) {
+func TestLifecycleDelete_NoBranchDelete(t *testing.T) {
 	env := setupTest(t)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
+		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+
+	// No BranchDelete expected since DeleteBranch=false
+
+	err := lcMgr.Delete(lifecycle.DeleteOptions{
+		RepoPath: env.dir,
+		WtPath:   wtPath,
+		Branch:   "feature/auth",
+		Force:    true,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "removed")
+}
+
+// ─── Sync Tests ──────────────────────────────────────────────────────────────
+
+func TestSync_Success_WithRemote(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CurrentBranch(env.dir).Return("develop", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().CommitsBehind(env.dir, "origin/main").Return(0, nil) // local main up to date with origin
+	env.git.EXPECT().Fetch(env.dir).Return(nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "origin/main").Return(2, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "origin/main").Return(3, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil) // local main not ahead
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "origin/main", gitops.MergeRunOptions{}).Return(nil)
+
+	err := syncRun("feature/auth")
+	require.NoError(t, err)
 
-	err := mergeRun("auth")
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "expected 'main'")
+	out := env.err.String()
+	assert.Contains(t, out, "↑2 ↓3")
+	assert.Contains(t, out, "Merging 3 commit(s)")
+	assert.Contains(t, out, "Synced")
+	assert.Contains(t, out, "feature/auth")
 }
 
-func TestMerge_PR_Success(t *testing.T) {
+func TestSync_Success_NoRemote(t *testing.T) {
 	env := setupTest(t)
-	mergePR = true
-
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
@@ -1205,155 +4983,162 @@ func TestMerge_PR_Success(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().Push(wtPath, "feature/auth", true).Return(nil)
-
-	// Mock gh pr create
-	ghPRCreateFunc = func(args []string) (string, error) {
-		assert.Contains(t, args, "--base")
-		assert.Contains(t, args, "--fill")
-		return "https://github.com/owner/repo/pull/42", nil
-	}
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(5, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{}).Return(nil)
 
-	err := mergeRun("feature/auth")
+	err := syncRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
-	assert.Contains(t, out, "Pull request created")
-	assert.Contains(t, out, "https://github.com/owner/repo/pull/42")
+	out := env.err.String()
+	assert.Contains(t, out, "↓5")
+	assert.Contains(t, out, "Merging 5 commit(s)")
+	assert.Contains(t, out, "Synced")
+	assert.Contains(t, out, "To undo: wt undo-sync feature/auth")
+
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "pre123", ws.PreSyncHEAD)
 }
 
-func TestMerge_PR_Draft(t *testing.T) {
+func TestSync_DivergedSuggestsRebase(t *testing.T) {
 	env := setupTest(t)
-	mergePR = true
-	mergeDraft = true
-	mergeTitle = "My PR"
-
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
-	}))
-
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().Push(wtPath, "feature/auth", true).Return(nil)
-
-	ghPRCreateFunc = func(args []string) (string, error) {
-		assert.Contains(t, args, "--draft")
-		assert.Contains(t, args, "--title")
-		assert.Contains(t, args, "My PR")
-		return "https://github.com/owner/repo/pull/43", nil
-	}
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(2, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(5, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{}).Return(nil)
 
-	err := mergeRun("feature/auth")
+	err := syncRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Pull request created")
+
+	out := env.err.String()
+	assert.Contains(t, out, "has diverged from 'main' (2 ahead, 5 behind)")
+	assert.Contains(t, out, "Tip: 'wt sync feature/auth --rebase'")
 }
 
-func TestMerge_DryRun_Local(t *testing.T) {
+func TestSync_AheadOnlyIsNotJustInSync(t *testing.T) {
 	env := setupTest(t)
-	dryRun = true
-	env.ui.DryRun = true
-
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
-	}))
-
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil).Times(2)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(3, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil)
+	// No Merge
 
-	err := mergeRun("feature/auth")
+	err := syncRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
-	assert.Contains(t, out, "Would merge")
-	assert.Contains(t, out, "Would pull")
-	assert.Contains(t, out, "Would push")
-	assert.DirExists(t, wtPath) // not removed in dry run
+	out := env.err.String()
+	assert.Contains(t, out, "3 commit(s) ahead")
+	assert.NotContains(t, out, "already in sync")
+	assert.NotContains(t, out, "Tip:")
 }
 
-func TestMerge_DryRun_PR(t *testing.T) {
+func TestSync_StrategyOption(t *testing.T) {
 	env := setupTest(t)
-	dryRun = true
-	env.ui.DryRun = true
-	mergePR = true
-
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
-
-	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
-	}))
+	syncStratOpts = []string{"theirs"}
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(5, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{StrategyOptions: []string{"theirs"}}).Return(nil)
 
-	err := mergeRun("feature/auth")
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
 	require.NoError(t, err)
-
-	out := env.out.String()
-	assert.Contains(t, out, "Would push")
-	assert.Contains(t, out, "Would run: gh")
 }
 
-func TestMerge_WorktreeNotFound(t *testing.T) {
-	env := setupTest(t)
-	_ = env
+func TestSync_StrategyOptionInvalid(t *testing.T) {
+	setupTest(t)
+	syncStratOpts = []string{"--theirs"}
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "nonexistent").Return("", fmt.Errorf("worktree not found: nonexistent"))
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid strategy option '--theirs'")
+}
 
-	err := mergeRun("nonexistent")
+func TestSync_StrategyOptionWithFFOnly(t *testing.T) {
+	setupTest(t)
+	syncStratOpts = []string{"ours"}
+	syncFFOnly = true
+
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "worktree not found")
+	assert.Contains(t, err.Error(), "--strategy-option cannot be used with --ff-only")
 }
 
-func TestMerge_CustomBase(t *testing.T) {
+func TestSync_RebaseMerges(t *testing.T) {
 	env := setupTest(t)
-	mergeBase = "develop"
-
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
-
-	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
-	}))
+	syncRebaseMerges = true
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	// Note: uses "develop" as base branch
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "develop").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().CurrentBranch(env.dir).Return("develop", nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil)
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
-	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(2, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(5, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath, "main", gitops.RebaseRunOptions{RebaseMerges: true}).Return(nil)
 
-	err := mergeRun("feature/auth")
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Merge complete")
 }
 
-func TestMerge_Continue_Success(t *testing.T) {
+func TestSync_RebaseMergesWithMergeOrFFOnly(t *testing.T) {
+	setupTest(t)
+	syncRebaseMerges = true
+
+	syncMerge = true
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--rebase-merges implies --rebase")
+
+	syncMerge = false
+	syncFFOnly = true
+	err = syncCmd.RunE(syncCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--rebase-merges implies --rebase")
+}
+
+func TestMerge_StrategyOptionRejectsPR(t *testing.T) {
+	setupTest(t)
+	mergeStratOpts = []string{"ours"}
+	mergePR = true
+
+	err := mergeCmd.RunE(mergeCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only applies to local merges")
+}
+
+func TestMerge_SignFromConfig(t *testing.T) {
 	env := setupTest(t)
+	viper.Set("merge.sign", true)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
@@ -1363,28 +5148,57 @@ func TestMerge_Continue_Success(t *testing.T) {
 	}))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().ConfigValue(mock.Anything, "user.signingkey").Return("ABCD1234", nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(true, nil)
-	env.git.EXPECT().HasConflicts(env.dir).Return(false, nil)
-	env.git.EXPECT().MergeContinue(env.dir).Return(nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
-
-	// Cleanup
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{Sign: true}).Return(nil)
 	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
 		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
-	err := mergeRun("feature/auth")
+	require.NoError(t, mergeRun("feature/auth"))
+}
+
+func TestMerge_SignWithKeySkipsKeyCheck(t *testing.T) {
+	setupTest(t)
+	mergeSign = "KEY42"
+
+	sign, key, err := mergeSigning()
 	require.NoError(t, err)
+	assert.True(t, sign)
+	assert.Equal(t, "KEY42", key)
+}
 
-	out := env.out.String()
-	assert.Contains(t, out, "Merge in progress")
-	assert.Contains(t, out, "Merge complete")
+func TestMerge_SignWithoutSigningKey(t *testing.T) {
+	env := setupTest(t)
+	mergeSign = signDefaultKey
+	env.git.EXPECT().ConfigValue(mock.Anything, "user.signingkey").Return("", nil)
+
+	_, _, err := mergeSigning()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git has no signing key")
 }
 
-func TestMerge_Continue_WithRemote(t *testing.T) {
+func TestMerge_SignRejectsPR(t *testing.T) {
+	setupTest(t)
+	mergeSign = signDefaultKey
+	mergePR = true
+
+	err := mergeCmd.RunE(mergeCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--gpg-sign only applies to local merges")
+}
+
+func TestMerge_SignFromConfigSignsAmendBasePick(t *testing.T) {
 	env := setupTest(t)
+	viper.Set("merge.sign", true)
+	mergeAmendBase = true
+	mergeNoCleanup = true
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
@@ -1394,169 +5208,204 @@ func TestMerge_Continue_WithRemote(t *testing.T) {
 	}))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().ConfigValue(mock.Anything, "user.signingkey").Return("ABCD1234", nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(true, nil)
-	env.git.EXPECT().HasConflicts(env.dir).Return(false, nil)
-	env.git.EXPECT().MergeContinue(env.dir).Return(nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
-	env.git.EXPECT().Push(env.dir, "main", false).Return(nil)
-
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
-	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(1, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("abc1234def5678", nil)
+	env.git.EXPECT().CherryPick(env.dir, "abc1234def5678", gitops.CherryPickRunOptions{Sign: true}).Return(nil)
 
-	err := mergeRun("feature/auth")
-	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Merge complete")
+	require.NoError(t, mergeRun("feature/auth"))
 }
 
-func TestMerge_Continue_UnresolvedConflicts(t *testing.T) {
+func TestSync_UsesRecordedBase(t *testing.T) {
 	env := setupTest(t)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:       "myrepo",
+		Branch:     "feature/auth",
+		BaseBranch: "develop",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "develop").Return(true, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(true, nil)
-	env.git.EXPECT().HasConflicts(env.dir).Return(true, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "develop").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "develop").Return(2, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "develop", gitops.MergeRunOptions{}).Return(nil)
 
-	err := mergeRun("auth")
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "unresolved conflicts")
+	err := syncRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Synced 'feature/auth' with 'develop'")
 }
 
-func TestMerge_Continue_DryRun(t *testing.T) {
+func TestSync_BaseFlagOverridesRecordedBase(t *testing.T) {
 	env := setupTest(t)
-	dryRun = true
-	env.ui.DryRun = true
-
+	syncBase = "release"
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
 	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
+		Repo:       "myrepo",
+		Branch:     "feature/auth",
+		BaseBranch: "develop",
 	}))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "release").Return(true, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
-	env.git.EXPECT().IsMergeInProgress(env.dir).Return(true, nil)
-	env.git.EXPECT().HasConflicts(env.dir).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "release").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "release").Return(0, nil)
 
-	err := mergeRun("feature/auth")
+	err := syncRun("feature/auth")
 	require.NoError(t, err)
-
-	out := env.out.String()
-	assert.Contains(t, out, "Would run: git merge --continue")
+	assert.Contains(t, env.err.String(), "already in sync with 'release'")
+	env.git.AssertNotCalled(t, "RefExists", mock.Anything, "develop")
 }
 
-// ─── Lifecycle Delete Tests ──────────────────────────────────────────────────
-
-func TestLifecycleDelete_FullCleanup(t *testing.T) {
+func TestSync_RecordedBaseMissing(t *testing.T) {
 	env := setupTest(t)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
 	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:            "myrepo",
-		Branch:          "feature/auth",
-		ClaudeSessionID: "c-123",
+		Repo:       "myrepo",
+		Branch:     "feature/auth",
+		BaseBranch: "develop",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "develop").Return(false, nil)
+
+	err := syncRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "recorded base branch 'develop' for this worktree no longer exists")
+	env.git.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSync_All_UsesRecordedBases(t *testing.T) {
+	env := setupTest(t)
+	syncAll = true
+
+	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
+	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "hotfix")
+	require.NoError(t, os.MkdirAll(wtPath1, 0755))
+	require.NoError(t, os.MkdirAll(wtPath2, 0755))
+	require.NoError(t, env.state.SetWorktree(wtPath2, &state.WorktreeState{
+		Repo:       "myrepo",
+		Branch:     "hotfix/login",
+		BaseBranch: "release",
 	}))
 
-	// Trust the project first
-	_, err := env.claude.TrustProject(wtPath)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath1, Branch: "feature/auth"},
+		{Path: wtPath2, Branch: "hotfix/login"},
+	}, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	for p, base := range map[string]string{wtPath1: "main", wtPath2: "release"} {
+		env.git.EXPECT().IsWorktreeDirty(p).Return(false, nil)
+		env.git.EXPECT().IsMergeInProgress(p).Return(false, nil)
+		env.git.EXPECT().IsRebaseInProgress(p).Return(false, nil)
+		env.git.EXPECT().CommitsAhead(p, base).Return(0, nil)
+		env.git.EXPECT().CommitsBehind(p, base).Return(1, nil)
+		env.git.EXPECT().HeadSHA(p).Return("pre123", nil)
+		env.git.EXPECT().Merge(p, base, gitops.MergeRunOptions{}).Return(nil)
+	}
+
+	err := syncCmd.RunE(syncCmd, nil)
 	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "2 synced")
+}
 
-	env.iterm.EXPECT().IsRunning().Return(true)
-	env.iterm.EXPECT().SessionExists("c-123").Return(true)
-	env.iterm.EXPECT().CloseWindow("c-123").Return(nil)
-
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, false).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
-	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
+func TestUndoSync_ResetsToPreSyncHEAD(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth", PreSyncHEAD: "pre1234567"}))
 
-	err = lcMgr.Delete(lifecycle.DeleteOptions{
-		RepoPath:     env.dir,
-		WtPath:       wtPath,
-		Branch:       "feature/auth",
-		Force:        false,
-		DeleteBranch: true,
-	})
-	require.NoError(t, err)
+	var prompted string
+	promptFunc = func(msg string) bool { prompted = msg; return true }
 
-	// Verify state removed
-	ws, _ := env.state.GetWorktree(wtPath)
-	assert.Nil(t, ws)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("post890abc", nil)
+	env.git.EXPECT().ResetHard(wtPath, "pre1234567").Return(nil)
 
-	// Verify trust removed
-	added, err := env.claude.TrustProject(wtPath)
+	err := undoSyncRun("feature/auth")
 	require.NoError(t, err)
-	assert.True(t, added, "trust should have been removed by cleanup")
+	assert.Contains(t, prompted, "Reset 'auth' from post890 to pre1234")
+	assert.Contains(t, env.err.String(), "Reset 'auth' to pre1234")
 
-	assert.Contains(t, env.out.String(), "Closed iTerm2 window")
-	assert.Contains(t, env.out.String(), "Removed git worktree")
-	assert.Contains(t, env.out.String(), "removed")
+	// The undo point is used up
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Empty(t, ws.PreSyncHEAD)
 }
 
-func TestLifecycleDelete_NoBranchDelete(t *testing.T) {
+func TestUndoSync_Declined(t *testing.T) {
 	env := setupTest(t)
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth", PreSyncHEAD: "pre1234567"}))
 
-	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
-		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("post890abc", nil)
 
-	// No BranchDelete expected since DeleteBranch=false
+	err := undoSyncRun("feature/auth")
+	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Undo cancelled")
+	env.git.AssertNotCalled(t, "ResetHard", mock.Anything, mock.Anything)
 
-	err := lcMgr.Delete(lifecycle.DeleteOptions{
-		RepoPath: env.dir,
-		WtPath:   wtPath,
-		Branch:   "feature/auth",
-		Force:    true,
-	})
+	ws, err := env.state.GetWorktree(wtPath)
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "removed")
+	assert.Equal(t, "pre1234567", ws.PreSyncHEAD)
 }
 
-// ─── Sync Tests ──────────────────────────────────────────────────────────────
-
-func TestSync_Success_WithRemote(t *testing.T) {
+func TestUndoSync_RefusesDirtyWorktree(t *testing.T) {
 	env := setupTest(t)
+	undoSyncForce = true
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
-
-	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
-	}))
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth", PreSyncHEAD: "pre1234567"}))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
-	env.git.EXPECT().Fetch(env.dir).Return(nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "origin/main").Return(2, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "origin/main").Return(3, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil) // local main not ahead
-	env.git.EXPECT().Merge(wtPath, "origin/main").Return(nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
 
-	err := syncRun("feature/auth")
-	require.NoError(t, err)
+	err := undoSyncRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has uncommitted changes")
+	env.git.AssertNotCalled(t, "ResetHard", mock.Anything, mock.Anything)
+}
 
-	out := env.out.String()
-	assert.Contains(t, out, "↑2 ↓3")
-	assert.Contains(t, out, "Merging 3 commit(s)")
-	assert.Contains(t, out, "Synced")
-	assert.Contains(t, out, "feature/auth")
+func TestUndoSync_NothingRecorded(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth"}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+
+	err := undoSyncRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no sync to undo for 'auth'")
 }
 
-func TestSync_Success_NoRemote(t *testing.T) {
+func TestSync_FFOnly(t *testing.T) {
 	env := setupTest(t)
+	syncFFOnly = true
+	viper.Set("rebase", true) // --ff-only implies merge even when config prefers rebase
+
 	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
@@ -1571,16 +5420,26 @@ func TestSync_Success_NoRemote(t *testing.T) {
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(5, nil)
-	env.git.EXPECT().Merge(wtPath, "main").Return(nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil)
+	env.git.EXPECT().IsAncestor(wtPath, "HEAD", "main").Return(true, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{FF: gitops.FFOnly}).Return(nil)
 
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
+	assert.Contains(t, env.err.String(), "Fast-forwarded")
+}
 
-	out := env.out.String()
-	assert.Contains(t, out, "↓5")
-	assert.Contains(t, out, "Merging 5 commit(s)")
-	assert.Contains(t, out, "Synced")
+func TestSync_FFOnly_WithRebase(t *testing.T) {
+	env := setupTest(t)
+	_ = env
+
+	syncFFOnly = true
+	syncRebase = true
+
+	err := syncCmd.RunE(syncCmd, []string{"auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
 }
 
 func TestSync_DirtyWorktree(t *testing.T) {
@@ -1615,11 +5474,12 @@ func TestSync_DirtyWorktree_Force(t *testing.T) {
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(1, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil)
-	env.git.EXPECT().Merge(wtPath, "main").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{}).Return(nil)
 
 	err := syncRun("auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Synced")
+	assert.Contains(t, env.err.String(), "Synced")
 }
 
 func TestSync_WorktreeNotFound(t *testing.T) {
@@ -1646,17 +5506,19 @@ func TestSync_CustomBase(t *testing.T) {
 	}))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "develop").Return(true, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath, "develop").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "develop").Return(1, nil)
-	env.git.EXPECT().Merge(wtPath, "develop").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "develop", gitops.MergeRunOptions{}).Return(nil)
 
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Synced")
+	assert.Contains(t, env.err.String(), "Synced")
 }
 
 func TestSync_Continue_Success(t *testing.T) {
@@ -1678,93 +5540,384 @@ func TestSync_Continue_Success(t *testing.T) {
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
-	assert.Contains(t, out, "Merge in progress")
-	assert.Contains(t, out, "Sync continued")
+	out := env.err.String()
+	assert.Contains(t, out, "Merge in progress")
+	assert.Contains(t, out, "Sync continued")
+}
+
+func TestSync_Continue_UnresolvedConflicts(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(true, nil)
+	env.git.EXPECT().HasConflicts(wtPath).Return(true, nil)
+
+	err := syncRun("auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unresolved conflicts")
+}
+
+func TestSync_DryRun(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().CommitsBehind(env.dir, "origin/main").Return(0, nil) // local main up to date with origin
+	env.git.EXPECT().CommitsAhead(wtPath, "origin/main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "origin/main").Return(3, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil) // local main not ahead
+
+	err := syncRun("feature/auth")
+	require.NoError(t, err)
+
+	out := env.err.String()
+	assert.Contains(t, out, "Would fetch")
+	assert.Contains(t, out, "Would merge")
+}
+
+func TestSync_All_Success(t *testing.T) {
+	env := setupTest(t)
+	syncAll = true
+
+	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
+	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "api")
+	require.NoError(t, os.MkdirAll(wtPath1, 0755))
+	require.NoError(t, os.MkdirAll(wtPath2, 0755))
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath1, Branch: "feature/auth"},
+		{Path: wtPath2, Branch: "feature/api"},
+	}, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().CommitsBehind(env.dir, "origin/main").Return(0, nil) // local main up to date with origin
+	env.git.EXPECT().Fetch(env.dir).Return(nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath1).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath1).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath1).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath1, "origin/main").Return(1, nil)
+	env.git.EXPECT().CommitsBehind(wtPath1, "origin/main").Return(3, nil)
+	env.git.EXPECT().CommitsBehind(wtPath1, "main").Return(0, nil) // local main not ahead
+	env.git.EXPECT().HeadSHA(wtPath1).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath1, "origin/main", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath2).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath2).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath2).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath2, "origin/main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath2, "origin/main").Return(1, nil)
+	env.git.EXPECT().CommitsBehind(wtPath2, "main").Return(0, nil) // local main not ahead
+	env.git.EXPECT().HeadSHA(wtPath2).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath2, "origin/main", gitops.MergeRunOptions{}).Return(nil)
+
+	err := syncAllRun()
+	require.NoError(t, err)
+
+	out := env.err.String()
+	assert.Contains(t, out, "Synced")
+	assert.Contains(t, out, "2 synced")
+}
+
+func TestSync_AllRepos(t *testing.T) {
+	env := setupTest(t)
+	syncAll = true
+	syncRepoAll = true
+
+	alpha := filepath.Join(env.dir, "alpha")
+	beta := filepath.Join(env.dir, "beta")
+	alphaWt := filepath.Join(env.dir, "alpha.worktrees", "auth")
+	betaWt := filepath.Join(env.dir, "beta.worktrees", "api")
+	goneWt := filepath.Join(env.dir, "gone.worktrees", "old")
+	require.NoError(t, os.MkdirAll(alpha, 0755))
+	require.NoError(t, os.MkdirAll(beta, 0755))
+	require.NoError(t, env.state.SetWorktree(alphaWt, &state.WorktreeState{Repo: "alpha", RepoPath: alpha, Branch: "feature/auth"}))
+	// Older entries carry no repo_path; the repo is inferred from the layout
+	require.NoError(t, env.state.SetWorktree(betaWt, &state.WorktreeState{Repo: "beta", Branch: "feature/api"}))
+	require.NoError(t, env.state.SetWorktree(goneWt, &state.WorktreeState{Repo: "gone", Branch: "feature/old"}))
+
+	// alpha: one worktree behind, synced
+	env.git.EXPECT().WorktreeList(alpha).Return([]gitops.WorktreeInfo{
+		{Path: alpha, Branch: "main"},
+		{Path: alphaWt, Branch: "feature/auth"},
+	}, nil)
+	env.git.EXPECT().HasRemote(alpha).Return(false, nil)
+	env.git.EXPECT().IsWorktreeDirty(alphaWt).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(alphaWt).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(alphaWt).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(alphaWt, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(alphaWt, "main").Return(2, nil)
+	env.git.EXPECT().HeadSHA(alphaWt).Return("pre123", nil)
+	env.git.EXPECT().Merge(alphaWt, "main", gitops.MergeRunOptions{}).Return(nil)
+
+	// beta: one dirty worktree, skipped
+	env.git.EXPECT().WorktreeList(beta).Return([]gitops.WorktreeInfo{
+		{Path: beta, Branch: "main"},
+		{Path: betaWt, Branch: "feature/api"},
+	}, nil)
+	env.git.EXPECT().HasRemote(beta).Return(false, nil)
+	env.git.EXPECT().IsWorktreeDirty(betaWt).Return(true, nil)
+
+	err := syncCmd.RunE(syncCmd, nil)
+	require.NoError(t, err)
+
+	msgs := env.err.String()
+	assert.Contains(t, msgs, "Syncing worktrees in alpha")
+	assert.Contains(t, msgs, "Syncing worktrees in beta")
+	assert.Contains(t, msgs, "Skipping 'gone' — repo not found")
+
+	summary := env.out.String()
+	assert.Regexp(t, `alpha\s.*1\s.*0\s.*0\s.*0\s.*ok`, summary)
+	assert.Regexp(t, `beta\s.*0\s.*0\s.*1\s.*0\s.*ok`, summary)
+	assert.Regexp(t, `gone\s.*missing`, summary)
+}
+
+func TestSync_RepoAllRequiresAll(t *testing.T) {
+	setupTest(t)
+	syncRepoAll = true
+
+	err := syncCmd.RunE(syncCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--repo-all requires --all")
+}
+
+func TestSync_UnknownBase(t *testing.T) {
+	env := setupTest(t)
+	syncBase = "v9.9.9"
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "v9.9.9").Return(false, nil)
+
+	err := syncRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown ref 'v9.9.9'")
+	env.git.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMerge_UnknownBase(t *testing.T) {
+	env := setupTest(t)
+	mergeBase = "develp"
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "develp").Return(false, nil)
+
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown ref 'develp'")
+}
+
+func TestSync_ConfiguredBaseMissing(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("base_branch", "mian")
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "mian").Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().BranchList(mock.Anything).Return([]string{"main", "develop"}, nil)
+
+	err := syncRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "base branch 'mian' does not exist")
+	assert.Contains(t, err.Error(), "available branches: main, develop")
+	env.git.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCheckBaseBranch_OnlyOnRemote(t *testing.T) {
+	env := setupTest(t)
+	env.git.EXPECT().BranchExists(mock.Anything, "release").Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "origin/release").Return(true, nil)
+
+	require.NoError(t, checkBaseBranch("release"))
+}
+
+func TestMerge_ConfiguredBaseMissing(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("base_branch", "mian")
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().BranchExists(mock.Anything, "mian").Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().RefExists(mock.Anything, "origin/mian").Return(false, nil)
+	env.git.EXPECT().BranchList(mock.Anything).Return([]string{"main", "feature/auth"}, nil)
+
+	err := mergeRun("feature/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "base branch 'mian' does not exist")
+	assert.Contains(t, err.Error(), "available branches: main, feature/auth")
+	env.git.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSync_All_OnlyBehind(t *testing.T) {
+	env := setupTest(t)
+	syncAll = true
+	syncOnlyBehind = true
+
+	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
+	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "api")
+	require.NoError(t, os.MkdirAll(wtPath1, 0755))
+	require.NoError(t, os.MkdirAll(wtPath2, 0755))
+
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath1, Branch: "feature/auth"},
+		{Path: wtPath2, Branch: "feature/api"},
+	}, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().AheadBehind(wtPath1, "main").Return(2, 0, nil) // up to date, skipped
+	env.git.EXPECT().AheadBehind(wtPath2, "main").Return(0, 3, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath2).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath2).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath2).Return(false, nil)
+	env.git.EXPECT().HeadSHA(wtPath2).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath2, "main", gitops.MergeRunOptions{}).Return(nil)
+
+	err := syncCmd.RunE(syncCmd, nil)
+	require.NoError(t, err)
+
+	out := env.err.String()
+	assert.Contains(t, out, "Skipping 1 up-to-date worktree(s): auth")
+	assert.Contains(t, out, "1 synced, 1 up-to-date")
+	env.git.AssertNotCalled(t, "Merge", wtPath1, mock.Anything, mock.Anything)
 }
 
-func TestSync_Continue_UnresolvedConflicts(t *testing.T) {
+func TestSync_All_AbortOnConflict(t *testing.T) {
 	env := setupTest(t)
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
-
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(true, nil)
-	env.git.EXPECT().HasConflicts(wtPath).Return(true, nil)
-
-	err := syncRun("auth")
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "unresolved conflicts")
-}
+	syncAll = true
+	syncAbort = true
 
-func TestSync_DryRun(t *testing.T) {
-	env := setupTest(t)
-	dryRun = true
-	env.ui.DryRun = true
+	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
+	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "api")
+	require.NoError(t, os.MkdirAll(wtPath1, 0755))
+	require.NoError(t, os.MkdirAll(wtPath2, 0755))
 
-	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
-	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath1, Branch: "feature/auth"},
+		{Path: wtPath2, Branch: "feature/api"},
+	}, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	for _, p := range []string{wtPath1, wtPath2} {
+		env.git.EXPECT().IsWorktreeDirty(p).Return(false, nil)
+		env.git.EXPECT().IsMergeInProgress(p).Return(false, nil)
+		env.git.EXPECT().IsRebaseInProgress(p).Return(false, nil)
+		env.git.EXPECT().CommitsAhead(p, "main").Return(0, nil)
+		env.git.EXPECT().CommitsBehind(p, "main").Return(2, nil)
+	}
+	env.git.EXPECT().HeadSHA(wtPath1).Return("pre111", nil)
+	env.git.EXPECT().Merge(wtPath1, "main", gitops.MergeRunOptions{}).Return(fmt.Errorf("conflict"))
+	env.git.EXPECT().MergeAbort(wtPath1).Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath2).Return("pre222", nil)
+	env.git.EXPECT().Merge(wtPath2, "main", gitops.MergeRunOptions{}).Return(nil)
 
-	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
-	}))
+	err := syncCmd.RunE(syncCmd, nil)
+	require.NoError(t, err)
 
-	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
-	env.git.EXPECT().CommitsAhead(wtPath, "origin/main").Return(0, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "origin/main").Return(3, nil)
-	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil) // local main not ahead
+	out := env.err.String()
+	assert.Contains(t, out, "aborted the merge, worktree left unchanged")
+	assert.Contains(t, out, "Skipped for conflicts (aborted, left unchanged): feature/auth")
+	assert.Contains(t, out, "1 synced, 0 up-to-date, 0 skipped, 1 conflicts, 0 failed")
 
-	err := syncRun("feature/auth")
+	// The aborted worktree has nothing to undo
+	ws, err := env.state.GetWorktree(wtPath1)
 	require.NoError(t, err)
+	if ws != nil {
+		assert.Empty(t, ws.PreSyncHEAD)
+	}
+}
 
-	out := env.out.String()
-	assert.Contains(t, out, "Would fetch")
-	assert.Contains(t, out, "Would merge")
+func TestSync_AbortOnConflictRequiresAll(t *testing.T) {
+	setupTest(t)
+	syncAbort = true
+
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--abort-on-conflict requires --all")
 }
 
-func TestSync_All_Success(t *testing.T) {
+
+func TestSync_All_FailFast(t *testing.T) {
 	env := setupTest(t)
 	syncAll = true
+	syncFailFast = true
 
 	wtPath1 := filepath.Join(env.dir, "repo.worktrees", "auth")
 	wtPath2 := filepath.Join(env.dir, "repo.worktrees", "api")
-	require.NoError(t, os.MkdirAll(wtPath1, 0755))
-	require.NoError(t, os.MkdirAll(wtPath2, 0755))
+	wtPath3 := filepath.Join(env.dir, "repo.worktrees", "docs")
+	for _, p := range []string{wtPath1, wtPath2, wtPath3} {
+		require.NoError(t, os.MkdirAll(p, 0755))
+	}
+	require.NoError(t, env.state.SetWorktree(wtPath1, &state.WorktreeState{Repo: "myrepo", Branch: "feature/auth"}))
 
 	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
 		{Path: env.dir, Branch: "main"},
 		{Path: wtPath1, Branch: "feature/auth"},
 		{Path: wtPath2, Branch: "feature/api"},
+		{Path: wtPath3, Branch: "feature/docs"},
 	}, nil)
-	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
-	env.git.EXPECT().Fetch(env.dir).Return(nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath1).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath1).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath1).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath1, "origin/main").Return(1, nil)
-	env.git.EXPECT().CommitsBehind(wtPath1, "origin/main").Return(3, nil)
-	env.git.EXPECT().CommitsBehind(wtPath1, "main").Return(0, nil) // local main not ahead
-	env.git.EXPECT().Merge(wtPath1, "origin/main").Return(nil)
-	env.git.EXPECT().IsWorktreeDirty(wtPath2).Return(false, nil)
-	env.git.EXPECT().IsMergeInProgress(wtPath2).Return(false, nil)
-	env.git.EXPECT().IsRebaseInProgress(wtPath2).Return(false, nil)
-	env.git.EXPECT().CommitsAhead(wtPath2, "origin/main").Return(0, nil)
-	env.git.EXPECT().CommitsBehind(wtPath2, "origin/main").Return(1, nil)
-	env.git.EXPECT().CommitsBehind(wtPath2, "main").Return(0, nil) // local main not ahead
-	env.git.EXPECT().Merge(wtPath2, "origin/main").Return(nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	for _, p := range []string{wtPath1, wtPath2} {
+		env.git.EXPECT().IsWorktreeDirty(p).Return(false, nil)
+		env.git.EXPECT().IsMergeInProgress(p).Return(false, nil)
+		env.git.EXPECT().IsRebaseInProgress(p).Return(false, nil)
+		env.git.EXPECT().CommitsAhead(p, "main").Return(0, nil)
+		env.git.EXPECT().CommitsBehind(p, "main").Return(2, nil)
+	}
+	// auth syncs, api conflicts, docs is never looked at
+	env.git.EXPECT().HeadSHA(wtPath1).Return("pre111", nil)
+	env.git.EXPECT().Merge(wtPath1, "main", gitops.MergeRunOptions{}).Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath2).Return("pre222", nil)
+	env.git.EXPECT().Merge(wtPath2, "main", gitops.MergeRunOptions{}).Return(fmt.Errorf("conflict"))
 
-	err := syncAllRun()
+	err := syncCmd.RunE(syncCmd, nil)
+	require.Error(t, err)
+	var stopped *ops.SyncStoppedError
+	require.ErrorAs(t, err, &stopped)
+	assert.Equal(t, "feature/api", stopped.Branch)
+	assert.Contains(t, err.Error(), "1 worktree(s) not synced")
+
+	// The worktree synced before the stop can still be undone
+	ws, err := env.state.GetWorktree(wtPath1)
 	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "pre111", ws.PreSyncHEAD)
+}
 
-	out := env.out.String()
-	assert.Contains(t, out, "Synced")
-	assert.Contains(t, out, "2 synced")
+func TestSync_FailFastRequiresAll(t *testing.T) {
+	setupTest(t)
+	syncFailFast = true
+
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--fail-fast requires --all")
+}
+func TestSync_OnlyBehindRequiresAll(t *testing.T) {
+	setupTest(t)
+	syncOnlyBehind = true
+
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--only-behind requires --all")
 }
 
 func TestSync_All_SkipsDirty(t *testing.T) {
@@ -1788,18 +5941,130 @@ func TestSync_All_SkipsDirty(t *testing.T) {
 	env.git.EXPECT().IsRebaseInProgress(wtPath2).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath2, "main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath2, "main").Return(2, nil)
-	env.git.EXPECT().Merge(wtPath2, "main").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath2).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath2, "main", gitops.MergeRunOptions{}).Return(nil)
 
 	err := syncAllRun()
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	errOut := env.err.String()
 	assert.Contains(t, out+errOut, "Skipping")
 	assert.Contains(t, out, "1 synced")
 	assert.Contains(t, out, "1 skipped")
 }
 
+func TestSync_All_JSON(t *testing.T) {
+	env := setupTest(t)
+	syncAll = true
+	syncJSON = true
+
+	wtAuth := filepath.Join(env.dir, "repo.worktrees", "auth")
+	wtAPI := filepath.Join(env.dir, "repo.worktrees", "api")
+	wtDocs := filepath.Join(env.dir, "repo.worktrees", "docs")
+	wtUI := filepath.Join(env.dir, "repo.worktrees", "ui")
+	for _, p := range []string{wtAuth, wtAPI, wtDocs, wtUI} {
+		require.NoError(t, os.MkdirAll(p, 0755))
+	}
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtAuth, Branch: "feature/auth"},
+		{Path: wtAPI, Branch: "feature/api"},
+		{Path: wtDocs, Branch: "feature/docs"},
+		{Path: wtUI, Branch: "feature/ui"},
+	}, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtAuth).Return(true, nil)
+	for _, p := range []string{wtAPI, wtDocs, wtUI} {
+		env.git.EXPECT().IsWorktreeDirty(p).Return(false, nil)
+		env.git.EXPECT().IsMergeInProgress(p).Return(false, nil)
+		env.git.EXPECT().IsRebaseInProgress(p).Return(false, nil)
+	}
+	// api: behind, merges cleanly
+	env.git.EXPECT().CommitsAhead(wtAPI, "main").Return(1, nil)
+	env.git.EXPECT().CommitsBehind(wtAPI, "main").Return(2, nil)
+	env.git.EXPECT().HeadSHA(wtAPI).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtAPI, "main", gitops.MergeRunOptions{}).Return(nil)
+	// docs: up to date
+	env.git.EXPECT().CommitsAhead(wtDocs, "main").Return(3, nil)
+	env.git.EXPECT().CommitsBehind(wtDocs, "main").Return(0, nil)
+	// ui: conflicts
+	env.git.EXPECT().CommitsAhead(wtUI, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtUI, "main").Return(1, nil)
+	env.git.EXPECT().HeadSHA(wtUI).Return("pre456", nil)
+	env.git.EXPECT().Merge(wtUI, "main", gitops.MergeRunOptions{}).Return(fmt.Errorf("conflict"))
+
+	require.NoError(t, syncCmd.RunE(syncCmd, nil))
+
+	var report struct {
+		Repo    string           `json:"repo"`
+		Base    string           `json:"base"`
+		Results []syncJSONResult `json:"results"`
+		Totals  syncJSONTotals   `json:"totals"`
+	}
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &report), "stdout is only JSON: %s", env.out.String())
+	assert.Equal(t, "myrepo", report.Repo)
+	assert.Equal(t, "main", report.Base)
+	assert.Equal(t, []syncJSONResult{
+		{Branch: "feature/auth", Action: "none", Status: "skipped", Reason: "uncommitted changes"},
+		{Branch: "feature/api", Path: wtAPI, Ahead: 1, Behind: 2, Action: "merge", Status: "synced"},
+		{Branch: "feature/docs", Ahead: 3, Action: "none", Status: "up-to-date"},
+		{Branch: "feature/ui", Path: wtUI, Behind: 1, Action: "merge", Status: "conflict", Reason: "merge conflict", Error: "conflict"},
+	}, report.Results)
+	assert.Equal(t, syncJSONTotals{Synced: 1, UpToDate: 1, Skipped: 1, Conflicts: 1}, report.Totals)
+}
+
+func TestSync_All_FFOnlyFailureExitsNonZero(t *testing.T) {
+	env := setupTest(t)
+	syncAll = true
+	syncFFOnly = true
+	syncJSON = true
+
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "fix")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: wtPath, Branch: "bugfix/login"},
+	}, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil)
+	env.git.EXPECT().IsAncestor(wtPath, "HEAD", "main").Return(true, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{FF: gitops.FFOnly}).Return(fmt.Errorf("not possible to fast-forward"))
+
+	err := syncCmd.RunE(syncCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 worktree(s) failed to sync")
+
+	var report struct {
+		Results []syncJSONResult `json:"results"`
+		Totals  syncJSONTotals   `json:"totals"`
+	}
+	require.NoError(t, json.Unmarshal(env.out.Bytes(), &report), "stdout is only JSON: %s", env.out.String())
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "failed", report.Results[0].Status)
+	assert.Equal(t, "fast-forward failed", report.Results[0].Reason)
+	assert.Equal(t, "not possible to fast-forward", report.Results[0].Error)
+	assert.Equal(t, syncJSONTotals{Failed: 1}, report.Totals)
+}
+
+func TestSync_JSONRequiresAll(t *testing.T) {
+	setupTest(t)
+	syncJSON = true
+
+	err := syncCmd.RunE(syncCmd, []string{"feature/auth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--json requires --all")
+}
+
 func TestSync_All_NoneFound(t *testing.T) {
 	env := setupTest(t)
 	syncAll = true
@@ -1810,7 +6075,7 @@ func TestSync_All_NoneFound(t *testing.T) {
 
 	err := syncAllRun()
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "No worktrees to sync")
+	assert.Contains(t, env.err.String(), "No worktrees to sync")
 }
 
 // ─── Sync Rebase Tests ───────────────────────────────────────────────────────
@@ -1831,16 +6096,19 @@ func TestSync_Rebase_Success_WithRemote(t *testing.T) {
 	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().CommitsBehind(env.dir, "origin/main").Return(0, nil) // local main up to date with origin
 	env.git.EXPECT().Fetch(env.dir).Return(nil)
 	env.git.EXPECT().CommitsAhead(wtPath, "origin/main").Return(2, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "origin/main").Return(3, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(0, nil) // local main not ahead
-	env.git.EXPECT().Rebase(wtPath, "origin/main").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath, "origin/main", gitops.RebaseRunOptions{}).Return(nil)
 
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "Rebasing")
 	assert.Contains(t, out, "Rebased")
 }
@@ -1863,12 +6131,13 @@ func TestSync_Rebase_Success_NoRemote(t *testing.T) {
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(5, nil)
-	env.git.EXPECT().Rebase(wtPath, "main").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath, "main", gitops.RebaseRunOptions{}).Return(nil)
 
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "Rebased")
 }
 
@@ -1890,7 +6159,8 @@ func TestSync_Rebase_Conflict(t *testing.T) {
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil)
-	env.git.EXPECT().Rebase(wtPath, "main").Return(assert.AnError)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath, "main", gitops.RebaseRunOptions{}).Return(assert.AnError)
 
 	err := syncRun("feature/auth")
 	require.Error(t, err)
@@ -1898,6 +6168,12 @@ func TestSync_Rebase_Conflict(t *testing.T) {
 
 	errOut := env.err.String()
 	assert.Contains(t, errOut, "rebase --abort")
+	assert.NotContains(t, errOut, "To undo")
+
+	// The undo point is saved before the conflicting rebase, for after --continue
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "pre123", ws.PreSyncHEAD)
 }
 
 func TestSync_Rebase_Continue_Success(t *testing.T) {
@@ -1906,8 +6182,9 @@ func TestSync_Rebase_Continue_Success(t *testing.T) {
 	require.NoError(t, os.MkdirAll(wtPath, 0755))
 
 	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
-		Repo:   "myrepo",
-		Branch: "feature/auth",
+		Repo:        "myrepo",
+		Branch:      "feature/auth",
+		PreSyncHEAD: "pre123",
 	}))
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
@@ -1920,9 +6197,45 @@ func TestSync_Rebase_Continue_Success(t *testing.T) {
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "Rebase in progress")
 	assert.Contains(t, out, "Sync continued")
+
+	// The undo point recorded when the rebase stopped survives --continue
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "pre123", ws.PreSyncHEAD)
+}
+
+func TestSync_UnreadableHEADClearsStaleUndo(t *testing.T) {
+	env := setupTest(t)
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	require.NoError(t, env.state.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:        "myrepo",
+		Branch:      "feature/auth",
+		PreSyncHEAD: "older",
+	}))
+
+	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
+	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
+	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
+	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
+	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("", assert.AnError)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{}).Return(nil)
+
+	err := syncRun("feature/auth")
+	require.NoError(t, err)
+	assert.NotContains(t, env.err.String(), "To undo")
+
+	// The older point predates this sync, so undoing to it would be wrong
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Empty(t, ws.PreSyncHEAD)
 }
 
 func TestSync_Rebase_Continue_UnresolvedConflicts(t *testing.T) {
@@ -1963,18 +6276,20 @@ func TestSync_Rebase_All(t *testing.T) {
 	env.git.EXPECT().IsRebaseInProgress(wtPath1).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath1, "main").Return(1, nil)
 	env.git.EXPECT().CommitsBehind(wtPath1, "main").Return(3, nil)
-	env.git.EXPECT().Rebase(wtPath1, "main").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath1).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath1, "main", gitops.RebaseRunOptions{}).Return(nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath2).Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(wtPath2).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath2).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath2, "main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath2, "main").Return(1, nil)
-	env.git.EXPECT().Rebase(wtPath2, "main").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath2).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath2, "main", gitops.RebaseRunOptions{}).Return(nil)
 
 	err := syncAllRun()
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "Rebased")
 	assert.Contains(t, out, "2 synced")
 }
@@ -1997,11 +6312,12 @@ func TestSync_Rebase_ConfigDefault(t *testing.T) {
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil)
-	env.git.EXPECT().Rebase(wtPath, "main").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath, "main", gitops.RebaseRunOptions{}).Return(nil)
 
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Rebased")
+	assert.Contains(t, env.err.String(), "Rebased")
 }
 
 func TestSync_MergeOverridesConfigRebase(t *testing.T) {
@@ -2024,11 +6340,12 @@ func TestSync_MergeOverridesConfigRebase(t *testing.T) {
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(1, nil)
-	env.git.EXPECT().Merge(wtPath, "main").Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{}).Return(nil)
 
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Synced")
+	assert.Contains(t, env.err.String(), "Synced")
 }
 
 func TestSync_All_SkipsRebaseInProgress(t *testing.T) {
@@ -2058,7 +6375,7 @@ func TestSync_All_SkipsRebaseInProgress(t *testing.T) {
 	err := syncAllRun()
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	errOut := env.err.String()
 	assert.Contains(t, out+errOut, "rebase in progress")
 	assert.Contains(t, out, "1 skipped")
@@ -2079,18 +6396,21 @@ func TestSync_WithRemote_LocalMainAhead(t *testing.T) {
 	env.git.EXPECT().IsMergeInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().CommitsBehind(env.dir, "origin/main").Return(0, nil) // local main up to date with origin
 	env.git.EXPECT().Fetch(env.dir).Return(nil)
 	// Remote is in sync but local main has unpushed commits
 	env.git.EXPECT().CommitsAhead(wtPath, "origin/main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "origin/main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath, "main").Return(2, nil) // local main has 2 unpushed commits
 	env.git.EXPECT().CommitsAhead(wtPath, "main").Return(0, nil)  // re-check ahead against local
-	env.git.EXPECT().Merge(wtPath, "main").Return(nil)            // merges from local main
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath, "main", gitops.MergeRunOptions{}).Return(nil)            // merges from local main
 
 	err := syncRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "↓2")
 	assert.Contains(t, out, "Merging 2 commit(s)")
 	assert.Contains(t, out, "Synced")
@@ -2108,6 +6428,8 @@ func TestSync_All_WithRemote_LocalMainAhead(t *testing.T) {
 		{Path: wtPath1, Branch: "feature/auth"},
 	}, nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(true, nil)
+	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
+	env.git.EXPECT().CommitsBehind(env.dir, "origin/main").Return(0, nil) // local main up to date with origin
 	env.git.EXPECT().Fetch(env.dir).Return(nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath1).Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(wtPath1).Return(false, nil)
@@ -2117,12 +6439,13 @@ func TestSync_All_WithRemote_LocalMainAhead(t *testing.T) {
 	env.git.EXPECT().CommitsBehind(wtPath1, "origin/main").Return(0, nil)
 	env.git.EXPECT().CommitsBehind(wtPath1, "main").Return(3, nil) // local main has 3 unpushed commits
 	env.git.EXPECT().CommitsAhead(wtPath1, "main").Return(0, nil)  // re-check ahead against local
-	env.git.EXPECT().Merge(wtPath1, "main").Return(nil)            // merges from local main
+	env.git.EXPECT().HeadSHA(wtPath1).Return("pre123", nil)
+	env.git.EXPECT().Merge(wtPath1, "main", gitops.MergeRunOptions{}).Return(nil)            // merges from local main
 
 	err := syncAllRun()
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "1 synced")
 	assert.Contains(t, out, "Synced")
 }
@@ -2143,23 +6466,25 @@ func TestMerge_Rebase_LocalSuccess(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2) // once in merge, once in finish
-	env.git.EXPECT().Rebase(wtPath, "main").Return(nil)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil) // ff merge
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath, "main", gitops.RebaseRunOptions{}).Return(nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil) // ff merge
 
 	// Cleanup
 	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
 		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
 	err := mergeRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "Rebasing")
 	assert.Contains(t, out, "Rebased")
 	assert.Contains(t, out, "Fast-forward")
@@ -2180,12 +6505,13 @@ func TestMerge_Rebase_Conflict(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
-	env.git.EXPECT().Rebase(wtPath, "main").Return(assert.AnError)
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath, "main", gitops.RebaseRunOptions{}).Return(assert.AnError)
 
 	err := mergeRun("feature/auth")
 	require.Error(t, err)
@@ -2194,6 +6520,11 @@ func TestMerge_Rebase_Conflict(t *testing.T) {
 	errOut := env.err.String()
 	assert.Contains(t, errOut, "rebase --abort")
 	assert.DirExists(t, wtPath) // worktree kept
+
+	// The kept worktree can be reset to before the rebase
+	ws, err := env.state.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "pre123", ws.PreSyncHEAD)
 }
 
 func TestMerge_Rebase_Continue_Success(t *testing.T) {
@@ -2208,23 +6539,24 @@ func TestMerge_Rebase_Continue_Success(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(true, nil)
 	env.git.EXPECT().HasConflicts(wtPath).Return(false, nil)
 	env.git.EXPECT().RebaseContinue(wtPath).Return(nil)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil) // ff merge
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil) // ff merge
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil)
 
 	// Cleanup
 	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
 		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
 	err := mergeRun("feature/auth")
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "Rebase in progress")
 	assert.Contains(t, out, "Merge complete")
 }
@@ -2243,21 +6575,23 @@ func TestMerge_Rebase_ConfigDefault(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
-	env.git.EXPECT().Rebase(wtPath, "main").Return(nil)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil) // ff merge
+	env.git.EXPECT().HeadSHA(wtPath).Return("pre123", nil)
+	env.git.EXPECT().Rebase(wtPath, "main", gitops.RebaseRunOptions{}).Return(nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil) // ff merge
 
 	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
 		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
 	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Rebased")
+	assert.Contains(t, env.err.String(), "Rebased")
 }
 
 func TestMerge_MergeOverridesConfigRebase(t *testing.T) {
@@ -2275,21 +6609,22 @@ func TestMerge_MergeOverridesConfigRebase(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
 	env.git.EXPECT().IsMergeInProgress(env.dir).Return(false, nil)
 	env.git.EXPECT().IsRebaseInProgress(wtPath).Return(false, nil)
 	env.git.EXPECT().CurrentBranch(env.dir).Return("main", nil)
 	env.git.EXPECT().HasRemote(mock.Anything).Return(false, nil).Times(2)
-	env.git.EXPECT().Merge(env.dir, "feature/auth").Return(nil)
+	env.git.EXPECT().Merge(env.dir, "feature/auth", gitops.MergeRunOptions{}).Return(nil)
 
 	env.git.EXPECT().WorktreeRemove(mock.Anything, wtPath, true).
 		Run(func(repoPath, path string, force bool) { _ = os.RemoveAll(path) }).Return(nil)
+	env.git.EXPECT().RefSHA(mock.Anything, mock.Anything).Return("abc1234def5678", nil)
 	env.git.EXPECT().BranchDelete(mock.Anything, "feature/auth", false).Return(nil)
 
 	err := mergeRun("feature/auth")
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "Merged")
-	assert.Contains(t, env.out.String(), "Merge complete")
+	assert.Contains(t, env.err.String(), "Merged")
+	assert.Contains(t, env.err.String(), "Merge complete")
 }
 
 func TestMerge_PR_RebaseWarning(t *testing.T) {
@@ -2307,8 +6642,10 @@ func TestMerge_PR_RebaseWarning(t *testing.T) {
 
 	env.git.EXPECT().ResolveWorktree(mock.Anything, "feature/auth").Return(wtPath, nil)
 	env.git.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
-	env.git.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+	env.git.EXPECT().IsAncestor(mock.Anything, mock.Anything, "main").Return(false, nil)
 	env.git.EXPECT().Push(wtPath, "feature/auth", true).Return(nil)
+	env.git.EXPECT().HeadSHA(wtPath).Return("abc1234def", nil)
+	env.git.EXPECT().RemoteBranchSHA(wtPath, "origin", "feature/auth").Return("abc1234def", nil)
 
 	ghPRCreateFunc = func(args []string) (string, error) {
 		return "https://github.com/owner/repo/pull/99", nil
@@ -2346,13 +6683,35 @@ func TestDiscover_FindsUnmanaged(t *testing.T) {
 	err := discoverRun()
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "1 unmanaged")
 	assert.Contains(t, out, "worktree-glittery-pebble")
 	assert.Contains(t, out, "external")
 	assert.NotContains(t, out, "feature/auth") // managed, should not appear
 }
 
+func TestDiscover_Wtignore(t *testing.T) {
+	env := setupTest(t)
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	externalPath := filepath.Join(env.dir, ".claude", "worktrees", "glittery-pebble")
+	require.NoError(t, os.WriteFile(filepath.Join(env.dir, ".wtignore"), []byte(".claude/worktrees/*\n"), 0644))
+
+	env.git.EXPECT().RepoName(mock.Anything).Return("myrepo", nil)
+	env.git.EXPECT().WorktreesDir(mock.Anything).Return(wtDir, nil)
+	env.git.EXPECT().WorktreeList(mock.Anything).Return([]gitops.WorktreeInfo{
+		{Path: env.dir, Branch: "main"},
+		{Path: externalPath, Branch: "worktree-glittery-pebble"},
+	}, nil)
+
+	err := discoverRun()
+	require.NoError(t, err)
+
+	out := env.err.String()
+	assert.Contains(t, out, "Skipped 1 worktrees matched by .wtignore")
+	assert.Contains(t, out, "No unmanaged worktrees found")
+	assert.NotContains(t, out, "worktree-glittery-pebble")
+}
+
 func TestDiscover_NoneFound(t *testing.T) {
 	env := setupTest(t)
 	wtDir := filepath.Join(env.dir, "repo.worktrees")
@@ -2372,7 +6731,7 @@ func TestDiscover_NoneFound(t *testing.T) {
 
 	err := discoverRun()
 	require.NoError(t, err)
-	assert.Contains(t, env.out.String(), "No unmanaged")
+	assert.Contains(t, env.err.String(), "No unmanaged")
 }
 
 func TestDiscover_Adopt(t *testing.T) {
@@ -2392,7 +6751,7 @@ func TestDiscover_Adopt(t *testing.T) {
 	err := discoverRun()
 	require.NoError(t, err)
 
-	out := env.out.String()
+	out := env.err.String()
 	assert.Contains(t, out, "Adopted")
 	assert.Contains(t, out, "worktree-glittery-pebble")
 
@@ -2423,7 +6782,7 @@ func TestDiscover_DryRun(t *testing.T) {
 	err := discoverRun()
 	require.NoError(t, err)
 
-	assert.Contains(t, env.out.String(), "Would adopt")
+	assert.Contains(t, env.err.String(), "Would adopt")
 
 	// Verify state was NOT written
 	ws, _ := env.state.GetWorktree(externalPath)
@@ -2447,3 +6806,165 @@ func TestWorktreeSource(t *testing.T) {
 	// Standard dir with no state still "wt"
 	assert.Equal(t, "wt", worktreeSource("/repo.worktrees/auth", standardDir, nil))
 }
+
+// ─── Clone Tests ─────────────────────────────────────────────────────────────
+
+func TestCloneDirName(t *testing.T) {
+	tests := []struct{ url, want string }{
+		{"https://github.com/joescharf/wt.git", "wt"},
+		{"https://github.com/joescharf/wt", "wt"},
+		{"git@github.com:joescharf/wt.git", "wt"},
+		{"/srv/git/project.git/", "project"},
+		{"host:repo", "repo"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, cloneDirName(tt.url), tt.url)
+	}
+}
+
+func TestClone_CreatesLayoutAndConfig(t *testing.T) {
+	env := setupTest(t)
+	configDirFunc = func() (string, error) { return filepath.Join(env.dir, "config"), nil }
+	dir := filepath.Join(env.dir, "myrepo")
+
+	env.git.EXPECT().Clone("git@example.com:me/myrepo.git", dir, false).
+		Run(func(url, dir string, bare bool) { _ = os.MkdirAll(filepath.Join(dir, ".git"), 0755) }).Return(nil)
+	env.git.EXPECT().CurrentBranch(dir).Return("develop", nil)
+
+	require.NoError(t, cloneRun("git@example.com:me/myrepo.git", dir))
+
+	assert.DirExists(t, dir+".worktrees")
+	content, err := os.ReadFile(filepath.Join(env.dir, "config", "config.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "base_branch: develop")
+	assert.Contains(t, env.err.String(), "Next: cd "+dir)
+}
+
+func TestClone_Bare(t *testing.T) {
+	env := setupTest(t)
+	cloneBare = true
+	configDirFunc = func() (string, error) { return filepath.Join(env.dir, "config"), nil }
+	dir := filepath.Join(env.dir, "myrepo")
+
+	env.git.EXPECT().Clone("/srv/myrepo.git", dir, true).Return(nil)
+	env.git.EXPECT().CurrentBranch(dir).Return("main", nil)
+
+	require.NoError(t, cloneRun("/srv/myrepo.git", dir))
+	assert.DirExists(t, dir+".worktrees")
+}
+
+func TestClone_ExistingConfigWarnsOnBaseMismatch(t *testing.T) {
+	env := setupTest(t)
+	cfgDir := filepath.Join(env.dir, "config")
+	configDirFunc = func() (string, error) { return cfgDir, nil }
+	require.NoError(t, os.MkdirAll(cfgDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte("base_branch: main\n"), 0644))
+	dir := filepath.Join(env.dir, "myrepo")
+
+	env.git.EXPECT().Clone("/srv/myrepo.git", dir, false).Return(nil)
+	env.git.EXPECT().CurrentBranch(dir).Return("master", nil)
+
+	require.NoError(t, cloneRun("/srv/myrepo.git", dir))
+
+	content, err := os.ReadFile(filepath.Join(cfgDir, "config.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "base_branch: main\n", string(content), "existing config must not be touched")
+	assert.Contains(t, env.err.String(), "Default branch is 'master' but base_branch is 'main'")
+}
+
+func TestClone_DestinationNotEmpty(t *testing.T) {
+	env := setupTest(t)
+	dir := filepath.Join(env.dir, "myrepo")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), nil, 0644))
+
+	err := cloneRun("/srv/myrepo.git", dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists and is not empty")
+	env.git.AssertNotCalled(t, "Clone", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestClone_DryRun(t *testing.T) {
+	env := setupTest(t)
+	dryRun = true
+	env.ui.DryRun = true
+	configDirFunc = func() (string, error) { return filepath.Join(env.dir, "config"), nil }
+	dir := filepath.Join(env.dir, "myrepo")
+
+	require.NoError(t, cloneRun("/srv/myrepo.git", dir))
+
+	errOut := env.err.String()
+	assert.Contains(t, errOut, "Would clone /srv/myrepo.git into "+dir)
+	assert.Contains(t, errOut, "Would create worktrees directory")
+	assert.Contains(t, errOut, "Would create config file")
+	assert.NoDirExists(t, dir+".worktrees")
+	env.git.AssertNotCalled(t, "Clone", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// writePluginStubs puts an executable wt-<name> for each name on a fresh PATH;
+// each records its args and WT_* environment in <dir>/out.
+func writePluginStubs(t *testing.T, names ...string) (dir string) {
+	t.Helper()
+	dir = t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := "#!/bin/sh\n" +
+		"echo \"args=$*\" > " + out + "\n" +
+		"echo \"root=$WT_REPO_ROOT\" >> " + out + "\n" +
+		"echo \"wtdir=$WT_WORKTREES_DIR\" >> " + out + "\n" +
+		"echo plugin ran\n" +
+		"exit 3\n"
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "wt-"+name), []byte(script), 0755))
+	}
+	t.Setenv("PATH", dir)
+	return dir
+}
+
+func TestPlugin_UnknownCommandRunsStub(t *testing.T) {
+	env := setupTest(t)
+	pluginDir := writePluginStubs(t, "foo")
+	wtDir := filepath.Join(env.dir, "repo.worktrees")
+	env.git.EXPECT().WorktreesDir(env.dir).Return(wtDir, nil)
+
+	args := []string{"foo", "--bar", "baz"}
+	path, ok := findPlugin(args)
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(pluginDir, "wt-foo"), path)
+
+	err := runPlugin(path, args[1:])
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.ExitCode(), "the plugin's exit code comes back")
+	assert.Equal(t, "plugin ran\n", env.out.String())
+
+	data, err := os.ReadFile(filepath.Join(pluginDir, "out"))
+	require.NoError(t, err)
+	assert.Equal(t, "args=--bar baz\nroot="+env.dir+"\nwtdir="+wtDir+"\n", string(data))
+}
+
+func TestPlugin_BuiltinsAndBranchesWin(t *testing.T) {
+	setupTest(t)
+	writePluginStubs(t, "list", "help", "feature")
+
+	for _, args := range [][]string{{"list"}, {"help"}, {"--verbose"}, {"feature/auth"}, {"nope"}, nil} {
+		_, ok := findPlugin(args)
+		assert.False(t, ok, "%v", args)
+	}
+}
+
+func TestPlugin_WorktreeShorthandWins(t *testing.T) {
+	env := setupTest(t)
+	writePluginStubs(t, "auth", "foo")
+	wtPath := filepath.Join(env.dir, "repo.worktrees", "auth")
+	env.git.EXPECT().ResolveWorktree(env.dir, "auth").Return(wtPath, nil)
+	env.git.EXPECT().ResolveWorktree(env.dir, "foo").Return("", fmt.Errorf("no worktree found for 'foo'"))
+
+	// Both have a plugin on PATH; only foo isn't also a worktree
+	_, ok := findPlugin([]string{"auth"})
+	require.True(t, ok)
+	assert.True(t, opensWorktree("auth"), "'wt auth' opens the worktree, not wt-auth")
+
+	_, ok = findPlugin([]string{"foo"})
+	require.True(t, ok)
+	assert.False(t, opensWorktree("foo"))
+}
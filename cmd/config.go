@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -82,15 +83,73 @@ rebase: {{ .Rebase }}
 # Skip Claude Code launch in new worktree windows (default: false)
 no_claude: {{ .NoClaude }}
 
+# Let the 'wt <branch>' shorthand offer to create a missing worktree (default: false)
+shorthand_create: {{ .ShorthandCreate }}
+
+# Regex that new branch names must match in 'wt create' (uncomment to enforce; --force skips it)
+# branch_pattern: ^(feat|fix|chore|docs)/[a-z0-9-]+$
+
+create:
+  # Fetch and branch new worktrees from origin/<base_branch> (default: false)
+  fetch_base: {{ .CreateFetchBase }}
+  # git config set in each new worktree only, as key=value (uncomment to use)
+  # git_config: ["user.email=me@work.com", "user.signingkey=ABC123"]
+  # Prefix for branch names made by 'wt create --desc' (uncomment to use)
+  # desc_prefix: exp/
+
+merge:
+  # Pull the base branch before a local merge (default: true)
+  pull: {{ .MergePull }}
+  # GPG-sign the commits local merges create; needs git's user.signingkey (default: false)
+  sign: {{ .MergeSign }}
+
+list:
+  # What the AGE column counts from: accessed (last time wt opened or focused
+  # the window, else created) or created (default: accessed)
+  age_source: {{ .ListAgeSource }}
+
+port:
+  # Assign each worktree a stable port, exported as WT_PORT (default: false)
+  enabled: {{ .PortEnabled }}
+  # Inclusive range to assign ports from (default: 4000-4999)
+  start: {{ .PortStart }}
+  end: {{ .PortEnd }}
+
+trust:
+  # Pre-approve Claude Code trust for worktrees in ~/.claude.json (default: true)
+  enabled: {{ .TrustEnabled }}
+
+iterm:
+  # Most iTerm2 windows created at once, e.g. by open --all (default: 1)
+  max_concurrent: {{ .ItermMaxConcurrent }}
+  # Minimum gap between window spawns (default: 200ms)
+  spawn_delay: {{ .ItermSpawnDelay }}
+  # iTerm2 profile for new windows; --profile overrides (uncomment to use; default: iTerm2's default profile)
+  # profile: Work
+
 # State file directory (uncomment to override)
 # state_dir: {{ .StateDir }}
+
+# Claude Code config file for trust entries (uncomment to override, default: ~/.claude.json)
+# claude_config_path: ~/.claude.json
 `
 
 type configTemplateData struct {
-	BaseBranch string
-	Rebase     bool
-	NoClaude   bool
-	StateDir   string
+	BaseBranch         string
+	Rebase             bool
+	NoClaude           bool
+	ShorthandCreate    bool
+	CreateFetchBase    bool
+	MergePull          bool
+	MergeSign          bool
+	ListAgeSource      string
+	PortEnabled        bool
+	PortStart          int
+	PortEnd            int
+	TrustEnabled       bool
+	ItermMaxConcurrent int
+	ItermSpawnDelay    time.Duration
+	StateDir           string
 }
 
 func configFilePath() (string, error) {
@@ -115,44 +174,71 @@ func configInitRun() error {
 		output.Warning("Overwriting existing config file")
 	}
 
-	// Build template data from current viper values
-	data := configTemplateData{
-		BaseBranch: viper.GetString("base_branch"),
-		Rebase:     viper.GetBool("rebase"),
-		NoClaude:   viper.GetBool("no_claude"),
-		StateDir:   viper.GetString("state_dir"),
+	content, err := renderConfig(currentConfigData())
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		output.DryRunMsg("Would create config file: %s", cfgPath)
+		_, _ = fmt.Fprintln(output.ErrOut)
+		_, _ = fmt.Fprint(output.Out, string(content))
+		return nil
+	}
+
+	if err := writeConfigFile(cfgPath, content); err != nil {
+		return err
+	}
+
+	output.Success("Config file created: %s", cfgPath)
+	_, _ = fmt.Fprintln(output.ErrOut)
+	_, _ = fmt.Fprint(output.Out, string(content))
+	return nil
+}
+
+// currentConfigData builds template data from the effective viper values.
+func currentConfigData() configTemplateData {
+	return configTemplateData{
+		BaseBranch:         viper.GetString("base_branch"),
+		Rebase:             viper.GetBool("rebase"),
+		NoClaude:           viper.GetBool("no_claude"),
+		ShorthandCreate:    viper.GetBool("shorthand_create"),
+		CreateFetchBase:    viper.GetBool("create.fetch_base"),
+		MergePull:          viper.GetBool("merge.pull"),
+		MergeSign:          viper.GetBool("merge.sign"),
+		ListAgeSource:      viper.GetString("list.age_source"),
+		PortEnabled:        viper.GetBool("port.enabled"),
+		PortStart:          viper.GetInt("port.start"),
+		PortEnd:            viper.GetInt("port.end"),
+		TrustEnabled:       viper.GetBool("trust.enabled"),
+		ItermMaxConcurrent: viper.GetInt("iterm.max_concurrent"),
+		ItermSpawnDelay:    viper.GetDuration("iterm.spawn_delay"),
+		StateDir:           viper.GetString("state_dir"),
 	}
+}
 
+// renderConfig renders the commented config file for data.
+func renderConfig(data configTemplateData) ([]byte, error) {
 	tmpl, err := template.New("config").Parse(configTemplate)
 	if err != nil {
-		return fmt.Errorf("template parse error: %w", err)
+		return nil, fmt.Errorf("template parse error: %w", err)
 	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return fmt.Errorf("template execute error: %w", err)
-	}
-
-	if dryRun {
-		output.DryRunMsg("Would create config file: %s", cfgPath)
-		_, _ = fmt.Fprintln(output.Out)
-		_, _ = fmt.Fprint(output.Out, buf.String())
-		return nil
+		return nil, fmt.Errorf("template execute error: %w", err)
 	}
+	return buf.Bytes(), nil
+}
 
-	// Create config directory
-	dir := filepath.Dir(cfgPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+// writeConfigFile writes content to cfgPath, creating its directory.
+func writeConfigFile(cfgPath string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(cfgPath), 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
-
-	if err := os.WriteFile(cfgPath, buf.Bytes(), 0644); err != nil {
+	if err := os.WriteFile(cfgPath, content, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
-
-	output.Success("Config file created: %s", cfgPath)
-	_, _ = fmt.Fprintln(output.Out)
-	_, _ = fmt.Fprint(output.Out, buf.String())
 	return nil
 }
 
@@ -162,11 +248,28 @@ type configKeyInfo struct {
 	EnvVar string
 }
 
+// configKeys lists every supported key; configTemplate must document each one.
 var configKeys = []configKeyInfo{
 	{Key: "base_branch", EnvVar: "WT_BASE_BRANCH"},
 	{Key: "rebase", EnvVar: "WT_REBASE"},
 	{Key: "no_claude", EnvVar: "WT_NO_CLAUDE"},
+	{Key: "shorthand_create", EnvVar: "WT_SHORTHAND_CREATE"},
+	{Key: "branch_pattern", EnvVar: "WT_BRANCH_PATTERN"},
+	{Key: "create.fetch_base", EnvVar: "WT_CREATE_FETCH_BASE"},
+	{Key: "create.git_config", EnvVar: "WT_CREATE_GIT_CONFIG"},
+	{Key: "create.desc_prefix", EnvVar: "WT_CREATE_DESC_PREFIX"},
+	{Key: "merge.pull", EnvVar: "WT_MERGE_PULL"},
+	{Key: "merge.sign", EnvVar: "WT_MERGE_SIGN"},
+	{Key: "list.age_source", EnvVar: "WT_LIST_AGE_SOURCE"},
+	{Key: "port.enabled", EnvVar: "WT_PORT_ENABLED"},
+	{Key: "port.start", EnvVar: "WT_PORT_START"},
+	{Key: "port.end", EnvVar: "WT_PORT_END"},
+	{Key: "trust.enabled", EnvVar: "WT_TRUST_ENABLED"},
+	{Key: "iterm.max_concurrent", EnvVar: "WT_ITERM_MAX_CONCURRENT"},
+	{Key: "iterm.spawn_delay", EnvVar: "WT_ITERM_SPAWN_DELAY"},
+	{Key: "iterm.profile", EnvVar: "WT_ITERM_PROFILE"},
 	{Key: "state_dir", EnvVar: "WT_STATE_DIR"},
+	{Key: "claude_config_path", EnvVar: "WT_CLAUDE_CONFIG"},
 }
 
 func configShowRun() error {
@@ -181,7 +284,7 @@ func configShowRun() error {
 	} else {
 		output.Info("Config file: (none)")
 	}
-	_, _ = fmt.Fprintln(output.Out)
+	_, _ = fmt.Fprintln(output.ErrOut)
 
 	// Read config file values to determine file source
 	fileValues := readConfigFileValues(cfgPath)
@@ -189,7 +292,7 @@ func configShowRun() error {
 	for _, k := range configKeys {
 		val := viper.Get(k.Key)
 		source := detectSource(k.Key, k.EnvVar, fileValues)
-		_, _ = fmt.Fprintf(output.Out, "  %-14s %v  %s\n", k.Key, val, source)
+		_, _ = fmt.Fprintf(output.Out, "  %-18s %v  %s\n", k.Key, val, source)
 	}
 
 	return nil
@@ -209,12 +312,25 @@ func readConfigFileValues(path string) map[string]bool {
 		return result
 	}
 
-	for key := range parsed {
-		result[key] = true
-	}
+	collectConfigKeys("", parsed, result)
 	return result
 }
 
+// collectConfigKeys records every key in m, using dotted paths for nested
+// sections (e.g. "create.fetch_base") to match viper's key names.
+func collectConfigKeys(prefix string, m map[string]any, result map[string]bool) {
+	for key, val := range m {
+		full := key
+		if prefix != "" {
+			full = prefix + "." + key
+		}
+		result[full] = true
+		if nested, ok := val.(map[string]any); ok {
+			collectConfigKeys(full, nested, result)
+		}
+	}
+}
+
 // detectSource determines where a config value is coming from.
 func detectSource(key, envVar string, fileValues map[string]bool) string {
 	if _, ok := os.LookupEnv(envVar); ok {
@@ -3,11 +3,15 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/joescharf/wt/pkg/claude"
 )
 
 func TestConfigInit_CreatesFile(t *testing.T) {
@@ -25,8 +29,29 @@ func TestConfigInit_CreatesFile(t *testing.T) {
 	assert.Contains(t, content, "base_branch: main")
 	assert.Contains(t, content, "rebase: false")
 	assert.Contains(t, content, "no_claude: false")
+	assert.Contains(t, content, "fetch_base: false")
+	assert.Contains(t, content, "start: 4000")
+	assert.Contains(t, content, "max_concurrent: 1")
+	assert.Contains(t, content, "spawn_delay: 200ms")
 	assert.Contains(t, content, "# state_dir:")
-	assert.Contains(t, env.out.String(), "Config file created")
+	assert.Contains(t, env.err.String(), "Config file created")
+}
+
+func TestConfigInit_DocumentsEveryKey(t *testing.T) {
+	env := setupTest(t)
+	configDirFunc = func() (string, error) { return env.dir, nil }
+
+	require.NoError(t, configInitRun())
+	data, err := os.ReadFile(filepath.Join(env.dir, "config.yaml"))
+	require.NoError(t, err)
+	content := string(data)
+
+	// Every key 'wt config show' reports must be scaffolded (possibly
+	// commented out) so new users can discover it
+	for _, k := range configKeys {
+		leaf := k.Key[strings.LastIndex(k.Key, ".")+1:]
+		assert.Regexp(t, `(?m)^\s*(# )?`+regexp.QuoteMeta(leaf)+`:`, content, "config init should document %s", k.Key)
+	}
 }
 
 func TestConfigInit_ExistingFile_NoForce(t *testing.T) {
@@ -114,8 +139,8 @@ func TestConfigShow_DefaultsOnly(t *testing.T) {
 	err := configShowRun()
 	require.NoError(t, err)
 
+	assert.Contains(t, env.err.String(), "Config file: (none)")
 	out := env.out.String()
-	assert.Contains(t, out, "Config file: (none)")
 	assert.Contains(t, out, "base_branch")
 	assert.Contains(t, out, "(default)")
 }
@@ -131,15 +156,27 @@ func TestConfigShow_WithConfigFile(t *testing.T) {
 	err := configShowRun()
 	require.NoError(t, err)
 
+	assert.Contains(t, env.err.String(), "Config file: "+cfgPath)
 	out := env.out.String()
-	assert.Contains(t, out, "Config file:")
-	assert.Contains(t, out, cfgPath)
 	assert.Contains(t, out, "base_branch")
 	assert.Contains(t, out, "(file)")
 	// Other keys should still show default
 	assert.Contains(t, out, "(default)")
 }
 
+func TestConfigShow_NestedKeyFromFile(t *testing.T) {
+	env := setupTest(t)
+	configDirFunc = func() (string, error) { return env.dir, nil }
+
+	cfgPath := filepath.Join(env.dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("create:\n  fetch_base: true\n"), 0644))
+
+	err := configShowRun()
+	require.NoError(t, err)
+
+	assert.Regexp(t, `create\.fetch_base\s+\S+\s+\(file\)`, env.out.String())
+}
+
 func TestConfigShow_WithEnvVar(t *testing.T) {
 	env := setupTest(t)
 	configDirFunc = func() (string, error) { return env.dir, nil }
@@ -175,3 +212,39 @@ func TestConfigEdit_NoConfigFile(t *testing.T) {
 	assert.Contains(t, err.Error(), "config file not found")
 	assert.Contains(t, err.Error(), "wt config init")
 }
+
+func TestClaudeConfigPath_Custom(t *testing.T) {
+	env := setupTest(t)
+	custom := filepath.Join(env.dir, "project", "claude.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(custom), 0755))
+	viper.Set("claude_config_path", custom)
+
+	path, err := claudeConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, custom, path)
+
+	// Trust writes land in the custom file, not the default one
+	_, err = claude.NewTrustManager(path).TrustProject(filepath.Join(env.dir, "repo.worktrees", "auth"))
+	require.NoError(t, err)
+	assert.FileExists(t, custom)
+	assert.NoFileExists(t, env.claude.Path())
+}
+
+func TestClaudeConfigPath_MissingDir(t *testing.T) {
+	env := setupTest(t)
+	viper.Set("claude_config_path", filepath.Join(env.dir, "nope", "claude.json"))
+
+	_, err := claudeConfigPath()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "claude_config_path")
+}
+
+func TestClaudeConfigPath_Default(t *testing.T) {
+	setupTest(t)
+
+	path, err := claudeConfigPath()
+	require.NoError(t, err)
+	def, err := claude.DefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, def, path)
+}
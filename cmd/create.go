@@ -2,26 +2,65 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/joescharf/wt/pkg/gitops"
 	"github.com/joescharf/wt/pkg/lifecycle"
+	"github.com/joescharf/wt/pkg/ops"
 	"github.com/joescharf/wt/internal/ui"
 )
 
 var (
-	createBase     string
-	createNoClaude bool
-	createExisting bool
+	createBase      string
+	createNoClaude  bool
+	createNoWindow  bool
+	createLatest    bool
+	createExisting  bool
+	createNoTrust   bool
+	createForce     bool
+	createPrintPath bool
+	createTemplate  string
+	createGitConfig []string
+	createFrom      string
+	createProfile   string
+	createDesc      string
+	createWtDir     string
 )
 
 var createCmd = &cobra.Command{
 	Use:     "create <branch>",
 	Aliases: []string{"new"},
 	Short:   "Create worktree + branch + iTerm2 window",
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if createTemplate != "" && (createBase != "" || createLatest || createExisting) {
+			return fmt.Errorf("--template cannot be used with --base, --base-latest, or --existing")
+		}
+		if createFrom != "" && (createTemplate != "" || createLatest || createExisting) {
+			return fmt.Errorf("--from cannot be used with --template, --base-latest, or --existing")
+		}
+		if createDesc != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("--desc names the branch; don't pass one too")
+			}
+			if createExisting {
+				return fmt.Errorf("--desc cannot be used with --existing")
+			}
+			branch, err := descBranch(createDesc)
+			if err != nil {
+				return err
+			}
+			return createRun(branch)
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("branch name required (or use --desc)")
+		}
 		return createRun(args[0])
 	},
 }
@@ -29,34 +68,195 @@ var createCmd = &cobra.Command{
 func init() {
 	createCmd.Flags().StringVar(&createBase, "base", "", "Base branch (default from config)")
 	createCmd.Flags().BoolVar(&createNoClaude, "no-claude", false, "Don't auto-launch claude in top pane")
+	createCmd.Flags().BoolVar(&createNoWindow, "no-window", false, "Create the worktree without opening an iTerm2 window (use 'wt open' later)")
+	createCmd.Flags().BoolVar(&createLatest, "base-latest", false, "Fetch and branch from origin/<base> (default from config create.fetch_base)")
 	createCmd.Flags().BoolVar(&createExisting, "existing", false, "Use existing branch instead of creating new")
+	createCmd.Flags().BoolVar(&createNoTrust, "no-trust", false, "Don't pre-approve Claude Code trust for the worktree (default from config trust.enabled)")
+	createCmd.Flags().StringVar(&createDesc, "desc", "", "Name the new branch from a description, e.g. \"try new caching layer\" (prefix from config create.desc_prefix)")
+	createCmd.Flags().StringVar(&createProfile, "profile", "", "iTerm2 profile for the new window (default from config iterm.profile)")
+	createCmd.Flags().BoolVar(&createForce, "force", false, "Prune stale worktree entries, replace a leftover directory (refuses if it has uncommitted work), and skip the branch_pattern check")
+	createCmd.Flags().BoolVar(&createPrintPath, "print-path", false, "Print only the worktree's absolute path to stdout (all other output goes to stderr)")
+	createCmd.Flags().StringVar(&createTemplate, "template", "", "Create the new branch from this template branch instead of the base (recorded in state)")
+	createCmd.Flags().StringArrayVar(&createGitConfig, "git-config", nil, "Set git config in the new worktree only, as key=value (repeatable; adds to config create.git_config; enables extensions.worktreeConfig for the repo)")
+	createCmd.Flags().StringVar(&createWtDir, "worktrees-dir", "", "Create the worktree in this directory instead of <repo>.worktrees (this worktree only)")
+	createCmd.Flags().StringVar(&createFrom, "from", "", "Create the new branch from this worktree's current HEAD (branch or dirname; unpushed commits included)")
 	_ = createCmd.RegisterFlagCompletionFunc("base", completeBranchNames)
+	_ = createCmd.RegisterFlagCompletionFunc("template", completeBranchNames)
+	_ = createCmd.RegisterFlagCompletionFunc("from", completeWorktreeNames)
 	rootCmd.AddCommand(createCmd)
 }
 
+// descBranch turns a --desc description into a new branch name: the slug with
+// config create.desc_prefix in front, and -2, -3, ... appended until it names
+// a branch that doesn't exist yet.
+func descBranch(desc string) (string, error) {
+	slug := gitops.Slugify(desc)
+	if slug == "" {
+		return "", fmt.Errorf("can't make a branch name from '%s': it has no letters or digits", desc)
+	}
+	base := viper.GetString("create.desc_prefix") + slug
+	name := base
+	for n := 2; ; n++ {
+		exists, err := gitClient.BranchExists(repoRoot, name)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			break
+		}
+		name = fmt.Sprintf("%s-%d", base, n)
+	}
+	output.Info("Branch name: %s", name)
+	return name, nil
+}
+
 func createRun(branch string) error {
 	baseBranch := createBase
 	if baseBranch == "" {
 		baseBranch = viper.GetString("base_branch")
+	} else if err := checkRef(baseBranch); err != nil {
+		return err
 	}
 
-	noClaude := createNoClaude || viper.GetBool("no_claude")
+	if createTemplate != "" {
+		ok, err := gitClient.RefExists(repoRoot, createTemplate)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("template '%s' not found (not a branch, tag, or commit)", createTemplate)
+		}
+	}
+
+	startPoint := ""
+	if createFrom != "" {
+		sha, err := worktreeHead(createFrom)
+		if err != nil {
+			return err
+		}
+		startPoint = sha
+	}
+
+	// Flags override config entries for the same key
+	gitConfig, err := parseGitConfig(append(viper.GetStringSlice("create.git_config"), createGitConfig...))
+	if err != nil {
+		return err
+	}
+
+	pattern, err := branchPattern()
+	if err != nil {
+		return err
+	}
+
+	wtDir := ""
+	if createWtDir != "" {
+		if wtDir, err = worktreesDirOverride(createWtDir); err != nil {
+			return err
+		}
+	}
 
 	result, err := lcMgr.Create(lifecycle.CreateOptions{
-		RepoPath:   repoRoot,
-		Branch:     branch,
-		BaseBranch: baseBranch,
-		NoClaude:   noClaude,
-		Existing:   createExisting,
-		DryRun:     dryRun,
+		RepoPath:        repoRoot,
+		Branch:          branch,
+		BaseBranch:      baseBranch,
+		PinBase:         createBase != "",
+		Template:        createTemplate,
+		StartPoint:      startPoint,
+		GitConfig:       gitConfig,
+		NoClaude:        createNoClaude,
+		DefaultNoClaude: viper.GetBool("no_claude"),
+		NoWindow:        createNoWindow,
+		FetchBase:       createTemplate == "" && createFrom == "" && (createLatest || viper.GetBool("create.fetch_base")),
+		Existing:        createExisting,
+		Pattern:         pattern,
+		Ports:           portRange(),
+		NoTrust:         createNoTrust || !trustEnabled(),
+		Profile:         itermProfile(createProfile, openProfile), // open --create passes its --profile through
+		WorktreesDir:    wtDir,
+		Force:           createForce,
+		DryRun:          dryRun,
 	})
 	if err != nil {
 		return err
 	}
 
 	if result.Created {
-		_, _ = fmt.Fprintln(output.Out)
+		_, _ = fmt.Fprintln(output.ErrOut)
 		output.Success("Worktree ready: %s", ui.Cyan(result.WtPath))
+	} else if result.AlreadyExisted {
+		output.Success("Worktree ready: %s (already existed)", ui.Cyan(result.WtPath))
+	}
+	if createPrintPath {
+		_, _ = fmt.Fprintln(output.Out, result.WtPath)
 	}
 	return nil
 }
+
+// worktreesDirOverride returns --worktrees-dir as an absolute path, creating
+// the directory if needed and checking a file can be written there, so a bad
+// path fails before git creates the branch. Dry runs only resolve the path.
+func worktreesDirOverride(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("--worktrees-dir: %w", err)
+	}
+	if dryRun {
+		return abs, nil
+	}
+	if err := os.MkdirAll(abs, 0755); err != nil {
+		return "", fmt.Errorf("--worktrees-dir: %w", err)
+	}
+	f, err := os.CreateTemp(abs, ".wt-write-check-*")
+	if err != nil {
+		return "", fmt.Errorf("--worktrees-dir %s is not writable: %w", abs, err)
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+	return abs, nil
+}
+
+// worktreeHead resolves the worktree named by branch or dirname and returns
+// the commit its HEAD points at.
+func worktreeHead(name string) (string, error) {
+	wtPath, err := gitClient.ResolveWorktree(repoRoot, name)
+	if err != nil {
+		return "", fmt.Errorf("--from: %w", err)
+	}
+	sha, err := gitClient.HeadSHA(wtPath)
+	if err != nil {
+		return "", fmt.Errorf("--from: could not read HEAD of '%s': %w", name, err)
+	}
+	output.Info("Branching from '%s' at %s", name, ops.ShortSHA(sha))
+	return sha, nil
+}
+
+// branchPattern compiles the branch_pattern config, which new branch names
+// must match. It returns nil when no pattern is set.
+func branchPattern() (*regexp.Regexp, error) {
+	expr := viper.GetString("branch_pattern")
+	if expr == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid branch_pattern %q: %w", expr, err)
+	}
+	return re, nil
+}
+
+// parseGitConfig turns key=value entries into a map; later entries win.
+func parseGitConfig(entries []string) (map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	cfg := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid git config '%s' (want key=value, e.g. user.email=me@work.com)", entry)
+		}
+		cfg[key] = value
+	}
+	return cfg, nil
+}
@@ -17,7 +17,9 @@ import (
 var (
 	deleteForce      bool
 	deleteBranchFlag bool
+	deleteKeepBranch bool
 	deleteAll        bool
+	deleteExcept     []string
 )
 
 // promptFunc is the confirmation prompt (default No), replaceable in tests.
@@ -42,12 +44,22 @@ func defaultPromptYes(msg string) bool {
 }
 
 var deleteCmd = &cobra.Command{
-	Use:               "delete [branch]",
-	Aliases:           []string{"rm"},
-	Short:             "Close iTerm2 window + remove worktree",
+	Use:     "delete [branch]",
+	Aliases: []string{"rm"},
+	Short:   "Close iTerm2 window + remove worktree",
+	Long: `Close the worktree's iTerm2 window, remove the worktree, and clean up its state.
+
+The git branch is kept by default (--keep-branch). Pass --branch (or
+--delete-branch) to delete it as well; this applies to every worktree with --all.`,
 	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if deleteBranchFlag && deleteKeepBranch {
+			return fmt.Errorf("--branch and --keep-branch cannot be used together")
+		}
+		if len(deleteExcept) > 0 && !deleteAll {
+			return fmt.Errorf("--except requires --all")
+		}
 		if deleteAll {
 			return deleteAllRun()
 		}
@@ -60,14 +72,22 @@ var deleteCmd = &cobra.Command{
 
 func init() {
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Force removal, skip safety checks")
-	deleteCmd.Flags().BoolVar(&deleteBranchFlag, "delete-branch", false, "Also delete the git branch")
+	deleteCmd.Flags().BoolVar(&deleteBranchFlag, "branch", false, "Also delete the git branch")
+	deleteCmd.Flags().BoolVar(&deleteBranchFlag, "delete-branch", false, "Also delete the git branch (same as --branch)")
+	deleteCmd.Flags().BoolVar(&deleteKeepBranch, "keep-branch", false, "Keep the git branch (default)")
 	deleteCmd.Flags().BoolVar(&deleteAll, "all", false, "Delete all worktrees")
+	deleteCmd.Flags().StringArrayVar(&deleteExcept, "except", nil, "With --all, keep this worktree (branch or dirname); repeatable")
+	_ = deleteCmd.RegisterFlagCompletionFunc("except", completeWorktreeNames)
 	rootCmd.AddCommand(deleteCmd)
 }
 
 // checkWorktreeSafety returns true if the worktree is safe to delete (no data loss risk).
 // If unsafe, it prints warnings and prompts the user. Returns false if the user declines.
 func checkWorktreeSafety(wtPath, dirname string) bool {
+	if !checkStashes(wtPath, dirname) {
+		return false
+	}
+
 	dirty, err := gitClient.IsWorktreeDirty(wtPath)
 	if err != nil {
 		output.VerboseLog("Could not check worktree status: %v", err)
@@ -82,8 +102,7 @@ func checkWorktreeSafety(wtPath, dirname string) bool {
 		return promptFunc(fmt.Sprintf("Delete '%s' with uncommitted changes?", dirname))
 	}
 
-	baseBranch := viper.GetString("base_branch")
-	unpushed, err := gitClient.HasUnpushedCommits(wtPath, baseBranch)
+	unpushed, err := gitClient.HasUnpushedCommits(wtPath, deleteBaseBranch(wtPath))
 	if err != nil {
 		output.VerboseLog("Could not check unpushed commits: %v", err)
 	}
@@ -100,7 +119,74 @@ func checkWorktreeSafety(wtPath, dirname string) bool {
 	return true
 }
 
+// checkStashes asks before deleting a worktree whose branch has stashes.
+// Stashes outlive the worktree, but are easy to forget once it's gone.
+// Returns false if the user declines.
+func checkStashes(wtPath, dirname string) bool {
+	stashes, err := gitClient.StashCount(wtPath)
+	if err != nil {
+		output.VerboseLog("Could not count stashes: %v", err)
+	}
+	if stashes == 0 {
+		return true
+	}
+
+	output.Warning("'%s' has %d stash(es) — they stay in 'git stash list' after the worktree is gone", dirname, stashes)
+	if dryRun {
+		output.DryRunMsg("Would prompt for confirmation (stashes)")
+		return true
+	}
+	return promptFunc(fmt.Sprintf("Delete '%s' with %d stash(es)?", dirname, stashes))
+}
+
+// checkBranchMerged returns true if the worktree's branch has no commits missing
+// from the base branch. Otherwise it warns and prompts before the branch is deleted.
+// Returns false if the user declines.
+func checkBranchMerged(wtPath, dirname string) bool {
+	baseBranch := deleteBaseBranch(wtPath)
+	ahead, err := gitClient.CommitsAhead(wtPath, baseBranch)
+	if err != nil {
+		output.VerboseLog("Could not check unmerged commits: %v", err)
+		return true
+	}
+	if ahead == 0 {
+		return true
+	}
+
+	output.Warning("'%s' has %d commit(s) not merged into %s", dirname, ahead, baseBranch)
+	if dryRun {
+		output.DryRunMsg("Would prompt for confirmation (unmerged branch)")
+		return true
+	}
+	return promptFunc(fmt.Sprintf("Delete branch of '%s' with unmerged commits?", dirname))
+}
+
+// deleteBaseBranch returns the base wtPath's safety checks compare against:
+// the one recorded by 'wt create --base', else base_branch.
+func deleteBaseBranch(wtPath string) string {
+	if base := recordedBase(wtPath); base != "" {
+		return base
+	}
+	return viper.GetString("base_branch")
+}
+
+// recordedBase returns the base recorded for wtPath by 'wt create --base', or
+// "" if none was.
+func recordedBase(wtPath string) string {
+	ws, err := stateMgr.GetWorktree(wtPath)
+	if err != nil || ws == nil {
+		return ""
+	}
+	return ws.BaseBranch
+}
+
 func deleteRun(branch string) error {
+	unlock, err := lockRepo(repoRoot, "delete")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	wtPath, err := gitClient.ResolveWorktree(repoRoot, branch)
 	if err != nil {
 		return err
@@ -113,6 +199,9 @@ func deleteRun(branch string) error {
 		if !checkWorktreeSafety(checkPath, checkDirname) {
 			return false, nil
 		}
+		if deleteBranchFlag && !checkBranchMerged(checkPath, checkDirname) {
+			return false, nil
+		}
 		return true, nil
 	}
 
@@ -124,6 +213,7 @@ func deleteRun(branch string) error {
 			Branch:       cleanupBranch,
 			Force:        deleteForce,
 			DeleteBranch: deleteBranchFlag,
+			NoTrust:      !trustEnabled(),
 			DryRun:       dryRun,
 		})
 	}
@@ -141,17 +231,34 @@ func deleteRun(branch string) error {
 		return err
 	}
 
-	_, _ = fmt.Fprintln(output.Out)
+	_, _ = fmt.Fprintln(output.ErrOut)
 	return nil
 }
 
 func deleteAllRun() error {
+	except, err := resolveExcept(deleteExcept)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return deleteAllPlanRun(except)
+	}
+
+	unlock, err := lockRepo(repoRoot, "delete --all")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Build safety check callback
 	safetyCheck := func(checkPath string) (bool, error) {
 		checkDirname := filepath.Base(checkPath)
 		if !checkWorktreeSafety(checkPath, checkDirname) {
 			return false, nil
 		}
+		if deleteBranchFlag && !checkBranchMerged(checkPath, checkDirname) {
+			return false, nil
+		}
 		return true, nil
 	}
 
@@ -163,6 +270,7 @@ func deleteAllRun() error {
 			Branch:       cleanupBranch,
 			Force:        deleteForce,
 			DeleteBranch: deleteBranchFlag,
+			NoTrust:      !trustEnabled(),
 			DryRun:       dryRun,
 		})
 	}
@@ -171,6 +279,7 @@ func deleteAllRun() error {
 		RepoPath:     repoRoot,
 		Force:        deleteForce,
 		DeleteBranch: deleteBranchFlag,
+		Except:       except,
 		DryRun:       dryRun,
 	}, safetyCheck, cleanup)
 	if err != nil {
@@ -178,7 +287,105 @@ func deleteAllRun() error {
 	}
 
 	if deleted > 0 {
-		_, _ = fmt.Fprintln(output.Out)
+		_, _ = fmt.Fprintln(output.ErrOut)
+	}
+	return nil
+}
+
+// resolveExcept resolves each --except name to its worktree path, the same
+// way 'wt delete <branch>' does, so a typo fails before anything is deleted.
+func resolveExcept(names []string) ([]string, error) {
+	var paths []string
+	for _, name := range names {
+		wtPath, err := gitClient.ResolveWorktree(repoRoot, name)
+		if err != nil {
+			return nil, fmt.Errorf("--except %s: %w", name, err)
+		}
+		paths = append(paths, wtPath)
+	}
+	return paths, nil
+}
+
+// deleteAllPlanRun prints the safety status of every worktree and whether
+// delete --all would remove it, ask first, skip it, or keep it. Nothing is
+// changed.
+func deleteAllPlanRun(except []string) error {
+	plans, err := ops.PlanDeleteAll(gitClient, opsLogger, ops.DeleteOptions{
+		RepoPath:     repoRoot,
+		BaseBranch:   viper.GetString("base_branch"),
+		BaseFor:      recordedBase,
+		Force:        deleteForce,
+		DeleteBranch: deleteBranchFlag,
+		Except:       except,
+	})
+	if err != nil {
+		return err
 	}
+	if len(plans) == 0 {
+		output.Info("No worktrees to delete")
+		return nil
+	}
+
+	var rows [][]string
+	counts := map[string]int{}
+	for _, p := range plans {
+		counts[p.Action]++
+		rows = append(rows, []string{
+			filepath.Base(p.WtPath),
+			p.Branch,
+			ui.GitStatusColor(deletePlanStatus(p)),
+			deletePlanAction(p.Action),
+		})
+	}
+
+	table := newTable()
+	table.Header("WORKTREE", "BRANCH", "STATUS", "ACTION")
+	_ = table.Bulk(rows)
+	_ = table.Render()
+
+	kept := ""
+	if counts[ops.DeleteActionKeep] > 0 {
+		kept = fmt.Sprintf(", keep %d", counts[ops.DeleteActionKeep])
+	}
+	output.DryRunMsg("Would delete %d, confirm %d, skip %d%s — nothing was changed",
+		counts[ops.DeleteActionDelete], counts[ops.DeleteActionConfirm], counts[ops.DeleteActionSkip], kept)
 	return nil
 }
+
+// deletePlanStatus summarizes the safety checks for one worktree, e.g.
+// "clean" or "dirty unpushed".
+func deletePlanStatus(p ops.DeletePlan) string {
+	if p.Missing {
+		return "missing"
+	}
+	if p.Action == ops.DeleteActionKeep {
+		return "-" // not checked
+	}
+	var parts []string
+	if p.Dirty {
+		parts = append(parts, "dirty")
+	}
+	if p.Unpushed {
+		parts = append(parts, "unpushed")
+	}
+	if p.Unmerged > 0 {
+		parts = append(parts, fmt.Sprintf("unmerged ↑%d", p.Unmerged))
+	}
+	if len(parts) == 0 {
+		return "clean"
+	}
+	return strings.Join(parts, " ")
+}
+
+func deletePlanAction(action string) string {
+	switch action {
+	case ops.DeleteActionDelete:
+		return ui.Green(action)
+	case ops.DeleteActionConfirm:
+		return ui.Yellow(action)
+	case ops.DeleteActionKeep:
+		return ui.Cyan(action)
+	default:
+		return action
+	}
+}
@@ -38,14 +38,21 @@ func discoverRun() error {
 	stateAdopt := func(path, repo, branch string) error {
 		return stateMgr.SetWorktree(path, &state.WorktreeState{
 			Repo:      repo,
+			RepoPath:  repoRoot,
 			Branch:    branch,
 			CreatedAt: state.FlexTime{Time: time.Now().UTC()},
 		})
 	}
 
+	ignore, err := ops.LoadIgnore(repoRoot)
+	if err != nil {
+		output.Warning("Could not read %s: %v", ops.IgnoreFile, err)
+	}
+
 	result, err := ops.Discover(gitClient, opsLogger, ops.DiscoverOptions{
 		RepoPath: repoRoot,
 		Adopt:    discoverAdopt,
+		Ignore:   ignore,
 		DryRun:   dryRun,
 	}, stateCheck, stateAdopt)
 	if err != nil {
@@ -54,11 +61,11 @@ func discoverRun() error {
 
 	// Print colored output for unmanaged worktrees (ops layer doesn't have UI colors)
 	if len(result.Unmanaged) > 0 {
-		_, _ = fmt.Fprintln(output.Out)
+		_, _ = fmt.Fprintln(output.ErrOut)
 		for _, wt := range result.Unmanaged {
 			output.Info("  %s  %s  (%s)", ui.Cyan(wt.Branch), wt.Path, wt.Source)
 		}
-		_, _ = fmt.Fprintln(output.Out)
+		_, _ = fmt.Fprintln(output.ErrOut)
 
 		if !discoverAdopt {
 			output.Info("Run 'wt discover --adopt' to create state entries for these worktrees")
@@ -66,7 +73,7 @@ func discoverRun() error {
 	}
 
 	if result.Adopted > 0 {
-		_, _ = fmt.Fprintln(output.Out)
+		_, _ = fmt.Fprintln(output.ErrOut)
 	}
 	return nil
 }
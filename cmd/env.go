@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env <branch>",
+	Short: "Print a worktree's environment as shell export lines",
+	Long: `Print WT_PATH, WT_BRANCH, WT_REPO and, when one is assigned, WT_PORT for a
+worktree as export lines, for hooks and scripts:
+
+  eval "$(wt env feature/auth)"
+
+Nothing else is written to stdout. No port is assigned here — use 'wt port'.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return envRun(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+}
+
+func envRun(branch string) error {
+	wtPath, err := gitClient.ResolveWorktree(repoRoot, branch)
+	if err != nil {
+		return err
+	}
+
+	repoName, err := gitClient.RepoName(repoRoot)
+	if err != nil {
+		return err
+	}
+
+	ws, err := stateMgr.GetWorktree(wtPath)
+	if err != nil {
+		return err
+	}
+	branchName := ""
+	if ws != nil {
+		branchName = ws.Branch
+	}
+	if branchName == "" {
+		if branchName, err = gitClient.CurrentBranch(wtPath); err != nil {
+			return err
+		}
+	}
+
+	vars := [][2]string{
+		{"WT_PATH", wtPath},
+		{"WT_BRANCH", branchName},
+		{"WT_REPO", repoName},
+	}
+	if ws != nil && ws.Port != 0 {
+		vars = append(vars, [2]string{"WT_PORT", strconv.Itoa(ws.Port)})
+	}
+	for _, v := range vars {
+		_, _ = fmt.Fprintf(output.Out, "export %s=%s\n", v[0], shellQuote(v[1]))
+	}
+	return nil
+}
+
+// shellQuote single-quotes s for POSIX shells when it contains anything
+// beyond a conservative set of safe characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-./:@+=,") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,24 +17,90 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/joescharf/wt/internal/ui"
+	"github.com/joescharf/wt/pkg/gitops"
+	"github.com/joescharf/wt/pkg/lifecycle"
+	"github.com/joescharf/wt/pkg/ops"
 	state "github.com/joescharf/wt/pkg/wtstate"
 )
 
+var (
+	listStaleWindows bool
+	listReopen       bool
+	listClear        bool
+	listFetch        bool
+	listJSON         bool
+	listOrphans      bool
+	listClean        bool
+	listLong         bool
+	listNumber       bool
+)
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "List worktrees with iTerm2 window status",
 	Args:    cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if listReopen && listClear {
+			return fmt.Errorf("--reopen and --clear cannot be used together")
+		}
+		if listClean && !listOrphans {
+			return fmt.Errorf("--clean requires --orphans")
+		}
+		if listOrphans {
+			if listJSON || listStaleWindows || listReopen || listClear {
+				return fmt.Errorf("--orphans cannot be used with --json, --stale-windows, --reopen, or --clear")
+			}
+			return listOrphansRun()
+		}
+		if listStaleWindows || listReopen || listClear {
+			if listJSON {
+				return fmt.Errorf("--json cannot be used with --stale-windows, --reopen, or --clear")
+			}
+			return listStaleRun()
+		}
 		return listRun()
 	},
 }
 
 func init() {
+	listCmd.Flags().BoolVar(&listStaleWindows, "stale-windows", false, "Only list worktrees whose recorded iTerm2 session no longer exists")
+	listCmd.Flags().BoolVar(&listReopen, "reopen", false, "Open new windows for stale worktrees (implies --stale-windows)")
+	listCmd.Flags().BoolVar(&listClear, "clear", false, "Clear stale session IDs from state (implies --stale-windows)")
+	listCmd.Flags().BoolVar(&listFetch, "fetch", false, "Fetch from origin first and show ahead/behind against origin/<base>")
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print worktrees as JSON to stdout")
+	listCmd.Flags().BoolVar(&listOrphans, "orphans", false, "List directories in the worktrees dir that git doesn't know as worktrees")
+	listCmd.Flags().BoolVar(&listClean, "clean", false, "With --orphans, remove the orphans that are empty or hold only a dead .git file")
+	listCmd.Flags().BoolVarP(&listLong, "long", "l", false, "Wide table with full path, repo, base, created and last-accessed times")
+	listCmd.Flags().BoolVar(&listNumber, "number", false, "Number the rows for 'wt switch <n>'")
 	rootCmd.AddCommand(listCmd)
 }
 
+// listEntry is one worktree row of 'wt list'; it is also the --json shape.
+type listEntry struct {
+	Branch       string    `json:"branch"`
+	Path         string    `json:"path"`
+	Source       string    `json:"source"`
+	Base         string    `json:"base"` // base ahead/behind is counted against: recorded by 'create --base', else config
+	WindowStatus string    `json:"window_status"`
+	Busy         bool      `json:"busy"` // open window with a session actively producing output
+	GitStatus    string    `json:"git_status"`
+	Stashes      int       `json:"stashes"` // stash entries made on this worktree's branch
+	CreatedAt    time.Time `json:"created_at,omitzero"`
+	AccessedAt   time.Time `json:"last_accessed_at,omitzero"`  // last time wt opened or focused its window
+	Duplicate    bool      `json:"duplicate_branch,omitempty"` // another worktree has the same branch checked out
+}
+
 func listRun() error {
+	if listJSON {
+		output.NoProgress = true
+	}
+
+	ageFromAccess, err := listAgeFromAccess()
+	if err != nil {
+		return err
+	}
+
 	repoName, err := gitClient.RepoName(repoRoot)
 	if err != nil {
 		return err
@@ -45,26 +115,116 @@ func listRun() error {
 		output.Info("Pruned %d stale state entries", pruned)
 	}
 
-	_, _ = fmt.Fprintf(output.Out, "Worktrees for %s\n\n", ui.Cyan(repoName))
+	baseBranch := viper.GetString("base_branch")
+	againstRemote := false
+	if listFetch {
+		againstRemote = listFetchRemote()
+	}
 
 	worktrees, err := gitClient.WorktreeList(repoRoot)
 	if err != nil {
 		return err
 	}
-
-	termWidth := ui.TermWidth()
-	baseBranch := viper.GetString("base_branch")
+	duplicate := map[string]bool{}
+	for _, d := range ops.WarnDuplicateBranches(opsLogger, worktrees) {
+		duplicate[d.Branch] = true
+	}
 
 	wtDir, err := gitClient.WorktreesDir(repoRoot)
 	if err != nil {
 		output.VerboseLog("Could not get worktrees dir: %v", err)
 	}
 
+	entries := []listEntry{}
+	for _, wt := range worktrees {
+		// Skip the main repo worktree
+		if wt.Path == repoRoot {
+			continue
+		}
+
+		ws, _ := stateMgr.GetWorktree(wt.Path)
+		base := baseBranch
+		if ws != nil && ws.BaseBranch != "" {
+			base = ws.BaseBranch
+		}
+		statusRef := base
+		if againstRemote {
+			statusRef = "origin/" + base
+		}
+		entry := listEntry{
+			Branch:       wt.Branch,
+			Path:         wt.Path,
+			Source:       worktreeSource(wt.Path, wtDir, ws),
+			Base:         base,
+			WindowStatus: worktreeWindowStatus(ws),
+			GitStatus:    worktreeGitStatus(wt.Path, wt.Branch, statusRef),
+			Duplicate:    duplicate[wt.Branch],
+		}
+		if entry.WindowStatus == "open" {
+			entry.Busy = sessionsBusy(ws)
+		}
+		if n, err := gitClient.StashCount(wt.Path); err != nil {
+			output.VerboseLog("Could not count stashes for %s: %v", wt.Branch, err)
+		} else {
+			entry.Stashes = n
+		}
+		if ws != nil && !ws.CreatedAt.IsZero() {
+			entry.CreatedAt = ws.CreatedAt.Time
+		}
+		if ws != nil && !ws.LastAccessedAt.IsZero() {
+			entry.AccessedAt = ws.LastAccessedAt.Time
+		}
+		entries = append(entries, entry)
+	}
+
+	// Remember the row order for 'wt switch <n>'
+	listed := make([]string, len(entries))
+	for i, e := range entries {
+		listed[i] = e.Path
+	}
+	if err := stateMgr.SetLastListed(repoRoot, listed); err != nil {
+		output.VerboseLog("Could not record list order: %v", err)
+	}
+
+	if listJSON {
+		enc := json.NewEncoder(output.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Repo      string      `json:"repo"`
+			Worktrees []listEntry `json:"worktrees"`
+		}{repoName, entries})
+	}
+
+	_, _ = fmt.Fprintf(output.Out, "Worktrees for %s\n\n", ui.Cyan(repoName))
+	if listLong {
+		printListLong(repoName, entries)
+		return nil
+	}
+	termWidth := ui.TermWidth()
+
+	// BASE is only shown when some worktree tracks a non-default base
+	showBase := false
+	for _, e := range entries {
+		if e.Base != baseBranch {
+			showBase = true
+			break
+		}
+	}
+
 	// Budget column widths based on terminal size.
 	// Table overhead: 7 border chars + 12 padding chars (1 each side × 6 cols) = 19
 	// Fixed columns: SOURCE(8) + WINDOW(6) + STATUS(15) + AGE(4) = 33
-	const tableOverhead = 19
-	const fixedCols = 33
+	// BASE, when shown, adds 1 border + 2 padding chars and up to 12 chars of name
+	const maxBase = 12
+	tableOverhead, fixedCols := 19, 33
+	if showBase {
+		tableOverhead += 3
+		fixedCols += maxBase
+	}
+	if listNumber {
+		tableOverhead += 3
+		fixedCols += len(strconv.Itoa(len(entries)))
+	}
 	available := termWidth - tableOverhead - fixedCols
 	if available < 20 {
 		available = 20
@@ -79,112 +239,407 @@ func listRun() error {
 	}
 
 	var rows [][]string
+	for i, e := range entries {
+		age := listAge(e, ageFromAccess)
+
+		window := e.WindowStatus
+		if e.Busy {
+			window = "busy"
+		}
+
+		var row []string
+		if listNumber {
+			row = append(row, strconv.Itoa(i+1))
+		}
+		row = append(row,
+			truncRight(e.Branch, maxBranch),
+			truncLeft(e.Path, maxPath),
+			ui.SourceColor(e.Source),
+		)
+		if showBase {
+			row = append(row, truncRight(e.Base, maxBase))
+		}
+		rows = append(rows, append(row,
+			ui.StatusColor(window),
+			gitStatusCell(e),
+			age,
+		))
+	}
+
+	if len(rows) == 0 {
+		output.Warning("No worktrees found")
+	} else {
+		table := newTable()
+		var header []string
+		if listNumber {
+			header = append(header, "#")
+		}
+		header = append(header, "BRANCH", "PATH", "SOURCE")
+		if showBase {
+			header = append(header, "BASE")
+		}
+		table.Header(append(header, "WINDOW", "STATUS", "AGE"))
+		_ = table.Bulk(rows)
+		_ = table.Render()
+	}
+	_, _ = fmt.Fprintln(output.Out)
+	return nil
+}
+
+// listAgeFromAccess reports whether config list.age_source asks for the AGE
+// column to count from last access rather than creation.
+func listAgeFromAccess() (bool, error) {
+	switch source := viper.GetString("list.age_source"); source {
+	case "accessed", "":
+		return true, nil
+	case "created":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid list.age_source %q: use 'accessed' or 'created'", source)
+	}
+}
+
+// listAge is the AGE cell for e: time since it was last accessed when
+// fromAccess is set and an access is recorded, else since it was created,
+// or "-" if neither is known.
+func listAge(e listEntry, fromAccess bool) string {
+	t := e.CreatedAt
+	if fromAccess && !e.AccessedAt.IsZero() {
+		t = e.AccessedAt
+	}
+	if t.IsZero() {
+		return "-"
+	}
+	return formatAge(time.Since(t))
+}
+
+// printListLong prints the --long table: every column untruncated, plus the
+// repo, base, and created and last-accessed times the compact table leaves out.
+func printListLong(repoName string, entries []listEntry) {
+	if len(entries) == 0 {
+		output.Warning("No worktrees found")
+		_, _ = fmt.Fprintln(output.Out)
+		return
+	}
+
+	var rows [][]string
+	for i, e := range entries {
+		window := e.WindowStatus
+		if e.Busy {
+			window = "busy"
+		}
+		var row []string
+		if listNumber {
+			row = append(row, strconv.Itoa(i+1))
+		}
+		rows = append(rows, append(row,
+			e.Branch,
+			e.Path,
+			repoName,
+			ui.SourceColor(e.Source),
+			e.Base,
+			ui.StatusColor(window),
+			gitStatusCell(e),
+			formatTimestamp(e.CreatedAt),
+			formatTimestamp(e.AccessedAt),
+		))
+	}
+
+	var header []string
+	if listNumber {
+		header = append(header, "#")
+	}
+	table := newTable()
+	table.Header(append(header, "BRANCH", "PATH", "REPO", "SOURCE", "BASE", "WINDOW", "STATUS", "CREATED", "LAST ACCESSED"))
+	_ = table.Bulk(rows)
+	_ = table.Render()
+	_, _ = fmt.Fprintln(output.Out)
+}
+
+// newTable returns a colorized, left-aligned table writing to output.Out.
+func newTable() *tablewriter.Table {
+	return tablewriter.NewTable(output.Out,
+		tablewriter.WithRenderer(renderer.NewColorized(renderer.ColorizedConfig{
+			Header: renderer.Tint{FG: renderer.Colors{color.FgHiBlue, color.Bold}},
+			Border: renderer.Tint{FG: renderer.Colors{color.FgHiBlack}},
+		})),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment:  tw.CellAlignment{Global: tw.AlignLeft},
+				Formatting: tw.CellFormatting{AutoFormat: tw.Off, AutoWrap: tw.WrapTruncate},
+			},
+			Row: tw.CellConfig{
+				Alignment:  tw.CellAlignment{Global: tw.AlignLeft},
+				Formatting: tw.CellFormatting{AutoWrap: tw.WrapTruncate},
+			},
+		}),
+		tablewriter.WithHeaderAutoFormat(tw.Off),
+	)
+}
+
+// worktreeGitStatus summarizes a worktree's git state for the STATUS column:
+// "clean", "?" when it can't be read, or any of rebasing, merging, dirty,
+// ↑ahead and ↓behind (against ref) joined by spaces.
+func worktreeGitStatus(wtPath, branch, ref string) string {
+	dirty, err := gitClient.IsWorktreeDirty(wtPath)
+	if err != nil {
+		output.VerboseLog("Could not check status for %s: %v", branch, err)
+		return "?"
+	}
+	ahead, aheadErr := gitClient.CommitsAhead(wtPath, ref)
+	if aheadErr != nil {
+		output.VerboseLog("Could not check ahead status for %s: %v", branch, aheadErr)
+	}
+	behind, behindErr := gitClient.CommitsBehind(wtPath, ref)
+	if behindErr != nil {
+		output.VerboseLog("Could not check behind status for %s: %v", branch, behindErr)
+	}
+
+	var parts []string
+	if rebasing, err := gitClient.IsRebaseInProgress(wtPath); err != nil {
+		output.VerboseLog("Could not check rebase status for %s: %v", branch, err)
+	} else if rebasing {
+		parts = append(parts, "rebasing")
+	}
+	if merging, err := gitClient.IsMergeInProgress(wtPath); err != nil {
+		output.VerboseLog("Could not check merge status for %s: %v", branch, err)
+	} else if merging {
+		parts = append(parts, "merging")
+	}
+	if dirty {
+		parts = append(parts, "dirty")
+	}
+	if ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", ahead))
+	}
+	if behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", behind))
+	}
+	if len(parts) == 0 {
+		return "clean"
+	}
+	return strings.Join(parts, " ")
+}
+
+// gitStatusCell renders the STATUS column: the git status plus ⚑N when the
+// worktree's branch has stashes, which are easy to forget about.
+func gitStatusCell(e listEntry) string {
+	cell := ui.GitStatusColor(e.GitStatus)
+	if e.Stashes > 0 {
+		cell += " " + ui.Yellow(fmt.Sprintf("⚑%d", e.Stashes))
+	}
+	return cell
+}
+
+// worktreeWindowStatus reports "open", "stale" (recorded session is gone),
+// or "closed" (no session recorded) for a worktree's state entry.
+func worktreeWindowStatus(ws *state.WorktreeState) string {
+	if ws == nil || ws.ClaudeSessionID == "" {
+		return "closed"
+	}
+	if itermClient.IsRunning() && itermClient.SessionExists(ws.ClaudeSessionID) {
+		return "open"
+	}
+	return "stale"
+}
+
+// sessionsBusy reports whether either pane of a worktree's window is
+// actively running something, so it isn't interrupted mid-task.
+func sessionsBusy(ws *state.WorktreeState) bool {
+	for _, id := range []string{ws.ClaudeSessionID, ws.ShellSessionID} {
+		if id != "" && itermClient.SessionBusy(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// listFetchRemote fetches once, when a remote exists, so ahead/behind reflect
+// the remote. It reports whether worktrees should be compared against
+// origin/<base> (after a successful fetch) rather than the local base branch.
+func listFetchRemote() bool {
+	hasRemote, err := gitClient.HasRemote(repoRoot)
+	if err != nil {
+		output.VerboseLog("Could not check for remote: %v", err)
+	}
+	if !hasRemote {
+		output.Info("No remote configured — showing status against the local base branch")
+		return false
+	}
+	if dryRun {
+		output.DryRunMsg("Would fetch from origin")
+		return false
+	}
+
+	spinner := output.StartSpinner("Fetching")
+	err = gitClient.Fetch(repoRoot)
+	spinner.Stop()
+	if err != nil {
+		output.Warning("Fetch failed: %v (showing status against the local base branch)", err)
+		return false
+	}
+	output.Info("Refreshed from origin — ahead/behind is against origin/<base>")
+	return true
+}
+
+// listStaleRun lists worktrees with stale windows and, with --reopen or
+// --clear, opens fresh windows for them or clears their session IDs.
+func listStaleRun() error {
+	worktrees, err := gitClient.WorktreeList(repoRoot)
+	if err != nil {
+		return err
+	}
+
+	stale := 0
 	for _, wt := range worktrees {
-		// Skip the main repo worktree
 		if wt.Path == repoRoot {
 			continue
 		}
-
-		// Check iTerm2 window status
-		windowStatus := "closed"
 		ws, _ := stateMgr.GetWorktree(wt.Path)
-		if ws != nil && ws.ClaudeSessionID != "" {
-			if itermClient.IsRunning() && itermClient.SessionExists(ws.ClaudeSessionID) {
-				windowStatus = "open"
-			} else {
-				windowStatus = "stale"
-			}
+		if worktreeWindowStatus(ws) != "stale" {
+			continue
 		}
+		stale++
 
-		// Check git status
-		gitStatus := "clean"
-		dirty, err := gitClient.IsWorktreeDirty(wt.Path)
-		if err != nil {
-			output.VerboseLog("Could not check status for %s: %v", wt.Branch, err)
-			gitStatus = "?"
-		} else {
-			ahead, aheadErr := gitClient.CommitsAhead(wt.Path, baseBranch)
-			if aheadErr != nil {
-				output.VerboseLog("Could not check ahead status for %s: %v", wt.Branch, aheadErr)
-			}
-			behind, behindErr := gitClient.CommitsBehind(wt.Path, baseBranch)
-			if behindErr != nil {
-				output.VerboseLog("Could not check behind status for %s: %v", wt.Branch, behindErr)
-			}
+		_, _ = fmt.Fprintf(output.Out, "%s  %s\n", ui.Cyan(wt.Branch), wt.Path)
 
-			var parts []string
-			if rebasing, err := gitClient.IsRebaseInProgress(wt.Path); err != nil {
-				output.VerboseLog("Could not check rebase status for %s: %v", wt.Branch, err)
-			} else if rebasing {
-				parts = append(parts, "rebasing")
-			}
-			if merging, err := gitClient.IsMergeInProgress(wt.Path); err != nil {
-				output.VerboseLog("Could not check merge status for %s: %v", wt.Branch, err)
-			} else if merging {
-				parts = append(parts, "merging")
-			}
-			if dirty {
-				parts = append(parts, "dirty")
-			}
-			if ahead > 0 {
-				parts = append(parts, fmt.Sprintf("↑%d", ahead))
+		switch {
+		case listReopen:
+			if _, err := lcMgr.Open(lifecycle.OpenOptions{
+				RepoPath:        repoRoot,
+				WtPath:          wt.Path,
+				Branch:          wt.Branch,
+				DefaultNoClaude: viper.GetBool("no_claude"),
+				Ports:           portRange(),
+				NoTrust:         !trustEnabled(),
+				Profile:         itermProfile(),
+				DryRun:          dryRun,
+			}); err != nil {
+				output.Warning("Failed to reopen '%s': %v", wt.Branch, err)
 			}
-			if behind > 0 {
-				parts = append(parts, fmt.Sprintf("↓%d", behind))
+		case listClear:
+			if dryRun {
+				output.DryRunMsg("Would clear stale session IDs for '%s'", wt.Branch)
+				continue
 			}
-			if len(parts) > 0 {
-				gitStatus = strings.Join(parts, " ")
+			ws.ClaudeSessionID = ""
+			ws.ShellSessionID = ""
+			if err := stateMgr.SetWorktree(wt.Path, ws); err != nil {
+				output.Warning("Failed to clear session for '%s': %v", wt.Branch, err)
 			}
 		}
+	}
 
-		// Calculate age
-		age := "-"
-		if ws != nil && !ws.CreatedAt.IsZero() {
-			age = formatAge(time.Since(ws.CreatedAt.Time))
-		}
+	switch {
+	case stale == 0:
+		output.Info("No stale windows")
+	case listClear && !dryRun:
+		output.Success("Cleared %d stale window(s)", stale)
+	case !listReopen && !listClear:
+		output.Info("%d stale window(s) — use --reopen or --clear", stale)
+	}
+	return nil
+}
 
-		// Determine source
-		source := worktreeSource(wt.Path, wtDir, ws)
+// orphanDir is a directory in the worktrees dir that isn't a registered
+// worktree. Removable orphans are empty or hold only a .git file left behind
+// by a botched removal; anything else may be someone's work.
+type orphanDir struct {
+	Path string
+	Kind string // "empty", "dead" (only a .git file), or "has files"
+}
 
-		displayBranch := truncRight(wt.Branch, maxBranch)
-		displayPath := truncLeft(wt.Path, maxPath)
+func (o orphanDir) removable() bool { return o.Kind != "has files" }
 
-		rows = append(rows, []string{
-			displayBranch,
-			displayPath,
-			ui.SourceColor(source),
-			ui.StatusColor(windowStatus),
-			ui.GitStatusColor(gitStatus),
-			age,
-		})
+// findOrphans returns the subdirectories of wtDir that aren't in worktrees.
+func findOrphans(wtDir string, worktrees []gitops.WorktreeInfo) ([]orphanDir, error) {
+	entries, err := os.ReadDir(wtDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	known := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		known[wt.Path] = true
 	}
 
-	if len(rows) == 0 {
-		output.Warning("No worktrees found")
-	} else {
-		table := tablewriter.NewTable(output.Out,
-			tablewriter.WithRenderer(renderer.NewColorized(renderer.ColorizedConfig{
-				Header: renderer.Tint{FG: renderer.Colors{color.FgHiBlue, color.Bold}},
-				Border: renderer.Tint{FG: renderer.Colors{color.FgHiBlack}},
-			})),
-			tablewriter.WithConfig(tablewriter.Config{
-				Header: tw.CellConfig{
-					Alignment:  tw.CellAlignment{Global: tw.AlignLeft},
-					Formatting: tw.CellFormatting{AutoFormat: tw.Off, AutoWrap: tw.WrapTruncate},
-				},
-				Row: tw.CellConfig{
-					Alignment:  tw.CellAlignment{Global: tw.AlignLeft},
-					Formatting: tw.CellFormatting{AutoWrap: tw.WrapTruncate},
-				},
-			}),
-			tablewriter.WithHeaderAutoFormat(tw.Off),
-		)
+	var orphans []orphanDir
+	for _, e := range entries {
+		path := filepath.Join(wtDir, e.Name())
+		if !e.IsDir() || known[path] {
+			continue
+		}
+		contents, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		kind := "has files"
+		switch {
+		case len(contents) == 0:
+			kind = "empty"
+		case len(contents) == 1 && contents[0].Name() == ".git" && !contents[0].IsDir():
+			kind = "dead"
+		}
+		orphans = append(orphans, orphanDir{Path: path, Kind: kind})
+	}
+	return orphans, nil
+}
 
-		table.Header("BRANCH", "PATH", "SOURCE", "WINDOW", "STATUS", "AGE")
-		_ = table.Bulk(rows)
-		_ = table.Render()
+// listOrphansRun lists orphaned worktree directories and, with --clean,
+// removes the removable ones.
+func listOrphansRun() error {
+	wtDir, err := gitClient.WorktreesDir(repoRoot)
+	if err != nil {
+		return err
+	}
+	worktrees, err := gitClient.WorktreeList(repoRoot)
+	if err != nil {
+		return err
+	}
+	orphans, err := findOrphans(wtDir, worktrees)
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		output.Info("No orphaned directories in %s", wtDir)
+		return nil
+	}
+
+	removable, removed := 0, 0
+	for _, o := range orphans {
+		kind := o.Kind
+		if o.removable() {
+			removable++
+		} else {
+			kind = ui.Yellow(kind)
+		}
+		_, _ = fmt.Fprintf(output.Out, "%s  %s\n", o.Path, kind)
+
+		if !listClean || !o.removable() {
+			continue
+		}
+		if dryRun {
+			output.DryRunMsg("Would remove %s", o.Path)
+			continue
+		}
+		if err := os.RemoveAll(o.Path); err != nil {
+			output.Warning("Failed to remove %s: %v", o.Path, err)
+			continue
+		}
+		removed++
+	}
+
+	switch {
+	case listClean && !dryRun:
+		output.Success("Removed %d orphaned director(ies)", removed)
+		if kept := len(orphans) - removable; kept > 0 {
+			output.Info("Left %d with files in place — check and remove them manually", kept)
+		}
+	case !listClean:
+		output.Info("%d orphaned director(ies), %d removable — use --clean to remove those", len(orphans), removable)
 	}
-	_, _ = fmt.Fprintln(output.Out)
 	return nil
 }
 
@@ -210,6 +665,14 @@ func truncLeft(s string, max int) string {
 	return "…" + s[len(s)-(max-1):]
 }
 
+// formatTimestamp formats t in local time for tables, or "-" if unset.
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
 func formatAge(d time.Duration) string {
 	switch {
 	case d < time.Hour:
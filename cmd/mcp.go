@@ -90,6 +90,7 @@ func mcpServeRun() error {
 
 	cfg := wmcp.Config{
 		BaseBranch: viper.GetString("base_branch"),
+		Version:    buildVersion,
 	}
 	srv := wmcp.NewServer(gc, itermClient, sm, cfg)
 	return srv.ServeStdio(context.Background())
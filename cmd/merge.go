@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -13,15 +15,28 @@ import (
 )
 
 var (
-	mergePR        bool
-	mergeNoCleanup bool
-	mergeBase      string
-	mergeTitle     string
-	mergeBody      string
-	mergeDraft     bool
-	mergeForce     bool
-	mergeRebase    bool
-	mergeMerge     bool
+	mergePR           bool
+	mergeNoCleanup    bool
+	mergeBase         string
+	mergeTitle        string
+	mergeBody         string
+	mergeBodyFile     string
+	mergeDraft        bool
+	mergeForce        bool
+	mergeRebase       bool
+	mergeMerge        bool
+	mergeSquash       bool
+	mergeNoFF         bool
+	mergeNoPull       bool
+	mergeNoGHCheck    bool
+	mergeContinue     bool
+	mergeThenCheckout string
+	mergeResetOnPush  bool
+	mergeStratOpts    []string
+	mergeIsolated     bool
+	mergeKeepWindow   bool
+	mergeAmendBase    bool
+	mergeSign         string
 )
 
 // ghPRCreateFunc is the function used to create a PR via gh CLI, replaceable in tests.
@@ -32,36 +47,202 @@ func defaultGHPRCreate(args []string) (string, error) {
 	return strings.TrimSpace(string(out)), err
 }
 
+// ghAuthCheckFunc verifies gh is installed and logged in before --pr pushes
+// anything, replaceable in tests.
+var ghAuthCheckFunc = defaultGHAuthCheck
+
+func defaultGHAuthCheck() error {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return fmt.Errorf("gh CLI not found — install it from https://cli.github.com (or pass --no-gh-check)")
+	}
+	if out, err := exec.Command("gh", "auth", "status").CombinedOutput(); err != nil {
+		return fmt.Errorf("gh is not authenticated — run 'gh auth login' (or pass --no-gh-check): %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 var mergeCmd = &cobra.Command{
-	Use:               "merge [branch]",
-	Aliases:           []string{"mg"},
-	Short:             "Merge worktree branch into base branch or create PR",
-	Args:              cobra.ExactArgs(1),
+	Use:     "merge [branch...]",
+	Aliases: []string{"mg"},
+	Short:   "Merge worktree branch into base branch or create PR",
+	Long: `Merge a worktree's branch into the base branch, or open a PR with --pr.
+
+Given several branches, merges each in turn into base, pulling base only
+before the first, and stops at the first one that fails.`,
+	Args:              cobra.MinimumNArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if mergeSquash && mergeRebase {
+			return fmt.Errorf("--squash and --rebase cannot be used together")
+		}
+		if mergeNoFF && (mergeRebase || mergeSquash) {
+			return fmt.Errorf("--no-ff cannot be used together with --rebase or --squash")
+		}
+		if mergeThenCheckout != "" && mergePR {
+			return fmt.Errorf("--then-checkout only applies to local merges, not --pr")
+		}
+		if mergeBody != "" && mergeBodyFile != "" {
+			return fmt.Errorf("--body and --body-file cannot be used together")
+		}
+		if mergeIsolated && mergePR {
+			return fmt.Errorf("--isolated only applies to local merges, not --pr")
+		}
+		if mergeAmendBase && mergePR {
+			return fmt.Errorf("--amend-base only applies to local merges, not --pr")
+		}
+		if mergeAmendBase && mergeNoFF {
+			return fmt.Errorf("--amend-base and --no-ff cannot be used together")
+		}
+		if mergeKeepWindow && mergeNoCleanup {
+			return fmt.Errorf("--keep-window only applies when the worktree is cleaned up, not with --no-cleanup/--keep")
+		}
+		if mergeIsolated && mergeContinue {
+			return fmt.Errorf("--isolated and --continue cannot be used together")
+		}
+		if mergeSign != "" && mergePR {
+			return fmt.Errorf("--gpg-sign only applies to local merges, not --pr")
+		}
+		if len(mergeStratOpts) > 0 && mergePR {
+			return fmt.Errorf("--strategy-option only applies to local merges, not --pr")
+		}
+		if len(args) > 1 && mergePR {
+			return fmt.Errorf("--pr takes a single branch; merging several branches is for local merges")
+		}
+		if len(args) > 1 && mergeContinue {
+			return fmt.Errorf("--continue takes a single branch — finish the interrupted merge, then merge the rest")
+		}
+		if err := validateStrategyOptions(mergeStratOpts); err != nil {
+			return err
+		}
+		if len(args) > 1 {
+			return mergeRunAll(args)
+		}
 		return mergeRun(args[0])
 	},
 }
 
 func init() {
 	mergeCmd.Flags().BoolVar(&mergePR, "pr", false, "Create PR instead of local merge")
-	mergeCmd.Flags().BoolVar(&mergeNoCleanup, "no-cleanup", false, "Keep worktree after merge")
+	mergeCmd.Flags().BoolVar(&mergeNoCleanup, "no-cleanup", false, "Keep the worktree, its branch, and its iTerm2 window after merge")
+	mergeCmd.Flags().BoolVar(&mergeNoCleanup, "keep", false, "Merge and push, but keep iterating in the worktree (same as --no-cleanup)")
+	mergeCmd.Flags().BoolVar(&mergeNoCleanup, "push-only", false, "Alias for --keep")
+	mergeCmd.Flags().BoolVar(&mergeKeepWindow, "keep-window", false, "Remove the worktree and branch but leave its iTerm2 window open")
 	mergeCmd.Flags().StringVar(&mergeBase, "base", "", "Target branch (default from config)")
 	mergeCmd.Flags().StringVar(&mergeTitle, "title", "", "PR title (--pr only)")
-	mergeCmd.Flags().StringVar(&mergeBody, "body", "", "PR body (--pr only, uses --fill if empty)")
+	mergeCmd.Flags().StringVar(&mergeBody, "body", "", "PR body (--pr only; default: the repo's PR template, else --fill)")
+	mergeCmd.Flags().StringVar(&mergeBodyFile, "body-file", "", "Read the PR body from this file (--pr only)")
 	mergeCmd.Flags().BoolVar(&mergeDraft, "draft", false, "Create draft PR (--pr only)")
+	mergeCmd.Flags().BoolVar(&mergeNoGHCheck, "no-gh-check", false, "Skip checking that gh is installed and logged in before pushing (--pr only)")
 	mergeCmd.Flags().BoolVar(&mergeForce, "force", false, "Skip safety checks")
 	mergeCmd.Flags().BoolVar(&mergeRebase, "rebase", false, "Use rebase-then-fast-forward instead of merge")
 	mergeCmd.Flags().BoolVar(&mergeMerge, "merge", false, "Use merge (overrides config rebase default)")
+	mergeCmd.Flags().BoolVar(&mergeContinue, "continue", false, "Only continue an in-progress merge/rebase (error if none)")
+	mergeCmd.Flags().BoolVar(&mergeSquash, "squash", false, "Squash the branch into a single commit on base")
+	mergeCmd.Flags().BoolVar(&mergeNoPull, "no-pull", false, "Don't pull the base branch first; merge against the local base (default from config merge.pull)")
+	mergeCmd.Flags().BoolVar(&mergeNoFF, "no-ff", false, "Always create a merge commit, even when a fast-forward is possible")
+	mergeCmd.Flags().BoolVar(&mergeAmendBase, "amend-base", false, "Cherry-pick a single-commit branch onto base instead of merging it")
+	mergeCmd.Flags().StringVar(&mergeThenCheckout, "then-checkout", "", "After a successful merge, check out this branch in the main repo")
+	mergeCmd.Flags().BoolVar(&mergeResetOnPush, "reset-on-push-failure", false, "If pushing the base branch fails, reset it to before the merge without asking")
+	mergeCmd.Flags().StringArrayVarP(&mergeStratOpts, "strategy-option", "X", nil, "Pass -X <opt> to git merge/rebase (e.g. ours, theirs, patience); repeatable")
+	mergeCmd.Flags().StringVar(&mergeSign, "gpg-sign", "", "GPG-sign the commits the merge creates, optionally with this key (default from config merge.sign)")
+	mergeCmd.Flags().Lookup("gpg-sign").NoOptDefVal = signDefaultKey
+	mergeCmd.Flags().BoolVar(&mergeIsolated, "isolated", false, "Merge in a temporary worktree on the base branch, leaving the main repo's checkout untouched")
 	_ = mergeCmd.RegisterFlagCompletionFunc("base", completeBranchNames)
+	_ = mergeCmd.RegisterFlagCompletionFunc("then-checkout", completeBranchNames)
 	rootCmd.AddCommand(mergeCmd)
 }
 
 func mergeRun(branch string) error {
+	unlock, err := lockRepo(repoRoot, "merge")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	result, err := mergeBranch(branch, mergeNoPull || !viper.GetBool("merge.pull"), true)
+	if err != nil {
+		return err
+	}
+
+	if result.PushFailed && mergeThenCheckout != "" {
+		output.Warning("Not checking out '%s' — the base branch isn't pushed", mergeThenCheckout)
+	} else if result.Success && mergeThenCheckout != "" {
+		if err := thenCheckout(mergeThenCheckout); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintln(output.ErrOut)
+	return nil
+}
+
+// mergeRunAll merges branches into base one after another, pulling base only
+// before the first. It stops at the first branch that fails or whose push
+// fails, leaving that branch's worktree as the single-branch merge would, and
+// says how to pick up from there.
+func mergeRunAll(branches []string) error {
+	unlock, err := lockRepo(repoRoot, "merge")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	noPull := mergeNoPull || !viper.GetBool("merge.pull")
+	var merged []string
+	for i, branch := range branches {
+		output.Info("Merging '%s' (%d of %d)", branch, i+1, len(branches))
+		result, err := mergeBranch(branch, noPull, i == 0)
+		// Base is current from here on; pulling again would only slow things down
+		noPull = true
+		if err == nil && result.PushFailed {
+			err = fmt.Errorf("'%s' merged but the base branch isn't pushed", branch)
+		}
+		if err != nil {
+			rest := branches[i+1:]
+			mergeSummary(merged, branch, rest)
+			if len(rest) > 0 {
+				output.Info("Once '%s' is sorted out (e.g. 'wt merge %s --continue'), run 'wt merge %s' for the rest", branch, branch, strings.Join(rest, " "))
+			}
+			return err
+		}
+		merged = append(merged, branch)
+		_, _ = fmt.Fprintln(output.ErrOut)
+	}
+
+	mergeSummary(merged, "", nil)
+	if mergeThenCheckout != "" {
+		if err := thenCheckout(mergeThenCheckout); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintln(output.ErrOut)
+	return nil
+}
+
+// mergeSummary reports the outcome of a multi-branch merge: the branches
+// merged, the one that failed (if any), and those never attempted.
+func mergeSummary(merged []string, failed string, skipped []string) {
+	if len(merged) > 0 {
+		output.Success("Merged %d branch(es): %s", len(merged), strings.Join(merged, ", "))
+	}
+	if failed != "" {
+		output.Error("Failed: %s", failed)
+	}
+	if len(skipped) > 0 {
+		output.Warning("Not merged: %s", strings.Join(skipped, ", "))
+	}
+}
+
+// mergeBranch merges (or opens a PR for) one worktree's branch, cleaning the
+// worktree up afterwards as configured. noPull skips pulling base first;
+// checkThen checks the --then-checkout branch exists, and that the main repo
+// is clean enough to switch to it, before merging.
+func mergeBranch(branch string, noPull, checkThen bool) (*ops.MergeResult, error) {
 	// Resolve worktree
 	wtPath, err := gitClient.ResolveWorktree(repoRoot, branch)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get branch name from state or fall back to input
@@ -71,9 +252,54 @@ func mergeRun(branch string) error {
 		branchName = ws.Branch
 	}
 
-	baseBranch := mergeBase
-	if baseBranch == "" {
-		baseBranch = viper.GetString("base_branch")
+	baseBranch, err := resolveBaseBranch(mergeBase, ws)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkThen && mergeThenCheckout != "" {
+		exists, err := gitClient.BranchExists(repoRoot, mergeThenCheckout)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("--then-checkout branch '%s' does not exist", mergeThenCheckout)
+		}
+		dirty, err := gitClient.IsWorktreeDirty(repoRoot)
+		if err != nil {
+			return nil, err
+		}
+		if dirty {
+			return nil, fmt.Errorf("the main repo has uncommitted changes, so --then-checkout couldn't switch to '%s' after the merge — commit or stash them first", mergeThenCheckout)
+		}
+	}
+
+	bodyFile := ""
+	if mergePR {
+		bodyFile, err = prBodyFile(wtPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Fail before pushing rather than leave a pushed branch without a PR
+	if mergePR && !mergeContinue && !mergeNoGHCheck {
+		if err := ghAuthCheckFunc(); err != nil {
+			if !dryRun {
+				return nil, err
+			}
+			output.Warning("%v", err)
+		}
+	}
+
+	sign, signKey, err := mergeSigning()
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := resolveStrategy(mergeRebase, mergeMerge || mergeNoFF)
+	if mergeSquash {
+		strategy = "squash"
 	}
 
 	// Build cleanup callback using lifecycle manager
@@ -84,32 +310,135 @@ func mergeRun(branch string) error {
 			Branch:       cleanupBranch,
 			Force:        true,
 			DeleteBranch: !mergePR, // delete branch for local merge, not for PR
+			NoTrust:      !trustEnabled(),
+			KeepWindow:   mergeKeepWindow,
 			DryRun:       dryRun,
+			// A squash-merged branch never looks merged to 'git branch -d';
+			// only force-delete it with --force or the user's consent. An
+			// isolated merge leaves HEAD off base, so 'git branch -d' balks
+			// even though the branch is merged, as it does for a branch whose
+			// one commit --amend-base cherry-picked onto base.
+			ConfirmForceBranch: func(b string) bool {
+				return mergeForce || ((mergeIsolated || mergeAmendBase) && strategy != "squash") || promptFunc(fmt.Sprintf("Branch '%s' isn't merged by git's reckoning (expected after --squash). Force-delete it?", b))
+			},
 		})
 	}
 
 	result, err := ops.Merge(gitClient, opsLogger, ops.MergeOptions{
-		RepoPath:  repoRoot,
-		BaseBranch: baseBranch,
-		Branch:    branchName,
-		WtPath:    wtPath,
-		Strategy:  resolveStrategy(mergeRebase, mergeMerge),
-		Force:     mergeForce,
-		DryRun:    dryRun,
-		CreatePR:  mergePR,
-		NoCleanup: mergeNoCleanup,
-		PRTitle:   mergeTitle,
-		PRBody:    mergeBody,
-		PRDraft:   mergeDraft,
+		RepoPath:        repoRoot,
+		BaseBranch:      baseBranch,
+		Branch:          branchName,
+		WtPath:          wtPath,
+		Strategy:        strategy,
+		NoFF:            mergeNoFF,
+		AmendBase:       mergeAmendBase,
+		StrategyOptions: mergeStratOpts,
+		Sign:            sign,
+		SignKey:         signKey,
+		NoPull:          noPull,
+		Continue:        mergeContinue,
+		Force:           mergeForce,
+		DryRun:          dryRun,
+		CreatePR:        mergePR,
+		NoCleanup:       mergeNoCleanup,
+		Isolated:        mergeIsolated,
+		PRTitle:         mergeTitle,
+		PRBody:          mergeBody,
+		PRBodyFile:      bodyFile,
+		PRDraft:         mergeDraft,
+		ConfirmResetBase: func(b string) bool {
+			return mergeResetOnPush || promptFunc(fmt.Sprintf("Pushing '%s' failed. Reset local '%s' to before the merge?", b, b))
+		},
 	}, cleanup, ghPRCreateFunc)
+	// Rebasing rewrote the branch; keep an undo point in case the worktree
+	// stays (a conflict or --no-cleanup). Cleanup already dropped its state.
+	if result != nil && result.PreRebaseHEAD != "" {
+		if err := stateMgr.SetPreSyncHEAD(wtPath, result.PreRebaseHEAD); err != nil {
+			output.Warning("Could not record undo point for '%s': %v", branchName, err)
+		}
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if result.PRCreated && result.PRURL != "" {
 		_, _ = fmt.Fprintln(output.Out, result.PRURL)
 	}
+	return result, nil
+}
+
+// signDefaultKey is the value --gpg-sign takes when passed without a key:
+// sign with git's configured user.signingkey.
+const signDefaultKey = "default"
+
+// mergeSigning resolves --gpg-sign and merge.sign into whether to sign and
+// with which key (empty for git's user.signingkey). Without an explicit key
+// it checks git has one, so a merge doesn't fail halfway through on gpg.
+// PRs never sign from config alone.
+func mergeSigning() (bool, string, error) {
+	key := ""
+	if mergeSign != "" && mergeSign != signDefaultKey {
+		key = mergeSign
+	}
+	if mergeSign == "" && (mergePR || !viper.GetBool("merge.sign")) {
+		return false, "", nil
+	}
+	if key == "" {
+		configured, err := gitClient.ConfigValue(repoRoot, "user.signingkey")
+		if err != nil {
+			return false, "", err
+		}
+		if configured == "" {
+			return false, "", fmt.Errorf("signing requested but git has no signing key — set 'git config user.signingkey <key>' or pass --gpg-sign=<key>")
+		}
+	}
+	return true, key, nil
+}
+
+// prTemplateNames are the PR template locations GitHub recognizes, relative
+// to the repository root.
+var prTemplateNames = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
 
-	_, _ = fmt.Fprintln(output.Out)
+// prBodyFile returns the file gh should read the PR body from: --body-file if
+// given (it must exist), else the branch's PR template when --body is empty.
+// An empty result means gh fills the body itself.
+func prBodyFile(wtPath string) (string, error) {
+	if mergeBodyFile != "" {
+		if _, err := os.Stat(mergeBodyFile); err != nil {
+			return "", fmt.Errorf("--body-file: %w", err)
+		}
+		return mergeBodyFile, nil
+	}
+	if mergeBody != "" {
+		return "", nil
+	}
+	for _, name := range prTemplateNames {
+		path := filepath.Join(wtPath, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			output.VerboseLog("Using PR template %s", name)
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// thenCheckout switches the main repo to branch once a merge has finished.
+func thenCheckout(branch string) error {
+	if dryRun {
+		output.DryRunMsg("Would check out '%s' in the main repo", branch)
+		return nil
+	}
+
+	if err := gitClient.Checkout(repoRoot, branch); err != nil {
+		return fmt.Errorf("merge succeeded, but checking out '%s' failed: %w", branch, err)
+	}
+	output.Success("Checked out '%s' in the main repo", branch)
 	return nil
 }
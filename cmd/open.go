@@ -1,37 +1,86 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/joescharf/wt/pkg/iterm"
 	"github.com/joescharf/wt/pkg/lifecycle"
 )
 
-var openNoClaude bool
+var (
+	openNoClaude    bool
+	openClaude      bool
+	openWait        bool
+	openName        string
+	openProfile     string
+	openAdoptWindow bool
+	openBackground  bool
+	openAll         bool
+	openCreate      bool
+)
+
+// openWaitInterval is how often --wait polls iTerm2, replaceable in tests.
+var openWaitInterval = time.Second
 
 var openCmd = &cobra.Command{
 	Use:               "open <branch>",
 	Short:             "Open or focus iTerm2 window for an existing worktree",
-	Args:              cobra.ExactArgs(1),
+	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return openRun(args[0])
+		if openClaude && openNoClaude {
+			return fmt.Errorf("--claude and --no-claude cannot be used together")
+		}
+		if openAll {
+			if len(args) > 0 {
+				return fmt.Errorf("--all opens every worktree; don't pass a branch")
+			}
+			if openWait || openName != "" {
+				return fmt.Errorf("--wait and --name apply to a single worktree, not --all")
+			}
+			return openAllRun()
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("branch name required (or use --all)")
+		}
+		return openRun(args[0], true)
 	},
 }
 
 func init() {
-	openCmd.Flags().BoolVar(&openNoClaude, "no-claude", false, "Don't auto-launch claude in top pane")
+	openCmd.Flags().BoolVar(&openNoClaude, "no-claude", false, "Don't auto-launch claude in top pane (remembered for later opens)")
+	openCmd.Flags().BoolVar(&openClaude, "claude", false, "Launch claude even if the worktree was created with --no-claude (remembered for later opens)")
+	openCmd.Flags().BoolVar(&openWait, "wait", false, "Block until the worktree's iTerm2 window is closed")
+	openCmd.Flags().StringVar(&openName, "name", "", "Custom iTerm2 window title (remembered for later reopens)")
+	openCmd.Flags().StringVar(&openProfile, "profile", "", "iTerm2 profile for a new window (default from config iterm.profile)")
+	openCmd.Flags().BoolVar(&openBackground, "background", false, "Create the iTerm2 window without bringing it to the front")
+	openCmd.Flags().BoolVar(&openAll, "all", false, "Open windows in the background for every managed worktree whose window isn't open")
+	openCmd.Flags().BoolVar(&openAdoptWindow, "adopt-window", false, "Adopt an open iTerm2 window whose cwd is in the worktree instead of creating one")
+	openCmd.Flags().BoolVar(&openCreate, "create", false, "Create the worktree without prompting if it doesn't exist")
 	rootCmd.AddCommand(openCmd)
 }
 
 // openRun is the core logic for opening/focusing an iTerm2 window.
-// Exported for reuse by create and root shorthand.
-func openRun(branch string) error {
+// Exported for reuse by create and root shorthand. If the worktree doesn't
+// exist, allowCreate lets it be created (with --create, or after a prompt);
+// otherwise that is an error.
+func openRun(branch string, allowCreate bool) error {
 	// Resolve worktree path
 	wtPath, err := gitClient.ResolveWorktree(repoRoot, branch)
 	if err != nil {
+		if !allowCreate {
+			return fmt.Errorf("%w (use 'wt create %s' to create it)", err, branch)
+		}
+		if openCreate {
+			return createRun(branch)
+		}
 		// Worktree not found — offer to create it
 		output.Warning("Worktree not found: %s", branch)
 		if promptDefaultYes(fmt.Sprintf("Create worktree '%s'?", branch)) {
@@ -40,15 +89,111 @@ func openRun(branch string) error {
 		return nil
 	}
 
-	noClaude := openNoClaude || viper.GetBool("no_claude")
-
-	_, err = lcMgr.Open(lifecycle.OpenOptions{
-		RepoPath: repoRoot,
-		WtPath:   wtPath,
-		Branch:   branch,
-		NoClaude: noClaude,
-		DryRun:   dryRun,
+	result, err := lcMgr.Open(lifecycle.OpenOptions{
+		RepoPath:        repoRoot,
+		WtPath:          wtPath,
+		Branch:          branch,
+		NoClaude:        openNoClaude,
+		DefaultNoClaude: viper.GetBool("no_claude"),
+		Claude:          openClaude,
+		Ports:           portRange(),
+		NoTrust:         !trustEnabled(),
+		Title:           openName,
+		Profile:         itermProfile(openProfile),
+		AdoptWindow:     openAdoptWindow,
+		NoFocus:         openBackground,
+		DryRun:          dryRun,
 	})
-	return err
+	if err != nil || !openWait {
+		return err
+	}
+
+	if dryRun {
+		output.DryRunMsg("Would wait for the iTerm2 window to close")
+		return nil
+	}
+	if result.SessionID == "" {
+		return nil // no window was opened (no terminal available)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	output.Info("Waiting for the iTerm2 window to close (Ctrl-C to stop waiting)")
+	if err := iterm.WaitForClose(ctx, itermClient, result.SessionID, openWaitInterval); err != nil {
+		return fmt.Errorf("stopped waiting for window: %w", err)
+	}
+	output.Success("Window closed")
+	return nil
 }
 
+// openAllRun opens a background window for every worktree recorded in state
+// whose window isn't open, then prints how many were opened.
+func openAllRun() error {
+	worktrees, err := gitClient.WorktreeList(repoRoot)
+	if err != nil {
+		return err
+	}
+
+	var opened, alreadyOpen, failed int
+	headless := false
+	for _, wt := range worktrees {
+		if wt.Path == repoRoot {
+			continue
+		}
+		ws, _ := stateMgr.GetWorktree(wt.Path)
+		if ws == nil {
+			continue // not managed by wt
+		}
+		if worktreeWindowStatus(ws) == "open" {
+			alreadyOpen++
+			continue
+		}
+
+		branch := wt.Branch
+		if ws.Branch != "" {
+			branch = ws.Branch
+		}
+		result, err := lcMgr.Open(lifecycle.OpenOptions{
+			RepoPath:        repoRoot,
+			WtPath:          wt.Path,
+			Branch:          branch,
+			NoClaude:        openNoClaude,
+			DefaultNoClaude: viper.GetBool("no_claude"),
+			Claude:          openClaude,
+			Ports:           portRange(),
+			NoTrust:         !trustEnabled(),
+			Profile:         itermProfile(openProfile),
+			AdoptWindow:     openAdoptWindow,
+			NoFocus:         true,
+			DryRun:          dryRun,
+		})
+		if err != nil {
+			output.Warning("Failed to open '%s': %v", branch, err)
+			failed++
+			continue
+		}
+		if result.SessionID == "" && !dryRun {
+			// No terminal available; the rest would be skipped the same way
+			headless = true
+			break
+		}
+		opened++
+	}
+
+	_, _ = fmt.Fprintln(output.ErrOut)
+	switch {
+	case dryRun:
+		output.DryRunMsg("Would open %d window(s), %d already open", opened, alreadyOpen)
+	case headless:
+		output.Warning("No terminal available — opened %d window(s), %d already open", opened, alreadyOpen)
+	case opened == 0 && failed == 0:
+		output.Info("No windows to open — %d already open", alreadyOpen)
+	default:
+		output.Success("Opened %d window(s), %d already open", opened, alreadyOpen)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to open %d window(s)", failed)
+	}
+	return nil
+}
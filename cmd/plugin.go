@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// pluginPrefix names external commands: 'wt foo' runs wt-foo from PATH when
+// foo isn't a built-in command.
+const pluginPrefix = "wt-"
+
+// findPlugin returns the wt-<name> executable on PATH that args (the command
+// line after 'wt') should run, if args[0] names no built-in command. Flags and
+// names with a '/' (branches like feature/auth) never match a plugin.
+func findPlugin(args []string) (string, bool) {
+	if len(args) == 0 {
+		return "", false
+	}
+	name := args[0]
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsRune(name, '/') {
+		return "", false
+	}
+
+	// help and completion are only added once Execute runs
+	rootCmd.InitDefaultHelpCmd()
+	rootCmd.InitDefaultCompletionCmd()
+	if c, _, err := rootCmd.Find(args[:1]); err == nil && c != rootCmd {
+		return "", false
+	}
+	if strings.HasPrefix(name, "__") { // cobra's hidden completion commands
+		return "", false
+	}
+
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// opensWorktree reports whether 'wt <name>' names an existing worktree of the
+// current repo. That shorthand wins over a wt-<name> plugin, so installing a
+// plugin never hides a worktree.
+func opensWorktree(name string) bool {
+	if repoRoot == "" {
+		return false
+	}
+	_, err := gitClient.ResolveWorktree(repoRoot, name)
+	return err == nil
+}
+
+// runPlugin runs the external command at path with args, passing the repo it
+// was run in as WT_REPO_ROOT and WT_WORKTREES_DIR (unset outside a repo).
+// A non-zero exit comes back as an *exec.ExitError.
+func runPlugin(path string, args []string) error {
+	c := exec.Command(path, args...)
+	c.Stdin = os.Stdin
+	c.Stdout = output.Out
+	c.Stderr = output.ErrOut
+	c.Env = os.Environ()
+	if repoRoot != "" {
+		c.Env = append(c.Env, "WT_REPO_ROOT="+repoRoot)
+		if dir, err := gitClient.WorktreesDir(repoRoot); err == nil {
+			c.Env = append(c.Env, "WT_WORKTREES_DIR="+dir)
+		} else {
+			output.VerboseLog("Could not resolve worktrees dir: %v", err)
+		}
+	}
+	output.VerboseLog("Running %s", path)
+	return c.Run()
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var portCmd = &cobra.Command{
+	Use:               "port <branch>",
+	Short:             "Print the port assigned to a worktree (WT_PORT)",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return portRun(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(portCmd)
+}
+
+func portRun(branch string) error {
+	wtPath, err := gitClient.ResolveWorktree(repoRoot, branch)
+	if err != nil {
+		return err
+	}
+
+	ws, err := stateMgr.GetWorktree(wtPath)
+	if err != nil {
+		return err
+	}
+	if ws != nil && ws.Port != 0 {
+		_, _ = fmt.Fprintln(output.Out, ws.Port)
+		return nil
+	}
+
+	ports := portRange()
+	if !ports.Enabled() {
+		return fmt.Errorf("no port assigned to '%s' (set port.enabled: true in config to assign ports)", branch)
+	}
+
+	if dryRun {
+		output.DryRunMsg("Would assign a port from %d-%d to '%s'", ports.Start, ports.End, branch)
+		return nil
+	}
+
+	port, err := stateMgr.AssignPort(wtPath, ports)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(output.Out, port)
+	return nil
+}
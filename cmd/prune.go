@@ -1,46 +1,217 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 
 	"github.com/spf13/cobra"
 
 	"github.com/joescharf/wt/pkg/ops"
+	state "github.com/joescharf/wt/pkg/wtstate"
+)
+
+var (
+	pruneWindows   bool
+	pruneJSON      bool
+	pruneStateOnly bool
+	pruneTrustOnly bool
 )
 
 var pruneCmd = &cobra.Command{
 	Use:   "prune",
 	Short: "Clean up stale state and git worktree tracking",
-	Args:  cobra.NoArgs,
+	Long: `Clean up stale state and git worktree tracking.
+
+--state-only and --trust-only run just that part: stale state entries, or
+stale Claude Code trust entries. Neither runs git worktree prune.
+
+--windows only handles worktrees removed outside wt (e.g. with
+'git worktree remove'): it closes their still-open iTerm2 windows and clears
+their state entries, without running git worktree prune.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if pruneJSON && pruneWindows {
+			return fmt.Errorf("--json cannot be used with --windows")
+		}
+		if pruneStateOnly && pruneTrustOnly {
+			return fmt.Errorf("--state-only and --trust-only cannot be used together")
+		}
+		if pruneWindows && (pruneStateOnly || pruneTrustOnly) {
+			return fmt.Errorf("--windows cannot be used with --state-only or --trust-only")
+		}
+		if pruneWindows {
+			return pruneWindowsRun()
+		}
 		return pruneRun()
 	},
 }
 
 func init() {
+	pruneCmd.Flags().BoolVar(&pruneWindows, "windows", false, "Only close windows and clear state for worktrees whose directory is gone")
+	pruneCmd.Flags().BoolVar(&pruneStateOnly, "state-only", false, "Only prune stale state entries (no trust entries, no git worktree prune)")
+	pruneCmd.Flags().BoolVar(&pruneTrustOnly, "trust-only", false, "Only prune stale Claude Code trust entries (no state, no git worktree prune)")
+	pruneCmd.Flags().BoolVar(&pruneJSON, "json", false, "Print what was (or, with --dry-run, would be) pruned as JSON to stdout")
 	rootCmd.AddCommand(pruneCmd)
 }
 
 func pruneRun() error {
-	// Build trust pruner (nil-safe)
+	// Build trust pruner (nil-safe, skipped when trust management is disabled)
 	var trustPrune ops.TrustPruner
-	if claudeTrust != nil {
-		trustPrune = func(dir string) (int, error) {
-			return claudeTrust.PruneProjects(dir)
+	if claudeTrust != nil && trustEnabled() {
+		trustPrune = claudeTrust.PruneProjects
+	}
+	statePrune := ops.StatePruner(stateMgr.PruneExcept)
+	switch {
+	case pruneTrustOnly:
+		if trustPrune == nil {
+			return fmt.Errorf("--trust-only: trust management is disabled (config trust.enabled)")
+		}
+		statePrune = nil
+	case pruneStateOnly:
+		trustPrune = nil
+	}
+	scoped := pruneStateOnly || pruneTrustOnly
+
+	ignore, err := ops.LoadIgnore(repoRoot)
+	if err != nil {
+		output.Warning("Could not read %s: %v", ops.IgnoreFile, err)
+	}
+
+	// Windows are looked up before prune drops the entries that name them
+	var before *state.State
+	if pruneJSON {
+		output.NoProgress = true
+		if before, err = stateMgr.Load(); err != nil {
+			return err
 		}
 	}
 
 	result, err := ops.Prune(gitClient, opsLogger, ops.PruneOptions{
 		RepoPath: repoRoot,
+		Ignore:   ignore,
+		NoGit:    scoped,
 		DryRun:   dryRun,
-	}, stateMgr.Prune, trustPrune)
+	}, statePrune, trustPrune)
 	if err != nil {
 		return err
 	}
+	if pruneJSON {
+		return printPruneJSON(result, before, !scoped)
+	}
 
 	totalPruned := result.StatePruned + result.TrustPruned
 	if totalPruned > 0 {
-		_, _ = fmt.Fprintln(output.Out)
+		_, _ = fmt.Fprintln(output.ErrOut)
+	}
+	return nil
+}
+
+// printPruneJSON writes the 'prune --json' report to stdout. before is the
+// state as it was ahead of the prune, used to find the pruned entries' windows;
+// gitPrune is whether git worktree prune was in scope.
+func printPruneJSON(result *ops.PruneResult, before *state.State, gitPrune bool) error {
+	report := struct {
+		DryRun   bool     `json:"dry_run,omitempty"`
+		State    []string `json:"state"`   // worktree paths whose state entries are pruned
+		Trust    []string `json:"trust"`   // project paths whose trust entries are pruned
+		Windows  []string `json:"windows"` // pruned worktrees whose iTerm2 window is still open ('prune --windows' closes them)
+		GitPrune bool     `json:"git_prune"`
+	}{
+		DryRun:   dryRun,
+		State:    append([]string{}, result.StatePaths...),
+		Trust:    append([]string{}, result.TrustPaths...),
+		Windows:  []string{},
+		GitPrune: (dryRun && gitPrune) || result.GitPruned,
+	}
+
+	// Only query sessions when iTerm2 is already running, never launch it
+	if len(result.StatePaths) > 0 && itermClient.IsRunning() {
+		for _, path := range result.StatePaths {
+			ws := before.Worktrees[path]
+			if ws == nil {
+				continue
+			}
+			for _, id := range []string{ws.ClaudeSessionID, ws.ShellSessionID} {
+				if id != "" && itermClient.SessionExists(id) {
+					report.Windows = append(report.Windows, path)
+					break // both panes share one window
+				}
+			}
+		}
+	}
+
+	enc := json.NewEncoder(output.Out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// pruneWindowsRun closes the iTerm2 windows of state entries whose worktree
+// directory no longer exists, then drops those entries. Paths matched by
+// .wtignore are left alone, as in a full prune.
+func pruneWindowsRun() error {
+	ignore, err := ops.LoadIgnore(repoRoot)
+	if err != nil {
+		output.Warning("Could not read %s: %v", ops.IgnoreFile, err)
+	}
+
+	st, err := stateMgr.Load()
+	if err != nil {
+		return err
+	}
+	var gone []string
+	for path := range st.Worktrees {
+		if ignore.Match(path) {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			gone = append(gone, path)
+		}
+	}
+	sort.Strings(gone)
+
+	if len(gone) == 0 {
+		output.Success("No state for removed worktrees")
+		return nil
+	}
+
+	// Only query sessions when iTerm2 is already running, never launch it
+	running := itermClient.IsRunning()
+	closed := 0
+	for _, path := range gone {
+		ws := st.Worktrees[path]
+		dirname := filepath.Base(path)
+
+		if running {
+			for _, id := range []string{ws.ClaudeSessionID, ws.ShellSessionID} {
+				if id == "" || !itermClient.SessionExists(id) {
+					continue
+				}
+				if dryRun {
+					output.DryRunMsg("Would close iTerm2 window for removed worktree '%s'", dirname)
+				} else if err := itermClient.CloseWindow(id); err != nil {
+					output.Warning("Failed to close window for '%s': %v", dirname, err)
+				} else {
+					output.Success("Closed iTerm2 window for removed worktree '%s'", dirname)
+				}
+				closed++
+				break // both panes share one window
+			}
+		}
+
+		if dryRun {
+			output.DryRunMsg("Would clear state for '%s'", path)
+			continue
+		}
+		if err := stateMgr.RemoveWorktree(path); err != nil {
+			output.Warning("Failed to clear state for '%s': %v", path, err)
+		}
+	}
+
+	if !dryRun {
+		output.Success("Closed %d window(s), cleared %d state entries", closed, len(gone))
 	}
 	return nil
 }
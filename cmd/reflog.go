@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joescharf/wt/pkg/ops"
+)
+
+var reflogJSON bool
+
+var reflogCmd = &cobra.Command{
+	Use:   "reflog <branch>",
+	Short: "Show a worktree branch's reflog, for recovering lost commits",
+	Long: `Show where a branch has pointed, newest first: one line per reflog entry
+with the commit, the selector and what moved the branch (commit, reset,
+rebase, merge, ...).
+
+After a sync, merge or reset went wrong, find the commit from before it and
+recover it with e.g. 'git -C <worktree> reset --hard <sha>'.
+
+The branch can be given by branch name or worktree dirname. A detached
+worktree shows its HEAD reflog. A name that isn't a worktree is passed to git
+as a ref, so branches without a worktree work too.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reflogRun(args[0])
+	},
+}
+
+func init() {
+	reflogCmd.Flags().BoolVar(&reflogJSON, "json", false, "Print entries as JSON to stdout")
+	rootCmd.AddCommand(reflogCmd)
+}
+
+// reflogEntry is the --json shape of one gitops.ReflogEntry.
+type reflogEntry struct {
+	SHA      string `json:"sha"`
+	Selector string `json:"selector"`
+	Action   string `json:"action"`
+	Message  string `json:"message"`
+}
+
+func reflogRun(name string) error {
+	path, ref := reflogTarget(name)
+	entries, err := gitClient.Reflog(path, ref)
+	if err != nil {
+		return err
+	}
+
+	if reflogJSON {
+		out := make([]reflogEntry, 0, len(entries))
+		for _, e := range entries {
+			out = append(out, reflogEntry{SHA: e.SHA, Selector: e.Selector, Action: e.Action, Message: e.Message})
+		}
+		enc := json.NewEncoder(output.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if len(entries) == 0 {
+		output.Info("No reflog entries for '%s'", ref)
+		return nil
+	}
+	for _, e := range entries {
+		subject := e.Message
+		if e.Action != "" {
+			subject = e.Action + ": " + e.Message
+		}
+		_, _ = fmt.Fprintf(output.Out, "%s %s %s\n", ops.ShortSHA(e.SHA), e.Selector, subject)
+	}
+	return nil
+}
+
+// reflogTarget returns where to run git reflog and which ref to show for
+// name: the branch checked out in the worktree name resolves to (HEAD if it
+// is detached), or name itself in the main repo if no worktree matches.
+func reflogTarget(name string) (path, ref string) {
+	wtPath, err := gitClient.ResolveWorktree(repoRoot, name)
+	if err != nil {
+		return repoRoot, name
+	}
+	branch, err := gitClient.CurrentBranch(wtPath)
+	if err != nil || branch == "" {
+		return repoRoot, name
+	}
+	return wtPath, branch
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joescharf/wt/pkg/ops"
+)
+
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Detect and fix worktrees with broken gitdir links",
+	Long: `Check each worktree's .git file and, if any point to a gitdir that no
+longer exists (e.g. after moving the main repo), run 'git worktree repair'.
+
+It also warns about branches checked out in more than one worktree, which
+commands that take a branch can't resolve reliably; those are left to you.
+
+Use -n to only report broken worktrees.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return repairRun()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(repairCmd)
+}
+
+func repairRun() error {
+	_, err := ops.Repair(gitClient, opsLogger, ops.RepairOptions{
+		RepoPath: repoRoot,
+		DryRun:   dryRun,
+	})
+	return err
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/joescharf/wt/internal/ui"
+	"github.com/joescharf/wt/pkg/gitops"
+	"github.com/joescharf/wt/pkg/lifecycle"
+)
+
+var (
+	restoreNoClaude bool
+	restoreNoWindow bool
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <branch>",
+	Short: "Recreate a deleted worktree from its existing branch",
+	Long: `Recreate the worktree for a branch that still exists (e.g. after
+'wt delete' without --branch) and reopen its iTerm2 window.
+
+Unlike create, restore never makes a new branch: it errors if the branch
+doesn't exist. The short dirname form also works (auth for feature/auth).`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBranchNames,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return restoreRun(args[0])
+	},
+}
+
+func init() {
+	restoreCmd.Flags().BoolVar(&restoreNoClaude, "no-claude", false, "Don't auto-launch claude in top pane")
+	restoreCmd.Flags().BoolVar(&restoreNoWindow, "no-window", false, "Restore the worktree without opening an iTerm2 window")
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func restoreRun(input string) error {
+	branch, err := resolveRestoreBranch(input)
+	if err != nil {
+		return err
+	}
+
+	result, err := lcMgr.Create(lifecycle.CreateOptions{
+		RepoPath:        repoRoot,
+		Branch:          branch,
+		NoClaude:        restoreNoClaude,
+		DefaultNoClaude: viper.GetBool("no_claude"),
+		NoWindow:        restoreNoWindow,
+		Existing:        true,
+		Ports:           portRange(),
+		NoTrust:         !trustEnabled(),
+		Profile:         itermProfile(),
+		DryRun:          dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.Created {
+		_, _ = fmt.Fprintln(output.ErrOut)
+		output.Success("Worktree restored: %s", ui.Cyan(result.WtPath))
+	}
+	return nil
+}
+
+// resolveRestoreBranch maps input to an existing local branch, matching either
+// the full branch name or its dirname (last path component).
+func resolveRestoreBranch(input string) (string, error) {
+	exists, err := gitClient.BranchExists(repoRoot, input)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return input, nil
+	}
+
+	branches, err := gitClient.BranchList(repoRoot)
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, b := range branches {
+		if gitops.BranchToDirname(b) == input {
+			matches = append(matches, b)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("branch '%s' does not exist (use 'wt create %s' to start a new one)", input, input)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("'%s' matches several branches %v; use the full branch name", input, matches)
+	}
+}
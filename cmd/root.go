@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -27,8 +31,9 @@ var (
 	opsLogger   ops.Logger
 	lcMgr       *lifecycle.Manager
 
-	verbose  bool
+	verbose  int // -v per level; -vv traces git commands
 	dryRun   bool
+	noLock   bool   // skip the repo lock taken by merge, sync, and delete
 	repoRoot string // resolved once from CWD at startup
 )
 
@@ -40,13 +45,20 @@ func (l *uiLogger) Success(format string, args ...interface{}) { l.u.Success(for
 func (l *uiLogger) Warning(format string, args ...interface{}) { l.u.Warning(format, args...) }
 func (l *uiLogger) Verbose(format string, args ...interface{}) { l.u.VerboseLog(format, args...) }
 
+// Begin implements ops.StepLogger with a spinner that clears when the step ends.
+func (l *uiLogger) Begin(step string) func() { return l.u.StartSpinner(step).Stop }
+
 var rootCmd = &cobra.Command{
 	Use:   "wt",
 	Short: "Git worktree manager with iTerm2 integration",
 	Long: `wt manages git worktrees with dedicated iTerm2 windows.
 Each worktree gets a window with Claude on top and a shell on bottom.
 
-Shorthand: wt <branch>   (same as: wt open <branch>)`,
+Shorthand: wt <branch>   (opens or focuses an existing worktree; use
+'wt create' for new ones, or set shorthand_create to be offered one)
+
+External commands: wt <name> runs wt-<name> from PATH when <name> isn't a
+built-in command, with WT_REPO_ROOT and WT_WORKTREES_DIR set.`,
 	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	SilenceUsage:      true,
@@ -54,7 +66,8 @@ Shorthand: wt <branch>   (same as: wt open <branch>)`,
 	DisableAutoGenTag: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 1 {
-			return openRun(args[0])
+			// A typo'd branch shouldn't quietly become a new worktree
+			return openRun(args[0], viper.GetBool("shorthand_create"))
 		}
 		return listRun()
 	},
@@ -67,6 +80,24 @@ func Execute(version, commit, date string) {
 	buildCommit = commit
 	buildDate = date
 
+	// Like git, an unknown 'wt foo' runs wt-foo from PATH
+	if path, ok := findPlugin(os.Args[1:]); ok {
+		initConfig()
+		initDeps()
+		if !opensWorktree(os.Args[1]) {
+			err := runPlugin(path, os.Args[2:])
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -76,8 +107,9 @@ func Execute(version, commit, date string) {
 func init() {
 	cobra.OnInitialize(initConfig, initDeps)
 
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	rootCmd.PersistentFlags().CountVarP(&verbose, "verbose", "v", "Verbose output (-vv also logs git commands and their durations)")
 	rootCmd.PersistentFlags().BoolVarP(&dryRun, "dry-run", "n", false, "Show what would happen without making changes")
+	rootCmd.PersistentFlags().BoolVar(&noLock, "no-lock", false, "Don't take the repo lock that keeps merge, sync, and delete from running at once")
 }
 
 func initConfig() {
@@ -93,6 +125,7 @@ func initConfig() {
 	viper.SetConfigType("yaml")
 
 	viper.SetEnvPrefix("WT")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // create.fetch_base → WT_CREATE_FETCH_BASE
 	viper.AutomaticEnv()
 
 	// Defaults via viper.SetDefault()
@@ -100,6 +133,23 @@ func initConfig() {
 	viper.SetDefault("base_branch", "main")
 	viper.SetDefault("no_claude", false)
 	viper.SetDefault("rebase", false)
+	viper.SetDefault("branch_pattern", "")
+	viper.SetDefault("shorthand_create", false)
+	viper.SetDefault("create.fetch_base", false)
+	viper.SetDefault("create.git_config", []string{})
+	viper.SetDefault("create.desc_prefix", "")
+	viper.SetDefault("merge.pull", true)
+	viper.SetDefault("merge.sign", false)
+	viper.SetDefault("list.age_source", "accessed")
+	viper.SetDefault("port.enabled", false)
+	viper.SetDefault("port.start", 4000)
+	viper.SetDefault("port.end", 4999)
+	viper.SetDefault("trust.enabled", true)
+	viper.SetDefault("iterm.max_concurrent", 1)
+	viper.SetDefault("iterm.spawn_delay", 200*time.Millisecond)
+	viper.SetDefault("iterm.profile", "")
+	viper.SetDefault("claude_config_path", "")
+	_ = viper.BindEnv("claude_config_path", "WT_CLAUDE_CONFIG", "WT_CLAUDE_CONFIG_PATH")
 
 	// Read config file if it exists (optional)
 	_ = viper.ReadInConfig()
@@ -107,18 +157,21 @@ func initConfig() {
 
 func initDeps() {
 	output = ui.New()
-	output.Verbose = verbose
+	output.Verbosity = verbose
 	output.DryRun = dryRun
 
 	stateDir := viper.GetString("state_dir")
 	statePath := filepath.Join(stateDir, "state.json")
 	stateMgr = state.NewManager(statePath)
 
-	gitClient = gitops.NewClient()
-	itermClient = iterm.NewClient()
+	gitClient = newGitClient()
+	// Batch opens (open --all, the MCP server) would otherwise fire osascript window spawns back to back
+	itermClient = iterm.NewThrottledClient(iterm.NewClient(), viper.GetInt("iterm.max_concurrent"), viper.GetDuration("iterm.spawn_delay"))
 
-	if claudePath, err := claude.DefaultPath(); err == nil {
+	if claudePath, err := claudeConfigPath(); err == nil {
 		claudeTrust = claude.NewTrustManager(claudePath)
+	} else if viper.GetString("claude_config_path") != "" {
+		output.Warning("Claude trust disabled: %v", err)
 	}
 
 	// Resolve repo root from CWD once at startup
@@ -130,6 +183,157 @@ func initDeps() {
 	lcMgr = lifecycle.NewManager(gitClient, itermClient, stateMgr, claudeTrust, opsLogger)
 }
 
+// newGitClient returns the git client, tracing each git command line and its
+// duration at -vv.
+func newGitClient() *gitops.RealClient {
+	c := gitops.NewClient()
+	if output.Verbosity >= 2 {
+		c.Runner = gitops.TracingRunner(gitops.ExecRunner, func(cmdline string, elapsed time.Duration, err error) {
+			if err != nil {
+				output.Trace("%s (%s, %v)", cmdline, elapsed.Round(time.Millisecond), err)
+				return
+			}
+			output.Trace("%s (%s)", cmdline, elapsed.Round(time.Millisecond))
+		})
+	}
+	return c
+}
+
+// claudeConfigPath returns the Claude Code config file to manage trust in:
+// claude_config_path (WT_CLAUDE_CONFIG) if set, otherwise ~/.claude.json.
+// A custom path's parent directory must exist; whether it's writable is only
+// found out when trust is first written there.
+func claudeConfigPath() (string, error) {
+	path := viper.GetString("claude_config_path")
+	if path == "" {
+		return claude.DefaultPath()
+	}
+
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Dir(path)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("claude_config_path directory %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("claude_config_path directory %s is not a directory", dir)
+	}
+	return path, nil
+}
+
+// trustEnabled reports whether wt may pre-approve and clean up Claude Code
+// trust entries in ~/.claude.json (config trust.enabled).
+func trustEnabled() bool {
+	return viper.GetBool("trust.enabled")
+}
+
+// itermProfile returns the first non-empty --profile flag value, falling
+// back to config iterm.profile (empty means iTerm2's default profile).
+func itermProfile(flags ...string) string {
+	for _, f := range flags {
+		if f != "" {
+			return f
+		}
+	}
+	return viper.GetString("iterm.profile")
+}
+
+// lockRepo takes the repo-level lock for a destructive operation (op names it,
+// e.g. "merge") and returns the function that releases it. Dry runs and
+// --no-lock skip it; a repo whose git dir can't be found runs unlocked.
+func lockRepo(repoPath, op string) (func(), error) {
+	if dryRun || noLock {
+		return func() {}, nil
+	}
+	lock, err := gitops.LockRepo(repoPath, "wt "+op)
+	if errors.Is(err, gitops.ErrRepoLocked) {
+		return nil, fmt.Errorf("%w; wait for it to finish or use --no-lock", err)
+	}
+	if err != nil {
+		output.VerboseLog("Running without repo lock: %v", err)
+		return func() {}, nil
+	}
+	return func() {
+		if err := lock.Unlock(); err != nil {
+			output.VerboseLog("Could not release repo lock: %v", err)
+		}
+	}, nil
+}
+
+// portRange returns the configured per-worktree port range, or the zero
+// range when port assignment is disabled.
+func portRange() state.PortRange {
+	if !viper.GetBool("port.enabled") {
+		return state.PortRange{}
+	}
+	return state.PortRange{Start: viper.GetInt("port.start"), End: viper.GetInt("port.end")}
+}
+
+// checkRef returns a clean "unknown ref" error when a user-supplied ref (e.g.
+// --base) doesn't resolve to a commit, rather than letting git fail later.
+func checkRef(ref string) error {
+	ok, err := gitClient.RefExists(repoRoot, ref)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("unknown ref '%s' (not a branch, tag, or commit)", ref)
+	}
+	return nil
+}
+
+// checkBaseBranch verifies a configured base branch exists locally or, when a
+// remote is present, as origin/<base>, so a misconfigured base_branch fails up
+// front with the branches to choose from rather than deep inside git.
+func checkBaseBranch(base string) error {
+	exists, err := gitClient.BranchExists(repoRoot, base)
+	if err != nil || exists {
+		return err
+	}
+	if hasRemote, _ := gitClient.HasRemote(repoRoot); hasRemote {
+		if ok, err := gitClient.RefExists(repoRoot, "origin/"+base); err == nil && ok {
+			return nil
+		}
+	}
+
+	msg := fmt.Sprintf("base branch '%s' does not exist (check base_branch in 'wt config show', or pass --base)", base)
+	if branches, err := gitClient.BranchList(repoRoot); err == nil && len(branches) > 0 {
+		msg += "; available branches: " + strings.Join(branches, ", ")
+	}
+	return errors.New(msg)
+}
+
+// resolveBaseBranch picks the base a worktree syncs with or merges into: the
+// --base flag wins, then the base recorded by 'wt create --base', then config.
+func resolveBaseBranch(flag string, ws *state.WorktreeState) (string, error) {
+	if flag != "" {
+		return flag, checkRef(flag)
+	}
+	if ws != nil && ws.BaseBranch != "" {
+		ok, err := gitClient.RefExists(repoRoot, ws.BaseBranch)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", fmt.Errorf("recorded base branch '%s' for this worktree no longer exists (pass --base)", ws.BaseBranch)
+		}
+		return ws.BaseBranch, nil
+	}
+	base := viper.GetString("base_branch")
+	return base, checkBaseBranch(base)
+}
+
 // resolveStrategy determines the merge strategy based on flags and config.
 // --rebase flag wins, then --merge flag wins, then config, then default "merge".
 func resolveStrategy(rebaseFlag, mergeFlag bool) string {
@@ -144,3 +348,13 @@ func resolveStrategy(rebaseFlag, mergeFlag bool) string {
 	}
 	return "merge"
 }
+
+// validateStrategyOptions checks every --strategy-option value.
+func validateStrategyOptions(opts []string) error {
+	for _, o := range opts {
+		if err := gitops.ValidateStrategyOption(o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
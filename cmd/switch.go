@@ -2,34 +2,218 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/joescharf/wt/internal/ui"
+	"github.com/joescharf/wt/pkg/gitops"
+	"github.com/joescharf/wt/pkg/iterm"
+	"github.com/joescharf/wt/pkg/lifecycle"
 )
 
+var (
+	switchNext        bool
+	switchPrev        bool
+	switchOpenIfStale bool
+)
+
+// currentDirFunc returns the working directory used to find the current
+// worktree for --next/--prev, replaceable in tests.
+var currentDirFunc = os.Getwd
+
 var switchCmd = &cobra.Command{
-	Use:               "switch <branch>",
-	Aliases:           []string{"go"},
-	Short:             "Focus existing worktree's iTerm2 window",
-	Args:              cobra.ExactArgs(1),
+	Use:     "switch [branch|n]",
+	Aliases: []string{"go"},
+	Short:   "Focus existing worktree's iTerm2 window",
+	Long: `Focus the iTerm2 window of an existing worktree.
+
+A number n picks the nth row of the last 'wt list' for this repo (see
+'wt list --number'). If the repo hasn't been listed yet, the number is taken as
+a branch name.
+
+--next and --prev cycle through the repo's worktrees in branch order, starting
+from the current one (the worktree containing the working directory, or else
+the one whose iTerm2 session wt is running in), and wrap around at the ends.
+
+If the recorded window is gone (e.g. iTerm2 was restarted), switch offers to
+open a new one; --open-if-stale opens it without asking.`,
+	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if switchNext && switchPrev {
+			return fmt.Errorf("--next and --prev cannot be used together")
+		}
+		if switchNext || switchPrev {
+			if len(args) > 0 {
+				return fmt.Errorf("a branch cannot be combined with --next or --prev")
+			}
+			step := 1
+			if switchPrev {
+				step = -1
+			}
+			return switchCycleRun(step)
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("a branch is required (or use --next/--prev)")
+		}
 		return switchRun(args[0])
 	},
 }
 
 func init() {
+	switchCmd.Flags().BoolVar(&switchNext, "next", false, "Focus the next worktree after the current one")
+	switchCmd.Flags().BoolVar(&switchPrev, "prev", false, "Focus the previous worktree before the current one")
+	switchCmd.Flags().BoolVar(&switchOpenIfStale, "open-if-stale", false, "Open a new window without asking if the recorded one is gone")
 	rootCmd.AddCommand(switchCmd)
 }
 
 func switchRun(branch string) error {
-	wtPath, err := gitClient.ResolveWorktree(repoRoot, branch)
+	wtPath, err := listedWorktree(branch)
+	if err != nil {
+		return err
+	}
+	if wtPath != "" {
+		return focusWorktree(wtPath, filepath.Base(wtPath))
+	}
+
+	wtPath, err = gitClient.ResolveWorktree(repoRoot, branch)
 	if err != nil {
 		return err
 	}
+	return focusWorktree(wtPath, branch)
+}
 
+// listedWorktree returns the worktree a numeric arg picks from the last
+// 'wt list' of this repo. It returns "" when arg isn't a number or the repo
+// has no recorded listing, so arg can be resolved as a branch instead.
+func listedWorktree(arg string) (string, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		return "", nil
+	}
+	paths, err := stateMgr.LastListed(repoRoot)
+	if err != nil || len(paths) == 0 {
+		return "", nil
+	}
+	if n > len(paths) {
+		return "", fmt.Errorf("no worktree #%d in the last 'wt list' (it listed %d)", n, len(paths))
+	}
+
+	wtPath := paths[n-1]
+	if _, err := os.Stat(wtPath); err != nil {
+		return "", fmt.Errorf("worktree #%d (%s) no longer exists; run 'wt list' again", n, filepath.Base(wtPath))
+	}
+	return wtPath, nil
+}
+
+// switchCycleRun focuses the worktree step places after the current one in
+// branch order, wrapping around at the ends.
+func switchCycleRun(step int) error {
+	worktrees, err := cycleWorktrees()
+	if err != nil {
+		return err
+	}
+	if len(worktrees) == 0 {
+		return fmt.Errorf("no worktrees to switch to")
+	}
+
+	target := adjacentWorktree(worktrees, currentWorktreePath(worktrees), step)
+	name := target.Branch
+	if name == "" {
+		name = filepath.Base(target.Path)
+	}
+	return focusWorktree(target.Path, name)
+}
+
+// cycleWorktrees returns the repo's worktrees, excluding the main repo, sorted
+// by branch (then path) so --next/--prev visit them in a stable order.
+func cycleWorktrees() ([]gitops.WorktreeInfo, error) {
+	all, err := gitClient.WorktreeList(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var worktrees []gitops.WorktreeInfo
+	for _, wt := range all {
+		if wt.Path != repoRoot {
+			worktrees = append(worktrees, wt)
+		}
+	}
+	sort.SliceStable(worktrees, func(i, j int) bool {
+		if worktrees[i].Branch != worktrees[j].Branch {
+			return worktrees[i].Branch < worktrees[j].Branch
+		}
+		return worktrees[i].Path < worktrees[j].Path
+	})
+	return worktrees, nil
+}
+
+// currentWorktreePath returns the worktree containing the working directory
+// or, failing that, the one whose iTerm2 session ($ITERM_SESSION_ID) wt runs
+// in. It returns "" when neither identifies a worktree.
+func currentWorktreePath(worktrees []gitops.WorktreeInfo) string {
+	if cwd, err := currentDirFunc(); err == nil {
+		best := ""
+		for _, wt := range worktrees {
+			if pathWithin(cwd, wt.Path) && len(wt.Path) > len(best) {
+				best = wt.Path
+			}
+		}
+		if best != "" {
+			return best
+		}
+	}
+
+	// ITERM_SESSION_ID looks like "w0t1p0:<unique id>"
+	_, sessionID, ok := strings.Cut(os.Getenv("ITERM_SESSION_ID"), ":")
+	if !ok || sessionID == "" {
+		return ""
+	}
+	for _, wt := range worktrees {
+		ws, err := stateMgr.GetWorktree(wt.Path)
+		if err == nil && ws != nil && (ws.ClaudeSessionID == sessionID || ws.ShellSessionID == sessionID) {
+			return wt.Path
+		}
+	}
+	return ""
+}
+
+// pathWithin reports whether path is dir or inside it.
+func pathWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// adjacentWorktree returns the worktree step places from current, wrapping
+// around. When current isn't among worktrees, --next starts at the first and
+// --prev at the last.
+func adjacentWorktree(worktrees []gitops.WorktreeInfo, current string, step int) gitops.WorktreeInfo {
+	n := len(worktrees)
+	idx := -1
+	for i, wt := range worktrees {
+		if wt.Path == current {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		if step > 0 {
+			return worktrees[0]
+		}
+		return worktrees[n-1]
+	}
+	return worktrees[((idx+step)%n+n)%n]
+}
+
+// focusWorktree focuses the recorded iTerm2 window of the worktree at wtPath;
+// name is how the user refers to it in hints.
+func focusWorktree(wtPath, name string) error {
 	ws, err := stateMgr.GetWorktree(wtPath)
 	if err != nil {
 		return err
@@ -37,7 +221,7 @@ func switchRun(branch string) error {
 
 	if ws == nil || ws.ClaudeSessionID == "" {
 		output.Warning("No iTerm2 session recorded for this worktree")
-		output.Info("Use 'wt open %s' to create a window", branch)
+		output.Info("Use 'wt open %s' to create a window", name)
 		return fmt.Errorf("no iTerm2 session for worktree")
 	}
 
@@ -51,14 +235,37 @@ func switchRun(branch string) error {
 	}
 
 	if itermClient.SessionExists(ws.ClaudeSessionID) {
-		if err := itermClient.FocusWindow(ws.ClaudeSessionID); err != nil {
+		ids := iterm.SessionIDs{ClaudeSessionID: ws.ClaudeSessionID, ShellSessionID: ws.ShellSessionID}
+		if err := lcMgr.Focus(ids, wtPath); err != nil {
 			return err
 		}
 		output.Success("Focused iTerm2 window for '%s'", ui.Cyan(filepath.Base(wtPath)))
-	} else {
-		output.Warning("iTerm2 window no longer exists")
-		output.Info("Use 'wt open %s' to create a new window", branch)
+		return nil
 	}
 
-	return nil
+	output.Warning("iTerm2 window no longer exists")
+	if !switchOpenIfStale && !promptFunc(fmt.Sprintf("Open a new window for '%s'?", name)) {
+		output.Info("Use 'wt open %s' to create a new window", name)
+		return nil
+	}
+	return reopenWorktree(wtPath, ws.Branch, name)
+}
+
+// reopenWorktree opens a fresh iTerm2 window for the worktree at wtPath after
+// its recorded one went away, as 'wt open' would.
+func reopenWorktree(wtPath, branch, name string) error {
+	if branch == "" {
+		branch = name
+	}
+	_, err := lcMgr.Open(lifecycle.OpenOptions{
+		RepoPath:        repoRoot,
+		WtPath:          wtPath,
+		Branch:          branch,
+		DefaultNoClaude: viper.GetBool("no_claude"),
+		Ports:           portRange(),
+		NoTrust:         !trustEnabled(),
+		Profile:         itermProfile(),
+		DryRun:          dryRun,
+	})
+	return err
 }
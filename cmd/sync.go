@@ -1,20 +1,35 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/joescharf/wt/internal/ui"
 	"github.com/joescharf/wt/pkg/ops"
 )
 
 var (
-	syncBase   string
-	syncForce  bool
-	syncAll    bool
-	syncRebase bool
-	syncMerge  bool
+	syncBase         string
+	syncForce        bool
+	syncAll          bool
+	syncRebase       bool
+	syncMerge        bool
+	syncFFOnly       bool
+	syncContinue     bool
+	syncOnlyBehind   bool
+	syncRepoAll      bool
+	syncAbort        bool
+	syncStratOpts    []string
+	syncFailFast     bool
+	syncJSON         bool
+	syncRebaseMerges bool
 )
 
 var syncCmd = &cobra.Command{
@@ -24,7 +39,43 @@ var syncCmd = &cobra.Command{
 	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: completeWorktreeNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if syncFFOnly && syncRebase {
+			return fmt.Errorf("--ff-only and --rebase cannot be used together")
+		}
+		if syncOnlyBehind && !syncAll {
+			return fmt.Errorf("--only-behind requires --all")
+		}
+		if syncRepoAll && !syncAll {
+			return fmt.Errorf("--repo-all requires --all")
+		}
+		if syncAbort && !syncAll {
+			return fmt.Errorf("--abort-on-conflict requires --all")
+		}
+		if syncFailFast && !syncAll {
+			return fmt.Errorf("--fail-fast requires --all")
+		}
+		if syncJSON && !syncAll {
+			return fmt.Errorf("--json requires --all")
+		}
+		if syncJSON && syncRepoAll {
+			return fmt.Errorf("--json cannot be used with --repo-all")
+		}
+		if syncRebaseMerges && (syncMerge || syncFFOnly) {
+			return fmt.Errorf("--rebase-merges implies --rebase and cannot be used with --merge or --ff-only")
+		}
+		if len(syncStratOpts) > 0 && syncFFOnly {
+			return fmt.Errorf("--strategy-option cannot be used with --ff-only")
+		}
+		if err := validateStrategyOptions(syncStratOpts); err != nil {
+			return err
+		}
 		if syncAll {
+			if syncContinue {
+				return fmt.Errorf("--continue applies to a single worktree, not --all")
+			}
+			if syncRepoAll {
+				return syncAllReposRun()
+			}
 			return syncAllRun()
 		}
 		if len(args) == 0 {
@@ -40,11 +91,26 @@ func init() {
 	syncCmd.Flags().BoolVar(&syncAll, "all", false, "Sync all worktrees")
 	syncCmd.Flags().BoolVar(&syncRebase, "rebase", false, "Use rebase instead of merge")
 	syncCmd.Flags().BoolVar(&syncMerge, "merge", false, "Use merge (overrides config rebase default)")
+	syncCmd.Flags().BoolVar(&syncContinue, "continue", false, "Only continue an in-progress merge/rebase (error if none)")
+	syncCmd.Flags().BoolVar(&syncFFOnly, "ff-only", false, "Only fast-forward; fail if the worktree has diverged from base")
+	syncCmd.Flags().BoolVar(&syncOnlyBehind, "only-behind", false, "With --all, skip up-to-date worktrees using a single ahead/behind check each")
+	syncCmd.Flags().BoolVar(&syncRepoAll, "repo-all", false, "With --all, sync worktrees in every repo recorded in state")
+	syncCmd.Flags().BoolVar(&syncAbort, "abort-on-conflict", false, "With --all, abort a conflicting merge/rebase and leave that worktree unchanged")
+	syncCmd.Flags().BoolVar(&syncFailFast, "fail-fast", false, "With --all, stop at the first conflict or error and leave the remaining worktrees untouched")
+	syncCmd.Flags().BoolVar(&syncJSON, "json", false, "With --all, print per-worktree results and totals as JSON to stdout")
+	syncCmd.Flags().StringArrayVarP(&syncStratOpts, "strategy-option", "X", nil, "Pass -X <opt> to git merge/rebase (e.g. ours, theirs, patience); repeatable")
+	syncCmd.Flags().BoolVar(&syncRebaseMerges, "rebase-merges", false, "Rebase, keeping the branch's own merge commits instead of flattening them (implies --rebase)")
 	_ = syncCmd.RegisterFlagCompletionFunc("base", completeBranchNames)
 	rootCmd.AddCommand(syncCmd)
 }
 
 func syncRun(branch string) error {
+	unlock, err := lockRepo(repoRoot, "sync")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Resolve worktree
 	wtPath, err := gitClient.ResolveWorktree(repoRoot, branch)
 	if err != nil {
@@ -58,43 +124,346 @@ func syncRun(branch string) error {
 		branchName = ws.Branch
 	}
 
-	baseBranch := syncBase
-	if baseBranch == "" {
-		baseBranch = viper.GetString("base_branch")
+	baseBranch, err := resolveBaseBranch(syncBase, ws)
+	if err != nil {
+		return err
 	}
 
-	_, err = ops.Sync(gitClient, opsLogger, ops.SyncOptions{
-		RepoPath:   repoRoot,
-		BaseBranch: baseBranch,
-		Branch:     branchName,
-		WtPath:     wtPath,
-		Strategy:   resolveStrategy(syncRebase, syncMerge),
-		Force:      syncForce,
-		DryRun:     dryRun,
+	result, err := ops.Sync(gitClient, opsLogger, ops.SyncOptions{
+		RepoPath:        repoRoot,
+		BaseBranch:      baseBranch,
+		Branch:          branchName,
+		WtPath:          wtPath,
+		Strategy:        resolveStrategy(syncRebase || syncRebaseMerges, syncMerge || syncFFOnly),
+		FFOnly:          syncFFOnly,
+		Continue:        syncContinue,
+		Force:           syncForce,
+		DryRun:          dryRun,
+		StrategyOptions: syncStratOpts,
+		RebaseMerges:    syncRebaseMerges,
 	})
+	if result != nil && recordSyncUndo([]ops.SyncResult{*result}) > 0 && err == nil {
+		output.Info("To undo: wt undo-sync %s", branch)
+	}
+	if err == nil && result != nil && mergedDiverged(*result) {
+		output.Info("Tip: 'wt sync %s --rebase' replays your commits onto '%s' for a linear history", branch, baseBranch)
+	}
 	return err
 }
 
+// mergedDiverged reports whether a sync merged base into a branch that also
+// had commits of its own, so a merge commit was (or would be) created where a
+// rebase would have kept history linear.
+func mergedDiverged(r ops.SyncResult) bool {
+	return r.Success && r.Strategy == "merge" && r.Ahead > 0 && r.Behind > 0
+}
+
 func syncAllRun() error {
+	if syncJSON {
+		output.NoProgress = true
+	}
+
+	unlock, err := lockRepo(repoRoot, "sync --all")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	baseBranch := syncBase
 	if baseBranch == "" {
 		baseBranch = viper.GetString("base_branch")
+		if err := checkBaseBranch(baseBranch); err != nil {
+			return err
+		}
+	} else if err := checkRef(baseBranch); err != nil {
+		return err
 	}
 
 	results, err := ops.SyncAll(gitClient, opsLogger, ops.SyncOptions{
-		RepoPath:   repoRoot,
-		BaseBranch: baseBranch,
-		Strategy:   resolveStrategy(syncRebase, syncMerge),
-		Force:      syncForce,
-		DryRun:     dryRun,
+		RepoPath:        repoRoot,
+		BaseBranch:      baseBranch,
+		Strategy:        resolveStrategy(syncRebase || syncRebaseMerges, syncMerge || syncFFOnly),
+		FFOnly:          syncFFOnly,
+		OnlyBehind:      syncOnlyBehind,
+		AbortOnConflict: syncAbort,
+		FailFast:        syncFailFast,
+		BaseFor:         recordedBaseFor(),
+		StrategyOptions: syncStratOpts,
+		RebaseMerges:    syncRebaseMerges,
+		Force:           syncForce,
+		DryRun:          dryRun,
 	})
-	if err != nil {
+	// --fail-fast still records undo points for the worktrees synced before the stop
+	var stopped *ops.SyncStoppedError
+	if err != nil && !errors.As(err, &stopped) {
 		return err
 	}
+	if syncJSON {
+		if jsonErr := printSyncJSON(baseBranch, results, stopped); jsonErr != nil {
+			return jsonErr
+		}
+	}
+	if recordSyncUndo(results) > 0 {
+		output.Info("To undo a worktree's sync: wt undo-sync <branch>")
+	}
+	diverged := 0
+	for _, r := range results {
+		if mergedDiverged(r) {
+			diverged++
+		}
+	}
+	if diverged > 0 {
+		output.Info("Tip: %d worktree(s) had diverged — 'wt sync --all --rebase' keeps history linear", diverged)
+	}
 
 	// Print blank line before summary if there were results
 	if len(results) > 0 {
-		_, _ = fmt.Fprintln(output.Out)
+		_, _ = fmt.Fprintln(output.ErrOut)
+	}
+	if err != nil {
+		return err
+	}
+	if n := countSyncFailed(results); n > 0 {
+		return fmt.Errorf("%d worktree(s) failed to sync", n)
 	}
 	return nil
 }
+
+// countSyncFailed counts the results whose sync failed without a conflict;
+// any make 'sync --all' exit non-zero.
+func countSyncFailed(results []ops.SyncResult) int {
+	n := 0
+	for _, r := range results {
+		if r.Failed {
+			n++
+		}
+	}
+	return n
+}
+
+// syncJSONResult is one worktree's outcome in 'sync --all --json'.
+type syncJSONResult struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path,omitempty"`
+	Ahead  int    `json:"ahead"`
+	Behind int    `json:"behind"`
+	Action string `json:"action"` // merge, rebase, fast-forward, or none
+	Status string `json:"status"` // synced, up-to-date, skipped, conflict, or failed
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"` // git's error output, for conflicts and failures
+}
+
+// syncJSONTotals counts the results by status.
+type syncJSONTotals struct {
+	Synced    int `json:"synced"`
+	UpToDate  int `json:"up_to_date"`
+	Skipped   int `json:"skipped"`
+	Conflicts int `json:"conflicts"`
+	Failed    int `json:"failed"`
+}
+
+// printSyncJSON writes the 'sync --all --json' report to stdout. stopped is
+// set when --fail-fast ended the run early.
+func printSyncJSON(baseBranch string, results []ops.SyncResult, stopped *ops.SyncStoppedError) error {
+	repoName, err := gitClient.RepoName(repoRoot)
+	if err != nil {
+		return err
+	}
+
+	report := struct {
+		Repo      string           `json:"repo"`
+		Base      string           `json:"base"`
+		DryRun    bool             `json:"dry_run,omitempty"`
+		Results   []syncJSONResult `json:"results"`
+		Totals    syncJSONTotals   `json:"totals"`
+		StoppedAt string           `json:"stopped_at,omitempty"` // --fail-fast: branch the run stopped at
+	}{Repo: repoName, Base: baseBranch, DryRun: dryRun, Results: []syncJSONResult{}}
+
+	for _, r := range results {
+		jr := syncJSONResult{Branch: r.Branch, Path: r.WtPath, Ahead: r.Ahead, Behind: r.Behind, Action: "none"}
+		if r.Err != nil {
+			jr.Error = r.Err.Error()
+		}
+		if !r.Skipped && !r.AlreadySynced {
+			jr.Action = r.Strategy
+			if syncFFOnly {
+				jr.Action = "fast-forward"
+			}
+		}
+		switch {
+		case r.Skipped:
+			jr.Status, jr.Reason = "skipped", r.SkipReason
+			report.Totals.Skipped++
+		case r.AlreadySynced:
+			jr.Status = "up-to-date"
+			report.Totals.UpToDate++
+		case r.Conflict:
+			jr.Status, jr.Reason = "conflict", r.Strategy+" conflict"
+			if r.Aborted {
+				jr.Reason += ", aborted and left unchanged"
+			}
+			report.Totals.Conflicts++
+		case r.Success:
+			jr.Status = "synced"
+			report.Totals.Synced++
+		default:
+			jr.Status, jr.Reason = "failed", r.FailReason
+			if jr.Reason == "" {
+				jr.Reason = jr.Action + " failed"
+			}
+			report.Totals.Failed++
+		}
+		report.Results = append(report.Results, jr)
+	}
+	if stopped != nil {
+		report.StoppedAt = stopped.Branch
+	}
+
+	enc := json.NewEncoder(output.Out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// syncAllReposRun runs 'sync --all' in every repo recorded in state (one fetch
+// per repo), then prints a summary grouped by repo. Repos missing on disk, or
+// where an explicit --base doesn't resolve, are skipped with a warning.
+func syncAllReposRun() error {
+	repos, err := stateMgr.RepoPaths()
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		output.Info("No repos recorded in state")
+		return nil
+	}
+
+	baseBranch := syncBase
+	if baseBranch == "" {
+		baseBranch = viper.GetString("base_branch")
+	}
+
+	var rows [][]string
+	var stopErr error
+	undoable, failed := 0, 0
+	for _, repo := range repos {
+		name := filepath.Base(repo)
+		if _, err := os.Stat(repo); err != nil {
+			output.Warning("Skipping '%s' — repo not found at %s (run 'wt prune' to drop its stale worktrees)", name, repo)
+			rows = append(rows, []string{name, "-", "-", "-", "-", ui.Yellow("missing")})
+			continue
+		}
+		if syncBase != "" {
+			if ok, err := gitClient.RefExists(repo, syncBase); err != nil || !ok {
+				output.Warning("Skipping '%s' — unknown ref '%s'", name, syncBase)
+				rows = append(rows, []string{name, "-", "-", "-", "-", ui.Yellow("unknown base")})
+				continue
+			}
+		}
+		unlock, err := lockRepo(repo, "sync --all --repo-all")
+		if err != nil {
+			output.Warning("Skipping '%s' — %v", name, err)
+			rows = append(rows, []string{name, "-", "-", "-", "-", ui.Yellow("locked")})
+			continue
+		}
+
+		_, _ = fmt.Fprintln(output.ErrOut)
+		output.Info("Syncing worktrees in %s", ui.Cyan(name))
+		results, err := ops.SyncAll(gitClient, opsLogger, ops.SyncOptions{
+			RepoPath:        repo,
+			BaseBranch:      baseBranch,
+			Strategy:        resolveStrategy(syncRebase || syncRebaseMerges, syncMerge || syncFFOnly),
+			FFOnly:          syncFFOnly,
+			OnlyBehind:      syncOnlyBehind,
+			AbortOnConflict: syncAbort,
+			FailFast:        syncFailFast,
+			BaseFor:         recordedBaseFor(),
+			StrategyOptions: syncStratOpts,
+			RebaseMerges:    syncRebaseMerges,
+			Force:           syncForce,
+			DryRun:          dryRun,
+		})
+		unlock()
+		var stopped *ops.SyncStoppedError
+		if err != nil && !errors.As(err, &stopped) {
+			output.Warning("Sync failed in '%s': %v", name, err)
+			rows = append(rows, []string{name, "-", "-", "-", "-", ui.Red("error")})
+			if syncFailFast {
+				stopErr = fmt.Errorf("%s: %w", name, err)
+				break
+			}
+			continue
+		}
+		undoable += recordSyncUndo(results)
+		failed += countSyncFailed(results)
+		rows = append(rows, syncSummaryRow(name, results))
+		if stopped != nil {
+			stopErr = fmt.Errorf("%s: %w", name, stopped)
+			break
+		}
+	}
+
+	if undoable > 0 {
+		output.Info("To undo a worktree's sync: wt undo-sync <branch> (from its repo)")
+	}
+	_, _ = fmt.Fprintln(output.ErrOut)
+	table := newTable()
+	table.Header("REPO", "SYNCED", "UP TO DATE", "SKIPPED", "FAILED", "STATUS")
+	_ = table.Bulk(rows)
+	_ = table.Render()
+	if stopErr == nil && failed > 0 {
+		return fmt.Errorf("%d worktree(s) failed to sync", failed)
+	}
+	return stopErr
+}
+
+// recordedBaseFor returns the lookup 'sync --all' uses to sync each worktree
+// with the base recorded by 'wt create --base', or nil when --base overrides it.
+func recordedBaseFor() func(string) string {
+	if syncBase != "" {
+		return nil
+	}
+	return recordedBase
+}
+
+// recordSyncUndo saves the pre-sync HEAD of every worktree a sync moved or
+// left mid-conflict so 'wt undo-sync' can reset it, returning how many were
+// recorded. A --continue run leaves the point saved when the conflict hit; an
+// attempt whose HEAD couldn't be read clears the older, now stale, point.
+func recordSyncUndo(results []ops.SyncResult) int {
+	recorded := 0
+	for _, r := range results {
+		if !r.Attempted || r.Aborted || (!r.Success && !r.Conflict) {
+			continue
+		}
+		if err := stateMgr.SetPreSyncHEAD(r.WtPath, r.PreSyncHEAD); err != nil {
+			output.Warning("Could not record undo point for '%s': %v", r.Branch, err)
+			continue
+		}
+		if r.PreSyncHEAD != "" {
+			recorded++
+		}
+	}
+	return recorded
+}
+
+// syncSummaryRow tallies one repo's sync results for the --repo-all summary.
+func syncSummaryRow(name string, results []ops.SyncResult) []string {
+	var synced, current, skipped, failed int
+	for _, r := range results {
+		switch {
+		case r.Skipped, r.Aborted:
+			skipped++
+		case r.AlreadySynced:
+			current++
+		case r.Success:
+			synced++
+		default:
+			failed++
+		}
+	}
+	status := ui.Green("ok")
+	if failed > 0 {
+		status = ui.Red("failed")
+	}
+	return []string{name, strconv.Itoa(synced), strconv.Itoa(current), strconv.Itoa(skipped), strconv.Itoa(failed), status}
+}
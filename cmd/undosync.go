@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joescharf/wt/pkg/ops"
+)
+
+var undoSyncForce bool
+
+var undoSyncCmd = &cobra.Command{
+	Use:   "undo-sync <branch>",
+	Short: "Reset a worktree's branch to where it was before the last sync",
+	Long: `Reset a worktree's branch to the HEAD it had before its last 'wt sync'
+(merge, rebase or fast-forward), using 'git reset --hard'.
+
+wt records that HEAD whenever a sync changes the branch or stops on a
+conflict (kept through 'sync --continue'), and when 'wt merge --rebase'
+rebases a worktree it leaves in place. The worktree must be clean, and
+you're asked to confirm unless --force is given. The old HEAD also stays
+reachable through 'git reflog', so an undo can itself be undone.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorktreeNames,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return undoSyncRun(args[0])
+	},
+}
+
+func init() {
+	undoSyncCmd.Flags().BoolVar(&undoSyncForce, "force", false, "Don't ask for confirmation (the worktree must still be clean)")
+	rootCmd.AddCommand(undoSyncCmd)
+}
+
+func undoSyncRun(branch string) error {
+	wtPath, err := gitClient.ResolveWorktree(repoRoot, branch)
+	if err != nil {
+		return err
+	}
+	dirname := filepath.Base(wtPath)
+
+	ws, err := stateMgr.GetWorktree(wtPath)
+	if err != nil {
+		return err
+	}
+	if ws == nil || ws.PreSyncHEAD == "" {
+		return fmt.Errorf("no sync to undo for '%s' (wt records one each time 'wt sync' or a rebase merge changes the branch)", dirname)
+	}
+	target := ws.PreSyncHEAD
+
+	dirty, err := gitClient.IsWorktreeDirty(wtPath)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("worktree '%s' has uncommitted changes — commit or stash them before undoing the sync", dirname)
+	}
+
+	head, err := gitClient.HeadSHA(wtPath)
+	if err != nil {
+		return err
+	}
+	if head == target {
+		output.Info("'%s' is already at %s, its HEAD before the last sync", dirname, ops.ShortSHA(target))
+		if !dryRun {
+			return stateMgr.SetPreSyncHEAD(wtPath, "")
+		}
+		return nil
+	}
+
+	if dryRun {
+		output.DryRunMsg("Would reset '%s' from %s to %s", dirname, ops.ShortSHA(head), ops.ShortSHA(target))
+		return nil
+	}
+
+	if !undoSyncForce && !promptFunc(fmt.Sprintf("Reset '%s' from %s to %s (before the last sync)? Commits made since then leave the branch", dirname, ops.ShortSHA(head), ops.ShortSHA(target))) {
+		output.Info("Undo cancelled")
+		return nil
+	}
+
+	if err := gitClient.ResetHard(wtPath, target); err != nil {
+		return err
+	}
+	if err := stateMgr.SetPreSyncHEAD(wtPath, ""); err != nil {
+		output.Warning("Failed to clear the undo point: %v", err)
+	}
+	output.Success("Reset '%s' to %s (previous HEAD %s is still in 'git reflog')", dirname, ops.ShortSHA(target), ops.ShortSHA(head))
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joescharf/wt/pkg/gitops"
+)
+
+var (
+	worktreesPorcelain bool
+	worktreesJSON      bool
+)
+
+var worktreesCmd = &cobra.Command{
+	Use:   "worktrees",
+	Short: "Print git's raw worktree inventory (no status lookups)",
+	Long: `Print the worktrees git knows about for this repo: path, branch, HEAD,
+and whether each is locked or prunable. Unlike 'wt list' it skips state,
+iTerm2 and git status lookups, so it is fast and stable for scripts and
+bug reports.
+
+By default each worktree is one tab-separated line:
+  <path> <branch|(detached)> <HEAD> <flags|->
+--porcelain prints git-style blocks instead, one "key value" per line with a
+blank line between worktrees.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if worktreesPorcelain && worktreesJSON {
+			return fmt.Errorf("--porcelain and --json cannot be used together")
+		}
+		return worktreesRun()
+	},
+}
+
+func init() {
+	worktreesCmd.Flags().BoolVar(&worktreesPorcelain, "porcelain", false, "Print git-style key/value blocks")
+	worktreesCmd.Flags().BoolVar(&worktreesJSON, "json", false, "Print worktrees as JSON to stdout")
+	rootCmd.AddCommand(worktreesCmd)
+}
+
+// worktreeEntry is the --json shape of one gitops.WorktreeInfo.
+type worktreeEntry struct {
+	Path     string `json:"path"`
+	Branch   string `json:"branch"`
+	HEAD     string `json:"head"`
+	Locked   bool   `json:"locked"`
+	Prunable bool   `json:"prunable"`
+}
+
+func worktreesRun() error {
+	worktrees, err := gitClient.WorktreeList(repoRoot)
+	if err != nil {
+		return err
+	}
+
+	if worktreesJSON {
+		entries := make([]worktreeEntry, 0, len(worktrees))
+		for _, wt := range worktrees {
+			entries = append(entries, worktreeEntry{Path: wt.Path, Branch: wt.Branch, HEAD: wt.HEAD, Locked: wt.Locked, Prunable: wt.Prunable})
+		}
+		enc := json.NewEncoder(output.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	for _, wt := range worktrees {
+		if worktreesPorcelain {
+			writeWorktreePorcelain(wt)
+			continue
+		}
+		branch := wt.Branch
+		if branch == "" {
+			branch = "(detached)"
+		}
+		flags := worktreeFlags(wt)
+		if len(flags) == 0 {
+			flags = []string{"-"}
+		}
+		_, _ = fmt.Fprintf(output.Out, "%s\t%s\t%s\t%s\n", wt.Path, branch, wt.HEAD, strings.Join(flags, ","))
+	}
+	return nil
+}
+
+// writeWorktreePorcelain prints wt as a block of "key value" lines followed by
+// a blank line, mirroring `git worktree list --porcelain`.
+func writeWorktreePorcelain(wt gitops.WorktreeInfo) {
+	_, _ = fmt.Fprintf(output.Out, "worktree %s\n", wt.Path)
+	_, _ = fmt.Fprintf(output.Out, "HEAD %s\n", wt.HEAD)
+	if wt.Branch != "" {
+		_, _ = fmt.Fprintf(output.Out, "branch %s\n", wt.Branch)
+	} else {
+		_, _ = fmt.Fprintln(output.Out, "detached")
+	}
+	for _, flag := range worktreeFlags(wt) {
+		_, _ = fmt.Fprintln(output.Out, flag)
+	}
+	_, _ = fmt.Fprintln(output.Out)
+}
+
+func worktreeFlags(wt gitops.WorktreeInfo) []string {
+	var flags []string
+	if wt.Locked {
+		flags = append(flags, "locked")
+	}
+	if wt.Prunable {
+		flags = append(flags, "prunable")
+	}
+	return flags
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"time"
 
@@ -19,8 +20,12 @@ import (
 // Config holds configurable settings for the MCP server.
 type Config struct {
 	BaseBranch string // default base branch (e.g. "main")
+	Version    string // wt version reported by wt_health
 }
 
+// lookPath finds the git binary for wt_health, replaceable in tests.
+var lookPath = exec.LookPath
+
 // Server wraps the wt dependencies and exposes them as MCP tools.
 type Server struct {
 	git   gitops.Client
@@ -34,6 +39,9 @@ func NewServer(gc gitops.Client, ic iterm.Client, sm *state.Manager, cfg Config)
 	if cfg.BaseBranch == "" {
 		cfg.BaseBranch = "main"
 	}
+	if cfg.Version == "" {
+		cfg.Version = "dev"
+	}
 	return &Server{
 		git:   gc,
 		iterm: ic,
@@ -52,6 +60,7 @@ func (s *Server) MCPServer() *server.MCPServer {
 	srv.AddTool(s.deleteTool())
 	srv.AddTool(s.syncTool())
 	srv.AddTool(s.mergeTool())
+	srv.AddTool(s.healthTool())
 
 	return srv
 }
@@ -208,13 +217,13 @@ func (s *Server) handleCreate(ctx context.Context, request mcp.CallToolRequest)
 		base = baseBranch
 	}
 
-	if err := s.git.WorktreeAdd(repoPath, wtPath, branch, base, newBranch); err != nil {
+	if err := s.git.WorktreeAdd(repoPath, wtPath, branch, base, newBranch, false); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to create worktree: %v", err)), nil
 	}
 
 	// Create iTerm2 window
 	sessionName := fmt.Sprintf("wt:%s:%s", repoName, dirname)
-	sessions, err := s.iterm.CreateWorktreeWindow(wtPath, sessionName, noClaude)
+	sessions, err := s.iterm.CreateWorktreeWindow(wtPath, sessionName, iterm.WindowOptions{NoClaude: noClaude})
 	if err != nil {
 		// Worktree was created but iTerm failed - still report partial success
 		result := map[string]any{
@@ -230,10 +239,12 @@ func (s *Server) handleCreate(ctx context.Context, request mcp.CallToolRequest)
 	// Save state
 	_ = s.state.SetWorktree(wtPath, &state.WorktreeState{
 		Repo:            repoName,
+		RepoPath:        repoPath,
 		Branch:          branch,
 		ClaudeSessionID: sessions.ClaudeSessionID,
 		ShellSessionID:  sessions.ShellSessionID,
 		CreatedAt:       state.FlexTime{Time: time.Now().UTC()},
+		BaseBranch:      request.GetString("base", ""), // an explicit base is pinned
 	})
 
 	result := map[string]any{
@@ -307,7 +318,7 @@ func (s *Server) handleOpen(ctx context.Context, request mcp.CallToolRequest) (*
 	// Create new window
 	dirname := filepath.Base(wtPath)
 	sessionName := fmt.Sprintf("wt:%s:%s", repoName, dirname)
-	sessions, err := s.iterm.CreateWorktreeWindow(wtPath, sessionName, noClaude)
+	sessions, err := s.iterm.CreateWorktreeWindow(wtPath, sessionName, iterm.WindowOptions{NoClaude: noClaude})
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to create iTerm2 window: %v", err)), nil
 	}
@@ -320,6 +331,7 @@ func (s *Server) handleOpen(ctx context.Context, request mcp.CallToolRequest) (*
 
 	_ = s.state.SetWorktree(wtPath, &state.WorktreeState{
 		Repo:            repoName,
+		RepoPath:        repoPath,
 		Branch:          branchName,
 		ClaudeSessionID: sessions.ClaudeSessionID,
 		ShellSessionID:  sessions.ShellSessionID,
@@ -384,27 +396,16 @@ func (s *Server) handleDelete(ctx context.Context, request mcp.CallToolRequest)
 		}
 	}
 
-	// Close iTerm2 window if it exists
-	ws, _ := s.state.GetWorktree(wtPath)
-	if ws != nil && ws.ClaudeSessionID != "" {
-		if s.iterm.IsRunning() && s.iterm.SessionExists(ws.ClaudeSessionID) {
-			_ = s.iterm.CloseWindow(ws.ClaudeSessionID)
-		}
-	}
-
-	// Remove worktree
-	if err := s.git.WorktreeRemove(repoPath, wtPath, force); err != nil {
+	err = s.cleanupWorktree(repoPath, wtPath, branch, cleanupOptions{
+		DeleteBranch: true,
+		ForceBranch:  true,
+		CloseWindow:  true,
+		Force:        force,
+	})
+	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("failed to remove worktree: %v", err)), nil
 	}
 
-	// Delete branch (try normal first, fall back to force)
-	if err := s.git.BranchDelete(repoPath, branch, false); err != nil {
-		_ = s.git.BranchDelete(repoPath, branch, true)
-	}
-
-	// Clean state
-	_ = s.state.RemoveWorktree(wtPath)
-
 	result := map[string]any{
 		"branch":        branch,
 		"worktree_path": wtPath,
@@ -447,7 +448,7 @@ func (s *Server) handleSync(ctx context.Context, request mcp.CallToolRequest) (*
 		return mcp.NewToolResultError(fmt.Sprintf("worktree not found for branch '%s': %v", branch, err)), nil
 	}
 
-	baseBranch := s.cfg.BaseBranch
+	baseBranch := s.worktreeBase(wtPath)
 	mergeSource := baseBranch
 
 	// Fetch if remote exists
@@ -473,11 +474,11 @@ func (s *Server) handleSync(ctx context.Context, request mcp.CallToolRequest) (*
 	}
 
 	if strategy == "rebase" {
-		if err := s.git.Rebase(wtPath, mergeSource); err != nil {
+		if err := s.git.Rebase(wtPath, mergeSource, gitops.RebaseRunOptions{}); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("rebase failed: %v", err)), nil
 		}
 	} else {
-		if err := s.git.Merge(wtPath, mergeSource); err != nil {
+		if err := s.git.Merge(wtPath, mergeSource, gitops.MergeRunOptions{}); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("merge failed: %v", err)), nil
 		}
 	}
@@ -528,10 +529,10 @@ func (s *Server) handleMerge(ctx context.Context, request mcp.CallToolRequest) (
 		return mcp.NewToolResultError(fmt.Sprintf("worktree not found for branch '%s': %v", branch, err)), nil
 	}
 
-	baseBranch := s.cfg.BaseBranch
+	baseBranch := s.worktreeBase(wtPath)
 
 	// Check if there are commits to merge
-	hasCommits, _ := s.git.HasUnpushedCommits(wtPath, baseBranch)
+	hasCommits, _ := s.git.HasCommitsToMerge(wtPath, baseBranch)
 	if !hasCommits {
 		result := map[string]any{
 			"branch": branch,
@@ -555,14 +556,14 @@ func (s *Server) handleMergeLocal(repoPath, wtPath, branch, baseBranch, strategy
 
 	if strategy == "rebase" {
 		// Rebase-then-fast-forward
-		if err := s.git.Rebase(wtPath, baseBranch); err != nil {
+		if err := s.git.Rebase(wtPath, baseBranch, gitops.RebaseRunOptions{}); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("rebase failed: %v", err)), nil
 		}
-		if err := s.git.Merge(repoRoot, branch); err != nil {
+		if err := s.git.Merge(repoRoot, branch, gitops.MergeRunOptions{}); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("fast-forward merge failed: %v", err)), nil
 		}
 	} else {
-		if err := s.git.Merge(repoRoot, branch); err != nil {
+		if err := s.git.Merge(repoRoot, branch, gitops.MergeRunOptions{}); err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("merge failed: %v", err)), nil
 		}
 	}
@@ -574,17 +575,12 @@ func (s *Server) handleMergeLocal(repoPath, wtPath, branch, baseBranch, strategy
 	}
 
 	// Post-merge cleanup: close iTerm, remove worktree, delete branch, clean state
-	ws, _ := s.state.GetWorktree(wtPath)
-	if ws != nil && ws.ClaudeSessionID != "" {
-		if s.iterm.IsRunning() && s.iterm.SessionExists(ws.ClaudeSessionID) {
-			_ = s.iterm.CloseWindow(ws.ClaudeSessionID)
-			time.Sleep(500 * time.Millisecond)
-		}
-	}
-
-	_ = s.git.WorktreeRemove(repoPath, wtPath, false)
-	_ = s.git.BranchDelete(repoPath, branch, false)
-	_ = s.state.RemoveWorktree(wtPath)
+	_ = s.cleanupWorktree(repoPath, wtPath, branch, cleanupOptions{
+		DeleteBranch: true,
+		CloseWindow:  true,
+		CloseWait:    500 * time.Millisecond,
+		KeepGoing:    true,
+	})
 
 	result := map[string]any{
 		"branch":      branch,
@@ -621,10 +617,91 @@ func (s *Server) handleMergePR(repoPath, wtPath, branch, baseBranch string) (*mc
 	return mcp.NewToolResultText(string(data)), nil
 }
 
+// wt_health
+func (s *Server) healthTool() (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("wt_health",
+		mcp.WithDescription("Report server health without side effects: version, git availability, whether iTerm2 is running, and whether the state file is readable. ok is true when git is available and state is readable."),
+	)
+	return tool, s.handleHealth
+}
+
+func (s *Server) handleHealth(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gitPath, gitErr := lookPath("git")
+	_, stateErr := s.state.Load()
+
+	result := map[string]any{
+		"ok":             gitErr == nil && stateErr == nil,
+		"version":        s.cfg.Version,
+		"git_available":  gitErr == nil,
+		"git_path":       gitPath,
+		"iterm_running":  s.iterm.IsRunning(),
+		"state_path":     s.state.Path(),
+		"state_readable": stateErr == nil,
+	}
+	if stateErr != nil {
+		result["state_error"] = stateErr.Error()
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to marshal result: %v", err)), nil
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
 
+// cleanupOptions selects what cleanupWorktree tears down besides the
+// worktree directory and its state entry.
+type cleanupOptions struct {
+	DeleteBranch bool          // delete the branch
+	ForceBranch  bool          // retry a failed branch delete with force
+	CloseWindow  bool          // close the worktree's iTerm2 window if it's still open
+	CloseWait    time.Duration // pause after closing the window, before removing the worktree
+	Force        bool          // remove the worktree even if it has local changes
+	KeepGoing    bool          // still delete the branch and state after a failed worktree removal
+}
+
+// cleanupWorktree removes the worktree at wtPath and its state entry, closing
+// its window and deleting its branch as opts asks. Only a failed worktree
+// removal is reported; the window, branch, and state steps are best-effort.
+func (s *Server) cleanupWorktree(repoPath, wtPath, branch string, opts cleanupOptions) error {
+	if opts.CloseWindow {
+		ws, _ := s.state.GetWorktree(wtPath)
+		if ws != nil && ws.ClaudeSessionID != "" {
+			if s.iterm.IsRunning() && s.iterm.SessionExists(ws.ClaudeSessionID) {
+				_ = s.iterm.CloseWindow(ws.ClaudeSessionID)
+				time.Sleep(opts.CloseWait)
+			}
+		}
+	}
+
+	removeErr := s.git.WorktreeRemove(repoPath, wtPath, opts.Force)
+	if removeErr != nil && !opts.KeepGoing {
+		return removeErr
+	}
+
+	if opts.DeleteBranch {
+		if err := s.git.BranchDelete(repoPath, branch, false); err != nil && opts.ForceBranch {
+			_ = s.git.BranchDelete(repoPath, branch, true)
+		}
+	}
+
+	_ = s.state.RemoveWorktree(wtPath)
+	return removeErr
+}
+
+// worktreeBase returns the base recorded for the worktree by a create with an
+// explicit base, falling back to the configured base branch.
+func (s *Server) worktreeBase(wtPath string) string {
+	if ws, _ := s.state.GetWorktree(wtPath); ws != nil && ws.BaseBranch != "" {
+		return ws.BaseBranch
+	}
+	return s.cfg.BaseBranch
+}
+
 // resolveWorktreePath finds the worktree path for a given branch by searching
 // the worktree list using the shared resolution logic.
 func (s *Server) resolveWorktreePath(repoPath, branch string) (string, error) {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -35,6 +37,7 @@ type mockGitClient struct {
 	commitsAhead int
 	commitsBehind int
 	hasUnpushed  bool
+	hasCommitsToMerge bool
 
 	// Track calls
 	addedWorktrees   []wtAddCall
@@ -46,6 +49,7 @@ type mockGitClient struct {
 	pullCalls        int
 	pruneCalls       int
 	deletedBranches  []string
+	branchDeleteErr  error // unforced deletes only
 
 	// Error injection
 	repoRootErr      error
@@ -119,7 +123,7 @@ func (m *mockGitClient) WorktreeList(repoPath string) ([]gitops.WorktreeInfo, er
 	return m.worktrees, nil
 }
 
-func (m *mockGitClient) WorktreeAdd(repoPath, wtPath, branch, base string, newBranch bool) error {
+func (m *mockGitClient) WorktreeAdd(repoPath, wtPath, branch, base string, newBranch, force bool) error {
 	if m.worktreeAddErr != nil {
 		return m.worktreeAddErr
 	}
@@ -143,6 +147,9 @@ func (m *mockGitClient) BranchExists(repoPath, branch string) (bool, error) {
 }
 
 func (m *mockGitClient) BranchDelete(repoPath, branch string, force bool) error {
+	if m.branchDeleteErr != nil && !force {
+		return m.branchDeleteErr
+	}
 	m.deletedBranches = append(m.deletedBranches, branch)
 	return nil
 }
@@ -168,6 +175,14 @@ func (m *mockGitClient) HasUnpushedCommits(worktreePath, baseBranch string) (boo
 	return m.hasUnpushed, nil
 }
 
+func (m *mockGitClient) StashCount(worktreePath string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockGitClient) HasCommitsToMerge(worktreePath, baseBranch string) (bool, error) {
+	return m.hasCommitsToMerge, nil
+}
+
 func (m *mockGitClient) HasRemote(repoPath string) (bool, error) {
 	if m.hasRemoteErr != nil {
 		return false, m.hasRemoteErr
@@ -183,7 +198,7 @@ func (m *mockGitClient) Fetch(repoPath string) error {
 	return nil
 }
 
-func (m *mockGitClient) Merge(repoPath, branch string) error {
+func (m *mockGitClient) Merge(repoPath, branch string, opts gitops.MergeRunOptions) error {
 	if m.mergeErr != nil {
 		return m.mergeErr
 	}
@@ -191,7 +206,7 @@ func (m *mockGitClient) Merge(repoPath, branch string) error {
 	return nil
 }
 
-func (m *mockGitClient) Rebase(repoPath, branch string) error {
+func (m *mockGitClient) Rebase(repoPath, branch string, opts gitops.RebaseRunOptions) error {
 	if m.rebaseErr != nil {
 		return m.rebaseErr
 	}
@@ -207,6 +222,10 @@ func (m *mockGitClient) Push(worktreePath, branch string, setUpstream bool) erro
 	return nil
 }
 
+func (m *mockGitClient) Checkout(repoPath, branch string) error {
+	return nil
+}
+
 func (m *mockGitClient) Pull(repoPath string) error {
 	if m.pullErr != nil {
 		return m.pullErr
@@ -229,6 +248,52 @@ func (m *mockGitClient) CommitsBehind(worktreePath, baseBranch string) (int, err
 	return m.commitsBehind, nil
 }
 
+func (m *mockGitClient) AheadBehind(worktreePath, baseBranch string) (int, int, error) {
+	if m.aheadErr != nil {
+		return 0, 0, m.aheadErr
+	}
+	if m.behindErr != nil {
+		return 0, 0, m.behindErr
+	}
+	return m.commitsAhead, m.commitsBehind, nil
+}
+
+func (m *mockGitClient) CommitSubjects(repoPath, baseBranch, branch string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockGitClient) Reflog(repoPath, ref string) ([]gitops.ReflogEntry, error) {
+	return nil, nil
+}
+
+func (m *mockGitClient) IsAncestor(repoPath, maybeAncestor, ref string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockGitClient) RefExists(repoPath, ref string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockGitClient) HeadSHA(path string) (string, error) {
+	return "", nil
+}
+
+func (m *mockGitClient) ResetHard(repoPath, ref string) error {
+	return nil
+}
+
+func (m *mockGitClient) SetLocalConfig(path, key, value string) error {
+	return nil
+}
+
+func (m *mockGitClient) ConfigValue(path, key string) (string, error) {
+	return "", nil
+}
+
+func (m *mockGitClient) RemoteBranchSHA(path, remote, branch string) (string, error) {
+	return "", nil
+}
+
 func (m *mockGitClient) WorktreePrune(repoPath string) error {
 	if m.pruneErr != nil {
 		return m.pruneErr
@@ -237,6 +302,10 @@ func (m *mockGitClient) WorktreePrune(repoPath string) error {
 	return nil
 }
 
+func (m *mockGitClient) WorktreeRepair(repoPath string) error {
+	return nil
+}
+
 func (m *mockGitClient) BranchList(repoPath string) ([]string, error) {
 	var branches []string
 	for b := range m.branches {
@@ -254,6 +323,22 @@ func (m *mockGitClient) ResolveWorktree(repoPath, input string) (string, error)
 	return "", fmt.Errorf("worktree not found: %s", input)
 }
 
+func (m *mockGitClient) RefSHA(repoPath, ref string) (string, error) {
+	return "", nil
+}
+
+func (m *mockGitClient) CherryPick(repoPath, ref string, opts gitops.CherryPickRunOptions) error {
+	return nil
+}
+
+func (m *mockGitClient) CherryPickContinue(repoPath string) error {
+	return nil
+}
+
+func (m *mockGitClient) CherryPickAbort(repoPath string) error {
+	return nil
+}
+
 func (m *mockGitClient) MergeContinue(repoPath string) error {
 	return nil
 }
@@ -270,10 +355,18 @@ func (m *mockGitClient) RebaseContinue(repoPath string) error {
 	return nil
 }
 
+func (m *mockGitClient) Clone(url, dir string, bare bool) error {
+	return nil
+}
+
 func (m *mockGitClient) RebaseAbort(repoPath string) error {
 	return nil
 }
 
+func (m *mockGitClient) MergeAbort(repoPath string) error {
+	return nil
+}
+
 func (m *mockGitClient) IsRebaseInProgress(repoPath string) (bool, error) {
 	return false, nil
 }
@@ -300,11 +393,11 @@ func (m *mockItermClient) EnsureRunning() error {
 	m.running = true
 	return nil
 }
-func (m *mockItermClient) CreateWorktreeWindow(path, name string, noClaude bool) (*iterm.SessionIDs, error) {
+func (m *mockItermClient) CreateWorktreeWindow(path, name string, opts iterm.WindowOptions) (*iterm.SessionIDs, error) {
 	if m.createErr != nil {
 		return nil, m.createErr
 	}
-	m.createCalls = append(m.createCalls, itermCreateCall{path, name, noClaude})
+	m.createCalls = append(m.createCalls, itermCreateCall{path, name, opts.NoClaude})
 	return &iterm.SessionIDs{
 		ClaudeSessionID: "mock-claude-session",
 		ShellSessionID:  "mock-shell-session",
@@ -313,10 +406,25 @@ func (m *mockItermClient) CreateWorktreeWindow(path, name string, noClaude bool)
 func (m *mockItermClient) SessionExists(sessionID string) bool {
 	return m.sessions[sessionID]
 }
+func (m *mockItermClient) SessionBusy(sessionID string) bool {
+	return false
+}
+func (m *mockItermClient) PreviewCommand(path, name string, opts iterm.WindowOptions) string {
+	return ""
+}
+func (m *mockItermClient) ListWindows() ([]iterm.WindowInfo, error) {
+	return nil, nil
+}
 func (m *mockItermClient) FocusWindow(sessionID string) error {
 	m.focusCalls = append(m.focusCalls, sessionID)
 	return nil
 }
+func (m *mockItermClient) ActiveSession() (string, error) {
+	if len(m.focusCalls) == 0 {
+		return "", nil
+	}
+	return m.focusCalls[len(m.focusCalls)-1], nil
+}
 func (m *mockItermClient) CloseWindow(sessionID string) error {
 	m.closeCalls = append(m.closeCalls, sessionID)
 	return nil
@@ -721,6 +829,96 @@ func TestHandleDelete_MissingBranch(t *testing.T) {
 	assert.True(t, result.IsError)
 }
 
+func TestCleanupWorktree_Options(t *testing.T) {
+	const wtPath = "/tmp/testrepo.worktrees/feature"
+
+	for _, deleteBranch := range []bool{false, true} {
+		for _, closeWindow := range []bool{false, true} {
+			for _, force := range []bool{false, true} {
+				opts := cleanupOptions{DeleteBranch: deleteBranch, CloseWindow: closeWindow, Force: force}
+				t.Run(fmt.Sprintf("%+v", opts), func(t *testing.T) {
+					srv, gc, ic, sm := newTestServer(t)
+					require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
+						Repo:            "testrepo",
+						Branch:          "feature/login",
+						ClaudeSessionID: "sess-1",
+					}))
+					ic.sessions["sess-1"] = true
+
+					require.NoError(t, srv.cleanupWorktree("/tmp/testrepo", wtPath, "feature/login", opts))
+
+					require.Len(t, gc.removedWorktrees, 1)
+					assert.Equal(t, force, gc.removedWorktrees[0].force)
+
+					if deleteBranch {
+						assert.Equal(t, []string{"feature/login"}, gc.deletedBranches)
+					} else {
+						assert.Empty(t, gc.deletedBranches)
+					}
+
+					if closeWindow {
+						assert.Equal(t, []string{"sess-1"}, ic.closeCalls)
+					} else {
+						assert.Empty(t, ic.closeCalls)
+					}
+
+					ws, _ := sm.GetWorktree(wtPath)
+					assert.Nil(t, ws, "state should be removed")
+				})
+			}
+		}
+	}
+}
+
+func TestCleanupWorktree_RemoveFailsKeepsBranchAndState(t *testing.T) {
+	const wtPath = "/tmp/testrepo.worktrees/feature"
+	srv, gc, _, sm := newTestServer(t)
+	gc.worktreeRemoveErr = fmt.Errorf("worktree is locked")
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{Repo: "testrepo", Branch: "feature/login"}))
+
+	err := srv.cleanupWorktree("/tmp/testrepo", wtPath, "feature/login", cleanupOptions{DeleteBranch: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "locked")
+
+	assert.Empty(t, gc.deletedBranches)
+	ws, _ := sm.GetWorktree(wtPath)
+	assert.NotNil(t, ws, "state should be kept when removal fails")
+}
+
+func TestCleanupWorktree_KeepGoingAfterRemoveFails(t *testing.T) {
+	const wtPath = "/tmp/testrepo.worktrees/feature"
+	srv, gc, _, sm := newTestServer(t)
+	gc.worktreeRemoveErr = fmt.Errorf("worktree is locked")
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{Repo: "testrepo", Branch: "feature/login"}))
+
+	err := srv.cleanupWorktree("/tmp/testrepo", wtPath, "feature/login", cleanupOptions{DeleteBranch: true, KeepGoing: true})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"feature/login"}, gc.deletedBranches)
+	ws, _ := sm.GetWorktree(wtPath)
+	assert.Nil(t, ws, "state should be removed anyway")
+}
+
+func TestCleanupWorktree_ForceBranchOnlyWhenAsked(t *testing.T) {
+	const wtPath = "/tmp/testrepo.worktrees/feature"
+
+	for _, forceBranch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("ForceBranch=%v", forceBranch), func(t *testing.T) {
+			srv, gc, _, _ := newTestServer(t)
+			gc.branchDeleteErr = fmt.Errorf("branch not fully merged")
+
+			opts := cleanupOptions{DeleteBranch: true, ForceBranch: forceBranch}
+			require.NoError(t, srv.cleanupWorktree("/tmp/testrepo", wtPath, "feature/login", opts))
+
+			if forceBranch {
+				assert.Equal(t, []string{"feature/login"}, gc.deletedBranches)
+			} else {
+				assert.Empty(t, gc.deletedBranches, "an unmerged branch is kept")
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Tests: wt_sync
 // ---------------------------------------------------------------------------
@@ -843,6 +1041,33 @@ func TestHandleSync_CustomBaseBranch(t *testing.T) {
 	assert.Equal(t, "develop", gc.mergeCalls[0])
 }
 
+func TestHandleSync_RecordedBaseBranch(t *testing.T) {
+	srv, gc, _, sm := newTestServer(t)
+	ctx := context.Background()
+
+	gc.worktrees = []gitops.WorktreeInfo{
+		{Path: "/tmp/testrepo", Branch: "main", HEAD: "abc123"},
+		{Path: "/tmp/testrepo.worktrees/feature", Branch: "feature/login", HEAD: "def456"},
+	}
+	gc.commitsBehind = 2
+	require.NoError(t, sm.SetWorktree("/tmp/testrepo.worktrees/feature", &state.WorktreeState{
+		Branch:     "feature/login",
+		BaseBranch: "release",
+	}))
+
+	req := callToolReq("wt_sync", map[string]any{
+		"repo_path": "/tmp/testrepo",
+		"branch":    "feature/login",
+	})
+	result, err := srv.handleSync(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	// Should merge the worktree's recorded base, not the configured "main"
+	require.Len(t, gc.mergeCalls, 1)
+	assert.Equal(t, "release", gc.mergeCalls[0])
+}
+
 func TestHandleSync_WithFetch(t *testing.T) {
 	srv, gc, _, _ := newTestServer(t)
 	ctx := context.Background()
@@ -877,7 +1102,7 @@ func TestHandleMerge_LocalSuccess(t *testing.T) {
 		{Path: "/tmp/testrepo", Branch: "main", HEAD: "abc123"},
 		{Path: "/tmp/testrepo.worktrees/feature", Branch: "feature/login", HEAD: "def456"},
 	}
-	gc.hasUnpushed = true
+	gc.hasCommitsToMerge = true
 
 	req := callToolReq("wt_merge", map[string]any{
 		"repo_path": "/tmp/testrepo",
@@ -894,6 +1119,28 @@ func TestHandleMerge_LocalSuccess(t *testing.T) {
 	require.Len(t, gc.mergeCalls, 1)
 }
 
+func TestHandleMerge_PushedBranchStillMerges(t *testing.T) {
+	srv, gc, _, _ := newTestServer(t)
+	ctx := context.Background()
+
+	gc.worktrees = []gitops.WorktreeInfo{
+		{Path: "/tmp/testrepo", Branch: "main", HEAD: "abc123"},
+		{Path: "/tmp/testrepo.worktrees/feature", Branch: "feature/login", HEAD: "def456"},
+	}
+	// Up to date with its upstream, but its commits aren't on main yet
+	gc.hasUnpushed = false
+	gc.hasCommitsToMerge = true
+
+	req := callToolReq("wt_merge", map[string]any{
+		"repo_path": "/tmp/testrepo",
+		"branch":    "feature/login",
+	})
+	result, err := srv.handleMerge(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	require.Len(t, gc.mergeCalls, 1)
+}
+
 func TestHandleMerge_RebaseStrategy(t *testing.T) {
 	srv, gc, _, _ := newTestServer(t)
 	ctx := context.Background()
@@ -902,7 +1149,7 @@ func TestHandleMerge_RebaseStrategy(t *testing.T) {
 		{Path: "/tmp/testrepo", Branch: "main", HEAD: "abc123"},
 		{Path: "/tmp/testrepo.worktrees/feature", Branch: "feature/login", HEAD: "def456"},
 	}
-	gc.hasUnpushed = true
+	gc.hasCommitsToMerge = true
 
 	req := callToolReq("wt_merge", map[string]any{
 		"repo_path": "/tmp/testrepo",
@@ -926,7 +1173,7 @@ func TestHandleMerge_PR(t *testing.T) {
 		{Path: "/tmp/testrepo", Branch: "main", HEAD: "abc123"},
 		{Path: "/tmp/testrepo.worktrees/feature", Branch: "feature/login", HEAD: "def456"},
 	}
-	gc.hasUnpushed = true
+	gc.hasCommitsToMerge = true
 
 	req := callToolReq("wt_merge", map[string]any{
 		"repo_path": "/tmp/testrepo",
@@ -953,7 +1200,7 @@ func TestHandleMerge_LocalCleansUp(t *testing.T) {
 		{Path: "/tmp/testrepo", Branch: "main", HEAD: "abc123"},
 		{Path: "/tmp/testrepo.worktrees/feature", Branch: "feature/login", HEAD: "def456"},
 	}
-	gc.hasUnpushed = true
+	gc.hasCommitsToMerge = true
 
 	// Set state with session info so cleanup can close iTerm
 	require.NoError(t, sm.SetWorktree("/tmp/testrepo.worktrees/feature", &state.WorktreeState{
@@ -993,7 +1240,7 @@ func TestHandleMerge_NothingToMerge(t *testing.T) {
 		{Path: "/tmp/testrepo", Branch: "main", HEAD: "abc123"},
 		{Path: "/tmp/testrepo.worktrees/feature", Branch: "feature/login", HEAD: "abc123"},
 	}
-	gc.hasUnpushed = false
+	gc.hasCommitsToMerge = false
 
 	req := callToolReq("wt_merge", map[string]any{
 		"repo_path": "/tmp/testrepo",
@@ -1036,6 +1283,58 @@ func TestHandleMerge_WorktreeNotFound(t *testing.T) {
 	assert.True(t, result.IsError)
 }
 
+// ---------------------------------------------------------------------------
+// Tests: wt_health
+// ---------------------------------------------------------------------------
+
+func TestHandleHealth_Healthy(t *testing.T) {
+	srv, _, _, sm := newTestServer(t)
+	srv.cfg.Version = "1.2.3"
+	orig := lookPath
+	lookPath = func(string) (string, error) { return "/usr/bin/git", nil }
+	t.Cleanup(func() { lookPath = orig })
+
+	result, err := srv.handleHealth(context.Background(), callToolReq("wt_health", nil))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	var health map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &health))
+	assert.Equal(t, true, health["ok"])
+	assert.Equal(t, "1.2.3", health["version"])
+	assert.Equal(t, true, health["git_available"])
+	assert.Equal(t, "/usr/bin/git", health["git_path"])
+	assert.Equal(t, true, health["iterm_running"])
+	assert.Equal(t, sm.Path(), health["state_path"])
+	assert.Equal(t, true, health["state_readable"])
+	assert.NotContains(t, health, "state_error")
+
+	// No side effects: the state file is not created
+	assert.NoFileExists(t, sm.Path())
+}
+
+func TestHandleHealth_Unhealthy(t *testing.T) {
+	srv, _, ic, sm := newTestServer(t)
+	ic.running = false
+	orig := lookPath
+	lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+	t.Cleanup(func() { lookPath = orig })
+	require.NoError(t, os.WriteFile(sm.Path(), []byte("{not json"), 0644))
+
+	result, err := srv.handleHealth(context.Background(), callToolReq("wt_health", nil))
+	require.NoError(t, err)
+	assert.False(t, result.IsError, "health reports problems in its result, not as a tool error")
+
+	var health map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &health))
+	assert.Equal(t, false, health["ok"])
+	assert.Equal(t, "dev", health["version"])
+	assert.Equal(t, false, health["git_available"])
+	assert.Equal(t, false, health["iterm_running"])
+	assert.Equal(t, false, health["state_readable"])
+	assert.NotEmpty(t, health["state_error"])
+}
+
 // ---------------------------------------------------------------------------
 // Tests: Integration -- verify all tools are registered via HandleMessage
 // ---------------------------------------------------------------------------
@@ -1076,6 +1375,7 @@ func TestMCPIntegration_ListTools(t *testing.T) {
 		"wt_delete",
 		"wt_sync",
 		"wt_merge",
+		"wt_health",
 	}
 	for _, name := range expectedTools {
 		assert.True(t, toolNames[name], "expected tool %q to be registered", name)
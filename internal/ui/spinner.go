@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// isTerminal reports whether w is an interactive terminal, replaceable in tests.
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Spinner is a transient status line on ErrOut, e.g. "⠋ Fetching…".
+// A nil *Spinner is valid and does nothing.
+type Spinner struct {
+	w    io.Writer
+	msg  string
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// StartSpinner shows msg with an animated spinner until Stop is called.
+// It is a no-op (returning nil) when ErrOut is not a terminal or NoProgress is set,
+// so piped and machine-readable output never contains spinner frames, and in
+// verbose mode, whose messages and -vv git trace would be overwritten by it.
+func (u *UI) StartSpinner(msg string) *Spinner {
+	if u.NoProgress || u.Verbosity > 0 || !isTerminal(u.ErrOut) {
+		return nil
+	}
+	s := &Spinner{
+		w:    u.ErrOut,
+		msg:  msg,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *Spinner) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for i := 0; ; i++ {
+		_, _ = fmt.Fprintf(s.w, "\r%s %s…", blue(spinnerFrames[i%len(spinnerFrames)]), s.msg)
+		select {
+		case <-s.stop:
+			// Clear the status line so the next message starts on a clean line
+			_, _ = fmt.Fprint(s.w, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop clears the spinner line. It is safe to call more than once.
+func (s *Spinner) Stop() {
+	if s == nil {
+		return
+	}
+	s.once.Do(func() {
+		close(s.stop)
+		<-s.done
+	})
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for the spinner goroutine to write to.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func forceTerminal(t *testing.T) {
+	t.Helper()
+	orig := isTerminal
+	isTerminal = func(io.Writer) bool { return true }
+	t.Cleanup(func() { isTerminal = orig })
+}
+
+func TestSpinner_NonTTYIsSilent(t *testing.T) {
+	errOut := &syncBuffer{}
+	u := &UI{Out: &syncBuffer{}, ErrOut: errOut}
+
+	s := u.StartSpinner("Fetching")
+	assert.Nil(t, s)
+	s.Stop()
+
+	assert.Empty(t, errOut.String())
+}
+
+func TestSpinner_NoProgressIsSilent(t *testing.T) {
+	forceTerminal(t)
+	errOut := &syncBuffer{}
+	u := &UI{Out: &syncBuffer{}, ErrOut: errOut, NoProgress: true}
+
+	u.StartSpinner("Fetching").Stop()
+
+	assert.Empty(t, errOut.String())
+}
+
+func TestSpinner_VerboseIsSilent(t *testing.T) {
+	forceTerminal(t)
+	errOut := &syncBuffer{}
+	u := &UI{Out: &syncBuffer{}, ErrOut: errOut, Verbosity: 2}
+
+	u.StartSpinner("Fetching").Stop()
+	u.Trace("git fetch origin (12ms)")
+
+	out := errOut.String()
+	assert.NotContains(t, out, "Fetching…")
+	assert.Contains(t, out, "git fetch origin (12ms)")
+}
+
+func TestSpinner_TTYShowsAndClears(t *testing.T) {
+	forceTerminal(t)
+	errOut := &syncBuffer{}
+	u := &UI{Out: &syncBuffer{}, ErrOut: errOut}
+
+	s := u.StartSpinner("Merging")
+	s.Stop()
+	s.Stop() // idempotent
+
+	out := errOut.String()
+	assert.Contains(t, out, "Merging…")
+	assert.True(t, strings.HasSuffix(out, "\r\033[K"), "spinner line should be cleared")
+}
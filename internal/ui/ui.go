@@ -11,11 +11,16 @@ import (
 )
 
 // UI provides colored output and respects verbose/dry-run modes.
+//
+// Messages (Info, Success, Warning, Error, verbose and trace logs) go to
+// ErrOut; Out is reserved for the data a command was asked for (tables,
+// JSON, paths) so that stdout can be piped or captured cleanly.
 type UI struct {
-	Verbose bool
-	DryRun  bool
-	Out     io.Writer
-	ErrOut  io.Writer
+	Verbosity  int // 0 normal, 1 verbose (-v), 2 also traces commands (-vv)
+	DryRun     bool
+	NoProgress bool // suppress spinners, e.g. for machine-readable output
+	Out        io.Writer
+	ErrOut     io.Writer
 }
 
 // New creates a UI with default stdout/stderr writers.
@@ -32,6 +37,8 @@ var (
 	warningPrefix = color.New(color.FgHiYellow).Sprint("⚠")
 	errorPrefix   = color.New(color.FgHiRed).Sprint("✗")
 	verbosePrefix = color.New(color.FgHiBlue).Sprint("  →")
+	tracePrefix   = color.New(color.FgHiBlack).Sprint("  $")
+	blue          = color.New(color.FgHiBlue).SprintFunc()
 	cyan          = color.New(color.FgHiCyan).SprintFunc()
 	green         = color.New(color.FgHiGreen).SprintFunc()
 	yellow        = color.New(color.FgHiYellow).SprintFunc()
@@ -58,11 +65,13 @@ func Red(s string) string {
 	return red(s)
 }
 
-// StatusColor returns the string colored by status: green for "open", yellow for "stale", red for "closed".
+// StatusColor returns the string colored by status: green for "open", cyan for "busy", yellow for "stale", red for "closed".
 func StatusColor(status string) string {
 	switch status {
 	case "open":
 		return green(status)
+	case "busy":
+		return cyan(status)
 	case "stale":
 		return yellow(status)
 	case "closed":
@@ -105,11 +114,11 @@ func GitStatusColor(status string) string {
 }
 
 func (u *UI) Info(format string, a ...any) {
-	_, _ = fmt.Fprintf(u.Out, "%s %s\n", infoPrefix, fmt.Sprintf(format, a...))
+	_, _ = fmt.Fprintf(u.ErrOut, "%s %s\n", infoPrefix, fmt.Sprintf(format, a...))
 }
 
 func (u *UI) Success(format string, a ...any) {
-	_, _ = fmt.Fprintf(u.Out, "%s %s\n", successPrefix, fmt.Sprintf(format, a...))
+	_, _ = fmt.Fprintf(u.ErrOut, "%s %s\n", successPrefix, fmt.Sprintf(format, a...))
 }
 
 func (u *UI) Warning(format string, a ...any) {
@@ -121,8 +130,15 @@ func (u *UI) Error(format string, a ...any) {
 }
 
 func (u *UI) VerboseLog(format string, a ...any) {
-	if u.Verbose {
-		_, _ = fmt.Fprintf(u.Out, "%s %s\n", verbosePrefix, fmt.Sprintf(format, a...))
+	if u.Verbosity >= 1 {
+		_, _ = fmt.Fprintf(u.ErrOut, "%s %s\n", verbosePrefix, fmt.Sprintf(format, a...))
+	}
+}
+
+// Trace logs low-level detail such as executed commands to ErrOut at -vv.
+func (u *UI) Trace(format string, a ...any) {
+	if u.Verbosity >= 2 {
+		_, _ = fmt.Fprintf(u.ErrOut, "%s %s\n", tracePrefix, fmt.Sprintf(format, a...))
 	}
 }
 
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessagesGoToErrOut(t *testing.T) {
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	u := &UI{Verbosity: 1, DryRun: true, Out: out, ErrOut: errOut}
+
+	u.Info("info")
+	u.Success("success")
+	u.Warning("warning")
+	u.Error("error")
+	u.VerboseLog("verbose")
+	u.DryRunMsg("dry-run")
+
+	assert.Empty(t, out.String(), "stdout is reserved for data")
+	for _, msg := range []string{"info", "success", "warning", "error", "verbose", "[DRY-RUN] dry-run"} {
+		assert.Contains(t, errOut.String(), msg)
+	}
+}
+
+func TestVerbosityLevels(t *testing.T) {
+	tests := []struct {
+		level     int
+		wantLog   bool
+		wantTrace bool
+	}{
+		{0, false, false},
+		{1, true, false},
+		{2, true, true},
+	}
+	for _, tt := range tests {
+		errOut := &bytes.Buffer{}
+		u := &UI{Verbosity: tt.level, Out: &bytes.Buffer{}, ErrOut: errOut}
+
+		u.VerboseLog("checking status")
+		u.Trace("git status --porcelain (3ms)")
+
+		assert.Equal(t, tt.wantLog, bytes.Contains(errOut.Bytes(), []byte("checking status")), "level %d verbose log", tt.level)
+		assert.Equal(t, tt.wantTrace, bytes.Contains(errOut.Bytes(), []byte("git status --porcelain (3ms)")), "level %d trace", tt.level)
+	}
+}
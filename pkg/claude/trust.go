@@ -2,8 +2,10 @@ package claude
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -114,24 +116,25 @@ func (m *TrustManager) UntrustProject(projectPath string) error {
 }
 
 // PruneProjects removes project entries whose paths are under worktreesDir
-// and whose directories no longer exist on disk. Returns the number pruned.
-func (m *TrustManager) PruneProjects(worktreesDir string) (int, error) {
+// and whose directories no longer exist on disk. Returns the pruned project
+// paths in sorted order; with dryRun they are returned but left in place.
+func (m *TrustManager) PruneProjects(worktreesDir string, dryRun bool) ([]string, error) {
 	top, err := m.loadRaw()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	projectsRaw, ok := top["projects"]
 	if !ok {
-		return 0, nil
+		return nil, nil
 	}
 
 	var projects map[string]json.RawMessage
 	if err := json.Unmarshal(projectsRaw, &projects); err != nil {
-		return 0, nil
+		return nil, nil
 	}
 
-	pruned := 0
+	var pruned []string
 	for key := range projects {
 		projectPath := decodeProjectKey(key)
 		if !strings.HasPrefix(projectPath, worktreesDir+string(filepath.Separator)) {
@@ -139,11 +142,12 @@ func (m *TrustManager) PruneProjects(worktreesDir string) (int, error) {
 		}
 		if _, err := os.Stat(projectPath); os.IsNotExist(err) {
 			delete(projects, key)
-			pruned++
+			pruned = append(pruned, projectPath)
 		}
 	}
+	sort.Strings(pruned)
 
-	if pruned > 0 {
+	if len(pruned) > 0 && !dryRun {
 		projectsData, err := json.Marshal(projects)
 		if err != nil {
 			return pruned, err
@@ -152,7 +156,7 @@ func (m *TrustManager) PruneProjects(worktreesDir string) (int, error) {
 		return pruned, m.saveRaw(top)
 	}
 
-	return 0, nil
+	return pruned, nil
 }
 
 // loadRaw reads the config file into a top-level map preserving all fields.
@@ -191,7 +195,7 @@ func (m *TrustManager) saveRaw(top map[string]json.RawMessage) error {
 
 	tmp := m.path + ".tmp"
 	if err := os.WriteFile(tmp, data, 0644); err != nil {
-		return err
+		return fmt.Errorf("%s is not writable: %w", dir, err)
 	}
 	return os.Rename(tmp, m.path)
 }
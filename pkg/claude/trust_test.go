@@ -41,6 +41,18 @@ func TestTrustProject_NewFile(t *testing.T) {
 	assert.True(t, trusted)
 }
 
+func TestTrustProject_UnwritableDir(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".claude.json")
+	// Stands in for a read-only directory, which root could still write to
+	require.NoError(t, os.Mkdir(path+".tmp", 0755))
+
+	_, err := NewTrustManager(path).TrustProject("/Users/joe/worktrees/auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), dir+" is not writable")
+	assert.NoFileExists(t, path)
+}
+
 func TestTrustProject_PreservesTopLevelFields(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, ".claude.json")
@@ -218,10 +230,20 @@ func TestPruneProjects(t *testing.T) {
 	_, err = mgr.TrustProject(outsidePath)
 	require.NoError(t, err)
 
+	// A dry run reports the stale entry without touching the file
+	before, err := os.ReadFile(path)
+	require.NoError(t, err)
+	pruned, err := mgr.PruneProjects(worktreesDir, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{stalePath}, pruned)
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
 	// Prune
-	pruned, err := mgr.PruneProjects(worktreesDir)
+	pruned, err = mgr.PruneProjects(worktreesDir, false)
 	require.NoError(t, err)
-	assert.Equal(t, 1, pruned, "should prune only the stale entry under worktreesDir")
+	assert.Equal(t, []string{stalePath}, pruned, "should prune only the stale entry under worktreesDir")
 
 	// Verify: existing still present, stale gone, outside preserved
 	data, err := os.ReadFile(path)
@@ -243,9 +265,9 @@ func TestPruneProjects_NothingToPrune(t *testing.T) {
 	path := filepath.Join(dir, ".claude.json")
 	mgr := NewTrustManager(path)
 
-	pruned, err := mgr.PruneProjects("/some/worktrees")
+	pruned, err := mgr.PruneProjects("/some/worktrees", false)
 	require.NoError(t, err)
-	assert.Equal(t, 0, pruned)
+	assert.Empty(t, pruned)
 }
 
 func TestDefaultPath(t *testing.T) {
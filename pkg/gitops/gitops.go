@@ -5,14 +5,93 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // WorktreeInfo holds parsed worktree metadata from `git worktree list --porcelain`.
 type WorktreeInfo struct {
-	Path   string
-	Branch string
-	HEAD   string
+	Path     string
+	Branch   string // empty for a detached HEAD
+	HEAD     string
+	Locked   bool // `git worktree lock`ed; git refuses to prune or move it
+	Prunable bool // directory is gone; `git worktree prune` would drop it
+	Bare     bool // the bare repository itself (no working tree)
+}
+
+// FFMode selects how `git merge` treats a merge that could fast-forward.
+type FFMode string
+
+const (
+	FFDefault FFMode = ""      // git's default: fast-forward when possible
+	FFOnly    FFMode = "only"  // --ff-only: fail unless the merge can fast-forward
+	NoFF      FFMode = "no-ff" // --no-ff: always create a merge commit
+)
+
+// MergeRunOptions configures a single `git merge` invocation.
+type MergeRunOptions struct {
+	FF       FFMode // ignored with Squash
+	Squash   bool   // --squash, then commit the result as a single commit
+	NoVerify bool   // --no-verify: skip the pre-merge-commit and commit-msg hooks
+	Message  string // commit message (-m); empty keeps git's default
+	Sign     bool   // GPG-sign the merge (or squash) commit
+	SignKey  string // with Sign, the key to sign with; empty uses user.signingkey
+
+	StrategyOptions []string // each passed as -X <opt> (e.g. "ours", "patience")
+}
+
+// RebaseRunOptions configures a single `git rebase` invocation. The zero
+// value is a plain `git rebase <upstream>`.
+type RebaseRunOptions struct {
+	StrategyOptions []string // each passed as -X <opt>
+	Sign            bool     // GPG-sign the rebased commits
+	SignKey         string   // with Sign, the key to sign with; empty uses user.signingkey
+	RebaseMerges    bool     // keep the branch's merge commits (--rebase-merges) instead of flattening them
+}
+
+// CherryPickRunOptions configures a single `git cherry-pick` invocation.
+type CherryPickRunOptions struct {
+	Sign    bool   // GPG-sign the picked commit
+	SignKey string // with Sign, the key to sign with; empty uses user.signingkey
+}
+
+// ValidateStrategyOption rejects values that can't be a merge strategy
+// option, such as empty strings, flags, or anything containing whitespace.
+func ValidateStrategyOption(opt string) error {
+	if opt == "" {
+		return fmt.Errorf("strategy option must not be empty")
+	}
+	if strings.HasPrefix(opt, "-") {
+		return fmt.Errorf("invalid strategy option '%s': pass the option name without dashes (e.g. 'ours')", opt)
+	}
+	if strings.ContainsFunc(opt, unicode.IsSpace) {
+		return fmt.Errorf("invalid strategy option '%s': must not contain whitespace", opt)
+	}
+	return nil
+}
+
+// strategyOptionArgs turns strategy options into -X arguments.
+func strategyOptionArgs(opts []string) []string {
+	var args []string
+	for _, o := range opts {
+		args = append(args, "-X", o)
+	}
+	return args
+}
+
+// signArgs returns -S, or --gpg-sign=<key> for an explicit key, when sign is set.
+func signArgs(sign bool, key string) []string {
+	switch {
+	case !sign:
+		return nil
+	case key != "":
+		return []string{"--gpg-sign=" + key}
+	default:
+		return []string{"-S"}
+	}
 }
 
 // Client defines the interface for git operations.
@@ -20,25 +99,34 @@ type WorktreeInfo struct {
 // enabling path-based operation without relying on CWD.
 // Pure utility functions (BranchToDirname, ResolveWorktreePath) are package-level functions.
 type Client interface {
+	Clone(url, dir string, bare bool) error
 	RepoRoot(repoPath string) (string, error)
 	RepoName(repoPath string) (string, error)
 	WorktreesDir(repoPath string) (string, error)
 	WorktreeList(repoPath string) ([]WorktreeInfo, error)
-	WorktreeAdd(repoPath, wtPath, branch, base string, newBranch bool) error
+	WorktreeAdd(repoPath, wtPath, branch, base string, newBranch, force bool) error
 	WorktreeRemove(repoPath, wtPath string, force bool) error
 	BranchExists(repoPath, branch string) (bool, error)
 	BranchDelete(repoPath, branch string, force bool) error
 	CurrentBranch(worktreePath string) (string, error)
+	Checkout(repoPath, branch string) error
 	ResolveWorktree(repoPath, input string) (string, error)
 	BranchList(repoPath string) ([]string, error)
 	IsWorktreeDirty(path string) (bool, error)
 	HasUnpushedCommits(path, baseBranch string) (bool, error)
+	StashCount(path string) (int, error)
+	HasCommitsToMerge(path, baseBranch string) (bool, error)
 	WorktreePrune(repoPath string) error
-	Merge(repoPath, branch string) error
+	WorktreeRepair(repoPath string) error
+	Merge(repoPath, branch string, opts MergeRunOptions) error
+	CherryPick(repoPath, ref string, opts CherryPickRunOptions) error
+	CherryPickContinue(repoPath string) error
+	CherryPickAbort(repoPath string) error
 	MergeContinue(repoPath string) error
+	MergeAbort(repoPath string) error
 	IsMergeInProgress(repoPath string) (bool, error)
 	HasConflicts(repoPath string) (bool, error)
-	Rebase(repoPath, branch string) error
+	Rebase(repoPath, upstream string, opts RebaseRunOptions) error
 	RebaseContinue(repoPath string) error
 	RebaseAbort(repoPath string) error
 	IsRebaseInProgress(repoPath string) (bool, error)
@@ -48,18 +136,107 @@ type Client interface {
 	Fetch(repoPath string) error
 	CommitsAhead(worktreePath, baseBranch string) (int, error)
 	CommitsBehind(worktreePath, baseBranch string) (int, error)
+	AheadBehind(worktreePath, baseBranch string) (ahead, behind int, err error)
+	CommitSubjects(repoPath, baseBranch, branch string) ([]string, error)
+	Reflog(repoPath, ref string) ([]ReflogEntry, error)
+	IsAncestor(repoPath, maybeAncestor, ref string) (bool, error)
+	RefExists(repoPath, ref string) (bool, error)
+	RefSHA(repoPath, ref string) (string, error)
+	HeadSHA(path string) (string, error)
+	ResetHard(repoPath, ref string) error
+	SetLocalConfig(path, key, value string) error
+	ConfigValue(path, key string) (string, error)
+	RemoteBranchSHA(path, remote, branch string) (string, error)
 }
 
 // RealClient implements Client using real git commands.
-type RealClient struct{}
+type RealClient struct {
+	// Runner executes every git command; nil uses ExecRunner. Wrap it (e.g.
+	// with TracingRunner) to observe the commands being run.
+	Runner CommandRunner
+}
 
 // NewClient returns a new RealClient.
 func NewClient() *RealClient {
 	return &RealClient{}
 }
 
+// CommandRunner executes a prepared git command and returns its output:
+// stdout and stderr when combined is true (like CombinedOutput), otherwise
+// just stdout (like Output).
+type CommandRunner func(cmd *exec.Cmd, combined bool) ([]byte, error)
+
+// ExecRunner runs cmd directly.
+func ExecRunner(cmd *exec.Cmd, combined bool) ([]byte, error) {
+	if combined {
+		return cmd.CombinedOutput()
+	}
+	return cmd.Output()
+}
+
+// TracingRunner wraps next, reporting each command line, how long it took,
+// and its error (if any) to trace once the command finishes. Only arguments
+// are reported, never the environment.
+func TracingRunner(next CommandRunner, trace func(cmdline string, elapsed time.Duration, err error)) CommandRunner {
+	return func(cmd *exec.Cmd, combined bool) ([]byte, error) {
+		start := time.Now()
+		out, err := next(cmd, combined)
+		trace(CommandLine(cmd), time.Since(start), err)
+		return out, err
+	}
+}
+
+// CommandLine formats cmd as a shell-like string, quoting arguments that
+// contain spaces or are empty.
+func CommandLine(cmd *exec.Cmd) string {
+	parts := make([]string, len(cmd.Args))
+	for i, arg := range cmd.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+			arg = strconv.Quote(arg)
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
+
+func (c *RealClient) run(cmd *exec.Cmd, combined bool) ([]byte, error) {
+	if c.Runner != nil {
+		return c.Runner(cmd, combined)
+	}
+	return ExecRunner(cmd, combined)
+}
+
+// Clone clones url into dir. With bare, the repository is cloned bare into
+// dir/.bare with a dir/.git file pointing at it, so dir is the repo root but
+// has no checkout of its own; origin's branches are fetched as remote-tracking
+// refs, as in a normal clone.
+func (c *RealClient) Clone(url, dir string, bare bool) error {
+	if !bare {
+		out, err := c.run(exec.Command("git", "clone", url, dir), true)
+		if err != nil {
+			return fmt.Errorf("git clone failed: %s: %w", strings.TrimSpace(string(out)), err)
+		}
+		return nil
+	}
+
+	out, err := c.run(exec.Command("git", "clone", "--bare", url, filepath.Join(dir, ".bare")), true)
+	if err != nil {
+		return fmt.Errorf("git clone --bare failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: ./.bare\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write .git file: %w", err)
+	}
+	// A bare clone maps origin's branches straight to local ones; restore the
+	// remote-tracking refs that sync and merge compare against
+	out, err = c.run(exec.Command("git", "-C", dir, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*"), true)
+	if err != nil {
+		return fmt.Errorf("git config remote.origin.fetch failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return c.Fetch(dir)
+}
+
 func (c *RealClient) RepoRoot(repoPath string) (string, error) {
-	out, err := exec.Command("git", "-C", repoPath, "rev-parse", "--git-common-dir").Output()
+	out, err := c.run(exec.Command("git", "-C", repoPath, "rev-parse", "--git-common-dir"), false)
 	if err != nil {
 		return "", fmt.Errorf("not inside a git repository: %w", err)
 	}
@@ -111,12 +288,19 @@ func (c *RealClient) WorktreeList(repoPath string) ([]WorktreeInfo, error) {
 		return nil, err
 	}
 
-	out, err := exec.Command("git", "-C", root, "worktree", "list", "--porcelain").Output()
+	out, err := c.run(exec.Command("git", "-C", root, "worktree", "list", "--porcelain"), false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
-	return ParseWorktreeListPorcelain(string(out)), nil
+	// A bare repo (wt clone --bare) has no working tree to manage
+	var worktrees []WorktreeInfo
+	for _, wt := range ParseWorktreeListPorcelain(string(out)) {
+		if !wt.Bare {
+			worktrees = append(worktrees, wt)
+		}
+	}
+	return worktrees, nil
 }
 
 // ParseWorktreeListPorcelain parses the output of `git worktree list --porcelain`.
@@ -133,6 +317,12 @@ func ParseWorktreeListPorcelain(output string) []WorktreeInfo {
 		case strings.HasPrefix(line, "branch "):
 			branch := strings.TrimPrefix(line, "branch ")
 			current.Branch = strings.TrimPrefix(branch, "refs/heads/")
+		case line == "locked" || strings.HasPrefix(line, "locked "):
+			current.Locked = true
+		case line == "prunable" || strings.HasPrefix(line, "prunable "):
+			current.Prunable = true
+		case line == "bare":
+			current.Bare = true
 		case line == "":
 			if current.Path != "" {
 				worktrees = append(worktrees, current)
@@ -148,26 +338,47 @@ func ParseWorktreeListPorcelain(output string) []WorktreeInfo {
 	return worktrees
 }
 
-func (c *RealClient) WorktreeAdd(repoPath, wtPath, branch, base string, newBranch bool) error {
+func (c *RealClient) WorktreeAdd(repoPath, wtPath, branch, base string, newBranch, force bool) error {
 	root, err := c.RepoRoot(repoPath)
 	if err != nil {
 		return err
 	}
 
-	var cmd *exec.Cmd
+	args := []string{"-C", root, "worktree", "add"}
+	if force {
+		// Overrides a stale registration for wtPath (or branch) left by a
+		// worktree whose directory was removed out-of-band
+		args = append(args, "--force")
+	}
 	if newBranch {
-		cmd = exec.Command("git", "-C", root, "worktree", "add", "-b", branch, wtPath, base)
+		// Branching off origin/<base> would otherwise make it the upstream,
+		// so push and status would compare against base, not the branch
+		if c.isRemoteRef(root, base) {
+			args = append(args, "--no-track")
+		}
+		args = append(args, "-b", branch, wtPath, base)
 	} else {
-		cmd = exec.Command("git", "-C", root, "worktree", "add", wtPath, branch)
+		args = append(args, wtPath, branch)
 	}
+	cmd := exec.Command("git", args...)
 
-	out, err := cmd.CombinedOutput()
+	out, err := c.run(cmd, true)
 	if err != nil {
 		return fmt.Errorf("git worktree add failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
 	return nil
 }
 
+// isRemoteRef reports whether ref names a remote-tracking branch (e.g.
+// origin/main).
+func (c *RealClient) isRemoteRef(repoPath, ref string) bool {
+	if ref == "" {
+		return false
+	}
+	_, err := c.run(exec.Command("git", "-C", repoPath, "show-ref", "--verify", "--quiet", "refs/remotes/"+ref), false)
+	return err == nil
+}
+
 func (c *RealClient) WorktreeRemove(repoPath, wtPath string, force bool) error {
 	root, err := c.RepoRoot(repoPath)
 	if err != nil {
@@ -180,7 +391,7 @@ func (c *RealClient) WorktreeRemove(repoPath, wtPath string, force bool) error {
 	}
 	args = append(args, wtPath)
 
-	out, err := exec.Command("git", args...).CombinedOutput()
+	out, err := c.run(exec.Command("git", args...), true)
 	if err != nil {
 		return fmt.Errorf("git worktree remove failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
@@ -193,7 +404,7 @@ func (c *RealClient) BranchExists(repoPath, branch string) (bool, error) {
 		return false, err
 	}
 
-	err = exec.Command("git", "-C", root, "show-ref", "--verify", "--quiet", "refs/heads/"+branch).Run()
+	_, err = c.run(exec.Command("git", "-C", root, "show-ref", "--verify", "--quiet", "refs/heads/"+branch), false)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
 			return false, nil
@@ -214,7 +425,7 @@ func (c *RealClient) BranchDelete(repoPath, branch string, force bool) error {
 		flag = "-D"
 	}
 
-	out, err := exec.Command("git", "-C", root, "branch", flag, branch).CombinedOutput()
+	out, err := c.run(exec.Command("git", "-C", root, "branch", flag, branch), true)
 	if err != nil {
 		return fmt.Errorf("git branch delete failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
@@ -227,7 +438,7 @@ func (c *RealClient) BranchList(repoPath string) ([]string, error) {
 		return nil, err
 	}
 
-	out, err := exec.Command("git", "-C", root, "branch", "--format=%(refname:short)").Output()
+	out, err := c.run(exec.Command("git", "-C", root, "branch", "--format=%(refname:short)"), false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list branches: %w", err)
 	}
@@ -242,43 +453,193 @@ func (c *RealClient) BranchList(repoPath string) ([]string, error) {
 }
 
 func (c *RealClient) IsWorktreeDirty(path string) (bool, error) {
-	out, err := exec.Command("git", "-C", path, "status", "--porcelain").Output()
+	out, err := c.run(exec.Command("git", "-C", path, "status", "--porcelain"), false)
 	if err != nil {
 		return false, fmt.Errorf("failed to check worktree status: %w", err)
 	}
 	return strings.TrimSpace(string(out)) != "", nil
 }
 
+// StashCount returns how many stash entries were made on the branch checked
+// out at path. The stash list is shared by every worktree of a repository, so
+// entries are matched by the branch recorded in their subject ("WIP on <branch>:"
+// or "On <branch>:").
+func (c *RealClient) StashCount(path string) (int, error) {
+	branch, err := c.CurrentBranch(path)
+	if err != nil {
+		return 0, err
+	}
+	out, err := c.run(exec.Command("git", "-C", path, "stash", "list", "--format=%gs"), false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list stashes: %w", err)
+	}
+	count := 0
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "WIP on "+branch+": ") || strings.HasPrefix(line, "On "+branch+": ") {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// HasUnpushedCommits reports whether HEAD at path has commits its upstream
+// lacks or, with no upstream configured, commits baseBranch lacks. Use
+// HasCommitsToMerge to decide whether there's anything to merge: a pushed
+// branch has no unpushed commits but may still be unmerged.
 func (c *RealClient) HasUnpushedCommits(path, baseBranch string) (bool, error) {
 	// Try upstream first
-	out, err := exec.Command("git", "-C", path, "log", "@{upstream}..HEAD", "--oneline").Output()
+	out, err := c.run(exec.Command("git", "-C", path, "log", "@{upstream}..HEAD", "--oneline"), false)
 	if err == nil {
 		return strings.TrimSpace(string(out)) != "", nil
 	}
 
 	// No upstream configured, fall back to baseBranch
-	out, err = exec.Command("git", "-C", path, "log", baseBranch+"..HEAD", "--oneline").Output()
+	out, err = c.run(exec.Command("git", "-C", path, "log", baseBranch+"..HEAD", "--oneline"), false)
 	if err != nil {
 		return false, fmt.Errorf("failed to check unpushed commits: %w", err)
 	}
 	return strings.TrimSpace(string(out)) != "", nil
 }
 
+// HasCommitsToMerge reports whether HEAD at path has commits of its own since
+// it forked from baseBranch (merge-base..HEAD). Commits added to baseBranch
+// after the fork don't count.
+func (c *RealClient) HasCommitsToMerge(path, baseBranch string) (bool, error) {
+	out, err := c.run(exec.Command("git", "-C", path, "merge-base", baseBranch, "HEAD"), false)
+	if err != nil {
+		return false, fmt.Errorf("failed to find merge base with '%s': %w", baseBranch, err)
+	}
+	mergeBase := strings.TrimSpace(string(out))
+
+	out, err = c.run(exec.Command("git", "-C", path, "rev-list", "--count", mergeBase+"..HEAD"), false)
+	if err != nil {
+		return false, fmt.Errorf("failed to count commits to merge: %w", err)
+	}
+	return strings.TrimSpace(string(out)) != "0", nil
+}
+
 func (c *RealClient) WorktreePrune(repoPath string) error {
 	root, err := c.RepoRoot(repoPath)
 	if err != nil {
 		return err
 	}
 
-	out, err := exec.Command("git", "-C", root, "worktree", "prune").CombinedOutput()
+	out, err := c.run(exec.Command("git", "-C", root, "worktree", "prune"), true)
 	if err != nil {
 		return fmt.Errorf("git worktree prune failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
 	return nil
 }
 
+// WorktreeRepair runs git worktree repair from the main repository, rewriting
+// the links between it and its worktrees (e.g. after the main repo was moved).
+func (c *RealClient) WorktreeRepair(repoPath string) error {
+	root, err := c.RepoRoot(repoPath)
+	if err != nil {
+		return err
+	}
+
+	out, err := c.run(exec.Command("git", "-C", root, "worktree", "repair"), true)
+	if err != nil {
+		return fmt.Errorf("git worktree repair failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// GitdirBroken reports whether the worktree at wtPath has a .git file whose
+// gitdir pointer names a path that no longer exists. A missing or unreadable
+// .git file, or a .git directory (the main worktree), is not reported.
+func GitdirBroken(wtPath string) bool {
+	gitDir, ok := readGitdirFile(wtPath)
+	return ok && !isDir(gitDir)
+}
+
+// BranchDuplicate is a branch that more than one worktree has checked out.
+type BranchDuplicate struct {
+	Branch string
+	Paths  []string // in WorktreeList order
+}
+
+// DuplicateBranches returns the branches that more than one of worktrees
+// reports, sorted by branch. Git normally refuses this, but forced checkouts
+// and hand-edited worktrees can produce it, leaving ResolveWorktree to pick
+// one of them arbitrarily. Detached worktrees are ignored.
+func DuplicateBranches(worktrees []WorktreeInfo) []BranchDuplicate {
+	paths := map[string][]string{}
+	for _, wt := range worktrees {
+		if wt.Branch != "" {
+			paths[wt.Branch] = append(paths[wt.Branch], wt.Path)
+		}
+	}
+
+	var dups []BranchDuplicate
+	for branch, p := range paths {
+		if len(p) > 1 {
+			dups = append(dups, BranchDuplicate{Branch: branch, Paths: p})
+		}
+	}
+	sort.Slice(dups, func(i, j int) bool { return dups[i].Branch < dups[j].Branch })
+	return dups
+}
+
+// readGitdirFile returns the absolute gitdir named by wtPath/.git when it is a
+// "gitdir: <path>" file. Relative paths are resolved against wtPath.
+func readGitdirFile(wtPath string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(wtPath, ".git"))
+	if err != nil {
+		return "", false
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", false
+	}
+	return resolveRelative(wtPath, strings.TrimSpace(gitDir)), true
+}
+
+// resolveGitDir returns the per-worktree git directory for path (where
+// MERGE_HEAD and rebase state live) and the common git directory shared by all
+// worktrees. For a linked worktree the .git file's gitdir and the admin dir's
+// commondir file may both be relative; they are resolved against the worktree
+// and the admin dir respectively.
+func resolveGitDir(path string) (gitDir, commonDir string, err error) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot find .git directory: %w", err)
+	}
+
+	gitDir = dotGit
+	if !info.IsDir() {
+		var ok bool
+		gitDir, ok = readGitdirFile(path)
+		if !ok {
+			return "", "", fmt.Errorf("invalid .git file in %s", path)
+		}
+		if !isDir(gitDir) {
+			return "", "", fmt.Errorf("cannot find .git directory: %s does not exist (run 'wt repair')", gitDir)
+		}
+	}
+
+	commonDir = gitDir
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir = resolveRelative(gitDir, strings.TrimSpace(string(data)))
+		if !isDir(commonDir) {
+			return "", "", fmt.Errorf("cannot find common git directory: %s does not exist (run 'wt repair')", commonDir)
+		}
+	}
+	return gitDir, commonDir, nil
+}
+
+// resolveRelative returns p as-is if absolute, otherwise joined onto base.
+func resolveRelative(base, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(base, p)
+}
+
 func (c *RealClient) CurrentBranch(worktreePath string) (string, error) {
-	out, err := exec.Command("git", "-C", worktreePath, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	out, err := c.run(exec.Command("git", "-C", worktreePath, "rev-parse", "--abbrev-ref", "HEAD"), false)
 	if err != nil {
 		return "", fmt.Errorf("failed to get current branch: %w", err)
 	}
@@ -385,39 +746,143 @@ func BranchToDirname(branch string) string {
 	return parts[len(parts)-1]
 }
 
-func (c *RealClient) Merge(repoPath, branch string) error {
-	out, err := exec.Command("git", "-C", repoPath, "merge", branch, "--no-edit").CombinedOutput()
+// maxSlugLen caps the length of a branch name made by Slugify.
+const maxSlugLen = 50
+
+// Slugify turns a free-form description into a branch-name segment:
+// lowercase ASCII letters and digits, with every other run of characters
+// collapsed to a single hyphen ("Try new caching layer!" -> "try-new-caching-layer").
+// Long results are cut at a hyphen where possible. Returns "" if the
+// description has no letters or digits.
+func Slugify(desc string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(desc) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+	slug := b.String()
+	if len(slug) > maxSlugLen {
+		slug = slug[:maxSlugLen]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	return strings.TrimSuffix(slug, "-")
+}
+
+// Merge merges branch into the branch checked out at repoPath, as configured
+// by opts.
+func (c *RealClient) Merge(repoPath, branch string, opts MergeRunOptions) error {
+	args := []string{"-C", repoPath, "merge", branch}
+	switch {
+	case opts.Squash:
+		args = append(args, "--squash")
+	case opts.FF == FFOnly:
+		args = append(args, "--ff-only")
+	default:
+		args = append(args, "--no-edit")
+		if opts.FF == NoFF {
+			args = append(args, "--no-ff")
+		}
+		if opts.Message != "" {
+			args = append(args, "-m", opts.Message)
+		}
+		args = append(args, signArgs(opts.Sign, opts.SignKey)...)
+	}
+	if opts.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	args = append(args, strategyOptionArgs(opts.StrategyOptions)...)
+	out, err := c.run(exec.Command("git", args...), true)
 	if err != nil {
 		return fmt.Errorf("git merge failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
+
+	if !opts.Squash {
+		return nil
+	}
+
+	// --squash stages the changes but never commits
+	commitArgs := []string{"-C", repoPath, "commit", "--no-edit"}
+	if opts.Message != "" {
+		commitArgs = append(commitArgs, "-m", opts.Message)
+	}
+	if opts.NoVerify {
+		commitArgs = append(commitArgs, "--no-verify")
+	}
+	commitArgs = append(commitArgs, signArgs(opts.Sign, opts.SignKey)...)
+	out, err = c.run(exec.Command("git", commitArgs...), true)
+	if err != nil {
+		return fmt.Errorf("git commit failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// CherryPick applies the commit ref names onto the branch checked out in
+// repoPath. Like a merge, it leaves ORIG_HEAD at the commit before the pick,
+// which git cherry-pick doesn't do on its own. On a conflict the pick stays in
+// progress; check HasConflicts, then CherryPickContinue or CherryPickAbort.
+func (c *RealClient) CherryPick(repoPath, ref string, opts CherryPickRunOptions) error {
+	if out, err := c.run(exec.Command("git", "-C", repoPath, "update-ref", "ORIG_HEAD", "HEAD"), false); err != nil {
+		return fmt.Errorf("failed to record ORIG_HEAD: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	args := append([]string{"-C", repoPath, "cherry-pick"}, signArgs(opts.Sign, opts.SignKey)...)
+	out, err := c.run(exec.Command("git", append(args, ref)...), true)
+	if err != nil {
+		return fmt.Errorf("git cherry-pick failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func (c *RealClient) CherryPickContinue(repoPath string) error {
+	cmd := exec.Command("git", "-C", repoPath, "cherry-pick", "--continue")
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true") // skip editor prompt
+	out, err := c.run(cmd, true)
+	if err != nil {
+		return fmt.Errorf("git cherry-pick --continue failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func (c *RealClient) CherryPickAbort(repoPath string) error {
+	out, err := c.run(exec.Command("git", "-C", repoPath, "cherry-pick", "--abort"), true)
+	if err != nil {
+		return fmt.Errorf("git cherry-pick --abort failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
 	return nil
 }
 
 func (c *RealClient) MergeContinue(repoPath string) error {
 	cmd := exec.Command("git", "-C", repoPath, "merge", "--continue")
 	cmd.Env = append(os.Environ(), "GIT_EDITOR=true") // skip editor prompt
-	out, err := cmd.CombinedOutput()
+	out, err := c.run(cmd, true)
 	if err != nil {
 		return fmt.Errorf("git merge --continue failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
 	return nil
 }
 
+func (c *RealClient) MergeAbort(repoPath string) error {
+	out, err := c.run(exec.Command("git", "-C", repoPath, "merge", "--abort"), true)
+	if err != nil {
+		return fmt.Errorf("git merge --abort failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
 func (c *RealClient) IsMergeInProgress(repoPath string) (bool, error) {
 	// git has a MERGE_HEAD file when a merge is in progress
-	gitDir := filepath.Join(repoPath, ".git")
-	info, err := os.Stat(gitDir)
+	gitDir, _, err := resolveGitDir(repoPath)
 	if err != nil {
-		return false, fmt.Errorf("cannot find .git directory: %w", err)
-	}
-	// If .git is a file (worktree), read the actual git dir
-	if !info.IsDir() {
-		data, err := os.ReadFile(gitDir)
-		if err != nil {
-			return false, err
-		}
-		// Format: "gitdir: /path/to/actual/.git/worktrees/name"
-		gitDir = strings.TrimSpace(strings.TrimPrefix(string(data), "gitdir: "))
+		return false, err
 	}
 	_, err = os.Stat(filepath.Join(gitDir, "MERGE_HEAD"))
 	if err != nil {
@@ -430,15 +895,21 @@ func (c *RealClient) IsMergeInProgress(repoPath string) (bool, error) {
 }
 
 func (c *RealClient) HasConflicts(repoPath string) (bool, error) {
-	out, err := exec.Command("git", "-C", repoPath, "diff", "--name-only", "--diff-filter=U").Output()
+	out, err := c.run(exec.Command("git", "-C", repoPath, "diff", "--name-only", "--diff-filter=U"), false)
 	if err != nil {
 		return false, fmt.Errorf("failed to check for conflicts: %w", err)
 	}
 	return strings.TrimSpace(string(out)) != "", nil
 }
 
-func (c *RealClient) Rebase(repoPath, branch string) error {
-	out, err := exec.Command("git", "-C", repoPath, "rebase", branch).CombinedOutput()
+// Rebase rebases the branch checked out at repoPath onto upstream.
+func (c *RealClient) Rebase(repoPath, upstream string, opts RebaseRunOptions) error {
+	args := append([]string{"-C", repoPath, "rebase"}, strategyOptionArgs(opts.StrategyOptions)...)
+	args = append(args, signArgs(opts.Sign, opts.SignKey)...)
+	if opts.RebaseMerges {
+		args = append(args, "--rebase-merges")
+	}
+	out, err := c.run(exec.Command("git", append(args, upstream)...), true)
 	if err != nil {
 		return fmt.Errorf("git rebase failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
@@ -448,7 +919,7 @@ func (c *RealClient) Rebase(repoPath, branch string) error {
 func (c *RealClient) RebaseContinue(repoPath string) error {
 	cmd := exec.Command("git", "-C", repoPath, "rebase", "--continue")
 	cmd.Env = append(os.Environ(), "GIT_EDITOR=true") // skip editor prompt
-	out, err := cmd.CombinedOutput()
+	out, err := c.run(cmd, true)
 	if err != nil {
 		return fmt.Errorf("git rebase --continue failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
@@ -456,7 +927,7 @@ func (c *RealClient) RebaseContinue(repoPath string) error {
 }
 
 func (c *RealClient) RebaseAbort(repoPath string) error {
-	out, err := exec.Command("git", "-C", repoPath, "rebase", "--abort").CombinedOutput()
+	out, err := c.run(exec.Command("git", "-C", repoPath, "rebase", "--abort"), true)
 	if err != nil {
 		return fmt.Errorf("git rebase --abort failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
@@ -464,18 +935,9 @@ func (c *RealClient) RebaseAbort(repoPath string) error {
 }
 
 func (c *RealClient) IsRebaseInProgress(repoPath string) (bool, error) {
-	gitDir := filepath.Join(repoPath, ".git")
-	info, err := os.Stat(gitDir)
+	gitDir, _, err := resolveGitDir(repoPath)
 	if err != nil {
-		return false, fmt.Errorf("cannot find .git directory: %w", err)
-	}
-	// If .git is a file (worktree), read the actual git dir
-	if !info.IsDir() {
-		data, err := os.ReadFile(gitDir)
-		if err != nil {
-			return false, err
-		}
-		gitDir = strings.TrimSpace(strings.TrimPrefix(string(data), "gitdir: "))
+		return false, err
 	}
 	// Check for rebase-merge or rebase-apply directories
 	if _, err := os.Stat(filepath.Join(gitDir, "rebase-merge")); err == nil {
@@ -487,8 +949,17 @@ func (c *RealClient) IsRebaseInProgress(repoPath string) (bool, error) {
 	return false, nil
 }
 
+// Checkout switches the working tree at repoPath to an existing branch.
+func (c *RealClient) Checkout(repoPath, branch string) error {
+	out, err := c.run(exec.Command("git", "-C", repoPath, "checkout", branch), true)
+	if err != nil {
+		return fmt.Errorf("git checkout failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
 func (c *RealClient) Pull(repoPath string) error {
-	out, err := exec.Command("git", "-C", repoPath, "pull").CombinedOutput()
+	out, err := c.run(exec.Command("git", "-C", repoPath, "pull"), true)
 	if err != nil {
 		return fmt.Errorf("git pull failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
@@ -500,7 +971,7 @@ func (c *RealClient) Push(worktreePath, branch string, setUpstream bool) error {
 	if setUpstream {
 		args = append(args, "-u", "origin", branch)
 	}
-	out, err := exec.Command("git", args...).CombinedOutput()
+	out, err := c.run(exec.Command("git", args...), true)
 	if err != nil {
 		return fmt.Errorf("git push failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
@@ -512,7 +983,7 @@ func (c *RealClient) HasRemote(repoPath string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	out, err := exec.Command("git", "-C", root, "remote").Output()
+	out, err := c.run(exec.Command("git", "-C", root, "remote"), false)
 	if err != nil {
 		return false, fmt.Errorf("failed to check remotes: %w", err)
 	}
@@ -520,7 +991,7 @@ func (c *RealClient) HasRemote(repoPath string) (bool, error) {
 }
 
 func (c *RealClient) Fetch(repoPath string) error {
-	out, err := exec.Command("git", "-C", repoPath, "fetch").CombinedOutput()
+	out, err := c.run(exec.Command("git", "-C", repoPath, "fetch"), true)
 	if err != nil {
 		return fmt.Errorf("git fetch failed: %s: %w", strings.TrimSpace(string(out)), err)
 	}
@@ -528,7 +999,7 @@ func (c *RealClient) Fetch(repoPath string) error {
 }
 
 func (c *RealClient) CommitsAhead(worktreePath, baseBranch string) (int, error) {
-	out, err := exec.Command("git", "-C", worktreePath, "rev-list", "--count", baseBranch+"..HEAD").Output()
+	out, err := c.run(exec.Command("git", "-C", worktreePath, "rev-list", "--count", baseBranch+"..HEAD"), false)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count commits ahead: %w", err)
 	}
@@ -541,7 +1012,7 @@ func (c *RealClient) CommitsAhead(worktreePath, baseBranch string) (int, error)
 }
 
 func (c *RealClient) CommitsBehind(worktreePath, baseBranch string) (int, error) {
-	out, err := exec.Command("git", "-C", worktreePath, "rev-list", "--count", "HEAD.."+baseBranch).Output()
+	out, err := c.run(exec.Command("git", "-C", worktreePath, "rev-list", "--count", "HEAD.."+baseBranch), false)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count commits behind: %w", err)
 	}
@@ -553,6 +1024,163 @@ func (c *RealClient) CommitsBehind(worktreePath, baseBranch string) (int, error)
 	return count, nil
 }
 
+// AheadBehind returns how many commits HEAD is ahead of and behind baseBranch
+// in a single git call.
+func (c *RealClient) AheadBehind(worktreePath, baseBranch string) (ahead, behind int, err error) {
+	out, err := c.run(exec.Command("git", "-C", worktreePath, "rev-list", "--left-right", "--count", "HEAD..."+baseBranch), false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count commits ahead/behind: %w", err)
+	}
+	_, err = fmt.Sscanf(strings.TrimSpace(string(out)), "%d\t%d", &ahead, &behind)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse commit counts: %w", err)
+	}
+	return ahead, behind, nil
+}
+
+// CommitSubjects returns the subject lines of commits on branch that are not
+// on baseBranch, oldest first.
+func (c *RealClient) CommitSubjects(repoPath, baseBranch, branch string) ([]string, error) {
+	out, err := c.run(exec.Command("git", "-C", repoPath, "log", "--reverse", "--format=%s", baseBranch+".."+branch), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	var subjects []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects, nil
+}
+
+// ReflogEntry is one entry of a ref's reflog, newest first.
+type ReflogEntry struct {
+	SHA      string // commit the ref pointed at after this entry
+	Selector string // e.g. "feature/auth@{2}"
+	Action   string // what moved the ref, e.g. "commit", "reset", "rebase (finish)"
+	Message  string // the rest of the reflog subject, e.g. "moving to HEAD~1"
+}
+
+// Reflog returns the reflog of ref (e.g. a branch), newest first. Useful for
+// finding commits a sync, merge or reset moved the branch away from.
+func (c *RealClient) Reflog(repoPath, ref string) ([]ReflogEntry, error) {
+	out, err := c.run(exec.Command("git", "-C", repoPath, "reflog", "show", "--format=%H%x1f%gd%x1f%gs", ref, "--"), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reflog of '%s': %s: %w", ref, strings.TrimSpace(string(out)), err)
+	}
+	var entries []ReflogEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		action, message, ok := strings.Cut(fields[2], ": ")
+		if !ok {
+			action, message = "", fields[2]
+		}
+		entries = append(entries, ReflogEntry{SHA: fields[0], Selector: fields[1], Action: action, Message: message})
+	}
+	return entries, nil
+}
+
+// IsAncestor reports whether maybeAncestor is reachable from ref, i.e. ref
+// already contains every commit of maybeAncestor.
+func (c *RealClient) IsAncestor(repoPath, maybeAncestor, ref string) (bool, error) {
+	_, err := c.run(exec.Command("git", "-C", repoPath, "merge-base", "--is-ancestor", maybeAncestor, ref), false)
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check ancestry of '%s' in '%s': %w", maybeAncestor, ref, err)
+	}
+	return true, nil
+}
+
+// RefExists reports whether ref (branch, tag, remote ref, or sha) resolves to
+// a commit.
+func (c *RealClient) RefExists(repoPath, ref string) (bool, error) {
+	_, err := c.run(exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}"), false)
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to resolve ref '%s': %w", ref, err)
+	}
+	return true, nil
+}
+
+// RefSHA returns the full hash of the commit ref (branch, tag, or sha)
+// resolves to.
+func (c *RealClient) RefSHA(repoPath, ref string) (string, error) {
+	out, err := c.run(exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}"), false)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ref '%s': %w", ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// HeadSHA returns the full commit hash HEAD points at in path.
+func (c *RealClient) HeadSHA(path string) (string, error) {
+	out, err := c.run(exec.Command("git", "-C", path, "rev-parse", "HEAD"), false)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ResetHard moves the branch checked out in repoPath to ref and makes the
+// working tree match it, discarding uncommitted changes. It doesn't guard
+// against that itself: callers must check IsWorktreeDirty first, and the CLI
+// must confirm with the user.
+func (c *RealClient) ResetHard(repoPath, ref string) error {
+	out, err := c.run(exec.Command("git", "-C", repoPath, "reset", "--hard", ref), true)
+	if err != nil {
+		return fmt.Errorf("git reset --hard failed: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// SetLocalConfig sets a git config value for the worktree at path only.
+// Worktree-scoped config needs extensions.worktreeConfig, which is enabled in
+// the repo first; without it 'git config' would write to the config shared by
+// every worktree.
+func (c *RealClient) SetLocalConfig(path, key, value string) error {
+	out, err := c.run(exec.Command("git", "-C", path, "config", "extensions.worktreeConfig", "true"), true)
+	if err != nil {
+		return fmt.Errorf("failed to enable per-worktree config: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	out, err = c.run(exec.Command("git", "-C", path, "config", "--worktree", key, value), true)
+	if err != nil {
+		return fmt.Errorf("git config %s failed: %s: %w", key, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// ConfigValue returns the effective value of git config key at path, or ""
+// if it isn't set.
+func (c *RealClient) ConfigValue(path, key string) (string, error) {
+	out, err := c.run(exec.Command("git", "-C", path, "config", "--get", key), false)
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil // key not set
+		}
+		return "", fmt.Errorf("git config --get %s failed: %w", key, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RemoteBranchSHA returns the commit the remote-tracking branch
+// <remote>/<branch> points at, as last updated by a fetch or push.
+func (c *RealClient) RemoteBranchSHA(path, remote, branch string) (string, error) {
+	ref := "refs/remotes/" + remote + "/" + branch
+	out, err := c.run(exec.Command("git", "-C", path, "rev-parse", "--verify", "--quiet", ref), false)
+	if err != nil {
+		return "", fmt.Errorf("no remote-tracking branch '%s/%s'", remote, branch)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func isDir(path string) bool {
 	info, err := os.Stat(path)
 	return err == nil && info.IsDir()
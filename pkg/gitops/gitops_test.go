@@ -1,10 +1,13 @@
 package gitops
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +34,29 @@ func TestBranchToDirname(t *testing.T) {
 	}
 }
 
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		desc string
+		want string
+	}{
+		{"try new caching layer", "try-new-caching-layer"},
+		{"  Try   New Caching LAYER  ", "try-new-caching-layer"},
+		{"fix: user's login (v2)!", "fix-user-s-login-v2"},
+		{"feature/auth -- retry", "feature-auth-retry"},
+		{"café über 2", "caf-ber-2"},
+		{"!!!", ""},
+		{"", ""},
+		{"one two three four five six seven eight nine ten eleven", "one-two-three-four-five-six-seven-eight-nine-ten"},
+		{strings.Repeat("x", 60), strings.Repeat("x", 50)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, Slugify(tt.desc))
+		})
+	}
+}
+
 func TestParseWorktreeListPorcelain(t *testing.T) {
 	input := `worktree /Users/joe/myrepo
 HEAD abc123def456
@@ -64,6 +90,53 @@ branch refs/heads/main`
 	assert.Equal(t, "main", got[0].Branch)
 }
 
+func TestParseWorktreeListPorcelain_LockedPrunableDetached(t *testing.T) {
+	input := `worktree /repo
+HEAD abc123
+branch refs/heads/main
+
+worktree /repo.worktrees/auth
+HEAD def456
+branch refs/heads/feature/auth
+locked moving to external disk
+
+worktree /repo.worktrees/gone
+HEAD 789abc
+detached
+prunable gitdir file points to non-existent location
+
+`
+	got := ParseWorktreeListPorcelain(input)
+	require.Len(t, got, 3)
+
+	assert.False(t, got[0].Locked)
+	assert.False(t, got[0].Prunable)
+
+	assert.Equal(t, "feature/auth", got[1].Branch)
+	assert.True(t, got[1].Locked)
+	assert.False(t, got[1].Prunable)
+
+	assert.Equal(t, "", got[2].Branch)
+	assert.Equal(t, "789abc", got[2].HEAD)
+	assert.False(t, got[2].Locked)
+	assert.True(t, got[2].Prunable)
+}
+
+func TestParseWorktreeListPorcelain_Bare(t *testing.T) {
+	input := `worktree /repo/.bare
+bare
+
+worktree /repo.worktrees/auth
+HEAD def456
+branch refs/heads/feature/auth
+
+`
+	got := ParseWorktreeListPorcelain(input)
+	require.Len(t, got, 2)
+	assert.True(t, got[0].Bare)
+	assert.False(t, got[1].Bare)
+}
+
 func TestResolveWorktreePath(t *testing.T) {
 	dir := t.TempDir()
 	wtDir := filepath.Join(dir, "repo.worktrees")
@@ -158,6 +231,83 @@ func initTestRepo(t *testing.T) string {
 	return dir
 }
 
+// initBareOrigin returns a bare copy of a fresh test repo to clone from, and
+// the branch it has checked out.
+func initBareOrigin(t *testing.T) (url, branch string) {
+	t.Helper()
+	src := initTestRepo(t)
+	url = filepath.Join(t.TempDir(), "origin.git")
+	out, err := exec.Command("git", "clone", "--bare", src, url).CombinedOutput()
+	require.NoError(t, err, "bare clone failed: %s", string(out))
+
+	branch, err = NewClient().CurrentBranch(src)
+	require.NoError(t, err)
+	return url, branch
+}
+
+func TestClone_Integration(t *testing.T) {
+	url, branch := initBareOrigin(t)
+	parent, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	dir := filepath.Join(parent, "myrepo")
+
+	client := NewClient()
+	require.NoError(t, client.Clone(url, dir, false))
+
+	assert.DirExists(t, filepath.Join(dir, ".git"))
+	root, err := client.RepoRoot(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, root)
+
+	current, err := client.CurrentBranch(dir)
+	require.NoError(t, err)
+	assert.Equal(t, branch, current)
+}
+
+func TestClone_Bare_Integration(t *testing.T) {
+	url, branch := initBareOrigin(t)
+	parent, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	dir := filepath.Join(parent, "myrepo")
+
+	client := NewClient()
+	require.NoError(t, client.Clone(url, dir, true))
+
+	assert.DirExists(t, filepath.Join(dir, ".bare"))
+	assert.FileExists(t, filepath.Join(dir, ".git"))
+
+	// dir is the repo root and worktrees go next to it, as for a normal clone
+	root, err := client.RepoRoot(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, root)
+	wtDir, err := client.WorktreesDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir+".worktrees", wtDir)
+
+	ok, err := client.RefExists(dir, "origin/"+branch)
+	require.NoError(t, err)
+	assert.True(t, ok, "bare clone should have remote-tracking refs")
+
+	// The bare repo itself isn't listed as a worktree
+	list, err := client.WorktreeList(dir)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, client.WorktreeAdd(dir, wtPath, "feature/auth", branch, true, false))
+	list, err = client.WorktreeList(dir)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "feature/auth", list[0].Branch)
+}
+
+func TestClone_Fails(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "myrepo")
+	err := NewClient().Clone(filepath.Join(t.TempDir(), "missing.git"), dir, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git clone failed")
+}
+
 func TestRepoRoot_Integration(t *testing.T) {
 	repoDir := initTestRepo(t)
 
@@ -177,7 +327,7 @@ func TestWorktreeLifecycle_Integration(t *testing.T) {
 
 	// Create worktree with new branch
 	wtPath := filepath.Join(wtDir, "auth")
-	err = client.WorktreeAdd(repoDir, wtPath, "feature/auth", "HEAD", true)
+	err = client.WorktreeAdd(repoDir, wtPath, "feature/auth", "HEAD", true, false)
 	require.NoError(t, err)
 	assert.DirExists(t, wtPath)
 
@@ -207,6 +357,138 @@ func TestWorktreeLifecycle_Integration(t *testing.T) {
 	assert.NoDirExists(t, wtPath)
 }
 
+func TestWorktreeAdd_ForceAfterDirRemoved_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+	wtDir := repoDir + ".worktrees"
+	require.NoError(t, os.MkdirAll(wtDir, 0755))
+
+	client := NewClient()
+
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "feature/auth", "HEAD", true, false))
+
+	// Remove the directory out-of-band; git still has it registered
+	require.NoError(t, os.RemoveAll(wtPath))
+
+	err := client.WorktreeAdd(repoDir, wtPath, "feature/auth", "", false, false)
+	require.Error(t, err)
+
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "feature/auth", "", false, true))
+	assert.DirExists(t, wtPath)
+
+	branch, err := client.CurrentBranch(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "feature/auth", branch)
+}
+
+func TestWorktreeAdd_NoTrackFromRemoteRef(t *testing.T) {
+	var got [][]string
+	client := NewClient()
+	client.Runner = func(cmd *exec.Cmd, combined bool) ([]byte, error) {
+		got = append(got, cmd.Args)
+		if cmd.Args[3] == "rev-parse" {
+			return []byte("/repo/.git\n"), nil
+		}
+		if cmd.Args[3] == "show-ref" && cmd.Args[len(cmd.Args)-1] != "refs/remotes/origin/main" {
+			return nil, &exec.ExitError{}
+		}
+		return nil, nil
+	}
+
+	require.NoError(t, client.WorktreeAdd("/repo", "/wt/auth", "feature/auth", "origin/main", true, false))
+	assert.Equal(t, []string{"git", "-C", "/repo", "worktree", "add", "--no-track", "-b", "feature/auth", "/wt/auth", "origin/main"}, got[len(got)-1])
+
+	// A local start point keeps git's default tracking behaviour
+	got = nil
+	require.NoError(t, client.WorktreeAdd("/repo", "/wt/auth", "feature/auth", "main", true, false))
+	assert.Equal(t, []string{"git", "-C", "/repo", "worktree", "add", "-b", "feature/auth", "/wt/auth", "main"}, got[len(got)-1])
+}
+
+func TestWorktreeRepair_MovedRepo_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+	wtDir := repoDir + ".worktrees"
+	require.NoError(t, os.MkdirAll(wtDir, 0755))
+
+	client := NewClient()
+
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "feature/auth", "HEAD", true, false))
+	assert.False(t, GitdirBroken(wtPath))
+	assert.False(t, GitdirBroken(repoDir), "main worktree has a .git directory")
+
+	// Move the main repo; the worktree's .git file still points at the old location
+	movedRepo := repoDir + "-moved"
+	require.NoError(t, os.Rename(repoDir, movedRepo))
+	assert.True(t, GitdirBroken(wtPath))
+
+	_, err := client.IsWorktreeDirty(wtPath)
+	require.Error(t, err)
+
+	require.NoError(t, client.WorktreeRepair(movedRepo))
+	assert.False(t, GitdirBroken(wtPath))
+
+	branch, err := client.CurrentBranch(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "feature/auth", branch)
+}
+
+func TestResolveWorktree_OutsideWorktreesDir_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+	client := NewClient()
+
+	wtPath := filepath.Join(t.TempDir(), "auth")
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "feature/auth", "HEAD", true, false))
+
+	resolved, err := client.ResolveWorktree(repoDir, "feature/auth")
+	require.NoError(t, err)
+	want, _ := filepath.EvalSymlinks(wtPath)
+	got, _ := filepath.EvalSymlinks(resolved)
+	assert.Equal(t, want, got)
+}
+
+func TestDuplicateBranches(t *testing.T) {
+	dups := DuplicateBranches([]WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/b", Branch: "feature/b"},
+		{Path: "/wt/a1", Branch: "feature/a"},
+		{Path: "/wt/detached1"},
+		{Path: "/wt/detached2"},
+		{Path: "/wt/a2", Branch: "feature/a"},
+		{Path: "/wt/b2", Branch: "feature/b"},
+	})
+	assert.Equal(t, []BranchDuplicate{
+		{Branch: "feature/a", Paths: []string{"/wt/a1", "/wt/a2"}},
+		{Branch: "feature/b", Paths: []string{"/wt/b", "/wt/b2"}},
+	}, dups)
+
+	assert.Empty(t, DuplicateBranches([]WorktreeInfo{{Path: "/repo", Branch: "main"}, {Path: "/wt/a", Branch: "feature/a"}}))
+}
+
+func TestTracingRunner_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+
+	var lines []string
+	client := NewClient()
+	client.Runner = TracingRunner(ExecRunner, func(cmdline string, elapsed time.Duration, err error) {
+		lines = append(lines, fmt.Sprintf("%s err=%v", cmdline, err))
+	})
+
+	_, err := client.CurrentBranch(repoDir)
+	require.NoError(t, err)
+	ok, err := client.RefExists(repoDir, "nonexistent")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.Len(t, lines, 2)
+	assert.Equal(t, "git -C "+repoDir+" rev-parse --abbrev-ref HEAD err=<nil>", lines[0])
+	assert.Equal(t, "git -C "+repoDir+" rev-parse --verify --quiet nonexistent^{commit} err=exit status 1", lines[1])
+}
+
+func TestCommandLine(t *testing.T) {
+	cmd := exec.Command("git", "-C", "/tmp/my repo", "commit", "-m", "fix: it's done", "")
+	assert.Equal(t, `git -C "/tmp/my repo" commit -m "fix: it's done" ""`, CommandLine(cmd))
+}
+
 func TestBranchList_Integration(t *testing.T) {
 	repoDir := initTestRepo(t)
 
@@ -256,7 +538,7 @@ func TestHasUnpushedCommits_Integration(t *testing.T) {
 	require.NoError(t, err)
 
 	wtPath := filepath.Join(wtDir, "test-branch")
-	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true)
+	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false)
 	require.NoError(t, err)
 
 	// Get the main branch name
@@ -285,6 +567,46 @@ func TestHasUnpushedCommits_Integration(t *testing.T) {
 	assert.True(t, unpushed)
 }
 
+func TestHasCommitsToMerge_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+
+	client := NewClient()
+
+	wtPath := filepath.Join(repoDir+".worktrees", "test-branch")
+	require.NoError(t, os.MkdirAll(filepath.Dir(wtPath), 0755))
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false))
+
+	mainBranch, err := client.CurrentBranch(repoDir)
+	require.NoError(t, err)
+
+	commit := func(dir, name string) {
+		writeFile(t, filepath.Join(dir, name), name)
+		for _, args := range [][]string{{"add", name}, {"commit", "-m", "add " + name}} {
+			cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+			cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com", "GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+			require.NoError(t, cmd.Run())
+		}
+	}
+
+	has, err := client.HasCommitsToMerge(wtPath, mainBranch)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// Base advancing doesn't give the branch anything to merge
+	commit(repoDir, "base.txt")
+	has, err = client.HasCommitsToMerge(wtPath, mainBranch)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	commit(wtPath, "feature.txt")
+	has, err = client.HasCommitsToMerge(wtPath, mainBranch)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	_, err = client.HasCommitsToMerge(wtPath, "no-such-branch")
+	assert.Error(t, err)
+}
+
 func TestWorktreePrune_Integration(t *testing.T) {
 	repoDir := initTestRepo(t)
 
@@ -306,7 +628,7 @@ func TestCommitsAhead_Integration(t *testing.T) {
 	require.NoError(t, err)
 
 	wtPath := filepath.Join(wtDir, "test-branch")
-	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true)
+	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false)
 	require.NoError(t, err)
 
 	mainBranch, err := client.CurrentBranch(repoDir)
@@ -341,7 +663,7 @@ func TestCommitsBehind_Integration(t *testing.T) {
 	require.NoError(t, err)
 
 	wtPath := filepath.Join(wtDir, "test-branch")
-	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true)
+	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false)
 	require.NoError(t, err)
 
 	// Get the main branch name
@@ -366,6 +688,433 @@ func TestCommitsBehind_Integration(t *testing.T) {
 	assert.Equal(t, 3, behind)
 }
 
+func TestAheadBehind_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+
+	client := NewClient()
+
+	wtDir := repoDir + ".worktrees"
+	err := os.MkdirAll(wtDir, 0755)
+	require.NoError(t, err)
+
+	wtPath := filepath.Join(wtDir, "test-branch")
+	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false)
+	require.NoError(t, err)
+
+	mainBranch, err := client.CurrentBranch(repoDir)
+	require.NoError(t, err)
+
+	ahead, behind, err := client.AheadBehind(wtPath, mainBranch)
+	require.NoError(t, err)
+	assert.Equal(t, 0, ahead)
+	assert.Equal(t, 0, behind)
+
+	commit := func(dir, msg string) {
+		cmd := exec.Command("git", "-C", dir, "commit", "--allow-empty", "-m", msg)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com", "GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+		require.NoError(t, cmd.Run())
+	}
+	commit(wtPath, "feature commit")
+	for i := 0; i < 3; i++ {
+		commit(repoDir, "main commit")
+	}
+
+	ahead, behind, err = client.AheadBehind(wtPath, mainBranch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ahead)
+	assert.Equal(t, 3, behind)
+}
+
+func TestRefSHA_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+	client := NewClient()
+
+	out, err := exec.Command("git", "-C", repoDir, "branch", "feature/auth").CombinedOutput()
+	require.NoError(t, err, string(out))
+	head, err := client.HeadSHA(repoDir)
+	require.NoError(t, err)
+
+	sha, err := client.RefSHA(repoDir, "refs/heads/feature/auth")
+	require.NoError(t, err)
+	assert.Equal(t, head, sha)
+
+	_, err = client.RefSHA(repoDir, "refs/heads/nonexistent")
+	assert.Error(t, err)
+}
+
+func TestRefExists_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+
+	client := NewClient()
+
+	for _, args := range [][]string{
+		{"git", "-C", repoDir, "branch", "feature/auth"},
+		{"git", "-C", repoDir, "tag", "v1.0.0"},
+	} {
+		out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+		require.NoError(t, err, "cmd %v failed: %s", args, string(out))
+	}
+	sha, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+	require.NoError(t, err)
+
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"feature/auth", true},
+		{"v1.0.0", true},
+		{strings.TrimSpace(string(sha)), true},
+		{strings.TrimSpace(string(sha))[:7], true},
+		{"HEAD", true},
+		{"nonexistent", false},
+		{"origin/main", false},
+		{"0000000000000000000000000000000000000000", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			ok, err := client.RefExists(repoDir, tt.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestCommitSubjects_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+
+	client := NewClient()
+
+	wtDir := repoDir + ".worktrees"
+	err := os.MkdirAll(wtDir, 0755)
+	require.NoError(t, err)
+
+	wtPath := filepath.Join(wtDir, "test-branch")
+	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false)
+	require.NoError(t, err)
+
+	mainBranch, err := client.CurrentBranch(repoDir)
+	require.NoError(t, err)
+
+	// No commits yet
+	subjects, err := client.CommitSubjects(repoDir, mainBranch, "test-branch")
+	require.NoError(t, err)
+	assert.Empty(t, subjects)
+
+	for _, msg := range []string{"first change", "second change"} {
+		cmd := exec.Command("git", "-C", wtPath, "commit", "--allow-empty", "-m", msg)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com", "GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+		require.NoError(t, cmd.Run())
+	}
+
+	// Oldest first
+	subjects, err = client.CommitSubjects(repoDir, mainBranch, "test-branch")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first change", "second change"}, subjects)
+}
+
+func TestIsAncestor_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+
+	client := NewClient()
+
+	wtDir := repoDir + ".worktrees"
+	err := os.MkdirAll(wtDir, 0755)
+	require.NoError(t, err)
+
+	wtPath := filepath.Join(wtDir, "test-branch")
+	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false)
+	require.NoError(t, err)
+
+	mainBranch, err := client.CurrentBranch(repoDir)
+	require.NoError(t, err)
+
+	// Fresh branch: each is an ancestor of the other
+	ok, err := client.IsAncestor(repoDir, "test-branch", mainBranch)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	cmd := exec.Command("git", "-C", wtPath, "commit", "--allow-empty", "-m", "feature work")
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com", "GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+	require.NoError(t, cmd.Run())
+
+	// Base can fast-forward to the branch, but the branch is not merged into base
+	ok, err = client.IsAncestor(repoDir, mainBranch, "test-branch")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = client.IsAncestor(repoDir, "test-branch", mainBranch)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Unknown refs are errors, not "false"
+	_, err = client.IsAncestor(repoDir, "no-such-branch", mainBranch)
+	assert.Error(t, err)
+}
+
+func TestMerge_Args(t *testing.T) {
+	tests := []struct {
+		name string
+		opts MergeRunOptions
+		want []string
+	}{
+		{"default", MergeRunOptions{}, []string{"git", "-C", "/repo", "merge", "feature", "--no-edit"}},
+		{"no-ff", MergeRunOptions{FF: NoFF, Message: "msg"}, []string{"git", "-C", "/repo", "merge", "feature", "--no-edit", "--no-ff", "-m", "msg"}},
+		{"ff-only", MergeRunOptions{FF: FFOnly}, []string{"git", "-C", "/repo", "merge", "feature", "--ff-only"}},
+		{"no-verify", MergeRunOptions{NoVerify: true}, []string{"git", "-C", "/repo", "merge", "feature", "--no-edit", "--no-verify"}},
+		{"strategy options", MergeRunOptions{StrategyOptions: []string{"ours", "patience"}}, []string{"git", "-C", "/repo", "merge", "feature", "--no-edit", "-X", "ours", "-X", "patience"}},
+		{"sign", MergeRunOptions{FF: NoFF, Message: "msg", Sign: true}, []string{"git", "-C", "/repo", "merge", "feature", "--no-edit", "--no-ff", "-m", "msg", "-S"}},
+		{"sign with key", MergeRunOptions{Sign: true, SignKey: "ABCD1234"}, []string{"git", "-C", "/repo", "merge", "feature", "--no-edit", "--gpg-sign=ABCD1234"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			client := NewClient()
+			client.Runner = func(cmd *exec.Cmd, combined bool) ([]byte, error) {
+				got = cmd.Args
+				return nil, nil
+			}
+
+			require.NoError(t, client.Merge("/repo", "feature", tt.opts))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStashCount_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+	client := NewClient()
+
+	wtPath := filepath.Join(repoDir+".worktrees", "test-branch")
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false))
+
+	n, err := client.StashCount(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// stash pushes an untracked file (-u) with and without a message
+	stash := func(dir string, extra ...string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "scratch.txt"), []byte("wip"), 0644))
+		args := append([]string{"-C", dir, "stash", "push", "-u"}, extra...)
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, "git stash failed: %s", string(out))
+	}
+	stash(wtPath)
+	stash(wtPath, "-m", "half done")
+	stash(repoDir)
+
+	// The stash list is shared; each worktree only counts its own branch's entries
+	n, err = client.StashCount(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = client.StashCount(repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestReflog_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+	client := NewClient()
+
+	wtPath := filepath.Join(repoDir+".worktrees", "auth")
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "feature/auth", "HEAD", true, false))
+
+	git := func(args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", wtPath}, args...)...).CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, string(out))
+	}
+	git("commit", "--allow-empty", "-m", "first")
+	git("commit", "--allow-empty", "-m", "second")
+	lost, err := client.HeadSHA(wtPath)
+	require.NoError(t, err)
+	git("reset", "--hard", "HEAD~1")
+
+	entries, err := client.Reflog(repoDir, "feature/auth")
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, "reset", entries[0].Action)
+	assert.Equal(t, "moving to HEAD~1", entries[0].Message)
+	assert.Equal(t, "feature/auth@{0}", entries[0].Selector)
+	assert.Equal(t, ReflogEntry{SHA: lost, Selector: "feature/auth@{1}", Action: "commit", Message: "second"}, entries[1])
+	assert.Equal(t, "first", entries[2].Message)
+	assert.Equal(t, "branch", entries[3].Action)
+
+	_, err = client.Reflog(repoDir, "no-such-branch")
+	assert.Error(t, err)
+}
+
+func TestRebase_Args(t *testing.T) {
+	var got []string
+	client := NewClient()
+	client.Runner = func(cmd *exec.Cmd, combined bool) ([]byte, error) {
+		got = cmd.Args
+		return nil, nil
+	}
+
+	require.NoError(t, client.Rebase("/wt", "main", RebaseRunOptions{}))
+	assert.Equal(t, []string{"git", "-C", "/wt", "rebase", "main"}, got)
+
+	require.NoError(t, client.Rebase("/wt", "main", RebaseRunOptions{StrategyOptions: []string{"theirs"}}))
+	assert.Equal(t, []string{"git", "-C", "/wt", "rebase", "-X", "theirs", "main"}, got)
+
+	require.NoError(t, client.Rebase("/wt", "main", RebaseRunOptions{Sign: true}))
+	assert.Equal(t, []string{"git", "-C", "/wt", "rebase", "-S", "main"}, got)
+
+	require.NoError(t, client.Rebase("/wt", "main", RebaseRunOptions{RebaseMerges: true}))
+	assert.Equal(t, []string{"git", "-C", "/wt", "rebase", "--rebase-merges", "main"}, got)
+}
+
+func TestValidateStrategyOption(t *testing.T) {
+	for _, opt := range []string{"ours", "theirs", "patience", "diff-algorithm=histogram", "subtree=lib/foo"} {
+		assert.NoError(t, ValidateStrategyOption(opt), opt)
+	}
+	for _, opt := range []string{"", "-X", "--ours", "ours theirs", "ours\n"} {
+		assert.Error(t, ValidateStrategyOption(opt), opt)
+	}
+}
+
+func TestMerge_SquashNoVerifyCommits(t *testing.T) {
+	var got [][]string
+	client := NewClient()
+	client.Runner = func(cmd *exec.Cmd, combined bool) ([]byte, error) {
+		got = append(got, cmd.Args)
+		return nil, nil
+	}
+
+	require.NoError(t, client.Merge("/repo", "feature", MergeRunOptions{Squash: true, NoVerify: true, Message: "msg"}))
+	assert.Equal(t, [][]string{
+		{"git", "-C", "/repo", "merge", "feature", "--squash", "--no-verify"},
+		{"git", "-C", "/repo", "commit", "--no-edit", "-m", "msg", "--no-verify"},
+	}, got)
+}
+
+func TestMerge_SquashSignsCommit(t *testing.T) {
+	var got [][]string
+	client := NewClient()
+	client.Runner = func(cmd *exec.Cmd, combined bool) ([]byte, error) {
+		got = append(got, cmd.Args)
+		return nil, nil
+	}
+
+	require.NoError(t, client.Merge("/repo", "feature", MergeRunOptions{Squash: true, Message: "msg", Sign: true, SignKey: "KEY"}))
+	require.Len(t, got, 2)
+	assert.Equal(t, []string{"git", "-C", "/repo", "commit", "--no-edit", "-m", "msg", "--gpg-sign=KEY"}, got[1])
+}
+
+func TestMerge_Squash_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+
+	client := NewClient()
+
+	wtDir := repoDir + ".worktrees"
+	err := os.MkdirAll(wtDir, 0755)
+	require.NoError(t, err)
+
+	wtPath := filepath.Join(wtDir, "test-branch")
+	err = client.WorktreeAdd(repoDir, wtPath, "test-branch", "HEAD", true, false)
+	require.NoError(t, err)
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(wtPath, name), []byte(name), 0644))
+		for _, args := range [][]string{{"add", name}, {"commit", "-m", "add " + name}} {
+			cmd := exec.Command("git", append([]string{"-C", wtPath}, args...)...)
+			cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com", "GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+			require.NoError(t, cmd.Run())
+		}
+	}
+
+	err = client.Merge(repoDir, "test-branch", MergeRunOptions{Squash: true, Message: "Squash merge branch 'test-branch'"})
+	require.NoError(t, err)
+
+	// One new commit on main carrying the supplied message
+	out, err := exec.Command("git", "-C", repoDir, "log", "-1", "--format=%s").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "Squash merge branch 'test-branch'", strings.TrimSpace(string(out)))
+
+	out, err = exec.Command("git", "-C", repoDir, "rev-list", "--count", "HEAD").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "2", strings.TrimSpace(string(out)))
+}
+
+func TestCherryPick_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+	client := NewClient()
+
+	wtPath := filepath.Join(repoDir+".worktrees", "one-commit")
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "one-commit", "HEAD", true, false))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "a.txt"), []byte("a"), 0644))
+	for _, args := range [][]string{{"add", "a.txt"}, {"commit", "-m", "add a.txt"}} {
+		cmd := exec.Command("git", append([]string{"-C", wtPath}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com", "GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+		require.NoError(t, cmd.Run())
+	}
+
+	before, err := client.HeadSHA(repoDir)
+	require.NoError(t, err)
+	sha, err := client.HeadSHA(wtPath)
+	require.NoError(t, err)
+
+	require.NoError(t, client.CherryPick(repoDir, sha, CherryPickRunOptions{}))
+
+	out, err := exec.Command("git", "-C", repoDir, "log", "-1", "--format=%s").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "add a.txt", strings.TrimSpace(string(out)))
+
+	// ORIG_HEAD points at base before the pick, so a reset can undo it
+	out, err = exec.Command("git", "-C", repoDir, "rev-parse", "ORIG_HEAD").Output()
+	require.NoError(t, err)
+	assert.Equal(t, before, strings.TrimSpace(string(out)))
+}
+
+func TestCherryPick_Conflict_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+	client := NewClient()
+	commit := func(dir, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte(content), 0644))
+		for _, args := range [][]string{{"add", "a.txt"}, {"commit", "-m", "write " + content}} {
+			cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+			cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com", "GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+			require.NoError(t, cmd.Run())
+		}
+	}
+
+	wtPath := filepath.Join(repoDir+".worktrees", "conflict")
+	require.NoError(t, client.WorktreeAdd(repoDir, wtPath, "conflict", "HEAD", true, false))
+	commit(wtPath, "feature")
+	commit(repoDir, "base")
+	before, err := client.HeadSHA(repoDir)
+	require.NoError(t, err)
+
+	err = client.CherryPick(repoDir, "conflict", CherryPickRunOptions{})
+	require.Error(t, err)
+	conflicts, err := client.HasConflicts(repoDir)
+	require.NoError(t, err)
+	assert.True(t, conflicts)
+
+	// Abort restores base
+	require.NoError(t, client.CherryPickAbort(repoDir))
+	after, err := client.HeadSHA(repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	// Resolve and continue
+	require.Error(t, client.CherryPick(repoDir, "conflict", CherryPickRunOptions{}))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "a.txt"), []byte("resolved"), 0644))
+	out, err := exec.Command("git", "-C", repoDir, "add", "a.txt").CombinedOutput()
+	require.NoError(t, err, string(out))
+	require.NoError(t, client.CherryPickContinue(repoDir))
+
+	out, err = exec.Command("git", "-C", repoDir, "log", "-1", "--format=%s").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "write feature", strings.TrimSpace(string(out)))
+	conflicts, err = client.HasConflicts(repoDir)
+	require.NoError(t, err)
+	assert.False(t, conflicts)
+}
+
 func TestFetch_Integration(t *testing.T) {
 	// Fetch requires a remote, so we set up a local bare repo as origin
 	dir := t.TempDir()
@@ -405,6 +1154,48 @@ func TestFetch_Integration(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRemoteBranchSHA_Integration(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	bareDir := filepath.Join(dir, "origin.git")
+	repoDir := filepath.Join(dir, "repo")
+
+	cmds := [][]string{
+		{"git", "init", "--bare", bareDir},
+		{"git", "clone", bareDir, repoDir},
+		{"git", "-C", repoDir, "config", "user.email", "test@test.com"},
+		{"git", "-C", repoDir, "config", "user.name", "Test"},
+		{"git", "-C", repoDir, "checkout", "-b", "feature/auth"},
+		{"git", "-C", repoDir, "commit", "--allow-empty", "-m", "first"},
+		{"git", "-C", repoDir, "push", "-u", "origin", "feature/auth"},
+	}
+	for _, args := range cmds {
+		out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+		require.NoError(t, err, "cmd %v failed: %s", args, string(out))
+	}
+
+	client := NewClient()
+
+	// Pushed: the remote-tracking branch matches HEAD
+	head, err := client.HeadSHA(repoDir)
+	require.NoError(t, err)
+	remote, err := client.RemoteBranchSHA(repoDir, "origin", "feature/auth")
+	require.NoError(t, err)
+	assert.Equal(t, head, remote)
+
+	// A local commit that wasn't pushed leaves them different
+	out, err := exec.Command("git", "-C", repoDir, "commit", "--allow-empty", "-m", "second").CombinedOutput()
+	require.NoError(t, err, string(out))
+	head, err = client.HeadSHA(repoDir)
+	require.NoError(t, err)
+	assert.NotEqual(t, head, remote)
+
+	// Never pushed: no remote-tracking branch at all
+	_, err = client.RemoteBranchSHA(repoDir, "origin", "feature/other")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no remote-tracking branch 'origin/feature/other'")
+}
+
 func TestFetch_NoRemote_Integration(t *testing.T) {
 	// Fetch on a repo with no remote should fail
 	repoDir := initTestRepo(t)
@@ -418,3 +1209,191 @@ func TestFetch_NoRemote_Integration(t *testing.T) {
 	// We just verify it doesn't panic
 	_ = err
 }
+
+// fakeWorktree lays out a main .git dir with an admin dir for a linked
+// worktree, plus the worktree dir itself, and returns (mainGitDir, adminDir, wtPath).
+func fakeWorktree(t *testing.T) (string, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	mainGitDir := filepath.Join(dir, "repo", ".git")
+	adminDir := filepath.Join(mainGitDir, "worktrees", "auth")
+	wtPath := filepath.Join(dir, "repo.worktrees", "auth")
+	require.NoError(t, os.MkdirAll(adminDir, 0755))
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	return mainGitDir, adminDir, wtPath
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestResolveGitDir(t *testing.T) {
+	t.Run("main worktree directory", func(t *testing.T) {
+		mainGitDir, _, _ := fakeWorktree(t)
+		repo := filepath.Dir(mainGitDir)
+
+		gitDir, commonDir, err := resolveGitDir(repo)
+		require.NoError(t, err)
+		assert.Equal(t, mainGitDir, gitDir)
+		assert.Equal(t, mainGitDir, commonDir)
+	})
+
+	t.Run("absolute gitdir", func(t *testing.T) {
+		_, adminDir, wtPath := fakeWorktree(t)
+		writeFile(t, filepath.Join(wtPath, ".git"), "gitdir: "+adminDir+"\n")
+
+		gitDir, _, err := resolveGitDir(wtPath)
+		require.NoError(t, err)
+		assert.Equal(t, adminDir, gitDir)
+	})
+
+	t.Run("relative gitdir", func(t *testing.T) {
+		_, adminDir, wtPath := fakeWorktree(t)
+		writeFile(t, filepath.Join(wtPath, ".git"), "gitdir: ../../repo/.git/worktrees/auth\n")
+
+		gitDir, _, err := resolveGitDir(wtPath)
+		require.NoError(t, err)
+		assert.Equal(t, adminDir, gitDir)
+	})
+
+	t.Run("commondir", func(t *testing.T) {
+		mainGitDir, adminDir, wtPath := fakeWorktree(t)
+		writeFile(t, filepath.Join(wtPath, ".git"), "gitdir: "+adminDir+"\n")
+		writeFile(t, filepath.Join(adminDir, "commondir"), "../..\n")
+
+		gitDir, commonDir, err := resolveGitDir(wtPath)
+		require.NoError(t, err)
+		assert.Equal(t, adminDir, gitDir)
+		assert.Equal(t, mainGitDir, commonDir)
+	})
+
+	t.Run("missing commondir target", func(t *testing.T) {
+		_, adminDir, wtPath := fakeWorktree(t)
+		writeFile(t, filepath.Join(wtPath, ".git"), "gitdir: "+adminDir+"\n")
+		writeFile(t, filepath.Join(adminDir, "commondir"), "/nonexistent/.git\n")
+
+		_, _, err := resolveGitDir(wtPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "wt repair")
+	})
+
+	t.Run("broken gitdir", func(t *testing.T) {
+		_, _, wtPath := fakeWorktree(t)
+		writeFile(t, filepath.Join(wtPath, ".git"), "gitdir: ../moved/.git/worktrees/auth\n")
+
+		_, _, err := resolveGitDir(wtPath)
+		require.Error(t, err)
+		assert.True(t, GitdirBroken(wtPath))
+	})
+}
+
+func TestIsMergeInProgress_RelativeGitdir(t *testing.T) {
+	_, adminDir, wtPath := fakeWorktree(t)
+	writeFile(t, filepath.Join(wtPath, ".git"), "gitdir: ../../repo/.git/worktrees/auth\n")
+	writeFile(t, filepath.Join(adminDir, "commondir"), "../..\n")
+
+	client := NewClient()
+
+	inProgress, err := client.IsMergeInProgress(wtPath)
+	require.NoError(t, err)
+	assert.False(t, inProgress)
+
+	writeFile(t, filepath.Join(adminDir, "MERGE_HEAD"), "0123456789abcdef\n")
+	inProgress, err = client.IsMergeInProgress(wtPath)
+	require.NoError(t, err)
+	assert.True(t, inProgress)
+}
+
+func TestIsRebaseInProgress_RelativeGitdir(t *testing.T) {
+	_, adminDir, wtPath := fakeWorktree(t)
+	writeFile(t, filepath.Join(wtPath, ".git"), "gitdir: ../../repo/.git/worktrees/auth\n")
+
+	client := NewClient()
+
+	inProgress, err := client.IsRebaseInProgress(wtPath)
+	require.NoError(t, err)
+	assert.False(t, inProgress)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(adminDir, "rebase-merge"), 0755))
+	inProgress, err = client.IsRebaseInProgress(wtPath)
+	require.NoError(t, err)
+	assert.True(t, inProgress)
+}
+
+func TestResetHard_Integration(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	file := filepath.Join(dir, "README.md")
+
+	cmds := [][]string{
+		{"git", "init", "-b", "main", dir},
+		{"git", "-C", dir, "config", "user.email", "test@test.com"},
+		{"git", "-C", dir, "config", "user.name", "Test"},
+	}
+	for _, args := range cmds {
+		out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+		require.NoError(t, err, "cmd %v failed: %s", args, string(out))
+	}
+	commit := func(content, msg string) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+		for _, args := range [][]string{{"git", "-C", dir, "add", "README.md"}, {"git", "-C", dir, "commit", "-m", msg}} {
+			out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+			require.NoError(t, err, "cmd %v failed: %s", args, string(out))
+		}
+	}
+
+	client := NewClient()
+	commit("v1\n", "first")
+	before, err := client.HeadSHA(dir)
+	require.NoError(t, err)
+	commit("v2\n", "second")
+
+	// An uncommitted edit is discarded along with the second commit
+	require.NoError(t, os.WriteFile(file, []byte("scratch\n"), 0644))
+	require.NoError(t, client.ResetHard(dir, before))
+
+	head, err := client.HeadSHA(dir)
+	require.NoError(t, err)
+	assert.Equal(t, before, head)
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "v1\n", string(content))
+	dirty, err := client.IsWorktreeDirty(dir)
+	require.NoError(t, err)
+	assert.False(t, dirty)
+
+	err = client.ResetHard(dir, "no-such-ref")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git reset --hard failed")
+}
+
+func TestSetLocalConfig_Integration(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	repoDir := filepath.Join(dir, "repo")
+	wtDir := filepath.Join(dir, "repo.worktrees", "work")
+
+	cmds := [][]string{
+		{"git", "init", "-b", "main", repoDir},
+		{"git", "-C", repoDir, "config", "user.email", "me@home.com"},
+		{"git", "-C", repoDir, "config", "user.name", "Test"},
+		{"git", "-C", repoDir, "commit", "--allow-empty", "-m", "first"},
+		{"git", "-C", repoDir, "worktree", "add", "-b", "work", wtDir},
+	}
+	for _, args := range cmds {
+		out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+		require.NoError(t, err, "cmd %v failed: %s", args, string(out))
+	}
+
+	client := NewClient()
+	require.NoError(t, client.SetLocalConfig(wtDir, "user.email", "me@work.com"))
+
+	get := func(path string) string {
+		out, err := exec.Command("git", "-C", path, "config", "user.email").Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(out))
+	}
+	assert.Equal(t, "me@work.com", get(wtDir))
+	assert.Equal(t, "me@home.com", get(repoDir), "the main repo keeps its own value")
+}
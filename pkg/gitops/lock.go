@@ -0,0 +1,66 @@
+package gitops
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// RepoLockFile is the advisory lock file wt keeps in a repo's common git dir.
+const RepoLockFile = "wt.lock"
+
+// ErrRepoLocked is returned by LockRepo when another process holds the lock.
+var ErrRepoLocked = errors.New("another wt operation is running in this repo")
+
+// RepoLock is a held repo-level advisory lock. The lock is tied to the open
+// file, so the OS releases it if the process dies without calling Unlock.
+type RepoLock struct {
+	f *os.File
+}
+
+// LockRepo takes the advisory lock for the repo at repoPath without waiting,
+// so destructive operations (merge, sync, delete) don't run at the same time
+// against the shared index and refs. op (e.g. "wt merge") is recorded in the
+// lock file so a blocked caller can say who holds it. Returns an error
+// wrapping ErrRepoLocked if the lock is held elsewhere.
+func LockRepo(repoPath, op string) (*RepoLock, error) {
+	_, commonDir, err := resolveGitDir(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(commonDir, RepoLockFile)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		holder, _ := os.ReadFile(path)
+		_ = f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			if h := strings.TrimSpace(string(holder)); h != "" {
+				return nil, fmt.Errorf("%w (%s)", ErrRepoLocked, h)
+			}
+			return nil, ErrRepoLocked
+		}
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	// Best effort: the holder note is only used in the error above
+	if err := f.Truncate(0); err == nil {
+		_, _ = fmt.Fprintf(f, "pid %d: %s\n", os.Getpid(), op)
+	}
+	return &RepoLock{f: f}, nil
+}
+
+// Unlock releases the lock. The lock file itself is left in place; removing
+// it could let a waiting process lock a file that is about to disappear.
+func (l *RepoLock) Unlock() error {
+	_ = l.f.Truncate(0)
+	if err := syscall.Flock(int(l.f.Fd()), syscall.LOCK_UN); err != nil {
+		_ = l.f.Close()
+		return err
+	}
+	return l.f.Close()
+}
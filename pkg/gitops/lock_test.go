@@ -0,0 +1,44 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockRepo_Integration(t *testing.T) {
+	repoDir := initTestRepo(t)
+
+	lock, err := LockRepo(repoDir, "wt merge")
+	require.NoError(t, err)
+
+	// A second lock — from a linked worktree too — is refused and names the holder
+	_, err = LockRepo(repoDir, "wt sync")
+	require.ErrorIs(t, err, ErrRepoLocked)
+	assert.Contains(t, err.Error(), "wt merge")
+
+	wtPath := filepath.Join(repoDir+".worktrees", "auth")
+	require.NoError(t, NewClient().WorktreeAdd(repoDir, wtPath, "feature/auth", "HEAD", true, false))
+	_, err = LockRepo(wtPath, "wt delete")
+	require.ErrorIs(t, err, ErrRepoLocked)
+
+	require.NoError(t, lock.Unlock())
+
+	lock, err = LockRepo(repoDir, "wt sync")
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+
+	// The file stays behind, emptied
+	data, err := os.ReadFile(filepath.Join(repoDir, ".git", RepoLockFile))
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func TestLockRepo_NotARepo(t *testing.T) {
+	_, err := LockRepo(t.TempDir(), "wt merge")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRepoLocked)
+}
@@ -20,6 +20,70 @@ func (_m *MockClient) EXPECT() *MockClient_Expecter {
 	return &MockClient_Expecter{mock: &_m.Mock}
 }
 
+// AheadBehind provides a mock function with given fields: worktreePath, baseBranch
+func (_m *MockClient) AheadBehind(worktreePath string, baseBranch string) (int, int, error) {
+	ret := _m.Called(worktreePath, baseBranch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AheadBehind")
+	}
+
+	var r0 int
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (int, int, error)); ok {
+		return rf(worktreePath, baseBranch)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) int); ok {
+		r0 = rf(worktreePath, baseBranch)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) int); ok {
+		r1 = rf(worktreePath, baseBranch)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(worktreePath, baseBranch)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockClient_AheadBehind_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AheadBehind'
+type MockClient_AheadBehind_Call struct {
+	*mock.Call
+}
+
+// AheadBehind is a helper method to define mock.On call
+//   - worktreePath string
+//   - baseBranch string
+func (_e *MockClient_Expecter) AheadBehind(worktreePath interface{}, baseBranch interface{}) *MockClient_AheadBehind_Call {
+	return &MockClient_AheadBehind_Call{Call: _e.mock.On("AheadBehind", worktreePath, baseBranch)}
+}
+
+func (_c *MockClient_AheadBehind_Call) Run(run func(worktreePath string, baseBranch string)) *MockClient_AheadBehind_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_AheadBehind_Call) Return(ahead int, behind int, err error) *MockClient_AheadBehind_Call {
+	_c.Call.Return(ahead, behind, err)
+	return _c
+}
+
+func (_c *MockClient_AheadBehind_Call) RunAndReturn(run func(string, string) (int, int, error)) *MockClient_AheadBehind_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BranchDelete provides a mock function with given fields: repoPath, branch, force
 func (_m *MockClient) BranchDelete(repoPath string, branch string, force bool) error {
 	ret := _m.Called(repoPath, branch, force)
@@ -183,6 +247,301 @@ func (_c *MockClient_BranchList_Call) RunAndReturn(run func(string) ([]string, e
 	return _c
 }
 
+// Checkout provides a mock function with given fields: repoPath, branch
+func (_m *MockClient) Checkout(repoPath string, branch string) error {
+	ret := _m.Called(repoPath, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Checkout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(repoPath, branch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_Checkout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Checkout'
+type MockClient_Checkout_Call struct {
+	*mock.Call
+}
+
+// Checkout is a helper method to define mock.On call
+//   - repoPath string
+//   - branch string
+func (_e *MockClient_Expecter) Checkout(repoPath interface{}, branch interface{}) *MockClient_Checkout_Call {
+	return &MockClient_Checkout_Call{Call: _e.mock.On("Checkout", repoPath, branch)}
+}
+
+func (_c *MockClient_Checkout_Call) Run(run func(repoPath string, branch string)) *MockClient_Checkout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_Checkout_Call) Return(_a0 error) *MockClient_Checkout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_Checkout_Call) RunAndReturn(run func(string, string) error) *MockClient_Checkout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CherryPick provides a mock function with given fields: repoPath, ref, opts
+func (_m *MockClient) CherryPick(repoPath string, ref string, opts gitops.CherryPickRunOptions) error {
+	ret := _m.Called(repoPath, ref, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CherryPick")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, gitops.CherryPickRunOptions) error); ok {
+		r0 = rf(repoPath, ref, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CherryPick_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CherryPick'
+type MockClient_CherryPick_Call struct {
+	*mock.Call
+}
+
+// CherryPick is a helper method to define mock.On call
+//   - repoPath string
+//   - ref string
+//   - opts gitops.CherryPickRunOptions
+func (_e *MockClient_Expecter) CherryPick(repoPath interface{}, ref interface{}, opts interface{}) *MockClient_CherryPick_Call {
+	return &MockClient_CherryPick_Call{Call: _e.mock.On("CherryPick", repoPath, ref, opts)}
+}
+
+func (_c *MockClient_CherryPick_Call) Run(run func(repoPath string, ref string, opts gitops.CherryPickRunOptions)) *MockClient_CherryPick_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(gitops.CherryPickRunOptions))
+	})
+	return _c
+}
+
+func (_c *MockClient_CherryPick_Call) Return(_a0 error) *MockClient_CherryPick_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CherryPick_Call) RunAndReturn(run func(string, string, gitops.CherryPickRunOptions) error) *MockClient_CherryPick_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CherryPickAbort provides a mock function with given fields: repoPath
+func (_m *MockClient) CherryPickAbort(repoPath string) error {
+	ret := _m.Called(repoPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CherryPickAbort")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(repoPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CherryPickAbort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CherryPickAbort'
+type MockClient_CherryPickAbort_Call struct {
+	*mock.Call
+}
+
+// CherryPickAbort is a helper method to define mock.On call
+//   - repoPath string
+func (_e *MockClient_Expecter) CherryPickAbort(repoPath interface{}) *MockClient_CherryPickAbort_Call {
+	return &MockClient_CherryPickAbort_Call{Call: _e.mock.On("CherryPickAbort", repoPath)}
+}
+
+func (_c *MockClient_CherryPickAbort_Call) Run(run func(repoPath string)) *MockClient_CherryPickAbort_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_CherryPickAbort_Call) Return(_a0 error) *MockClient_CherryPickAbort_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CherryPickAbort_Call) RunAndReturn(run func(string) error) *MockClient_CherryPickAbort_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CherryPickContinue provides a mock function with given fields: repoPath
+func (_m *MockClient) CherryPickContinue(repoPath string) error {
+	ret := _m.Called(repoPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CherryPickContinue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(repoPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CherryPickContinue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CherryPickContinue'
+type MockClient_CherryPickContinue_Call struct {
+	*mock.Call
+}
+
+// CherryPickContinue is a helper method to define mock.On call
+//   - repoPath string
+func (_e *MockClient_Expecter) CherryPickContinue(repoPath interface{}) *MockClient_CherryPickContinue_Call {
+	return &MockClient_CherryPickContinue_Call{Call: _e.mock.On("CherryPickContinue", repoPath)}
+}
+
+func (_c *MockClient_CherryPickContinue_Call) Run(run func(repoPath string)) *MockClient_CherryPickContinue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_CherryPickContinue_Call) Return(_a0 error) *MockClient_CherryPickContinue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CherryPickContinue_Call) RunAndReturn(run func(string) error) *MockClient_CherryPickContinue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Clone provides a mock function with given fields: url, dir, bare
+func (_m *MockClient) Clone(url string, dir string, bare bool) error {
+	ret := _m.Called(url, dir, bare)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(url, dir, bare)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_Clone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clone'
+type MockClient_Clone_Call struct {
+	*mock.Call
+}
+
+// Clone is a helper method to define mock.On call
+//   - url string
+//   - dir string
+//   - bare bool
+func (_e *MockClient_Expecter) Clone(url interface{}, dir interface{}, bare interface{}) *MockClient_Clone_Call {
+	return &MockClient_Clone_Call{Call: _e.mock.On("Clone", url, dir, bare)}
+}
+
+func (_c *MockClient_Clone_Call) Run(run func(url string, dir string, bare bool)) *MockClient_Clone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockClient_Clone_Call) Return(_a0 error) *MockClient_Clone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_Clone_Call) RunAndReturn(run func(string, string, bool) error) *MockClient_Clone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CommitSubjects provides a mock function with given fields: repoPath, baseBranch, branch
+func (_m *MockClient) CommitSubjects(repoPath string, baseBranch string, branch string) ([]string, error) {
+	ret := _m.Called(repoPath, baseBranch, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitSubjects")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) ([]string, error)); ok {
+		return rf(repoPath, baseBranch, branch)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) []string); ok {
+		r0 = rf(repoPath, baseBranch, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(repoPath, baseBranch, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_CommitSubjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitSubjects'
+type MockClient_CommitSubjects_Call struct {
+	*mock.Call
+}
+
+// CommitSubjects is a helper method to define mock.On call
+//   - repoPath string
+//   - baseBranch string
+//   - branch string
+func (_e *MockClient_Expecter) CommitSubjects(repoPath interface{}, baseBranch interface{}, branch interface{}) *MockClient_CommitSubjects_Call {
+	return &MockClient_CommitSubjects_Call{Call: _e.mock.On("CommitSubjects", repoPath, baseBranch, branch)}
+}
+
+func (_c *MockClient_CommitSubjects_Call) Run(run func(repoPath string, baseBranch string, branch string)) *MockClient_CommitSubjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_CommitSubjects_Call) Return(_a0 []string, _a1 error) *MockClient_CommitSubjects_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_CommitSubjects_Call) RunAndReturn(run func(string, string, string) ([]string, error)) *MockClient_CommitSubjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CommitsAhead provides a mock function with given fields: worktreePath, baseBranch
 func (_m *MockClient) CommitsAhead(worktreePath string, baseBranch string) (int, error) {
 	ret := _m.Called(worktreePath, baseBranch)
@@ -297,6 +656,63 @@ func (_c *MockClient_CommitsBehind_Call) RunAndReturn(run func(string, string) (
 	return _c
 }
 
+// ConfigValue provides a mock function with given fields: path, key
+func (_m *MockClient) ConfigValue(path string, key string) (string, error) {
+	ret := _m.Called(path, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfigValue")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(path, key)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(path, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(path, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ConfigValue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigValue'
+type MockClient_ConfigValue_Call struct {
+	*mock.Call
+}
+
+// ConfigValue is a helper method to define mock.On call
+//   - path string
+//   - key string
+func (_e *MockClient_Expecter) ConfigValue(path interface{}, key interface{}) *MockClient_ConfigValue_Call {
+	return &MockClient_ConfigValue_Call{Call: _e.mock.On("ConfigValue", path, key)}
+}
+
+func (_c *MockClient_ConfigValue_Call) Run(run func(path string, key string)) *MockClient_ConfigValue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ConfigValue_Call) Return(_a0 string, _a1 error) *MockClient_ConfigValue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ConfigValue_Call) RunAndReturn(run func(string, string) (string, error)) *MockClient_ConfigValue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CurrentBranch provides a mock function with given fields: worktreePath
 func (_m *MockClient) CurrentBranch(worktreePath string) (string, error) {
 	ret := _m.Called(worktreePath)
@@ -399,6 +815,63 @@ func (_c *MockClient_Fetch_Call) RunAndReturn(run func(string) error) *MockClien
 	return _c
 }
 
+// HasCommitsToMerge provides a mock function with given fields: path, baseBranch
+func (_m *MockClient) HasCommitsToMerge(path string, baseBranch string) (bool, error) {
+	ret := _m.Called(path, baseBranch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasCommitsToMerge")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(path, baseBranch)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(path, baseBranch)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(path, baseBranch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_HasCommitsToMerge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasCommitsToMerge'
+type MockClient_HasCommitsToMerge_Call struct {
+	*mock.Call
+}
+
+// HasCommitsToMerge is a helper method to define mock.On call
+//   - path string
+//   - baseBranch string
+func (_e *MockClient_Expecter) HasCommitsToMerge(path interface{}, baseBranch interface{}) *MockClient_HasCommitsToMerge_Call {
+	return &MockClient_HasCommitsToMerge_Call{Call: _e.mock.On("HasCommitsToMerge", path, baseBranch)}
+}
+
+func (_c *MockClient_HasCommitsToMerge_Call) Run(run func(path string, baseBranch string)) *MockClient_HasCommitsToMerge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_HasCommitsToMerge_Call) Return(_a0 bool, _a1 error) *MockClient_HasCommitsToMerge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_HasCommitsToMerge_Call) RunAndReturn(run func(string, string) (bool, error)) *MockClient_HasCommitsToMerge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HasConflicts provides a mock function with given fields: repoPath
 func (_m *MockClient) HasConflicts(repoPath string) (bool, error) {
 	ret := _m.Called(repoPath)
@@ -471,11 +944,124 @@ func (_m *MockClient) HasRemote(repoPath string) (bool, error) {
 	if rf, ok := ret.Get(0).(func(string) bool); ok {
 		r0 = rf(repoPath)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(repoPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_HasRemote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasRemote'
+type MockClient_HasRemote_Call struct {
+	*mock.Call
+}
+
+// HasRemote is a helper method to define mock.On call
+//   - repoPath string
+func (_e *MockClient_Expecter) HasRemote(repoPath interface{}) *MockClient_HasRemote_Call {
+	return &MockClient_HasRemote_Call{Call: _e.mock.On("HasRemote", repoPath)}
+}
+
+func (_c *MockClient_HasRemote_Call) Run(run func(repoPath string)) *MockClient_HasRemote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_HasRemote_Call) Return(_a0 bool, _a1 error) *MockClient_HasRemote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_HasRemote_Call) RunAndReturn(run func(string) (bool, error)) *MockClient_HasRemote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasUnpushedCommits provides a mock function with given fields: path, baseBranch
+func (_m *MockClient) HasUnpushedCommits(path string, baseBranch string) (bool, error) {
+	ret := _m.Called(path, baseBranch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasUnpushedCommits")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(path, baseBranch)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(path, baseBranch)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(path, baseBranch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_HasUnpushedCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasUnpushedCommits'
+type MockClient_HasUnpushedCommits_Call struct {
+	*mock.Call
+}
+
+// HasUnpushedCommits is a helper method to define mock.On call
+//   - path string
+//   - baseBranch string
+func (_e *MockClient_Expecter) HasUnpushedCommits(path interface{}, baseBranch interface{}) *MockClient_HasUnpushedCommits_Call {
+	return &MockClient_HasUnpushedCommits_Call{Call: _e.mock.On("HasUnpushedCommits", path, baseBranch)}
+}
+
+func (_c *MockClient_HasUnpushedCommits_Call) Run(run func(path string, baseBranch string)) *MockClient_HasUnpushedCommits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_HasUnpushedCommits_Call) Return(_a0 bool, _a1 error) *MockClient_HasUnpushedCommits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_HasUnpushedCommits_Call) RunAndReturn(run func(string, string) (bool, error)) *MockClient_HasUnpushedCommits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HeadSHA provides a mock function with given fields: path
+func (_m *MockClient) HeadSHA(path string) (string, error) {
+	ret := _m.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeadSHA")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(path)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(string)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(repoPath)
+		r1 = rf(path)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -483,55 +1069,55 @@ func (_m *MockClient) HasRemote(repoPath string) (bool, error) {
 	return r0, r1
 }
 
-// MockClient_HasRemote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasRemote'
-type MockClient_HasRemote_Call struct {
+// MockClient_HeadSHA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HeadSHA'
+type MockClient_HeadSHA_Call struct {
 	*mock.Call
 }
 
-// HasRemote is a helper method to define mock.On call
-//   - repoPath string
-func (_e *MockClient_Expecter) HasRemote(repoPath interface{}) *MockClient_HasRemote_Call {
-	return &MockClient_HasRemote_Call{Call: _e.mock.On("HasRemote", repoPath)}
+// HeadSHA is a helper method to define mock.On call
+//   - path string
+func (_e *MockClient_Expecter) HeadSHA(path interface{}) *MockClient_HeadSHA_Call {
+	return &MockClient_HeadSHA_Call{Call: _e.mock.On("HeadSHA", path)}
 }
 
-func (_c *MockClient_HasRemote_Call) Run(run func(repoPath string)) *MockClient_HasRemote_Call {
+func (_c *MockClient_HeadSHA_Call) Run(run func(path string)) *MockClient_HeadSHA_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_HasRemote_Call) Return(_a0 bool, _a1 error) *MockClient_HasRemote_Call {
+func (_c *MockClient_HeadSHA_Call) Return(_a0 string, _a1 error) *MockClient_HeadSHA_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_HasRemote_Call) RunAndReturn(run func(string) (bool, error)) *MockClient_HasRemote_Call {
+func (_c *MockClient_HeadSHA_Call) RunAndReturn(run func(string) (string, error)) *MockClient_HeadSHA_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HasUnpushedCommits provides a mock function with given fields: path, baseBranch
-func (_m *MockClient) HasUnpushedCommits(path string, baseBranch string) (bool, error) {
-	ret := _m.Called(path, baseBranch)
+// IsAncestor provides a mock function with given fields: repoPath, maybeAncestor, ref
+func (_m *MockClient) IsAncestor(repoPath string, maybeAncestor string, ref string) (bool, error) {
+	ret := _m.Called(repoPath, maybeAncestor, ref)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasUnpushedCommits")
+		panic("no return value specified for IsAncestor")
 	}
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
-		return rf(path, baseBranch)
+	if rf, ok := ret.Get(0).(func(string, string, string) (bool, error)); ok {
+		return rf(repoPath, maybeAncestor, ref)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
-		r0 = rf(path, baseBranch)
+	if rf, ok := ret.Get(0).(func(string, string, string) bool); ok {
+		r0 = rf(repoPath, maybeAncestor, ref)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(path, baseBranch)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(repoPath, maybeAncestor, ref)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -539,31 +1125,32 @@ func (_m *MockClient) HasUnpushedCommits(path string, baseBranch string) (bool,
 	return r0, r1
 }
 
-// MockClient_HasUnpushedCommits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasUnpushedCommits'
-type MockClient_HasUnpushedCommits_Call struct {
+// MockClient_IsAncestor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsAncestor'
+type MockClient_IsAncestor_Call struct {
 	*mock.Call
 }
 
-// HasUnpushedCommits is a helper method to define mock.On call
-//   - path string
-//   - baseBranch string
-func (_e *MockClient_Expecter) HasUnpushedCommits(path interface{}, baseBranch interface{}) *MockClient_HasUnpushedCommits_Call {
-	return &MockClient_HasUnpushedCommits_Call{Call: _e.mock.On("HasUnpushedCommits", path, baseBranch)}
+// IsAncestor is a helper method to define mock.On call
+//   - repoPath string
+//   - maybeAncestor string
+//   - ref string
+func (_e *MockClient_Expecter) IsAncestor(repoPath interface{}, maybeAncestor interface{}, ref interface{}) *MockClient_IsAncestor_Call {
+	return &MockClient_IsAncestor_Call{Call: _e.mock.On("IsAncestor", repoPath, maybeAncestor, ref)}
 }
 
-func (_c *MockClient_HasUnpushedCommits_Call) Run(run func(path string, baseBranch string)) *MockClient_HasUnpushedCommits_Call {
+func (_c *MockClient_IsAncestor_Call) Run(run func(repoPath string, maybeAncestor string, ref string)) *MockClient_IsAncestor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_HasUnpushedCommits_Call) Return(_a0 bool, _a1 error) *MockClient_HasUnpushedCommits_Call {
+func (_c *MockClient_IsAncestor_Call) Return(_a0 bool, _a1 error) *MockClient_IsAncestor_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_HasUnpushedCommits_Call) RunAndReturn(run func(string, string) (bool, error)) *MockClient_HasUnpushedCommits_Call {
+func (_c *MockClient_IsAncestor_Call) RunAndReturn(run func(string, string, string) (bool, error)) *MockClient_IsAncestor_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -736,17 +1323,17 @@ func (_c *MockClient_IsWorktreeDirty_Call) RunAndReturn(run func(string) (bool,
 	return _c
 }
 
-// Merge provides a mock function with given fields: repoPath, branch
-func (_m *MockClient) Merge(repoPath string, branch string) error {
-	ret := _m.Called(repoPath, branch)
+// Merge provides a mock function with given fields: repoPath, branch, opts
+func (_m *MockClient) Merge(repoPath string, branch string, opts gitops.MergeRunOptions) error {
+	ret := _m.Called(repoPath, branch, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Merge")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(repoPath, branch)
+	if rf, ok := ret.Get(0).(func(string, string, gitops.MergeRunOptions) error); ok {
+		r0 = rf(repoPath, branch, opts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -762,13 +1349,14 @@ type MockClient_Merge_Call struct {
 // Merge is a helper method to define mock.On call
 //   - repoPath string
 //   - branch string
-func (_e *MockClient_Expecter) Merge(repoPath interface{}, branch interface{}) *MockClient_Merge_Call {
-	return &MockClient_Merge_Call{Call: _e.mock.On("Merge", repoPath, branch)}
+//   - opts gitops.MergeRunOptions
+func (_e *MockClient_Expecter) Merge(repoPath interface{}, branch interface{}, opts interface{}) *MockClient_Merge_Call {
+	return &MockClient_Merge_Call{Call: _e.mock.On("Merge", repoPath, branch, opts)}
 }
 
-func (_c *MockClient_Merge_Call) Run(run func(repoPath string, branch string)) *MockClient_Merge_Call {
+func (_c *MockClient_Merge_Call) Run(run func(repoPath string, branch string, opts gitops.MergeRunOptions)) *MockClient_Merge_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(string), args[1].(string), args[2].(gitops.MergeRunOptions))
 	})
 	return _c
 }
@@ -778,7 +1366,53 @@ func (_c *MockClient_Merge_Call) Return(_a0 error) *MockClient_Merge_Call {
 	return _c
 }
 
-func (_c *MockClient_Merge_Call) RunAndReturn(run func(string, string) error) *MockClient_Merge_Call {
+func (_c *MockClient_Merge_Call) RunAndReturn(run func(string, string, gitops.MergeRunOptions) error) *MockClient_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeAbort provides a mock function with given fields: repoPath
+func (_m *MockClient) MergeAbort(repoPath string) error {
+	ret := _m.Called(repoPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeAbort")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(repoPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_MergeAbort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeAbort'
+type MockClient_MergeAbort_Call struct {
+	*mock.Call
+}
+
+// MergeAbort is a helper method to define mock.On call
+//   - repoPath string
+func (_e *MockClient_Expecter) MergeAbort(repoPath interface{}) *MockClient_MergeAbort_Call {
+	return &MockClient_MergeAbort_Call{Call: _e.mock.On("MergeAbort", repoPath)}
+}
+
+func (_c *MockClient_MergeAbort_Call) Run(run func(repoPath string)) *MockClient_MergeAbort_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_MergeAbort_Call) Return(_a0 error) *MockClient_MergeAbort_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_MergeAbort_Call) RunAndReturn(run func(string) error) *MockClient_MergeAbort_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -883,181 +1517,413 @@ func (_m *MockClient) Push(worktreePath string, branch string, setUpstream bool)
 		panic("no return value specified for Push")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
-		r0 = rf(worktreePath, branch, setUpstream)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(worktreePath, branch, setUpstream)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_Push_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Push'
+type MockClient_Push_Call struct {
+	*mock.Call
+}
+
+// Push is a helper method to define mock.On call
+//   - worktreePath string
+//   - branch string
+//   - setUpstream bool
+func (_e *MockClient_Expecter) Push(worktreePath interface{}, branch interface{}, setUpstream interface{}) *MockClient_Push_Call {
+	return &MockClient_Push_Call{Call: _e.mock.On("Push", worktreePath, branch, setUpstream)}
+}
+
+func (_c *MockClient_Push_Call) Run(run func(worktreePath string, branch string, setUpstream bool)) *MockClient_Push_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockClient_Push_Call) Return(_a0 error) *MockClient_Push_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_Push_Call) RunAndReturn(run func(string, string, bool) error) *MockClient_Push_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rebase provides a mock function with given fields: repoPath, upstream, opts
+func (_m *MockClient) Rebase(repoPath string, upstream string, opts gitops.RebaseRunOptions) error {
+	ret := _m.Called(repoPath, upstream, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rebase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, gitops.RebaseRunOptions) error); ok {
+		r0 = rf(repoPath, upstream, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_Rebase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rebase'
+type MockClient_Rebase_Call struct {
+	*mock.Call
+}
+
+// Rebase is a helper method to define mock.On call
+//   - repoPath string
+//   - upstream string
+//   - opts gitops.RebaseRunOptions
+func (_e *MockClient_Expecter) Rebase(repoPath interface{}, upstream interface{}, opts interface{}) *MockClient_Rebase_Call {
+	return &MockClient_Rebase_Call{Call: _e.mock.On("Rebase", repoPath, upstream, opts)}
+}
+
+func (_c *MockClient_Rebase_Call) Run(run func(repoPath string, upstream string, opts gitops.RebaseRunOptions)) *MockClient_Rebase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(gitops.RebaseRunOptions))
+	})
+	return _c
+}
+
+func (_c *MockClient_Rebase_Call) Return(_a0 error) *MockClient_Rebase_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_Rebase_Call) RunAndReturn(run func(string, string, gitops.RebaseRunOptions) error) *MockClient_Rebase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RebaseAbort provides a mock function with given fields: repoPath
+func (_m *MockClient) RebaseAbort(repoPath string) error {
+	ret := _m.Called(repoPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebaseAbort")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(repoPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_RebaseAbort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebaseAbort'
+type MockClient_RebaseAbort_Call struct {
+	*mock.Call
+}
+
+// RebaseAbort is a helper method to define mock.On call
+//   - repoPath string
+func (_e *MockClient_Expecter) RebaseAbort(repoPath interface{}) *MockClient_RebaseAbort_Call {
+	return &MockClient_RebaseAbort_Call{Call: _e.mock.On("RebaseAbort", repoPath)}
+}
+
+func (_c *MockClient_RebaseAbort_Call) Run(run func(repoPath string)) *MockClient_RebaseAbort_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_RebaseAbort_Call) Return(_a0 error) *MockClient_RebaseAbort_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_RebaseAbort_Call) RunAndReturn(run func(string) error) *MockClient_RebaseAbort_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RebaseContinue provides a mock function with given fields: repoPath
+func (_m *MockClient) RebaseContinue(repoPath string) error {
+	ret := _m.Called(repoPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebaseContinue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(repoPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_RebaseContinue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebaseContinue'
+type MockClient_RebaseContinue_Call struct {
+	*mock.Call
+}
+
+// RebaseContinue is a helper method to define mock.On call
+//   - repoPath string
+func (_e *MockClient_Expecter) RebaseContinue(repoPath interface{}) *MockClient_RebaseContinue_Call {
+	return &MockClient_RebaseContinue_Call{Call: _e.mock.On("RebaseContinue", repoPath)}
+}
+
+func (_c *MockClient_RebaseContinue_Call) Run(run func(repoPath string)) *MockClient_RebaseContinue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_RebaseContinue_Call) Return(_a0 error) *MockClient_RebaseContinue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_RebaseContinue_Call) RunAndReturn(run func(string) error) *MockClient_RebaseContinue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefExists provides a mock function with given fields: repoPath, ref
+func (_m *MockClient) RefExists(repoPath string, ref string) (bool, error) {
+	ret := _m.Called(repoPath, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(repoPath, ref)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(repoPath, ref)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(repoPath, ref)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// MockClient_Push_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Push'
-type MockClient_Push_Call struct {
+// MockClient_RefExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefExists'
+type MockClient_RefExists_Call struct {
 	*mock.Call
 }
 
-// Push is a helper method to define mock.On call
-//   - worktreePath string
-//   - branch string
-//   - setUpstream bool
-func (_e *MockClient_Expecter) Push(worktreePath interface{}, branch interface{}, setUpstream interface{}) *MockClient_Push_Call {
-	return &MockClient_Push_Call{Call: _e.mock.On("Push", worktreePath, branch, setUpstream)}
+// RefExists is a helper method to define mock.On call
+//   - repoPath string
+//   - ref string
+func (_e *MockClient_Expecter) RefExists(repoPath interface{}, ref interface{}) *MockClient_RefExists_Call {
+	return &MockClient_RefExists_Call{Call: _e.mock.On("RefExists", repoPath, ref)}
 }
 
-func (_c *MockClient_Push_Call) Run(run func(worktreePath string, branch string, setUpstream bool)) *MockClient_Push_Call {
+func (_c *MockClient_RefExists_Call) Run(run func(repoPath string, ref string)) *MockClient_RefExists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(bool))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_Push_Call) Return(_a0 error) *MockClient_Push_Call {
-	_c.Call.Return(_a0)
+func (_c *MockClient_RefExists_Call) Return(_a0 bool, _a1 error) *MockClient_RefExists_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_Push_Call) RunAndReturn(run func(string, string, bool) error) *MockClient_Push_Call {
+func (_c *MockClient_RefExists_Call) RunAndReturn(run func(string, string) (bool, error)) *MockClient_RefExists_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Rebase provides a mock function with given fields: repoPath, branch
-func (_m *MockClient) Rebase(repoPath string, branch string) error {
-	ret := _m.Called(repoPath, branch)
+// RefSHA provides a mock function with given fields: repoPath, ref
+func (_m *MockClient) RefSHA(repoPath string, ref string) (string, error) {
+	ret := _m.Called(repoPath, ref)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Rebase")
+		panic("no return value specified for RefSHA")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(repoPath, branch)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(repoPath, ref)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(repoPath, ref)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(repoPath, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockClient_Rebase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rebase'
-type MockClient_Rebase_Call struct {
+// MockClient_RefSHA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefSHA'
+type MockClient_RefSHA_Call struct {
 	*mock.Call
 }
 
-// Rebase is a helper method to define mock.On call
+// RefSHA is a helper method to define mock.On call
 //   - repoPath string
-//   - branch string
-func (_e *MockClient_Expecter) Rebase(repoPath interface{}, branch interface{}) *MockClient_Rebase_Call {
-	return &MockClient_Rebase_Call{Call: _e.mock.On("Rebase", repoPath, branch)}
+//   - ref string
+func (_e *MockClient_Expecter) RefSHA(repoPath interface{}, ref interface{}) *MockClient_RefSHA_Call {
+	return &MockClient_RefSHA_Call{Call: _e.mock.On("RefSHA", repoPath, ref)}
 }
 
-func (_c *MockClient_Rebase_Call) Run(run func(repoPath string, branch string)) *MockClient_Rebase_Call {
+func (_c *MockClient_RefSHA_Call) Run(run func(repoPath string, ref string)) *MockClient_RefSHA_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_Rebase_Call) Return(_a0 error) *MockClient_Rebase_Call {
-	_c.Call.Return(_a0)
+func (_c *MockClient_RefSHA_Call) Return(_a0 string, _a1 error) *MockClient_RefSHA_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_Rebase_Call) RunAndReturn(run func(string, string) error) *MockClient_Rebase_Call {
+func (_c *MockClient_RefSHA_Call) RunAndReturn(run func(string, string) (string, error)) *MockClient_RefSHA_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RebaseAbort provides a mock function with given fields: repoPath
-func (_m *MockClient) RebaseAbort(repoPath string) error {
-	ret := _m.Called(repoPath)
+// Reflog provides a mock function with given fields: repoPath, ref
+func (_m *MockClient) Reflog(repoPath string, ref string) ([]gitops.ReflogEntry, error) {
+	ret := _m.Called(repoPath, ref)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RebaseAbort")
+		panic("no return value specified for Reflog")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(repoPath)
+	var r0 []gitops.ReflogEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]gitops.ReflogEntry, error)); ok {
+		return rf(repoPath, ref)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []gitops.ReflogEntry); ok {
+		r0 = rf(repoPath, ref)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gitops.ReflogEntry)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(repoPath, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockClient_RebaseAbort_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebaseAbort'
-type MockClient_RebaseAbort_Call struct {
+// MockClient_Reflog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reflog'
+type MockClient_Reflog_Call struct {
 	*mock.Call
 }
 
-// RebaseAbort is a helper method to define mock.On call
+// Reflog is a helper method to define mock.On call
 //   - repoPath string
-func (_e *MockClient_Expecter) RebaseAbort(repoPath interface{}) *MockClient_RebaseAbort_Call {
-	return &MockClient_RebaseAbort_Call{Call: _e.mock.On("RebaseAbort", repoPath)}
+//   - ref string
+func (_e *MockClient_Expecter) Reflog(repoPath interface{}, ref interface{}) *MockClient_Reflog_Call {
+	return &MockClient_Reflog_Call{Call: _e.mock.On("Reflog", repoPath, ref)}
 }
 
-func (_c *MockClient_RebaseAbort_Call) Run(run func(repoPath string)) *MockClient_RebaseAbort_Call {
+func (_c *MockClient_Reflog_Call) Run(run func(repoPath string, ref string)) *MockClient_Reflog_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_RebaseAbort_Call) Return(_a0 error) *MockClient_RebaseAbort_Call {
-	_c.Call.Return(_a0)
+func (_c *MockClient_Reflog_Call) Return(_a0 []gitops.ReflogEntry, _a1 error) *MockClient_Reflog_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_RebaseAbort_Call) RunAndReturn(run func(string) error) *MockClient_RebaseAbort_Call {
+func (_c *MockClient_Reflog_Call) RunAndReturn(run func(string, string) ([]gitops.ReflogEntry, error)) *MockClient_Reflog_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RebaseContinue provides a mock function with given fields: repoPath
-func (_m *MockClient) RebaseContinue(repoPath string) error {
-	ret := _m.Called(repoPath)
+// RemoteBranchSHA provides a mock function with given fields: path, remote, branch
+func (_m *MockClient) RemoteBranchSHA(path string, remote string, branch string) (string, error) {
+	ret := _m.Called(path, remote, branch)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RebaseContinue")
+		panic("no return value specified for RemoteBranchSHA")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(repoPath)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
+		return rf(path, remote, branch)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(path, remote, branch)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(path, remote, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockClient_RebaseContinue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebaseContinue'
-type MockClient_RebaseContinue_Call struct {
+// MockClient_RemoteBranchSHA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoteBranchSHA'
+type MockClient_RemoteBranchSHA_Call struct {
 	*mock.Call
 }
 
-// RebaseContinue is a helper method to define mock.On call
-//   - repoPath string
-func (_e *MockClient_Expecter) RebaseContinue(repoPath interface{}) *MockClient_RebaseContinue_Call {
-	return &MockClient_RebaseContinue_Call{Call: _e.mock.On("RebaseContinue", repoPath)}
+// RemoteBranchSHA is a helper method to define mock.On call
+//   - path string
+//   - remote string
+//   - branch string
+func (_e *MockClient_Expecter) RemoteBranchSHA(path interface{}, remote interface{}, branch interface{}) *MockClient_RemoteBranchSHA_Call {
+	return &MockClient_RemoteBranchSHA_Call{Call: _e.mock.On("RemoteBranchSHA", path, remote, branch)}
 }
 
-func (_c *MockClient_RebaseContinue_Call) Run(run func(repoPath string)) *MockClient_RebaseContinue_Call {
+func (_c *MockClient_RemoteBranchSHA_Call) Run(run func(path string, remote string, branch string)) *MockClient_RemoteBranchSHA_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_RebaseContinue_Call) Return(_a0 error) *MockClient_RebaseContinue_Call {
-	_c.Call.Return(_a0)
+func (_c *MockClient_RemoteBranchSHA_Call) Return(_a0 string, _a1 error) *MockClient_RemoteBranchSHA_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_RebaseContinue_Call) RunAndReturn(run func(string) error) *MockClient_RebaseContinue_Call {
+func (_c *MockClient_RemoteBranchSHA_Call) RunAndReturn(run func(string, string, string) (string, error)) *MockClient_RemoteBranchSHA_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1174,6 +2040,53 @@ func (_c *MockClient_RepoRoot_Call) RunAndReturn(run func(string) (string, error
 	return _c
 }
 
+// ResetHard provides a mock function with given fields: repoPath, ref
+func (_m *MockClient) ResetHard(repoPath string, ref string) error {
+	ret := _m.Called(repoPath, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetHard")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(repoPath, ref)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_ResetHard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetHard'
+type MockClient_ResetHard_Call struct {
+	*mock.Call
+}
+
+// ResetHard is a helper method to define mock.On call
+//   - repoPath string
+//   - ref string
+func (_e *MockClient_Expecter) ResetHard(repoPath interface{}, ref interface{}) *MockClient_ResetHard_Call {
+	return &MockClient_ResetHard_Call{Call: _e.mock.On("ResetHard", repoPath, ref)}
+}
+
+func (_c *MockClient_ResetHard_Call) Run(run func(repoPath string, ref string)) *MockClient_ResetHard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ResetHard_Call) Return(_a0 error) *MockClient_ResetHard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_ResetHard_Call) RunAndReturn(run func(string, string) error) *MockClient_ResetHard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResolveWorktree provides a mock function with given fields: repoPath, input
 func (_m *MockClient) ResolveWorktree(repoPath string, input string) (string, error) {
 	ret := _m.Called(repoPath, input)
@@ -1231,17 +2144,121 @@ func (_c *MockClient_ResolveWorktree_Call) RunAndReturn(run func(string, string)
 	return _c
 }
 
-// WorktreeAdd provides a mock function with given fields: repoPath, wtPath, branch, base, newBranch
-func (_m *MockClient) WorktreeAdd(repoPath string, wtPath string, branch string, base string, newBranch bool) error {
-	ret := _m.Called(repoPath, wtPath, branch, base, newBranch)
+// SetLocalConfig provides a mock function with given fields: path, key, value
+func (_m *MockClient) SetLocalConfig(path string, key string, value string) error {
+	ret := _m.Called(path, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLocalConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(path, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_SetLocalConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLocalConfig'
+type MockClient_SetLocalConfig_Call struct {
+	*mock.Call
+}
+
+// SetLocalConfig is a helper method to define mock.On call
+//   - path string
+//   - key string
+//   - value string
+func (_e *MockClient_Expecter) SetLocalConfig(path interface{}, key interface{}, value interface{}) *MockClient_SetLocalConfig_Call {
+	return &MockClient_SetLocalConfig_Call{Call: _e.mock.On("SetLocalConfig", path, key, value)}
+}
+
+func (_c *MockClient_SetLocalConfig_Call) Run(run func(path string, key string, value string)) *MockClient_SetLocalConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_SetLocalConfig_Call) Return(_a0 error) *MockClient_SetLocalConfig_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_SetLocalConfig_Call) RunAndReturn(run func(string, string, string) error) *MockClient_SetLocalConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StashCount provides a mock function with given fields: path
+func (_m *MockClient) StashCount(path string) (int, error) {
+	ret := _m.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StashCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(path)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_StashCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StashCount'
+type MockClient_StashCount_Call struct {
+	*mock.Call
+}
+
+// StashCount is a helper method to define mock.On call
+//   - path string
+func (_e *MockClient_Expecter) StashCount(path interface{}) *MockClient_StashCount_Call {
+	return &MockClient_StashCount_Call{Call: _e.mock.On("StashCount", path)}
+}
+
+func (_c *MockClient_StashCount_Call) Run(run func(path string)) *MockClient_StashCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_StashCount_Call) Return(_a0 int, _a1 error) *MockClient_StashCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_StashCount_Call) RunAndReturn(run func(string) (int, error)) *MockClient_StashCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WorktreeAdd provides a mock function with given fields: repoPath, wtPath, branch, base, newBranch, force
+func (_m *MockClient) WorktreeAdd(repoPath string, wtPath string, branch string, base string, newBranch bool, force bool) error {
+	ret := _m.Called(repoPath, wtPath, branch, base, newBranch, force)
 
 	if len(ret) == 0 {
 		panic("no return value specified for WorktreeAdd")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string, bool) error); ok {
-		r0 = rf(repoPath, wtPath, branch, base, newBranch)
+	if rf, ok := ret.Get(0).(func(string, string, string, string, bool, bool) error); ok {
+		r0 = rf(repoPath, wtPath, branch, base, newBranch, force)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1260,13 +2277,14 @@ type MockClient_WorktreeAdd_Call struct {
 //   - branch string
 //   - base string
 //   - newBranch bool
-func (_e *MockClient_Expecter) WorktreeAdd(repoPath interface{}, wtPath interface{}, branch interface{}, base interface{}, newBranch interface{}) *MockClient_WorktreeAdd_Call {
-	return &MockClient_WorktreeAdd_Call{Call: _e.mock.On("WorktreeAdd", repoPath, wtPath, branch, base, newBranch)}
+//   - force bool
+func (_e *MockClient_Expecter) WorktreeAdd(repoPath interface{}, wtPath interface{}, branch interface{}, base interface{}, newBranch interface{}, force interface{}) *MockClient_WorktreeAdd_Call {
+	return &MockClient_WorktreeAdd_Call{Call: _e.mock.On("WorktreeAdd", repoPath, wtPath, branch, base, newBranch, force)}
 }
 
-func (_c *MockClient_WorktreeAdd_Call) Run(run func(repoPath string, wtPath string, branch string, base string, newBranch bool)) *MockClient_WorktreeAdd_Call {
+func (_c *MockClient_WorktreeAdd_Call) Run(run func(repoPath string, wtPath string, branch string, base string, newBranch bool, force bool)) *MockClient_WorktreeAdd_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string), args[4].(bool), args[5].(bool))
 	})
 	return _c
 }
@@ -1276,7 +2294,7 @@ func (_c *MockClient_WorktreeAdd_Call) Return(_a0 error) *MockClient_WorktreeAdd
 	return _c
 }
 
-func (_c *MockClient_WorktreeAdd_Call) RunAndReturn(run func(string, string, string, string, bool) error) *MockClient_WorktreeAdd_Call {
+func (_c *MockClient_WorktreeAdd_Call) RunAndReturn(run func(string, string, string, string, bool, bool) error) *MockClient_WorktreeAdd_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1433,6 +2451,52 @@ func (_c *MockClient_WorktreeRemove_Call) RunAndReturn(run func(string, string,
 	return _c
 }
 
+// WorktreeRepair provides a mock function with given fields: repoPath
+func (_m *MockClient) WorktreeRepair(repoPath string) error {
+	ret := _m.Called(repoPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WorktreeRepair")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(repoPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_WorktreeRepair_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WorktreeRepair'
+type MockClient_WorktreeRepair_Call struct {
+	*mock.Call
+}
+
+// WorktreeRepair is a helper method to define mock.On call
+//   - repoPath string
+func (_e *MockClient_Expecter) WorktreeRepair(repoPath interface{}) *MockClient_WorktreeRepair_Call {
+	return &MockClient_WorktreeRepair_Call{Call: _e.mock.On("WorktreeRepair", repoPath)}
+}
+
+func (_c *MockClient_WorktreeRepair_Call) Run(run func(repoPath string)) *MockClient_WorktreeRepair_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_WorktreeRepair_Call) Return(_a0 error) *MockClient_WorktreeRepair_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_WorktreeRepair_Call) RunAndReturn(run func(string) error) *MockClient_WorktreeRepair_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WorktreesDir provides a mock function with given fields: repoPath
 func (_m *MockClient) WorktreesDir(repoPath string) (string, error) {
 	ret := _m.Called(repoPath)
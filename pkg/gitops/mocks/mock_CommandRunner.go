@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	exec "os/exec"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCommandRunner is an autogenerated mock type for the CommandRunner type
+type MockCommandRunner struct {
+	mock.Mock
+}
+
+type MockCommandRunner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCommandRunner) EXPECT() *MockCommandRunner_Expecter {
+	return &MockCommandRunner_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: cmd, combined
+func (_m *MockCommandRunner) Execute(cmd *exec.Cmd, combined bool) ([]byte, error) {
+	ret := _m.Called(cmd, combined)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*exec.Cmd, bool) ([]byte, error)); ok {
+		return rf(cmd, combined)
+	}
+	if rf, ok := ret.Get(0).(func(*exec.Cmd, bool) []byte); ok {
+		r0 = rf(cmd, combined)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*exec.Cmd, bool) error); ok {
+		r1 = rf(cmd, combined)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCommandRunner_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockCommandRunner_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - cmd *exec.Cmd
+//   - combined bool
+func (_e *MockCommandRunner_Expecter) Execute(cmd interface{}, combined interface{}) *MockCommandRunner_Execute_Call {
+	return &MockCommandRunner_Execute_Call{Call: _e.mock.On("Execute", cmd, combined)}
+}
+
+func (_c *MockCommandRunner_Execute_Call) Run(run func(cmd *exec.Cmd, combined bool)) *MockCommandRunner_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*exec.Cmd), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *MockCommandRunner_Execute_Call) Return(_a0 []byte, _a1 error) *MockCommandRunner_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCommandRunner_Execute_Call) RunAndReturn(run func(*exec.Cmd, bool) ([]byte, error)) *MockCommandRunner_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCommandRunner creates a new instance of MockCommandRunner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCommandRunner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCommandRunner {
+	mock := &MockCommandRunner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,6 +2,7 @@ package iterm
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -11,19 +12,30 @@ func ScriptIsRunning() string {
 }
 
 // ScriptCreateWorktreeWindow returns AppleScript to create a new iTerm2 window
-// with two panes: claude on top, shell on bottom.
-func ScriptCreateWorktreeWindow(wtPath, sessionName string, noClaude bool) string {
-	// Escape single quotes in paths for AppleScript
-	safePath := escapeAppleScript(wtPath)
-	safeName := escapeAppleScript(sessionName)
-
-	claudeCmd := fmt.Sprintf("cd '%s' && claude", safePath)
-	if noClaude {
-		claudeCmd = fmt.Sprintf("cd '%s'", safePath)
+// with two panes: claude on top, shell on bottom. opts.Env is exported in both
+// panes before anything else runs, and both panes use opts.Profile if set.
+func ScriptCreateWorktreeWindow(wtPath, sessionName string, opts WindowOptions) string {
+	safeName := escapeAppleScript(windowTitle(sessionName, opts))
+	profile := "default profile"
+	if opts.Profile != "" {
+		profile = fmt.Sprintf(`profile "%s"`, escapeAppleScript(opts.Profile))
 	}
+	claudeCmd, shellCmd := PaneCommands(wtPath, opts)
+	claudeCmd = escapeAppleScript(claudeCmd)
+	shellCmd = escapeAppleScript(shellCmd)
 
-	return fmt.Sprintf(`tell application "iTerm2"
-	set newWindow to (create window with default profile)
+	// NoFocus remembers the frontmost app and iTerm2 window, and brings both
+	// back once the new window is set up
+	saveApp, saveWindow, restoreWindow, restoreApp := "", "", "", ""
+	if opts.NoFocus {
+		saveApp = "set frontApp to (path to frontmost application as text)\n"
+		saveWindow = "\n\tset prevWindow to current window"
+		restoreWindow = "\n\tif prevWindow is not missing value then select prevWindow"
+		restoreApp = "\ntell application frontApp to activate"
+	}
+
+	return fmt.Sprintf(`%stell application "iTerm2"%s
+	set newWindow to (create window with %s)
 	tell newWindow
 		tell current session of current tab
 			set name to "%s:claude"
@@ -31,16 +43,52 @@ func ScriptCreateWorktreeWindow(wtPath, sessionName string, noClaude bool) strin
 			set claudeID to unique ID
 		end tell
 		tell current session of current tab
-			set shellSession to (split horizontally with default profile)
+			set shellSession to (split horizontally with %s)
 		end tell
 		tell shellSession
 			set name to "%s:shell"
-			write text "cd '%s'"
+			write text "%s"
 			set shellID to unique ID
 		end tell
-	end tell
-	return claudeID & "\t" & shellID
-end tell`, safeName, claudeCmd, safeName, safePath)
+	end tell%s
+	set sessionIDs to claudeID & "\t" & shellID
+end tell%s
+return sessionIDs`, saveApp, saveWindow, profile, safeName, claudeCmd, profile, safeName, shellCmd, restoreWindow, restoreApp)
+}
+
+// PaneCommands returns the shell commands typed into the top (claude) and
+// bottom (shell) panes of a new worktree window.
+func PaneCommands(wtPath string, opts WindowOptions) (claudeCmd, shellCmd string) {
+	shellCmd = fmt.Sprintf("cd '%s'", wtPath) + exportCommands(opts.Env)
+	claudeCmd = shellCmd + " && claude"
+	if opts.NoClaude {
+		claudeCmd = shellCmd
+	}
+	return claudeCmd, shellCmd
+}
+
+// windowTitle returns the title panes are named after: opts.Title if set,
+// otherwise sessionName.
+func windowTitle(sessionName string, opts WindowOptions) string {
+	if opts.Title != "" {
+		return opts.Title
+	}
+	return sessionName
+}
+
+// exportCommands renders env as " && export K='v'" clauses in key order.
+func exportCommands(env map[string]string) string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, " && export %s='%s'", k, env[k])
+	}
+	return b.String()
 }
 
 // ScriptSessionExists returns AppleScript to check if a session ID exists.
@@ -60,6 +108,27 @@ func ScriptSessionExists(sessionID string) string {
 end tell`, safe)
 }
 
+// ScriptSessionBusy returns AppleScript that prints "true" if a session is
+// processing (has produced output recently) and "false" otherwise.
+func ScriptSessionBusy(sessionID string) string {
+	safe := escapeAppleScript(sessionID)
+	return fmt.Sprintf(`tell application "iTerm2"
+	repeat with w in windows
+		repeat with t in tabs of w
+			repeat with s in sessions of t
+				if unique ID of s is "%s" then
+					if is processing of s then
+						return "true"
+					end if
+					return "false"
+				end if
+			end repeat
+		end repeat
+	end repeat
+	return "false"
+end tell`, safe)
+}
+
 // ScriptFocusWindow returns AppleScript to focus the window containing a session.
 func ScriptFocusWindow(sessionID string) string {
 	safe := escapeAppleScript(sessionID)
@@ -80,6 +149,21 @@ func ScriptFocusWindow(sessionID string) string {
 end tell`, safe)
 }
 
+// ScriptActiveSession returns AppleScript that prints the unique ID of the
+// current session of iTerm2's front window, or nothing when iTerm2 isn't the
+// frontmost app or has no windows.
+func ScriptActiveSession() string {
+	return `tell application "iTerm2"
+	if not frontmost then
+		return ""
+	end if
+	if (count of windows) is 0 then
+		return ""
+	end if
+	return unique ID of current session of current tab of current window
+end tell`
+}
+
 // ScriptCloseWindow returns AppleScript to close the window containing a session.
 func ScriptCloseWindow(sessionID string) string {
 	safe := escapeAppleScript(sessionID)
@@ -98,6 +182,28 @@ func ScriptCloseWindow(sessionID string) string {
 end tell`, safe)
 }
 
+// ScriptListWindows returns AppleScript that prints one line per window: the
+// working directory of its current tab's first session, then the unique ID of
+// each session in that tab, tab-separated.
+func ScriptListWindows() string {
+	return `tell application "iTerm2"
+	set output to ""
+	repeat with w in windows
+		set t to current tab of w
+		set winPath to ""
+		set ids to ""
+		repeat with s in sessions of t
+			if winPath is "" then
+				tell s to set winPath to (variable named "session.path")
+			end if
+			set ids to ids & tab & (unique ID of s)
+		end repeat
+		set output to output & winPath & ids & linefeed
+	end repeat
+	return output
+end tell`
+}
+
 // escapeAppleScript escapes characters that could break AppleScript strings.
 func escapeAppleScript(s string) string {
 	s = strings.ReplaceAll(s, `\`, `\\`)
@@ -8,7 +8,7 @@ import (
 )
 
 func TestScriptCreateWorktreeWindow(t *testing.T) {
-	script := ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", false)
+	script := ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", WindowOptions{})
 
 	assert.Contains(t, script, `cd '/Users/joe/repo.worktrees/auth' && claude`)
 	assert.Contains(t, script, `"wt:repo:auth:claude"`)
@@ -18,12 +18,53 @@ func TestScriptCreateWorktreeWindow(t *testing.T) {
 }
 
 func TestScriptCreateWorktreeWindow_NoClaude(t *testing.T) {
-	script := ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", true)
+	script := ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", WindowOptions{NoClaude: true})
 
 	assert.NotContains(t, script, "&& claude")
 	assert.Contains(t, script, `cd '/Users/joe/repo.worktrees/auth'`)
 }
 
+func TestScriptCreateWorktreeWindow_Env(t *testing.T) {
+	script := ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", WindowOptions{
+		Env: map[string]string{"WT_PORT": "4123", "A_VAR": "x"},
+	})
+
+	// Exported in both panes, sorted by key, before claude launches
+	assert.Equal(t, 2, strings.Count(script, "export A_VAR='x' && export WT_PORT='4123'"))
+	assert.Contains(t, script, `export WT_PORT='4123' && claude`)
+}
+
+func TestScriptCreateWorktreeWindow_Title(t *testing.T) {
+	script := ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", WindowOptions{Title: `Auth "bug" #123`})
+
+	assert.Contains(t, script, `"Auth \"bug\" #123:claude"`)
+	assert.Contains(t, script, `"Auth \"bug\" #123:shell"`)
+	assert.NotContains(t, script, "wt:repo:auth")
+}
+
+func TestScriptCreateWorktreeWindow_NoFocus(t *testing.T) {
+	script := ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", WindowOptions{NoFocus: true})
+
+	assert.True(t, strings.HasPrefix(script, "set frontApp to (path to frontmost application as text)\n"))
+	assert.Contains(t, script, "set prevWindow to current window\n\tset newWindow to")
+	assert.Contains(t, script, "if prevWindow is not missing value then select prevWindow")
+	assert.Contains(t, script, "tell application frontApp to activate\nreturn sessionIDs")
+
+	// Focus is left alone by default
+	assert.NotContains(t, ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", WindowOptions{}), "frontApp")
+}
+
+func TestScriptCreateWorktreeWindow_Profile(t *testing.T) {
+	script := ScriptCreateWorktreeWindow("/Users/joe/repo.worktrees/auth", "wt:repo:auth", WindowOptions{Profile: `Prod "red"`})
+
+	assert.Contains(t, script, `create window with profile "Prod \"red\""`)
+	assert.Contains(t, script, `split horizontally with profile "Prod \"red\""`)
+	assert.NotContains(t, script, "default profile")
+
+	// The default profile is used otherwise
+	assert.Equal(t, 2, strings.Count(ScriptCreateWorktreeWindow("/p", "wt:repo:auth", WindowOptions{}), "with default profile"))
+}
+
 func TestScriptSessionExists(t *testing.T) {
 	script := ScriptSessionExists("session-123")
 	assert.Contains(t, script, `"session-123"`)
@@ -31,12 +72,33 @@ func TestScriptSessionExists(t *testing.T) {
 	assert.Contains(t, script, `return "false"`)
 }
 
+func TestScriptSessionBusy(t *testing.T) {
+	script := ScriptSessionBusy("session-123")
+	assert.Contains(t, script, `"session-123"`)
+	assert.Contains(t, script, "is processing of s")
+	assert.Contains(t, script, `return "true"`)
+	assert.Contains(t, script, `return "false"`)
+}
+
+func TestScriptListWindows(t *testing.T) {
+	script := ScriptListWindows()
+	assert.Contains(t, script, "current tab of w")
+	assert.Contains(t, script, `variable named "session.path"`)
+	assert.Contains(t, script, "unique ID of s")
+}
+
 func TestScriptFocusWindow(t *testing.T) {
 	script := ScriptFocusWindow("session-456")
 	assert.Contains(t, script, `"session-456"`)
 	assert.Contains(t, script, "activate")
 }
 
+func TestScriptActiveSession(t *testing.T) {
+	script := ScriptActiveSession()
+	assert.Contains(t, script, "frontmost")
+	assert.Contains(t, script, "unique ID of current session of current tab of current window")
+}
+
 func TestScriptCloseWindow(t *testing.T) {
 	script := ScriptCloseWindow("session-789")
 	assert.Contains(t, script, `"session-789"`)
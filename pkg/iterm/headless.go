@@ -0,0 +1,38 @@
+package iterm
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrNoTerminal is returned by CreateWorktreeWindow when there is no desktop
+// session to open an iTerm2 window in, e.g. under cron, CI, or over SSH.
+var ErrNoTerminal = errors.New("no terminal available")
+
+// Headless reports whether wt is running without a desktop session to drive
+// iTerm2 from: off macOS, under CI, or outside a terminal app in a session
+// that isn't the logged-in user's GUI session.
+func Headless() bool {
+	return headless(runtime.GOOS, os.Getenv, guiSession)
+}
+
+func headless(goos string, getenv func(string) string, gui func() bool) bool {
+	if goos != "darwin" || getenv("CI") != "" {
+		return true
+	}
+	if getenv("TERM_PROGRAM") != "" {
+		return false // running inside a terminal app
+	}
+	return !gui()
+}
+
+// guiSession reports whether this process belongs to the GUI (Aqua) launchd
+// session, the only one osascript can reach iTerm2 from. cron jobs and SSH
+// logins run in Background or StandardIO sessions instead.
+func guiSession() bool {
+	out, err := exec.Command("launchctl", "managername").Output()
+	return err == nil && strings.TrimSpace(string(out)) == "Aqua"
+}
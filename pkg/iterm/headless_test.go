@@ -0,0 +1,30 @@
+package iterm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadless(t *testing.T) {
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		gui  bool
+		want bool
+	}{
+		{name: "not macOS", goos: "linux", env: map[string]string{"TERM_PROGRAM": "iTerm.app"}, gui: true, want: true},
+		{name: "CI", goos: "darwin", env: map[string]string{"CI": "true", "TERM_PROGRAM": "iTerm.app"}, gui: true, want: true},
+		{name: "terminal app", goos: "darwin", env: map[string]string{"TERM_PROGRAM": "iTerm.app"}, gui: false, want: false},
+		{name: "GUI session without terminal (e.g. MCP from a desktop app)", goos: "darwin", gui: true, want: false},
+		{name: "cron or SSH", goos: "darwin", gui: false, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			gui := func() bool { return tt.gui }
+			assert.Equal(t, tt.want, headless(tt.goos, getenv, gui))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package iterm
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -13,14 +14,33 @@ type SessionIDs struct {
 	ShellSessionID  string
 }
 
+// WindowOptions configures a new worktree window.
+type WindowOptions struct {
+	NoClaude bool              // don't auto-launch claude in the top pane
+	Env      map[string]string // variables exported in both panes (e.g. WT_PORT)
+	Title    string            // custom window title; replaces the generated session name
+	NoFocus  bool              // leave the current window and app in front instead of the new window
+	Profile  string            // iTerm2 profile for both panes; empty uses the default profile
+}
+
+// WindowInfo describes an open iTerm2 window, wt's or not.
+type WindowInfo struct {
+	SessionIDs []string // sessions of the window's current tab, in pane order
+	Path       string   // working directory of the first session
+}
+
 // Client defines the interface for iTerm2 operations.
 type Client interface {
 	IsRunning() bool
 	EnsureRunning() error
-	CreateWorktreeWindow(path, name string, noClaude bool) (*SessionIDs, error)
+	CreateWorktreeWindow(path, name string, opts WindowOptions) (*SessionIDs, error)
+	PreviewCommand(path, name string, opts WindowOptions) string
 	SessionExists(sessionID string) bool
+	SessionBusy(sessionID string) bool
 	FocusWindow(sessionID string) error
+	ActiveSession() (string, error)
 	CloseWindow(sessionID string) error
+	ListWindows() ([]WindowInfo, error)
 }
 
 // RealClient implements Client using osascript.
@@ -60,12 +80,16 @@ func (c *RealClient) EnsureRunning() error {
 	return fmt.Errorf("timed out waiting for iTerm2 to start")
 }
 
-func (c *RealClient) CreateWorktreeWindow(path, name string, noClaude bool) (*SessionIDs, error) {
+func (c *RealClient) CreateWorktreeWindow(path, name string, opts WindowOptions) (*SessionIDs, error) {
+	// Launching iTerm2 would only time out without a desktop session
+	if Headless() {
+		return nil, ErrNoTerminal
+	}
 	if err := c.EnsureRunning(); err != nil {
 		return nil, err
 	}
 
-	script := ScriptCreateWorktreeWindow(path, name, noClaude)
+	script := ScriptCreateWorktreeWindow(path, name, opts)
 	out, err := exec.Command("osascript", "-e", script).Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create iTerm2 window: %w", err)
@@ -82,6 +106,19 @@ func (c *RealClient) CreateWorktreeWindow(path, name string, noClaude bool) (*Se
 	}, nil
 }
 
+// PreviewCommand describes the window CreateWorktreeWindow would open, one
+// line each for the window title and the command typed into each pane, for
+// dry runs.
+func (c *RealClient) PreviewCommand(path, name string, opts WindowOptions) string {
+	claudeCmd, shellCmd := PaneCommands(path, opts)
+	title := windowTitle(name, opts)
+	window := title
+	if opts.Profile != "" {
+		window += fmt.Sprintf(" (profile: %s)", opts.Profile)
+	}
+	return fmt.Sprintf("window: %s\ntop pane (%s:claude): %s\nbottom pane (%s:shell): %s", window, title, claudeCmd, title, shellCmd)
+}
+
 func (c *RealClient) SessionExists(sessionID string) bool {
 	if sessionID == "" {
 		return false
@@ -93,6 +130,20 @@ func (c *RealClient) SessionExists(sessionID string) bool {
 	return strings.TrimSpace(string(out)) == "true"
 }
 
+// SessionBusy reports whether the session is actively producing output
+// (iTerm2's "is processing"), e.g. claude working or a chatty dev server.
+// A missing session, or any error, reports idle.
+func (c *RealClient) SessionBusy(sessionID string) bool {
+	if sessionID == "" {
+		return false
+	}
+	out, err := exec.Command("osascript", "-e", ScriptSessionBusy(sessionID)).Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(out)) == "true"
+}
+
 func (c *RealClient) FocusWindow(sessionID string) error {
 	if sessionID == "" {
 		return fmt.Errorf("empty session ID")
@@ -101,6 +152,17 @@ func (c *RealClient) FocusWindow(sessionID string) error {
 	return err
 }
 
+// ActiveSession returns the unique ID of the session that has focus in
+// iTerm2's front window, or "" when iTerm2 isn't the frontmost app or has no
+// windows.
+func (c *RealClient) ActiveSession() (string, error) {
+	out, err := exec.Command("osascript", "-e", ScriptActiveSession()).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to query the active iTerm2 session: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func (c *RealClient) CloseWindow(sessionID string) error {
 	if sessionID == "" {
 		return fmt.Errorf("empty session ID")
@@ -108,3 +170,72 @@ func (c *RealClient) CloseWindow(sessionID string) error {
 	_, err := exec.Command("osascript", "-e", ScriptCloseWindow(sessionID)).Output()
 	return err
 }
+
+// ListWindows returns every open iTerm2 window with its current tab's sessions
+// and working directory.
+func (c *RealClient) ListWindows() ([]WindowInfo, error) {
+	out, err := exec.Command("osascript", "-e", ScriptListWindows()).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list iTerm2 windows: %w", err)
+	}
+	return parseWindowList(string(out)), nil
+}
+
+// parseWindowList parses ScriptListWindows output: one line per window, the
+// path followed by its session IDs, tab-separated. Lines without a path or
+// session are skipped.
+func parseWindowList(out string) []WindowInfo {
+	var windows []WindowInfo
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(fields) < 2 || fields[0] == "" {
+			continue
+		}
+		windows = append(windows, WindowInfo{Path: fields[0], SessionIDs: fields[1:]})
+	}
+	return windows
+}
+
+// FocusVerified focuses the window holding ids.ClaudeSessionID, then checks
+// that one of its sessions is active in iTerm2, refocusing up to retries more
+// times, delay apart, when it isn't — focus can silently fail for a minimized
+// window or one on another Space. It reports whether the window was confirmed
+// in front; if the active session can't be queried it assumes so.
+func FocusVerified(c Client, ids SessionIDs, retries int, delay time.Duration) (bool, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.FocusWindow(ids.ClaudeSessionID); err != nil {
+			return false, err
+		}
+		active, err := c.ActiveSession()
+		if err != nil {
+			return true, nil
+		}
+		if active != "" && (active == ids.ClaudeSessionID || active == ids.ShellSessionID) {
+			return true, nil
+		}
+		if attempt >= retries {
+			return false, nil
+		}
+		time.Sleep(delay)
+	}
+}
+
+// WaitForClose polls c until sessionID no longer exists, checking every
+// interval. It returns ctx.Err() if the context is cancelled first.
+func WaitForClose(ctx context.Context, c Client, sessionID string, interval time.Duration) error {
+	if sessionID == "" {
+		return fmt.Errorf("empty session ID")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for c.SessionExists(sessionID) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package iterm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closingClient reports a session as existing for the first n polls.
+type closingClient struct {
+	Client
+	remaining int
+	polls     int
+}
+
+func (c *closingClient) SessionExists(sessionID string) bool {
+	c.polls++
+	if c.remaining > 0 {
+		c.remaining--
+		return true
+	}
+	return false
+}
+
+func TestWaitForClose(t *testing.T) {
+	c := &closingClient{remaining: 3}
+
+	err := WaitForClose(context.Background(), c, "session-1", time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 4, c.polls) // 3 × open, then closed
+}
+
+func TestWaitForClose_Cancelled(t *testing.T) {
+	c := &closingClient{remaining: 1 << 30}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := WaitForClose(ctx, c, "session-1", time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitForClose_EmptySession(t *testing.T) {
+	err := WaitForClose(context.Background(), &closingClient{}, "", time.Millisecond)
+	require.Error(t, err)
+}
+
+// focusClient brings a window to the front only from the nth focus on, and
+// reports active as the active session once it has.
+type focusClient struct {
+	Client
+	takesOn  int
+	active   string
+	queryErr error
+	focuses  int
+}
+
+func (c *focusClient) FocusWindow(sessionID string) error {
+	c.focuses++
+	return nil
+}
+
+func (c *focusClient) ActiveSession() (string, error) {
+	if c.queryErr != nil {
+		return "", c.queryErr
+	}
+	if c.focuses < c.takesOn {
+		return "other-window", nil
+	}
+	return c.active, nil
+}
+
+func TestFocusVerified(t *testing.T) {
+	ids := SessionIDs{ClaudeSessionID: "claude-1", ShellSessionID: "shell-1"}
+
+	c := &focusClient{takesOn: 1, active: "claude-1"}
+	front, err := FocusVerified(c, ids, 1, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, front)
+	assert.Equal(t, 1, c.focuses)
+
+	c = &focusClient{takesOn: 2, active: "shell-1"}
+	front, err = FocusVerified(c, ids, 1, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, front, "the retry took, with the shell pane active")
+	assert.Equal(t, 2, c.focuses)
+
+	c = &focusClient{takesOn: 1 << 30}
+	front, err = FocusVerified(c, ids, 1, time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, front)
+	assert.Equal(t, 2, c.focuses)
+
+	c = &focusClient{queryErr: assert.AnError}
+	front, err = FocusVerified(c, ids, 1, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, front, "unverifiable focus is assumed to have worked")
+	assert.Equal(t, 1, c.focuses)
+}
+
+func TestPreviewCommand(t *testing.T) {
+	c := NewClient()
+
+	assert.Equal(t, "window: wt:repo:auth\n"+
+		"top pane (wt:repo:auth:claude): cd '/Users/joe/repo.worktrees/auth' && export WT_PORT='4123' && claude\n"+
+		"bottom pane (wt:repo:auth:shell): cd '/Users/joe/repo.worktrees/auth' && export WT_PORT='4123'",
+		c.PreviewCommand("/Users/joe/repo.worktrees/auth", "wt:repo:auth", WindowOptions{Env: map[string]string{"WT_PORT": "4123"}}))
+
+	preview := c.PreviewCommand("/p", "wt:repo:auth", WindowOptions{NoClaude: true, Title: "Auth bug"})
+	assert.Contains(t, preview, "top pane (Auth bug:claude): cd '/p'\n")
+	assert.NotContains(t, preview, "claude\n")
+
+	preview = c.PreviewCommand("/p", "wt:repo:auth", WindowOptions{Profile: "Prod"})
+	assert.True(t, strings.HasPrefix(preview, "window: wt:repo:auth (profile: Prod)\n"))
+}
+
+func TestParseWindowList(t *testing.T) {
+	out := "/Users/joe/repo.worktrees/auth\tc-1\ts-1\n" +
+		"\tx-1\n" + // no path known
+		"/Users/joe/notes\n" + // no sessions
+		"/Users/joe/repo\tr-1\n"
+
+	assert.Equal(t, []WindowInfo{
+		{Path: "/Users/joe/repo.worktrees/auth", SessionIDs: []string{"c-1", "s-1"}},
+		{Path: "/Users/joe/repo", SessionIDs: []string{"r-1"}},
+	}, parseWindowList(out))
+	assert.Empty(t, parseWindowList(""))
+}
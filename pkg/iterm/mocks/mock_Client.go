@@ -20,6 +20,61 @@ func (_m *MockClient) EXPECT() *MockClient_Expecter {
 	return &MockClient_Expecter{mock: &_m.Mock}
 }
 
+// ActiveSession provides a mock function with no fields
+func (_m *MockClient) ActiveSession() (string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActiveSession")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ActiveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveSession'
+type MockClient_ActiveSession_Call struct {
+	*mock.Call
+}
+
+// ActiveSession is a helper method to define mock.On call
+func (_e *MockClient_Expecter) ActiveSession() *MockClient_ActiveSession_Call {
+	return &MockClient_ActiveSession_Call{Call: _e.mock.On("ActiveSession")}
+}
+
+func (_c *MockClient_ActiveSession_Call) Run(run func()) *MockClient_ActiveSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClient_ActiveSession_Call) Return(_a0 string, _a1 error) *MockClient_ActiveSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ActiveSession_Call) RunAndReturn(run func() (string, error)) *MockClient_ActiveSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CloseWindow provides a mock function with given fields: sessionID
 func (_m *MockClient) CloseWindow(sessionID string) error {
 	ret := _m.Called(sessionID)
@@ -66,9 +121,9 @@ func (_c *MockClient_CloseWindow_Call) RunAndReturn(run func(string) error) *Moc
 	return _c
 }
 
-// CreateWorktreeWindow provides a mock function with given fields: path, name, noClaude
-func (_m *MockClient) CreateWorktreeWindow(path string, name string, noClaude bool) (*iterm.SessionIDs, error) {
-	ret := _m.Called(path, name, noClaude)
+// CreateWorktreeWindow provides a mock function with given fields: path, name, opts
+func (_m *MockClient) CreateWorktreeWindow(path string, name string, opts iterm.WindowOptions) (*iterm.SessionIDs, error) {
+	ret := _m.Called(path, name, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateWorktreeWindow")
@@ -76,19 +131,19 @@ func (_m *MockClient) CreateWorktreeWindow(path string, name string, noClaude bo
 
 	var r0 *iterm.SessionIDs
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, bool) (*iterm.SessionIDs, error)); ok {
-		return rf(path, name, noClaude)
+	if rf, ok := ret.Get(0).(func(string, string, iterm.WindowOptions) (*iterm.SessionIDs, error)); ok {
+		return rf(path, name, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, bool) *iterm.SessionIDs); ok {
-		r0 = rf(path, name, noClaude)
+	if rf, ok := ret.Get(0).(func(string, string, iterm.WindowOptions) *iterm.SessionIDs); ok {
+		r0 = rf(path, name, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*iterm.SessionIDs)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, bool) error); ok {
-		r1 = rf(path, name, noClaude)
+	if rf, ok := ret.Get(1).(func(string, string, iterm.WindowOptions) error); ok {
+		r1 = rf(path, name, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,14 +159,14 @@ type MockClient_CreateWorktreeWindow_Call struct {
 // CreateWorktreeWindow is a helper method to define mock.On call
 //   - path string
 //   - name string
-//   - noClaude bool
-func (_e *MockClient_Expecter) CreateWorktreeWindow(path interface{}, name interface{}, noClaude interface{}) *MockClient_CreateWorktreeWindow_Call {
-	return &MockClient_CreateWorktreeWindow_Call{Call: _e.mock.On("CreateWorktreeWindow", path, name, noClaude)}
+//   - opts iterm.WindowOptions
+func (_e *MockClient_Expecter) CreateWorktreeWindow(path interface{}, name interface{}, opts interface{}) *MockClient_CreateWorktreeWindow_Call {
+	return &MockClient_CreateWorktreeWindow_Call{Call: _e.mock.On("CreateWorktreeWindow", path, name, opts)}
 }
 
-func (_c *MockClient_CreateWorktreeWindow_Call) Run(run func(path string, name string, noClaude bool)) *MockClient_CreateWorktreeWindow_Call {
+func (_c *MockClient_CreateWorktreeWindow_Call) Run(run func(path string, name string, opts iterm.WindowOptions)) *MockClient_CreateWorktreeWindow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(bool))
+		run(args[0].(string), args[1].(string), args[2].(iterm.WindowOptions))
 	})
 	return _c
 }
@@ -121,7 +176,7 @@ func (_c *MockClient_CreateWorktreeWindow_Call) Return(_a0 *iterm.SessionIDs, _a
 	return _c
 }
 
-func (_c *MockClient_CreateWorktreeWindow_Call) RunAndReturn(run func(string, string, bool) (*iterm.SessionIDs, error)) *MockClient_CreateWorktreeWindow_Call {
+func (_c *MockClient_CreateWorktreeWindow_Call) RunAndReturn(run func(string, string, iterm.WindowOptions) (*iterm.SessionIDs, error)) *MockClient_CreateWorktreeWindow_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -262,6 +317,157 @@ func (_c *MockClient_IsRunning_Call) RunAndReturn(run func() bool) *MockClient_I
 	return _c
 }
 
+// ListWindows provides a mock function with no fields
+func (_m *MockClient) ListWindows() ([]iterm.WindowInfo, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWindows")
+	}
+
+	var r0 []iterm.WindowInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]iterm.WindowInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []iterm.WindowInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]iterm.WindowInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListWindows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWindows'
+type MockClient_ListWindows_Call struct {
+	*mock.Call
+}
+
+// ListWindows is a helper method to define mock.On call
+func (_e *MockClient_Expecter) ListWindows() *MockClient_ListWindows_Call {
+	return &MockClient_ListWindows_Call{Call: _e.mock.On("ListWindows")}
+}
+
+func (_c *MockClient_ListWindows_Call) Run(run func()) *MockClient_ListWindows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClient_ListWindows_Call) Return(_a0 []iterm.WindowInfo, _a1 error) *MockClient_ListWindows_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListWindows_Call) RunAndReturn(run func() ([]iterm.WindowInfo, error)) *MockClient_ListWindows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewCommand provides a mock function with given fields: path, name, opts
+func (_m *MockClient) PreviewCommand(path string, name string, opts iterm.WindowOptions) string {
+	ret := _m.Called(path, name, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewCommand")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, iterm.WindowOptions) string); ok {
+		r0 = rf(path, name, opts)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockClient_PreviewCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewCommand'
+type MockClient_PreviewCommand_Call struct {
+	*mock.Call
+}
+
+// PreviewCommand is a helper method to define mock.On call
+//   - path string
+//   - name string
+//   - opts iterm.WindowOptions
+func (_e *MockClient_Expecter) PreviewCommand(path interface{}, name interface{}, opts interface{}) *MockClient_PreviewCommand_Call {
+	return &MockClient_PreviewCommand_Call{Call: _e.mock.On("PreviewCommand", path, name, opts)}
+}
+
+func (_c *MockClient_PreviewCommand_Call) Run(run func(path string, name string, opts iterm.WindowOptions)) *MockClient_PreviewCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(iterm.WindowOptions))
+	})
+	return _c
+}
+
+func (_c *MockClient_PreviewCommand_Call) Return(_a0 string) *MockClient_PreviewCommand_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_PreviewCommand_Call) RunAndReturn(run func(string, string, iterm.WindowOptions) string) *MockClient_PreviewCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SessionBusy provides a mock function with given fields: sessionID
+func (_m *MockClient) SessionBusy(sessionID string) bool {
+	ret := _m.Called(sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionBusy")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(sessionID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockClient_SessionBusy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SessionBusy'
+type MockClient_SessionBusy_Call struct {
+	*mock.Call
+}
+
+// SessionBusy is a helper method to define mock.On call
+//   - sessionID string
+func (_e *MockClient_Expecter) SessionBusy(sessionID interface{}) *MockClient_SessionBusy_Call {
+	return &MockClient_SessionBusy_Call{Call: _e.mock.On("SessionBusy", sessionID)}
+}
+
+func (_c *MockClient_SessionBusy_Call) Run(run func(sessionID string)) *MockClient_SessionBusy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_SessionBusy_Call) Return(_a0 bool) *MockClient_SessionBusy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_SessionBusy_Call) RunAndReturn(run func(string) bool) *MockClient_SessionBusy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SessionExists provides a mock function with given fields: sessionID
 func (_m *MockClient) SessionExists(sessionID string) bool {
 	ret := _m.Called(sessionID)
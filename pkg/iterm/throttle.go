@@ -0,0 +1,58 @@
+package iterm
+
+import (
+	"sync"
+	"time"
+)
+
+// ThrottledClient wraps a Client so window creation doesn't overwhelm
+// osascript: at most maxConcurrent windows are created at once, and each
+// spawn starts at least delay after the previous one. Other calls pass through.
+type ThrottledClient struct {
+	Client
+	sem   chan struct{}
+	delay time.Duration
+
+	mu        sync.Mutex
+	lastSpawn time.Time
+	sleep     func(time.Duration) // replaceable in tests
+}
+
+// NewThrottledClient returns c limited to maxConcurrent concurrent window
+// creations (at least 1) spaced at least delay apart.
+func NewThrottledClient(c Client, maxConcurrent int, delay time.Duration) *ThrottledClient {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return &ThrottledClient{
+		Client: c,
+		sem:    make(chan struct{}, maxConcurrent),
+		delay:  delay,
+		sleep:  time.Sleep,
+	}
+}
+
+func (t *ThrottledClient) CreateWorktreeWindow(path, name string, opts WindowOptions) (*SessionIDs, error) {
+	t.sem <- struct{}{}
+	defer func() { <-t.sem }()
+
+	t.waitToSpawn()
+	return t.Client.CreateWorktreeWindow(path, name, opts)
+}
+
+// waitToSpawn blocks until delay has passed since the previous spawn started.
+// Holding mu while sleeping queues concurrent spawns one delay apart.
+func (t *ThrottledClient) waitToSpawn() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.lastSpawn.IsZero() {
+		if wait := t.delay - time.Since(t.lastSpawn); wait > 0 {
+			t.sleep(wait)
+		}
+	}
+	t.lastSpawn = time.Now()
+}
@@ -0,0 +1,81 @@
+package iterm
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spawnCountingClient records the most window creations in flight at once.
+type spawnCountingClient struct {
+	Client
+	active atomic.Int32
+	peak   atomic.Int32
+	calls  atomic.Int32
+}
+
+func (c *spawnCountingClient) CreateWorktreeWindow(path, name string, opts WindowOptions) (*SessionIDs, error) {
+	n := c.active.Add(1)
+	defer c.active.Add(-1)
+	for {
+		p := c.peak.Load()
+		if n <= p || c.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	c.calls.Add(1)
+	time.Sleep(5 * time.Millisecond)
+	return &SessionIDs{ClaudeSessionID: "c-" + name, ShellSessionID: "s-" + name}, nil
+}
+
+func spawnAll(t *testing.T, c Client, n int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.CreateWorktreeWindow("/wt", "w", WindowOptions{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestThrottledClient_LimitsConcurrency(t *testing.T) {
+	inner := &spawnCountingClient{}
+	spawnAll(t, NewThrottledClient(inner, 2, 0), 10)
+
+	assert.Equal(t, int32(10), inner.calls.Load())
+	assert.LessOrEqual(t, inner.peak.Load(), int32(2))
+}
+
+func TestThrottledClient_SerializesByDefault(t *testing.T) {
+	inner := &spawnCountingClient{}
+	spawnAll(t, NewThrottledClient(inner, 0, 0), 5) // below 1 means 1
+
+	assert.Equal(t, int32(1), inner.peak.Load())
+}
+
+func TestThrottledClient_SpacesSpawns(t *testing.T) {
+	inner := &spawnCountingClient{}
+	tc := NewThrottledClient(inner, 1, 50*time.Millisecond)
+	var slept []time.Duration
+	tc.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	for i := 0; i < 3; i++ {
+		_, err := tc.CreateWorktreeWindow("/wt", "w", WindowOptions{})
+		require.NoError(t, err)
+	}
+
+	// The first spawn doesn't wait; later ones wait out the rest of the delay
+	require.Len(t, slept, 2)
+	for _, d := range slept {
+		assert.Greater(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, 50*time.Millisecond)
+	}
+}
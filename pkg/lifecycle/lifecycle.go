@@ -1,9 +1,15 @@
 package lifecycle
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joescharf/wt/pkg/claude"
@@ -21,6 +27,8 @@ type Manager struct {
 	state *state.Manager
 	trust *claude.TrustManager // nil-safe
 	log   ops.Logger
+
+	focusRetryDelay time.Duration // pause before refocusing a window that didn't come to the front
 }
 
 // NewManager creates a lifecycle Manager with the given dependencies.
@@ -32,17 +40,54 @@ func NewManager(git gitops.Client, it iterm.Client, sm *state.Manager, trust *cl
 		state: sm,
 		trust: trust,
 		log:   log,
+
+		focusRetryDelay: 300 * time.Millisecond,
 	}
 }
 
+// focusRetries is how many more times Focus refocuses a window that didn't
+// come to the front.
+const focusRetries = 1
+
+// Focus brings the window of the worktree at wtPath, holding ids, to the
+// front, checking that it got there and retrying once, and records the
+// access. A window that still isn't in front (e.g. minimized or on another
+// Space) only earns a warning.
+func (m *Manager) Focus(ids iterm.SessionIDs, wtPath string) error {
+	front, err := iterm.FocusVerified(m.iterm, ids, focusRetries, m.focusRetryDelay)
+	if err != nil {
+		return err
+	}
+	if !front {
+		m.log.Warning("The iTerm2 window for '%s' didn't come to the front — it may be minimized or on another Space", filepath.Base(wtPath))
+	}
+	if err := m.state.Touch(wtPath, time.Now()); err != nil {
+		m.log.Verbose("Could not record last access: %v", err)
+	}
+	return nil
+}
+
 // CreateOptions configures a worktree create operation.
 type CreateOptions struct {
-	RepoPath   string // root of the main repository
-	Branch     string // branch name to create
-	BaseBranch string // base branch (e.g., "main")
-	NoClaude   bool   // don't auto-launch claude in top pane
-	Existing   bool   // use existing branch instead of creating new
-	DryRun     bool
+	RepoPath        string            // root of the main repository
+	Branch          string            // branch name to create
+	BaseBranch      string            // base branch (e.g., "main")
+	Template        string            // template branch to create the new branch from instead of BaseBranch
+	StartPoint      string            // commit to create the new branch from instead of BaseBranch (e.g. another worktree's HEAD); not recorded
+	PinBase         bool              // record BaseBranch in state so sync and merge default to it
+	GitConfig       map[string]string // git config set in the new worktree only (e.g. user.email)
+	NoClaude        bool              // don't auto-launch claude in top pane; recorded for later opens
+	DefaultNoClaude bool              // config default for NoClaude; applies to this window only, not recorded
+	NoWindow        bool              // skip iTerm2 window creation (open later with Open)
+	FetchBase       bool              // fetch and branch from origin/<BaseBranch> when a remote exists
+	Existing        bool              // use existing branch instead of creating new
+	Pattern         *regexp.Regexp    // a newly-created branch's name must match (nil: any name); Force skips the check
+	Ports           state.PortRange   // assign a stable WT_PORT from this range (zero value disables)
+	NoTrust         bool              // don't pre-approve Claude Code trust (leave ~/.claude.json alone)
+	Profile         string            // iTerm2 profile for the window (empty: default profile)
+	WorktreesDir    string            // create the worktree in this directory instead of the repo's worktrees dir
+	Force           bool              // prune stale worktree entries and replace a leftover directory
+	DryRun          bool
 }
 
 // CreateResult describes the outcome of a create operation.
@@ -52,19 +97,27 @@ type CreateResult struct {
 	RepoName  string
 	Created   bool // false if delegated to Open (already existed)
 	SessionID string
+	// AlreadyExisted is set when the worktree was already checked out on
+	// the requested branch, so nothing was created.
+	AlreadyExisted bool
 }
 
 // Create creates a new worktree with an iTerm2 window.
-// If the worktree already exists, it delegates to Open (idempotent).
+// If the worktree already exists on the same branch, it delegates to Open and
+// reports AlreadyExisted, so re-running create is idempotent.
+// With NoWindow set, only the worktree and its state entry are created;
+// a window can be opened later via Open.
 func (m *Manager) Create(opts CreateOptions) (*CreateResult, error) {
 	repoName, err := m.git.RepoName(opts.RepoPath)
 	if err != nil {
 		return nil, err
 	}
 
-	wtDir, err := m.git.WorktreesDir(opts.RepoPath)
-	if err != nil {
-		return nil, err
+	wtDir := opts.WorktreesDir
+	if wtDir == "" {
+		if wtDir, err = m.git.WorktreesDir(opts.RepoPath); err != nil {
+			return nil, err
+		}
 	}
 
 	dirname := gitops.BranchToDirname(opts.Branch)
@@ -75,25 +128,47 @@ func (m *Manager) Create(opts CreateOptions) (*CreateResult, error) {
 	m.log.Verbose("Worktree path: %s", wtPath)
 	m.log.Verbose("Base branch: %s", opts.BaseBranch)
 
+	// Only a leftover at wtPath earns 'git worktree add --force'; a branch
+	// checked out elsewhere must still be refused
+	cleared := false
+	if opts.Force {
+		if cleared, err = m.clearLeftover(opts.RepoPath, wtPath, opts.DryRun); err != nil {
+			return nil, err
+		}
+	}
+
 	// If worktree already exists, delegate to open
-	if isDirectory(wtPath) {
-		m.log.Info("Worktree already exists, opening iTerm2 window")
+	if isDirectory(wtPath) && !cleared {
+		// A different branch can map to the same dirname; don't hand that
+		// worktree back as if it were this one. Detached HEADs pass.
+		if b, err := m.git.CurrentBranch(wtPath); err == nil && b != "HEAD" && b != opts.Branch {
+			return nil, fmt.Errorf("worktree %s already exists with branch '%s' checked out, not '%s'", wtPath, b, opts.Branch)
+		}
+		m.log.Info("Worktree already exists: %s", wtPath)
+		if opts.NoWindow {
+			return &CreateResult{WtPath: wtPath, Branch: opts.Branch, RepoName: repoName, AlreadyExisted: true}, nil
+		}
 		openResult, err := m.Open(OpenOptions{
-			RepoPath: opts.RepoPath,
-			WtPath:   wtPath,
-			Branch:   opts.Branch,
-			NoClaude: opts.NoClaude,
-			DryRun:   opts.DryRun,
+			RepoPath:        opts.RepoPath,
+			WtPath:          wtPath,
+			Branch:          opts.Branch,
+			NoClaude:        opts.NoClaude,
+			DefaultNoClaude: opts.DefaultNoClaude,
+			Ports:           opts.Ports,
+			NoTrust:         opts.NoTrust,
+			Profile:         opts.Profile,
+			DryRun:          opts.DryRun,
 		})
 		if err != nil {
 			return nil, err
 		}
 		return &CreateResult{
-			WtPath:    openResult.WtPath,
-			Branch:    openResult.Branch,
-			RepoName:  repoName,
-			Created:   false,
-			SessionID: openResult.SessionID,
+			WtPath:         openResult.WtPath,
+			Branch:         openResult.Branch,
+			RepoName:       repoName,
+			Created:        false,
+			SessionID:      openResult.SessionID,
+			AlreadyExisted: true,
 		}, nil
 	}
 
@@ -119,14 +194,54 @@ func (m *Manager) Create(opts CreateOptions) (*CreateResult, error) {
 	if branchExists && !opts.Existing {
 		m.log.Info("Branch '%s' already exists, using it", opts.Branch)
 	}
+	if !useExisting && opts.Pattern != nil && !opts.Pattern.MatchString(opts.Branch) {
+		if !opts.Force {
+			return nil, fmt.Errorf("branch name '%s' doesn't match branch_pattern %s (use --force to create it anyway)", opts.Branch, opts.Pattern)
+		}
+		m.log.Warning("Branch name '%s' doesn't match branch_pattern %s (--force)", opts.Branch, opts.Pattern)
+	}
+
+	baseRef := opts.BaseBranch
+	if opts.FetchBase && !useExisting {
+		baseRef = m.latestBase(opts)
+	}
+
+	// The template only matters when a new branch is made from it
+	fromTemplate := ""
+	if opts.Template != "" {
+		if useExisting {
+			m.log.Warning("Branch '%s' already exists; ignoring template '%s'", opts.Branch, opts.Template)
+		} else {
+			fromTemplate = opts.Template
+			baseRef = opts.Template
+		}
+	}
+	if opts.StartPoint != "" {
+		if useExisting {
+			m.log.Warning("Branch '%s' already exists; ignoring start point '%s'", opts.Branch, opts.StartPoint)
+		} else {
+			baseRef = opts.StartPoint
+		}
+	}
+
+	// An explicit base is recorded so later syncs and merges default to it
+	pinnedBase := ""
+	if opts.PinBase {
+		pinnedBase = opts.BaseBranch
+	}
 
 	if opts.DryRun {
 		if useExisting {
 			m.log.Info("Would create worktree from existing branch '%s'", opts.Branch)
 		} else {
-			m.log.Info("Would create worktree with new branch '%s' from '%s'", opts.Branch, opts.BaseBranch)
+			m.log.Info("Would create worktree with new branch '%s' from '%s'", opts.Branch, baseRef)
+		}
+		for _, key := range slices.Sorted(maps.Keys(opts.GitConfig)) {
+			m.log.Info("Would set git config %s=%s in the worktree", key, opts.GitConfig[key])
+		}
+		if !opts.NoWindow {
+			m.previewWindow(wtPath, fmt.Sprintf("wt:%s:%s", repoName, dirname), iterm.WindowOptions{NoClaude: opts.NoClaude || opts.DefaultNoClaude, Profile: opts.Profile}, opts.Ports)
 		}
-		m.log.Info("Would create iTerm2 window for %s", wtPath)
 		m.log.Info("Would save state")
 		return &CreateResult{WtPath: wtPath, Branch: opts.Branch, RepoName: repoName}, nil
 	}
@@ -134,46 +249,91 @@ func (m *Manager) Create(opts CreateOptions) (*CreateResult, error) {
 	// Create worktree
 	if useExisting {
 		m.log.Info("Creating worktree from existing branch '%s'", opts.Branch)
-		err = m.git.WorktreeAdd(opts.RepoPath, wtPath, opts.Branch, "", false)
+		err = m.git.WorktreeAdd(opts.RepoPath, wtPath, opts.Branch, "", false, cleared)
 	} else {
-		m.log.Info("Creating worktree with new branch '%s' from '%s'", opts.Branch, opts.BaseBranch)
-		err = m.git.WorktreeAdd(opts.RepoPath, wtPath, opts.Branch, opts.BaseBranch, true)
+		m.log.Info("Creating worktree with new branch '%s' from '%s'", opts.Branch, baseRef)
+		err = m.git.WorktreeAdd(opts.RepoPath, wtPath, opts.Branch, baseRef, true, cleared)
 	}
 	if err != nil {
 		return nil, err
 	}
 	m.log.Success("Git worktree created")
 
+	gitConfig := m.applyGitConfig(wtPath, opts.GitConfig)
+
 	// Pre-approve Claude Code trust
-	m.trustProject(wtPath)
+	if !opts.NoTrust {
+		m.trustProject(wtPath)
+	}
+
+	if opts.NoWindow {
+		if err := m.state.SetWorktree(wtPath, &state.WorktreeState{
+			Repo:         repoName,
+			RepoPath:     opts.RepoPath,
+			Branch:       opts.Branch,
+			CreatedAt:    state.FlexTime{Time: time.Now().UTC()},
+			Port:         m.assignPort(wtPath, opts.Ports),
+			FromTemplate: fromTemplate,
+			BaseBranch:   pinnedBase,
+			GitConfig:    gitConfig,
+			NoClaude:     opts.NoClaude,
+		}); err != nil {
+			m.log.Warning("Failed to save state: %v", err)
+		}
+		m.log.Success("Worktree ready: %s", wtPath)
+		m.log.Info("Skipped iTerm2 window — use 'wt open %s' to open one", opts.Branch)
+		return &CreateResult{WtPath: wtPath, Branch: opts.Branch, RepoName: repoName, Created: true}, nil
+	}
 
 	// Create iTerm2 window
 	sessionName := fmt.Sprintf("wt:%s:%s", repoName, dirname)
 	m.log.Info("Creating iTerm2 window (session: %s)", sessionName)
 
-	sessions, err := m.iterm.CreateWorktreeWindow(wtPath, sessionName, opts.NoClaude)
-	if err != nil {
+	winOpts, port := m.windowOptions(wtPath, opts.NoClaude || opts.DefaultNoClaude, opts.Ports)
+	winOpts.Profile = opts.Profile
+	sessions, err := m.iterm.CreateWorktreeWindow(wtPath, sessionName, winOpts)
+	headless := errors.Is(err, iterm.ErrNoTerminal)
+	if err != nil && !headless {
 		m.log.Warning("Worktree created but failed to open iTerm2 window: %v", err)
 		m.log.Info("Use 'wt open %s' to try again", opts.Branch)
 		return &CreateResult{WtPath: wtPath, Branch: opts.Branch, RepoName: repoName, Created: true}, nil
 	}
-
-	m.log.Verbose("Claude session: %s", sessions.ClaudeSessionID)
-	m.log.Verbose("Shell session:  %s", sessions.ShellSessionID)
+	if headless {
+		// Still record the worktree, as with NoWindow, so a later open finds it
+		sessions = &iterm.SessionIDs{}
+	} else {
+		m.log.Verbose("Claude session: %s", sessions.ClaudeSessionID)
+		m.log.Verbose("Shell session:  %s", sessions.ShellSessionID)
+	}
 
 	// Save state
-	if err := m.state.SetWorktree(wtPath, &state.WorktreeState{
+	entry := &state.WorktreeState{
 		Repo:            repoName,
+		RepoPath:        opts.RepoPath,
 		Branch:          opts.Branch,
 		ClaudeSessionID: sessions.ClaudeSessionID,
 		ShellSessionID:  sessions.ShellSessionID,
 		CreatedAt:       state.FlexTime{Time: time.Now().UTC()},
-	}); err != nil {
+		Port:            port,
+		FromTemplate:    fromTemplate,
+		BaseBranch:      pinnedBase,
+		GitConfig:       gitConfig,
+		NoClaude:        opts.NoClaude,
+	}
+	if !headless {
+		entry.LastAccessedAt = entry.CreatedAt
+	}
+	if err := m.state.SetWorktree(wtPath, entry); err != nil {
 		m.log.Warning("Failed to save state: %v", err)
 	}
 
 	m.log.Success("Worktree ready: %s", wtPath)
-	m.log.Success("iTerm2 window opened with Claude + shell panes")
+	if headless {
+		m.log.Warning("No terminal available, worktree created without window")
+		m.log.Info("Use 'wt open %s' from a desktop session to open one", opts.Branch)
+	} else {
+		m.log.Success("iTerm2 window opened with Claude + shell panes")
+	}
 
 	return &CreateResult{
 		WtPath:    wtPath,
@@ -184,13 +344,92 @@ func (m *Manager) Create(opts CreateOptions) (*CreateResult, error) {
 	}, nil
 }
 
+// latestBase fetches the remote and returns origin/<base> to branch from.
+// Without a remote, or if the fetch fails, it falls back to the local base.
+func (m *Manager) latestBase(opts CreateOptions) string {
+	hasRemote, err := m.git.HasRemote(opts.RepoPath)
+	if err != nil {
+		m.log.Verbose("Could not check for remote: %v", err)
+	}
+	if !hasRemote {
+		m.log.Verbose("No remote — branching from local '%s'", opts.BaseBranch)
+		return opts.BaseBranch
+	}
+
+	if opts.DryRun {
+		m.log.Info("Would fetch latest '%s'", opts.BaseBranch)
+		return "origin/" + opts.BaseBranch
+	}
+
+	m.log.Info("Fetching latest '%s'", opts.BaseBranch)
+	if err := m.git.Fetch(opts.RepoPath); err != nil {
+		m.log.Warning("Fetch failed: %v (branching from local '%s')", err, opts.BaseBranch)
+		return opts.BaseBranch
+	}
+	return "origin/" + opts.BaseBranch
+}
+
+// windowOptions builds the iTerm2 window options for a worktree, exporting
+// WT_PORT when port assignment is enabled. Returns the assigned port (0 if none).
+func (m *Manager) windowOptions(wtPath string, noClaude bool, ports state.PortRange) (iterm.WindowOptions, int) {
+	winOpts := iterm.WindowOptions{NoClaude: noClaude}
+	port := m.assignPort(wtPath, ports)
+	if port != 0 {
+		winOpts.Env = map[string]string{"WT_PORT": strconv.Itoa(port)}
+	}
+	return winOpts, port
+}
+
+// previewWindow logs the iTerm2 window a dry-run create would open and the
+// commands its panes would run. No port is assigned, since that's saved to state.
+func (m *Manager) previewWindow(wtPath, sessionName string, winOpts iterm.WindowOptions, ports state.PortRange) {
+	claude := "on"
+	if winOpts.NoClaude {
+		claude = "off"
+	}
+	m.log.Info("Would create iTerm2 window for %s (claude: %s)", wtPath, claude)
+	for _, line := range strings.Split(m.iterm.PreviewCommand(wtPath, sessionName, winOpts), "\n") {
+		m.log.Info("  %s", line)
+	}
+	if ports.Enabled() {
+		m.log.Info("  WT_PORT would be assigned from %d-%d and exported in both panes", ports.Start, ports.End)
+	}
+}
+
+// assignPort returns the worktree's stable port, or 0 if ports are disabled
+// or none could be assigned.
+func (m *Manager) assignPort(wtPath string, ports state.PortRange) int {
+	if !ports.Enabled() {
+		return 0
+	}
+	port, err := m.state.AssignPort(wtPath, ports)
+	if err != nil {
+		m.log.Warning("Could not assign port: %v", err)
+		return 0
+	}
+	m.log.Verbose("Port: %d", port)
+	return port
+}
+
 // OpenOptions configures a worktree open operation.
 type OpenOptions struct {
-	RepoPath string // for RepoName fallback
-	WtPath   string // resolved worktree filesystem path
-	Branch   string // branch name (for state lookup)
-	NoClaude bool
-	DryRun   bool
+	RepoPath        string          // for RepoName fallback
+	WtPath          string          // resolved worktree filesystem path
+	Branch          string          // branch name (for state lookup)
+	NoClaude        bool            // don't launch claude; remembered for later reopens
+	DefaultNoClaude bool            // config default for NoClaude; applies to this window only, not remembered
+	Claude          bool            // launch claude even if the worktree was recorded as no-claude; clears that
+	Ports           state.PortRange // assign a stable WT_PORT from this range (zero value disables)
+	NoTrust         bool            // don't pre-approve Claude Code trust
+	Title           string          // custom window title, remembered for later reopens
+	Profile         string          // iTerm2 profile for a new window (empty: default profile)
+	// AdoptWindow adopts an open iTerm2 window whose working directory is
+	// inside the worktree (e.g. one opened by hand) instead of creating one.
+	AdoptWindow bool
+	// NoFocus creates (or finds) the window without bringing it to the front,
+	// e.g. when opening several worktrees in a row.
+	NoFocus bool
+	DryRun  bool
 }
 
 // OpenResult describes the outcome of an open operation.
@@ -199,9 +438,12 @@ type OpenResult struct {
 	Branch    string
 	SessionID string
 	Focused   bool // true if an existing window was focused instead of creating new
+	Adopted   bool // true if an untracked window was adopted into state
 }
 
 // Open opens or focuses an iTerm2 window for an existing worktree.
+// It is idempotent: a live window is focused rather than duplicated, and a
+// worktree without a window (e.g. created with NoWindow) gets a new one.
 func (m *Manager) Open(opts OpenOptions) (*OpenResult, error) {
 	repoName, err := m.git.RepoName(opts.RepoPath)
 	if err != nil {
@@ -217,30 +459,79 @@ func (m *Manager) Open(opts OpenOptions) (*OpenResult, error) {
 	}
 	if ws != nil && ws.ClaudeSessionID != "" {
 		if m.iterm.IsRunning() && m.iterm.SessionExists(ws.ClaudeSessionID) {
+			if opts.NoFocus {
+				m.log.Info("iTerm2 window already open")
+				return &OpenResult{WtPath: opts.WtPath, Branch: opts.Branch, SessionID: ws.ClaudeSessionID}, nil
+			}
 			m.log.Info("iTerm2 window already open, focusing it")
-			if err := m.iterm.FocusWindow(ws.ClaudeSessionID); err != nil {
+			if err := m.Focus(iterm.SessionIDs{ClaudeSessionID: ws.ClaudeSessionID, ShellSessionID: ws.ShellSessionID}, opts.WtPath); err != nil {
 				return nil, err
 			}
 			return &OpenResult{WtPath: opts.WtPath, Branch: opts.Branch, SessionID: ws.ClaudeSessionID, Focused: true}, nil
 		}
 	}
 
+	if opts.AdoptWindow {
+		if win := m.findWindow(opts.WtPath); win != nil {
+			return m.adoptWindow(opts, ws, repoName, win)
+		}
+		m.log.Verbose("No open iTerm2 window in '%s' to adopt", opts.WtPath)
+	}
+
 	if opts.DryRun {
 		m.log.Info("Would open iTerm2 window for %s", opts.WtPath)
 		return &OpenResult{WtPath: opts.WtPath, Branch: opts.Branch}, nil
 	}
 
 	// Pre-approve Claude Code trust
-	m.trustProject(opts.WtPath)
+	if !opts.NoTrust {
+		m.trustProject(opts.WtPath)
+	}
 
 	sessionName := fmt.Sprintf("wt:%s:%s", repoName, dirname)
 	m.log.Info("Opening iTerm2 window for '%s'", dirname)
 
-	sessions, err := m.iterm.CreateWorktreeWindow(opts.WtPath, sessionName, opts.NoClaude)
+	// The worktree's recorded no-claude choice holds unless overridden; the
+	// config default only decides this window
+	keepNoClaude := !opts.Claude && (opts.NoClaude || (ws != nil && ws.NoClaude))
+	noClaude := keepNoClaude || (!opts.Claude && opts.DefaultNoClaude)
+	winOpts, port := m.windowOptions(opts.WtPath, noClaude, opts.Ports)
+	winOpts.NoFocus = opts.NoFocus
+	winOpts.Profile = opts.Profile
+	winOpts.Title = opts.Title
+	if winOpts.Title == "" && ws != nil {
+		winOpts.Title = ws.Title
+	}
+	sessions, err := m.iterm.CreateWorktreeWindow(opts.WtPath, sessionName, winOpts)
+	if errors.Is(err, iterm.ErrNoTerminal) {
+		m.log.Warning("No terminal available, skipped opening a window for '%s'", dirname)
+		return &OpenResult{WtPath: opts.WtPath, Branch: opts.Branch}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create iTerm2 window: %w", err)
 	}
 
+	entry := m.openEntry(opts, ws, repoName)
+	entry.ClaudeSessionID = sessions.ClaudeSessionID
+	entry.ShellSessionID = sessions.ShellSessionID
+	entry.Title = winOpts.Title
+	entry.NoClaude = keepNoClaude
+	if port != 0 {
+		entry.Port = port
+	}
+
+	if err := m.state.SetWorktree(opts.WtPath, entry); err != nil {
+		m.log.Warning("Window opened but failed to save state: %v", err)
+	}
+
+	m.log.Success("iTerm2 window opened for '%s'", dirname)
+	return &OpenResult{WtPath: opts.WtPath, Branch: entry.Branch, SessionID: sessions.ClaudeSessionID}, nil
+}
+
+// openEntry returns the state entry Open records for the worktree, before its
+// window sessions are set, marked as accessed now. Re-opening a known worktree
+// keeps its other recorded fields, including the original creation time.
+func (m *Manager) openEntry(opts OpenOptions, ws *state.WorktreeState, repoName string) *state.WorktreeState {
 	// Get branch from state or git
 	branchName := opts.Branch
 	if ws != nil && ws.Branch != "" {
@@ -251,18 +542,73 @@ func (m *Manager) Open(opts OpenOptions) (*OpenResult, error) {
 		}
 	}
 
-	if err := m.state.SetWorktree(opts.WtPath, &state.WorktreeState{
-		Repo:            repoName,
-		Branch:          branchName,
-		ClaudeSessionID: sessions.ClaudeSessionID,
-		ShellSessionID:  sessions.ShellSessionID,
-		CreatedAt:       state.FlexTime{Time: time.Now().UTC()},
-	}); err != nil {
-		m.log.Warning("Window opened but failed to save state: %v", err)
+	entry := &state.WorktreeState{CreatedAt: state.FlexTime{Time: time.Now().UTC()}}
+	if ws != nil {
+		*entry = *ws
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = state.FlexTime{Time: time.Now().UTC()}
+		}
 	}
+	entry.Repo = repoName
+	entry.RepoPath = opts.RepoPath
+	entry.Branch = branchName
+	entry.LastAccessedAt = state.FlexTime{Time: time.Now().UTC()}
+	return entry
+}
 
-	m.log.Success("iTerm2 window opened for '%s'", dirname)
-	return &OpenResult{WtPath: opts.WtPath, Branch: branchName, SessionID: sessions.ClaudeSessionID}, nil
+// findWindow returns the open iTerm2 window whose working directory is the
+// worktree or inside it, or nil if there is none.
+func (m *Manager) findWindow(wtPath string) *iterm.WindowInfo {
+	if !m.iterm.IsRunning() {
+		return nil
+	}
+	windows, err := m.iterm.ListWindows()
+	if err != nil {
+		m.log.Warning("Could not list iTerm2 windows: %v", err)
+		return nil
+	}
+	for i, w := range windows {
+		rel, err := filepath.Rel(wtPath, w.Path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return &windows[i]
+		}
+	}
+	return nil
+}
+
+// adoptWindow records win's sessions as the worktree's window and focuses it.
+// The first session becomes the Claude session and the second, if any, the
+// shell session.
+func (m *Manager) adoptWindow(opts OpenOptions, ws *state.WorktreeState, repoName string, win *iterm.WindowInfo) (*OpenResult, error) {
+	dirname := filepath.Base(opts.WtPath)
+	claudeID := win.SessionIDs[0]
+
+	if opts.DryRun {
+		m.log.Info("Would adopt the open iTerm2 window in '%s' (session %s)", win.Path, claudeID)
+		return &OpenResult{WtPath: opts.WtPath, Branch: opts.Branch, SessionID: claudeID, Adopted: true}, nil
+	}
+
+	entry := m.openEntry(opts, ws, repoName)
+	entry.ClaudeSessionID = claudeID
+	entry.ShellSessionID = ""
+	if len(win.SessionIDs) > 1 {
+		entry.ShellSessionID = win.SessionIDs[1]
+	}
+	if opts.Title != "" {
+		entry.Title = opts.Title
+	}
+	if err := m.state.SetWorktree(opts.WtPath, entry); err != nil {
+		return nil, fmt.Errorf("failed to save adopted window: %w", err)
+	}
+
+	m.log.Success("Adopted the open iTerm2 window for '%s'", dirname)
+	if opts.NoFocus {
+		return &OpenResult{WtPath: opts.WtPath, Branch: entry.Branch, SessionID: claudeID, Adopted: true}, nil
+	}
+	if err := m.Focus(iterm.SessionIDs{ClaudeSessionID: claudeID, ShellSessionID: entry.ShellSessionID}, opts.WtPath); err != nil {
+		m.log.Warning("Adopted window but could not focus it: %v", err)
+	}
+	return &OpenResult{WtPath: opts.WtPath, Branch: entry.Branch, SessionID: claudeID, Focused: true, Adopted: true}, nil
 }
 
 // DeleteOptions configures a worktree delete operation.
@@ -271,8 +617,15 @@ type DeleteOptions struct {
 	WtPath       string // resolved worktree filesystem path
 	Branch       string // resolved branch name
 	Force        bool   // force removal
-	DeleteBranch bool   // also delete the git branch
+	DeleteBranch bool   // also delete the git branch; false keeps it
+	NoTrust      bool   // don't remove the Claude Code trust entry
+	KeepWindow   bool   // leave the iTerm2 window open; its sessions are only dropped from state
 	DryRun       bool
+
+	// ConfirmForceBranch, when set, decides whether to fall back to
+	// 'git branch -D' for a branch git doesn't consider merged (e.g. after a
+	// squash merge) instead of Force.
+	ConfirmForceBranch func(branch string) bool
 }
 
 // Delete performs full worktree cleanup: close iTerm window, remove worktree,
@@ -283,7 +636,9 @@ func (m *Manager) Delete(opts DeleteOptions) error {
 	// Close iTerm2 window if it exists
 	ws, _ := m.state.GetWorktree(opts.WtPath)
 	if ws != nil && ws.ClaudeSessionID != "" {
-		if opts.DryRun {
+		if opts.KeepWindow {
+			m.log.Info("Leaving iTerm2 window open")
+		} else if opts.DryRun {
 			m.log.Info("Would close iTerm2 window")
 		} else if m.iterm.IsRunning() && m.iterm.SessionExists(ws.ClaudeSessionID) {
 			if err := m.iterm.CloseWindow(ws.ClaudeSessionID); err != nil {
@@ -316,20 +671,38 @@ func (m *Manager) Delete(opts DeleteOptions) error {
 		if opts.DryRun {
 			m.log.Info("Would delete branch '%s'", branchName)
 		} else {
+			// Once the branch is gone its commits are only reachable via the reflog
+			tip, tipErr := m.git.RefSHA(opts.RepoPath, "refs/heads/"+branchName)
+			if tipErr != nil {
+				m.log.Verbose("Could not resolve tip of '%s': %v", branchName, tipErr)
+			}
+
+			deleted := false
 			err := m.git.BranchDelete(opts.RepoPath, branchName, false)
 			if err != nil {
-				if opts.Force {
+				forceBranch := opts.Force
+				if opts.ConfirmForceBranch != nil {
+					forceBranch = opts.ConfirmForceBranch(branchName)
+				}
+				if forceBranch {
 					err = m.git.BranchDelete(opts.RepoPath, branchName, true)
 					if err == nil {
 						m.log.Success("Force-deleted branch '%s'", branchName)
+						deleted = true
 					} else {
 						m.log.Warning("Could not delete branch '%s': %v", branchName, err)
 					}
+				} else if opts.ConfirmForceBranch != nil {
+					m.log.Warning("Kept branch '%s' — git doesn't consider it merged (delete with 'git branch -D %s')", branchName, branchName)
 				} else {
 					m.log.Warning("Could not delete branch '%s' (may not exist or not fully merged)", branchName)
 				}
 			} else {
 				m.log.Success("Deleted branch '%s'", branchName)
+				deleted = true
+			}
+			if deleted && tip != "" {
+				m.log.Info("Branch '%s' was at %s — recover it with 'git branch %s %s'", branchName, tip, branchName, tip)
 			}
 		}
 	}
@@ -338,7 +711,7 @@ func (m *Manager) Delete(opts DeleteOptions) error {
 	if !opts.DryRun {
 		_ = m.state.RemoveWorktree(opts.WtPath)
 
-		if m.trust != nil {
+		if m.trust != nil && !opts.NoTrust {
 			if err := m.trust.UntrustProject(opts.WtPath); err != nil {
 				m.log.Warning("Failed to remove Claude trust: %v", err)
 			}
@@ -362,8 +735,124 @@ func (m *Manager) trustProject(wtPath string) {
 	}
 }
 
+// clearLeftover prepares wtPath for a forced create: it prunes worktree
+// entries whose directories are gone, then removes wtPath if it is a directory
+// git no longer tracks as a worktree. A live worktree is left alone (create
+// opens it as usual), and a leftover with uncommitted or unverifiable content
+// is refused rather than deleted. It reports whether a leftover directory was
+// cleared or a stale registration for wtPath pruned, i.e. whether adding the
+// worktree needs --force.
+func (m *Manager) clearLeftover(repoPath, wtPath string, dryRun bool) (bool, error) {
+	worktrees, err := m.git.WorktreeList(repoPath)
+	if err != nil {
+		return false, err
+	}
+	registered := false
+	for _, wt := range worktrees {
+		if wt.Path == wtPath {
+			registered = true
+		}
+	}
+
+	if dryRun {
+		m.log.Info("Would prune stale worktree entries")
+	} else if err := m.git.WorktreePrune(repoPath); err != nil {
+		m.log.Warning("Failed to prune worktrees: %v", err)
+	}
+
+	if !isDirectory(wtPath) {
+		return registered, nil
+	}
+	if registered {
+		return false, nil
+	}
+
+	// Only an empty directory or one of this repo's own worktrees is removed:
+	// inside another checkout, git would report that checkout's status instead
+	entries, err := os.ReadDir(wtPath)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) > 0 {
+		if !isWorktreeOf(repoPath, wtPath) {
+			return false, fmt.Errorf("leftover directory %s is not a worktree of this repo and is not empty — remove it manually", wtPath)
+		}
+		if dirty, err := m.git.IsWorktreeDirty(wtPath); err == nil && dirty {
+			return false, fmt.Errorf("leftover directory %s has uncommitted changes — move them aside before using --force", wtPath)
+		}
+	}
+
+	if dryRun {
+		m.log.Info("Would remove leftover directory: %s", wtPath)
+		return true, nil
+	}
+	if err := os.RemoveAll(wtPath); err != nil {
+		return false, fmt.Errorf("failed to remove leftover directory: %w", err)
+	}
+	m.log.Success("Removed leftover directory: %s", wtPath)
+	return true, nil
+}
+
+// isWorktreeOf reports whether dir has its own .git file pointing into
+// repoPath's worktree admin directory (.git/worktrees, or worktrees in a bare
+// repo).
+func isWorktreeOf(repoPath, dir string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		return false
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !ok {
+		return false
+	}
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(dir, gitdir)
+	}
+	gitdir = resolvePath(gitdir)
+	for _, admin := range []string{filepath.Join(repoPath, ".git", "worktrees"), filepath.Join(repoPath, "worktrees")} {
+		if strings.HasPrefix(gitdir, resolvePath(admin)+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePath cleans path and resolves symlinks where it can.
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
 // isDirectory checks if a path exists and is a directory.
 func isDirectory(path string) bool {
 	info, err := os.Stat(path)
 	return err == nil && info.IsDir()
 }
+
+// applyGitConfig sets each key in cfg for wtPath only, in key order, and
+// returns the ones that were applied. A failure is reported but doesn't undo
+// the worktree. Enabling extensions.worktreeConfig, which that needs, changes
+// the whole repo's config, so it's announced the first time.
+func (m *Manager) applyGitConfig(wtPath string, cfg map[string]string) map[string]string {
+	if len(cfg) == 0 {
+		return nil
+	}
+	if v, err := m.git.ConfigValue(wtPath, "extensions.worktreeConfig"); err == nil && v != "true" {
+		m.log.Warning("Enabling extensions.worktreeConfig in the repo's git config for worktree-only settings")
+	}
+	applied := make(map[string]string, len(cfg))
+	for _, key := range slices.Sorted(maps.Keys(cfg)) {
+		if err := m.git.SetLocalConfig(wtPath, key, cfg[key]); err != nil {
+			m.log.Warning("Failed to set git config %s: %v", key, err)
+			continue
+		}
+		m.log.Verbose("Set git config %s=%s", key, cfg[key])
+		applied[key] = cfg[key]
+	}
+	if len(applied) > 0 {
+		m.log.Success("Applied %d git config setting(s) to the worktree", len(applied))
+	}
+	return applied
+}
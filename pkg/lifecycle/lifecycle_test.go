@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/joescharf/wt/pkg/claude"
+	"github.com/joescharf/wt/pkg/gitops"
 	gmocks "github.com/joescharf/wt/pkg/gitops/mocks"
 	"github.com/joescharf/wt/pkg/iterm"
 	imocks "github.com/joescharf/wt/pkg/iterm/mocks"
@@ -54,8 +58,8 @@ func TestCreate_NewBranch(t *testing.T) {
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
 	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
-	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true).Return(nil)
-	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", false).
+	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "claude-123", ShellSessionID: "shell-456"}, nil)
 
 	result, err := m.Create(CreateOptions{
@@ -79,6 +83,54 @@ func TestCreate_NewBranch(t *testing.T) {
 	assert.Equal(t, "claude-123", ws.ClaudeSessionID)
 }
 
+func TestCreate_Profile(t *testing.T) {
+	m, mg, mi, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", iterm.WindowOptions{Profile: "Prod"}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "claude-123", ShellSessionID: "shell-456"}, nil)
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		Profile:    "Prod",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestCreate_WorktreesDirOverride(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	otherDir := filepath.Join(dir, "scratch")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(otherDir, "auth"), "feature/auth", "main", true, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(filepath.Join(otherDir, "auth"), "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "claude-123", ShellSessionID: "shell-456"}, nil)
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:     repoPath,
+		Branch:       "feature/auth",
+		BaseBranch:   "main",
+		WorktreesDir: otherDir,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(otherDir, "auth"), result.WtPath)
+	ws, err := sm.GetWorktree(filepath.Join(otherDir, "auth"))
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "feature/auth", ws.Branch)
+}
+
 func TestCreate_ExistingBranch(t *testing.T) {
 	m, mg, mi, _, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
@@ -87,8 +139,8 @@ func TestCreate_ExistingBranch(t *testing.T) {
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
 	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(true, nil)
-	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "", false).Return(nil)
-	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", false).
+	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "", false, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
 
 	result, err := m.Create(CreateOptions{
@@ -101,6 +153,305 @@ func TestCreate_ExistingBranch(t *testing.T) {
 	assert.True(t, result.Created)
 }
 
+func TestCreate_BranchPattern(t *testing.T) {
+	pattern := regexp.MustCompile(`^(feat|fix)/[a-z0-9-]+$`)
+
+	t.Run("conforming name is created", func(t *testing.T) {
+		m, mg, mi, _, dir := setupManager(t)
+		repoPath := filepath.Join(dir, "repo")
+		wtDir := repoPath + ".worktrees"
+
+		mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+		mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+		mg.EXPECT().BranchExists(repoPath, "feat/login-page").Return(false, nil)
+		mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "login-page"), "feat/login-page", "main", true, false).Return(nil)
+		mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "login-page"), "wt:myrepo:login-page", iterm.WindowOptions{}).
+			Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+		result, err := m.Create(CreateOptions{RepoPath: repoPath, Branch: "feat/login-page", BaseBranch: "main", Pattern: pattern})
+
+		require.NoError(t, err)
+		assert.True(t, result.Created)
+	})
+
+	t.Run("non-conforming name is rejected", func(t *testing.T) {
+		m, mg, _, _, dir := setupManager(t)
+		repoPath := filepath.Join(dir, "repo")
+
+		mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+		mg.EXPECT().WorktreesDir(repoPath).Return(repoPath+".worktrees", nil)
+		mg.EXPECT().BranchExists(repoPath, "Feature/Login").Return(false, nil)
+		// No WorktreeAdd
+
+		_, err := m.Create(CreateOptions{RepoPath: repoPath, Branch: "Feature/Login", BaseBranch: "main", Pattern: pattern})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "doesn't match branch_pattern")
+		assert.Contains(t, err.Error(), pattern.String())
+	})
+
+	t.Run("force bypasses the pattern", func(t *testing.T) {
+		m, mg, mi, _, dir := setupManager(t)
+		repoPath := filepath.Join(dir, "repo")
+		wtDir := repoPath + ".worktrees"
+
+		mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+		mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+		mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{{Path: repoPath, Branch: "main"}}, nil)
+		mg.EXPECT().WorktreePrune(repoPath).Return(nil)
+		mg.EXPECT().BranchExists(repoPath, "spike").Return(false, nil)
+		mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "spike"), "spike", "main", true, false).Return(nil)
+		mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "spike"), "wt:myrepo:spike", iterm.WindowOptions{}).
+			Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+		result, err := m.Create(CreateOptions{RepoPath: repoPath, Branch: "spike", BaseBranch: "main", Pattern: pattern, Force: true})
+
+		require.NoError(t, err)
+		assert.True(t, result.Created)
+	})
+
+	t.Run("existing branch is not checked", func(t *testing.T) {
+		m, mg, mi, _, dir := setupManager(t)
+		repoPath := filepath.Join(dir, "repo")
+		wtDir := repoPath + ".worktrees"
+
+		mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+		mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+		mg.EXPECT().BranchExists(repoPath, "legacy").Return(true, nil)
+		mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "legacy"), "legacy", "", false, false).Return(nil)
+		mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "legacy"), "wt:myrepo:legacy", iterm.WindowOptions{}).
+			Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+		result, err := m.Create(CreateOptions{RepoPath: repoPath, Branch: "legacy", BaseBranch: "main", Pattern: pattern})
+
+		require.NoError(t, err)
+		assert.True(t, result.Created)
+	})
+}
+
+func TestCreate_Force_RemovesLeftoverDir(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().WorktreePrune(repoPath).Return(nil)
+	mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{{Path: repoPath, Branch: "main"}}, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(true, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "", false, true).Return(nil)
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+		Force:      true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+	assert.NoDirExists(t, wtPath)
+}
+
+func TestCreate_Force_PrunesMissingDir(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{
+		{Path: repoPath, Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth", Prunable: true},
+	}, nil)
+	mg.EXPECT().WorktreePrune(repoPath).Return(nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "main", true, true).Return(nil)
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+		Force:      true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+}
+
+func TestCreate_Force_BranchCheckedOutElsewhereStillFails(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{
+		{Path: repoPath, Branch: "main"},
+		{Path: filepath.Join(dir, "elsewhere"), Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().WorktreePrune(repoPath).Return(nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(true, nil)
+	// No --force: git refuses a branch that is already checked out
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "", false, false).
+		Return(fmt.Errorf("git worktree add failed: fatal: 'feature/auth' is already checked out"))
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+		Force:      true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already checked out")
+}
+
+func TestCreate_Force_RefusesDirtyLeftover(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	writeGitdirFile(t, wtPath, filepath.Join(repoPath, ".git", "worktrees", "auth"))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().WorktreePrune(repoPath).Return(nil)
+	mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{{Path: repoPath, Branch: "main"}}, nil)
+	mg.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		Force:      true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "uncommitted changes")
+	assert.DirExists(t, wtPath)
+}
+
+func TestCreate_Force_RefusesNonEmptyUnknownDir(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "notes.txt"), []byte("wip"), 0644))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().WorktreePrune(repoPath).Return(nil)
+	mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{{Path: repoPath, Branch: "main"}}, nil)
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		Force:      true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remove it manually")
+	assert.FileExists(t, filepath.Join(wtPath, "notes.txt"))
+}
+func TestCreate_Force_RemovesOwnStaleWorktree(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "main.go"), []byte("package main"), 0644))
+	writeGitdirFile(t, wtPath, filepath.Join(repoPath, ".git", "worktrees", "auth"))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().WorktreePrune(repoPath).Return(nil)
+	mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{{Path: repoPath, Branch: "main"}}, nil)
+	mg.EXPECT().IsWorktreeDirty(wtPath).Return(false, fmt.Errorf("not a git repository"))
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(true, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "", false, true).Return(nil)
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+		Force:      true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+	assert.NoDirExists(t, wtPath)
+}
+
+func TestCreate_Force_RefusesOtherRepoWorktree(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	writeGitdirFile(t, wtPath, filepath.Join(dir, "other", ".git", "worktrees", "auth"))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().WorktreePrune(repoPath).Return(nil)
+	mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{{Path: repoPath, Branch: "main"}}, nil)
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		Force:      true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a worktree of this repo")
+	assert.FileExists(t, filepath.Join(wtPath, ".git"))
+}
+
+// writeGitdirFile gives dir the .git file of a linked worktree whose admin
+// directory is gitdir.
+func writeGitdirFile(t *testing.T, dir, gitdir string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: "+gitdir+"\n"), 0644))
+}
+
+func TestCreate_Force_DryRunLeavesLeftover(t *testing.T) {
+	m, mg, mi, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().WorktreeList(repoPath).Return([]gitops.WorktreeInfo{{Path: repoPath, Branch: "main"}}, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(true, nil)
+	mi.EXPECT().PreviewCommand(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).Return("")
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		Force:      true,
+		DryRun:     true,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.Created)
+	assert.DirExists(t, wtPath)
+}
+
 func TestCreate_AlreadyExists_DelegatesToOpen(t *testing.T) {
 	m, mg, mi, _, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
@@ -113,7 +464,7 @@ func TestCreate_AlreadyExists_DelegatesToOpen(t *testing.T) {
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil).Times(2)
 	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
 	// Open path — no existing session
-	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
 	mg.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
 
@@ -128,19 +479,23 @@ func TestCreate_AlreadyExists_DelegatesToOpen(t *testing.T) {
 }
 
 func TestCreate_DryRun(t *testing.T) {
-	m, mg, _, _, dir := setupManager(t)
+	m, mg, mi, _, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
 	wtDir := repoPath + ".worktrees"
 
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
 	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mi.EXPECT().PreviewCommand(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", iterm.WindowOptions{NoClaude: true}).
+		Return("window: wt:myrepo:auth\ntop pane (wt:myrepo:auth:claude): cd '/x'")
 	// Should NOT call WorktreeAdd or CreateWorktreeWindow
 
 	result, err := m.Create(CreateOptions{
 		RepoPath:   repoPath,
 		Branch:     "feature/auth",
 		BaseBranch: "main",
+		NoClaude:   true,
+		Ports:      state.PortRange{Start: 4000, End: 4099},
 		DryRun:     true,
 	})
 
@@ -157,8 +512,8 @@ func TestCreate_NoClaude(t *testing.T) {
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
 	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
-	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true).Return(nil)
-	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", true). // noClaude=true
+	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", iterm.WindowOptions{NoClaude: true}). // noClaude=true
 		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
 
 	result, err := m.Create(CreateOptions{
@@ -172,6 +527,65 @@ func TestCreate_NoClaude(t *testing.T) {
 	assert.True(t, result.Created)
 }
 
+func TestCreate_DefaultNoClaudeNotRecorded(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "main", true, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{NoClaude: true}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:        repoPath,
+		Branch:          "feature/auth",
+		BaseBranch:      "main",
+		DefaultNoClaude: true,
+	})
+	require.NoError(t, err)
+
+	// A config default isn't a per-worktree choice; changing the config later applies
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.False(t, ws.NoClaude)
+}
+
+func TestCreate_NoTerminal(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "main", true, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).Return(nil, iterm.ErrNoTerminal)
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+	assert.Empty(t, result.SessionID)
+	log := m.log.(*testLogger)
+	assert.Contains(t, log.warnings, "No terminal available, worktree created without window")
+
+	// Recorded like a NoWindow create, so 'wt open' finds it later
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "feature/auth", ws.Branch)
+	assert.Empty(t, ws.ClaudeSessionID)
+}
+
 func TestCreate_ITermFails_WorktreeStillCreated(t *testing.T) {
 	m, mg, mi, _, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
@@ -180,8 +594,8 @@ func TestCreate_ITermFails_WorktreeStillCreated(t *testing.T) {
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
 	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
-	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true).Return(nil)
-	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", false).
+	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(nil, fmt.Errorf("osascript failed"))
 
 	result, err := m.Create(CreateOptions{
@@ -213,8 +627,8 @@ func TestCreate_WithTrust(t *testing.T) {
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
 	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
-	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true).Return(nil)
-	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", false).
+	mg.EXPECT().WorktreeAdd(repoPath, filepath.Join(wtDir, "auth"), "feature/auth", "main", true, false).Return(nil)
+	mi.EXPECT().CreateWorktreeWindow(filepath.Join(wtDir, "auth"), "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
 
 	_, err := m.Create(CreateOptions{
@@ -224,41 +638,425 @@ func TestCreate_WithTrust(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	// Trust file should exist with the worktree path
-	_, statErr := os.Stat(trustPath)
-	assert.NoError(t, statErr)
-}
+	// Trust file should exist with the worktree path
+	_, statErr := os.Stat(trustPath)
+	assert.NoError(t, statErr)
+}
+
+func TestCreate_NoWindow(t *testing.T) {
+	m, mg, _, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "main", true, false).Return(nil)
+	// Should NOT call CreateWorktreeWindow
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+	assert.Empty(t, result.SessionID)
+
+	// State is saved without session IDs
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "feature/auth", ws.Branch)
+	assert.Empty(t, ws.ClaudeSessionID)
+	assert.Empty(t, ws.BaseBranch) // only an explicit base is pinned
+	assert.False(t, ws.CreatedAt.IsZero())
+}
+
+func TestCreate_PinBase(t *testing.T) {
+	m, mg, _, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "develop", true, false).Return(nil)
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "develop",
+		PinBase:    true,
+		NoWindow:   true,
+	})
+	require.NoError(t, err)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "develop", ws.BaseBranch)
+}
+
+func TestCreate_StartPoint(t *testing.T) {
+	m, mg, _, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth-v2")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth-v2").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth-v2", "abc1234", true, false).Return(nil)
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth-v2",
+		BaseBranch: "main",
+		StartPoint: "abc1234",
+		NoWindow:   true,
+	})
+	require.NoError(t, err)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Empty(t, ws.FromTemplate)
+}
+
+func TestCreate_FetchBase(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().HasRemote(repoPath).Return(true, nil)
+	mg.EXPECT().Fetch(repoPath).Return(nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "origin/main", true, false).Return(nil)
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+		FetchBase:  true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+}
+
+func TestCreate_FetchBase_NoRemote(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().HasRemote(repoPath).Return(false, nil)
+	// No Fetch — falls back to the local base
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "main", true, false).Return(nil)
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+		FetchBase:  true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+}
+
+func TestCreate_FetchBase_ExistingBranchSkipsFetch(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(true, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "", false, false).Return(nil)
+
+	_, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+		FetchBase:  true,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestCreate_NoWindow_AlreadyExists(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	// Should NOT delegate to Open
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.Created)
+	assert.True(t, result.AlreadyExisted)
+	assert.Equal(t, wtPath, result.WtPath)
+}
+
+func TestCreate_AlreadyExists_Idempotent(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
+		Repo: "myrepo", Branch: "feature/auth", ClaudeSessionID: "c1",
+	}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().SessionExists("c1").Return(true)
+	mi.EXPECT().FocusWindow("c1").Return(nil)
+	mi.EXPECT().ActiveSession().Return("c1", nil)
+
+	opts := CreateOptions{RepoPath: repoPath, Branch: "feature/auth", BaseBranch: "main"}
+	for range 2 {
+		result, err := m.Create(opts)
+		require.NoError(t, err)
+		assert.True(t, result.AlreadyExisted)
+		assert.False(t, result.Created)
+		assert.Equal(t, wtPath, result.WtPath)
+		assert.Equal(t, "c1", result.SessionID)
+	}
+}
+
+func TestCreate_AlreadyExists_OtherBranch(t *testing.T) {
+	m, mg, _, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+	require.NoError(t, os.MkdirAll(wtPath, 0755))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().CurrentBranch(wtPath).Return("bugfix/auth", nil)
+
+	_, err := m.Create(CreateOptions{RepoPath: repoPath, Branch: "feature/auth", BaseBranch: "main", NoWindow: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "branch 'bugfix/auth' checked out, not 'feature/auth'")
+}
+
+// --- Open Tests ---
+
+func TestOpen_NewWindow(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+	result, err := m.Open(OpenOptions{
+		RepoPath: repoPath,
+		WtPath:   wtPath,
+		Branch:   "auth",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.Focused)
+	assert.Equal(t, "c1", result.SessionID)
+	assert.Equal(t, "feature/auth", result.Branch) // resolved from git
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.WithinDuration(t, time.Now(), ws.LastAccessedAt.Time, time.Minute)
+}
+
+func TestOpen_Profile(t *testing.T) {
+	m, mg, mi, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{Profile: "Prod"}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+	_, err := m.Open(OpenOptions{
+		RepoPath: repoPath,
+		WtPath:   wtPath,
+		Branch:   "auth",
+		Profile:  "Prod",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestOpen_FocusExistingWindow(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	// Pre-populate state with existing session
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "existing-session",
+	}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().SessionExists("existing-session").Return(true)
+	mi.EXPECT().FocusWindow("existing-session").Return(nil)
+	mi.EXPECT().ActiveSession().Return("existing-session", nil)
+
+	result, err := m.Open(OpenOptions{
+		RepoPath: repoPath,
+		WtPath:   wtPath,
+		Branch:   "feature/auth",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Focused)
+	assert.Equal(t, "existing-session", result.SessionID)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), ws.LastAccessedAt.Time, time.Minute, "focusing counts as an access")
+}
+
+func TestOpen_FocusRetriesWhenWindowStaysBehind(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	m.focusRetryDelay = 0
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "existing-session",
+		ShellSessionID:  "existing-shell",
+	}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().SessionExists("existing-session").Return(true)
+	mi.EXPECT().FocusWindow("existing-session").Return(nil).Times(2)
+	// The first focus doesn't take (another window stays in front); the retry does
+	mi.EXPECT().ActiveSession().Return("someone-elses-session", nil).Once()
+	mi.EXPECT().ActiveSession().Return("existing-shell", nil).Once()
+
+	result, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "feature/auth"})
+
+	require.NoError(t, err)
+	assert.True(t, result.Focused)
+	assert.Empty(t, m.log.(*testLogger).warnings)
+}
+
+func TestOpen_FocusWarnsWhenWindowNeverComesToFront(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	m.focusRetryDelay = 0
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "existing-session",
+	}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().SessionExists("existing-session").Return(true)
+	mi.EXPECT().FocusWindow("existing-session").Return(nil).Times(2)
+	mi.EXPECT().ActiveSession().Return("", nil).Times(2)
+
+	result, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "feature/auth"})
 
-// --- Open Tests ---
+	require.NoError(t, err)
+	assert.True(t, result.Focused)
+	assert.Contains(t, m.log.(*testLogger).warnings, "The iTerm2 window for 'auth' didn't come to the front — it may be minimized or on another Space")
+}
 
-func TestOpen_NewWindow(t *testing.T) {
-	m, mg, mi, _, dir := setupManager(t)
+func TestOpen_NoFocus(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
 	wtPath := filepath.Join(dir, "wt", "auth")
 
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mg.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
-	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{NoFocus: true}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
 
 	result, err := m.Open(OpenOptions{
 		RepoPath: repoPath,
 		WtPath:   wtPath,
 		Branch:   "auth",
+		NoFocus:  true,
 	})
 
 	require.NoError(t, err)
 	assert.False(t, result.Focused)
-	assert.Equal(t, "c1", result.SessionID)
-	assert.Equal(t, "feature/auth", result.Branch) // resolved from git
+
+	// The session is still recorded
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "c1", ws.ClaudeSessionID)
 }
 
-func TestOpen_FocusExistingWindow(t *testing.T) {
+func TestOpen_NoTerminal(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).Return(nil, iterm.ErrNoTerminal)
+
+	result, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth"})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.SessionID)
+	log := m.log.(*testLogger)
+	assert.Contains(t, log.warnings, "No terminal available, skipped opening a window for 'auth'")
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Nil(t, ws, "nothing recorded without a window")
+}
+
+func TestOpen_NoFocusLeavesExistingWindow(t *testing.T) {
 	m, mg, mi, sm, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
 	wtPath := filepath.Join(dir, "wt", "auth")
 
-	// Pre-populate state with existing session
 	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
 		Repo:            "myrepo",
 		Branch:          "feature/auth",
@@ -268,16 +1066,17 @@ func TestOpen_FocusExistingWindow(t *testing.T) {
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mi.EXPECT().IsRunning().Return(true)
 	mi.EXPECT().SessionExists("existing-session").Return(true)
-	mi.EXPECT().FocusWindow("existing-session").Return(nil)
+	// No FocusWindow expectation — mock fails the test if called
 
 	result, err := m.Open(OpenOptions{
 		RepoPath: repoPath,
 		WtPath:   wtPath,
 		Branch:   "feature/auth",
+		NoFocus:  true,
 	})
 
 	require.NoError(t, err)
-	assert.True(t, result.Focused)
+	assert.False(t, result.Focused)
 	assert.Equal(t, "existing-session", result.SessionID)
 }
 
@@ -296,7 +1095,7 @@ func TestOpen_StaleSession_CreatesNew(t *testing.T) {
 	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
 	mi.EXPECT().IsRunning().Return(true)
 	mi.EXPECT().SessionExists("stale-session").Return(false) // session gone
-	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", false).
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
 		Return(&iterm.SessionIDs{ClaudeSessionID: "new-session", ShellSessionID: "s2"}, nil)
 
 	result, err := m.Open(OpenOptions{
@@ -310,6 +1109,197 @@ func TestOpen_StaleSession_CreatesNew(t *testing.T) {
 	assert.Equal(t, "new-session", result.SessionID)
 }
 
+func TestOpen_CustomTitleReusedOnReopen(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth"}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil).Times(2)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{Title: "Auth bug #123"}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil).Once()
+
+	_, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth", Title: "Auth bug #123"})
+	require.NoError(t, err)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "Auth bug #123", ws.Title)
+
+	// The window was closed outside wt; matching is by session ID, and a
+	// reopen without a title keeps the custom one
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().SessionExists("c1").Return(false)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{Title: "Auth bug #123"}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c2", ShellSessionID: "s2"}, nil).Once()
+
+	result, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth"})
+	require.NoError(t, err)
+	assert.Equal(t, "c2", result.SessionID)
+}
+
+func TestOpen_StoredNoClaudeReusedOnReopen(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	// As recorded by 'wt create --no-claude'
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth", NoClaude: true}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil).Times(2)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{NoClaude: true}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil).Once()
+
+	_, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth"})
+	require.NoError(t, err)
+
+	// Claude overrides the stored choice and clears it
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().SessionExists("c1").Return(false)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c2", ShellSessionID: "s2"}, nil).Once()
+
+	_, err = m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth", Claude: true})
+	require.NoError(t, err)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.False(t, ws.NoClaude)
+}
+
+func TestOpen_DefaultNoClaudeNotRecorded(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth"}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{NoClaude: true}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+	_, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth", DefaultNoClaude: true})
+	require.NoError(t, err)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.False(t, ws.NoClaude)
+}
+
+func TestOpen_AfterWindowlessCreate(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+	created := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+
+	// State as left behind by Create with NoWindow
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:      "myrepo",
+		Branch:    "feature/auth",
+		CreatedAt: state.FlexTime{Time: created},
+	}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+	result, err := m.Open(OpenOptions{
+		RepoPath: repoPath,
+		WtPath:   wtPath,
+		Branch:   "auth",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.Focused)
+	assert.Equal(t, "c1", result.SessionID)
+	assert.Equal(t, "feature/auth", result.Branch) // from state
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "c1", ws.ClaudeSessionID)
+	assert.True(t, created.Equal(ws.CreatedAt.Time), "original creation time should be preserved")
+}
+
+func TestOpen_AssignsStablePort(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+	ports := state.PortRange{Start: 4000, End: 4999}
+	want := state.PortFor(wtPath, ports, nil)
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil).Times(2)
+	mg.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{
+		Env: map[string]string{"WT_PORT": strconv.Itoa(want)},
+	}).Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil).Times(2)
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().SessionExists("c1").Return(false) // window was closed
+
+	opts := OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth", Ports: ports}
+	_, err := m.Open(opts)
+	require.NoError(t, err)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, want, ws.Port)
+
+	// Re-opening exports the same port
+	_, err = m.Open(opts)
+	require.NoError(t, err)
+}
+
+func TestOpen_PortsDisabledKeepsRecordedPort(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:   "myrepo",
+		Branch: "feature/auth",
+		Port:   4321,
+	}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+	_, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth"})
+	require.NoError(t, err)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, 4321, ws.Port)
+}
+
+func TestOpen_DoubleOpenIsIdempotent(t *testing.T) {
+	m, mg, mi, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil).Times(2)
+	mg.EXPECT().CurrentBranch(wtPath).Return("feature/auth", nil)
+	// Only one window is ever created
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil).Once()
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().SessionExists("c1").Return(true)
+	mi.EXPECT().FocusWindow("c1").Return(nil)
+	mi.EXPECT().ActiveSession().Return("c1", nil)
+
+	opts := OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth"}
+
+	first, err := m.Open(opts)
+	require.NoError(t, err)
+	assert.False(t, first.Focused)
+
+	second, err := m.Open(opts)
+	require.NoError(t, err)
+	assert.True(t, second.Focused)
+	assert.Equal(t, "c1", second.SessionID)
+}
+
 func TestOpen_DryRun(t *testing.T) {
 	m, mg, _, _, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
@@ -329,6 +1319,54 @@ func TestOpen_DryRun(t *testing.T) {
 	assert.Empty(t, result.SessionID)
 }
 
+func TestOpen_AdoptWindowByCwd(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+	created := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{Branch: "feature/auth", CreatedAt: state.FlexTime{Time: created}}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().ListWindows().Return([]iterm.WindowInfo{
+		{Path: filepath.Join(dir, "wt", "authz"), SessionIDs: []string{"x1"}}, // shares a prefix only
+		{Path: filepath.Join(wtPath, "src"), SessionIDs: []string{"c1", "s1"}},
+	}, nil)
+	mi.EXPECT().FocusWindow("c1").Return(nil)
+	mi.EXPECT().ActiveSession().Return("c1", nil)
+	// Should NOT call CreateWorktreeWindow
+
+	result, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth", AdoptWindow: true})
+	require.NoError(t, err)
+	assert.True(t, result.Adopted)
+	assert.Equal(t, "c1", result.SessionID)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "c1", ws.ClaudeSessionID)
+	assert.Equal(t, "s1", ws.ShellSessionID)
+	assert.Equal(t, "feature/auth", ws.Branch)
+	assert.True(t, created.Equal(ws.CreatedAt.Time))
+}
+
+func TestOpen_AdoptWindowNoMatchCreatesWindow(t *testing.T) {
+	m, mg, mi, _, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().CurrentBranch(wtPath).Return("auth", nil)
+	mi.EXPECT().IsRunning().Return(true)
+	mi.EXPECT().ListWindows().Return([]iterm.WindowInfo{{Path: dir, SessionIDs: []string{"x1"}}}, nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:auth", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c2", ShellSessionID: "s2"}, nil)
+
+	result, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "auth", AdoptWindow: true})
+	require.NoError(t, err)
+	assert.False(t, result.Adopted)
+	assert.Equal(t, "c2", result.SessionID)
+}
+
 // --- Delete Tests ---
 
 func TestDelete_FullCleanup(t *testing.T) {
@@ -347,6 +1385,7 @@ func TestDelete_FullCleanup(t *testing.T) {
 	mi.EXPECT().SessionExists("claude-123").Return(true)
 	mi.EXPECT().CloseWindow("claude-123").Return(nil)
 	mg.EXPECT().WorktreeRemove(repoPath, wtPath, false).Return(nil)
+	mg.EXPECT().RefSHA(repoPath, "refs/heads/feature/auth").Return("abc1234def5678", nil)
 	mg.EXPECT().BranchDelete(repoPath, "feature/auth", false).Return(nil)
 
 	err := m.Delete(DeleteOptions{
@@ -364,6 +1403,71 @@ func TestDelete_FullCleanup(t *testing.T) {
 	assert.Nil(t, ws)
 }
 
+func TestDelete_BranchRecoveryHint(t *testing.T) {
+	const hint = "Branch 'feature/auth' was at abc1234def5678 — recover it with 'git branch feature/auth abc1234def5678'"
+
+	t.Run("deleted", func(t *testing.T) {
+		m, mg, _, _, dir := setupManager(t)
+		repoPath := filepath.Join(dir, "repo")
+		wtPath := filepath.Join(dir, "wt", "auth")
+
+		mg.EXPECT().WorktreeRemove(repoPath, wtPath, false).Return(nil)
+		mg.EXPECT().RefSHA(repoPath, "refs/heads/feature/auth").Return("abc1234def5678", nil)
+		mg.EXPECT().BranchDelete(repoPath, "feature/auth", false).Return(nil)
+
+		require.NoError(t, m.Delete(DeleteOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "feature/auth", DeleteBranch: true}))
+		assert.Contains(t, m.log.(*testLogger).infos, hint)
+	})
+
+	t.Run("kept", func(t *testing.T) {
+		m, mg, _, _, dir := setupManager(t)
+		repoPath := filepath.Join(dir, "repo")
+		wtPath := filepath.Join(dir, "wt", "auth")
+
+		mg.EXPECT().WorktreeRemove(repoPath, wtPath, false).Return(nil)
+		mg.EXPECT().RefSHA(repoPath, "refs/heads/feature/auth").Return("abc1234def5678", nil)
+		mg.EXPECT().BranchDelete(repoPath, "feature/auth", false).Return(fmt.Errorf("not fully merged"))
+
+		require.NoError(t, m.Delete(DeleteOptions{
+			RepoPath: repoPath, WtPath: wtPath, Branch: "feature/auth", DeleteBranch: true,
+			ConfirmForceBranch: func(string) bool { return false },
+		}))
+		assert.NotContains(t, m.log.(*testLogger).infos, hint)
+	})
+}
+
+func TestDelete_KeepWindow(t *testing.T) {
+	m, mg, _, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "auth")
+
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{
+		Repo:            "myrepo",
+		Branch:          "feature/auth",
+		ClaudeSessionID: "claude-123",
+	}))
+
+	mg.EXPECT().WorktreeRemove(repoPath, wtPath, false).Return(nil)
+	mg.EXPECT().RefSHA(repoPath, "refs/heads/feature/auth").Return("abc1234def5678", nil)
+	mg.EXPECT().BranchDelete(repoPath, "feature/auth", false).Return(nil)
+	// No CloseWindow (mock fails on unexpected calls)
+
+	err := m.Delete(DeleteOptions{
+		RepoPath:     repoPath,
+		WtPath:       wtPath,
+		Branch:       "feature/auth",
+		DeleteBranch: true,
+		KeepWindow:   true,
+	})
+
+	require.NoError(t, err)
+
+	// The sessions go with the state entry
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+}
+
 func TestDelete_NoBranchDelete(t *testing.T) {
 	m, mg, _, sm, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
@@ -392,6 +1496,7 @@ func TestDelete_Force_BranchFallback(t *testing.T) {
 	wtPath := filepath.Join(dir, "wt", "auth")
 
 	mg.EXPECT().WorktreeRemove(repoPath, wtPath, true).Return(nil)
+	mg.EXPECT().RefSHA(repoPath, "refs/heads/feature/auth").Return("abc1234def5678", nil)
 	mg.EXPECT().BranchDelete(repoPath, "feature/auth", false).Return(fmt.Errorf("not fully merged"))
 	mg.EXPECT().BranchDelete(repoPath, "feature/auth", true).Return(nil) // force fallback
 
@@ -406,6 +1511,38 @@ func TestDelete_Force_BranchFallback(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDelete_ConfirmForceBranch(t *testing.T) {
+	for _, accept := range []bool{true, false} {
+		m, mg, _, _, dir := setupManager(t)
+		repoPath := filepath.Join(dir, "repo")
+		wtPath := filepath.Join(dir, "wt", "auth")
+
+		mg.EXPECT().WorktreeRemove(repoPath, wtPath, true).Return(nil)
+		mg.EXPECT().RefSHA(repoPath, "refs/heads/feature/auth").Return("abc1234def5678", nil)
+		mg.EXPECT().BranchDelete(repoPath, "feature/auth", false).Return(fmt.Errorf("not fully merged"))
+		if accept {
+			mg.EXPECT().BranchDelete(repoPath, "feature/auth", true).Return(nil)
+		}
+		// When declined, no force delete happens even though Force removes the worktree
+
+		var asked string
+		err := m.Delete(DeleteOptions{
+			RepoPath:     repoPath,
+			WtPath:       wtPath,
+			Branch:       "feature/auth",
+			Force:        true,
+			DeleteBranch: true,
+			ConfirmForceBranch: func(branch string) bool {
+				asked = branch
+				return accept
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "feature/auth", asked)
+	}
+}
+
 func TestDelete_BranchFromState(t *testing.T) {
 	m, mg, _, sm, dir := setupManager(t)
 	repoPath := filepath.Join(dir, "repo")
@@ -417,6 +1554,7 @@ func TestDelete_BranchFromState(t *testing.T) {
 	}))
 
 	mg.EXPECT().WorktreeRemove(repoPath, wtPath, false).Return(nil)
+	mg.EXPECT().RefSHA(repoPath, "refs/heads/feature/auth").Return("abc1234def5678", nil)
 	mg.EXPECT().BranchDelete(repoPath, "feature/auth", false).Return(nil) // uses state branch, not opts.Branch
 
 	err := m.Delete(DeleteOptions{
@@ -506,3 +1644,54 @@ func TestDelete_ITermNotRunning(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestOpen_KeepsFromTemplate(t *testing.T) {
+	m, mg, mi, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtPath := filepath.Join(dir, "wt", "svc")
+
+	require.NoError(t, sm.SetWorktree(wtPath, &state.WorktreeState{Branch: "svc", FromTemplate: "template/service"}))
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mi.EXPECT().CreateWorktreeWindow(wtPath, "wt:myrepo:svc", iterm.WindowOptions{}).
+		Return(&iterm.SessionIDs{ClaudeSessionID: "c1", ShellSessionID: "s1"}, nil)
+
+	_, err := m.Open(OpenOptions{RepoPath: repoPath, WtPath: wtPath, Branch: "svc"})
+	require.NoError(t, err)
+
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, "template/service", ws.FromTemplate)
+}
+
+func TestCreate_GitConfigFailureKeepsWorktree(t *testing.T) {
+	m, mg, _, sm, dir := setupManager(t)
+	repoPath := filepath.Join(dir, "repo")
+	wtDir := repoPath + ".worktrees"
+	wtPath := filepath.Join(wtDir, "auth")
+
+	mg.EXPECT().RepoName(repoPath).Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir(repoPath).Return(wtDir, nil)
+	mg.EXPECT().BranchExists(repoPath, "feature/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd(repoPath, wtPath, "feature/auth", "main", true, false).Return(nil)
+	mg.EXPECT().ConfigValue(wtPath, "extensions.worktreeConfig").Return("true", nil)
+	mg.EXPECT().SetLocalConfig(wtPath, "user.email", "me@work.com").Return(nil)
+	mg.EXPECT().SetLocalConfig(wtPath, "user.signingkey", "bad").Return(fmt.Errorf("boom"))
+
+	result, err := m.Create(CreateOptions{
+		RepoPath:   repoPath,
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		NoWindow:   true,
+		GitConfig:  map[string]string{"user.email": "me@work.com", "user.signingkey": "bad"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+	assert.Contains(t, m.log.(*testLogger).warnings, "Failed to set git config user.signingkey: boom")
+	assert.Len(t, m.log.(*testLogger).warnings, 1, "already enabled, so no notice")
+
+	// Only the applied setting is recorded
+	ws, err := sm.GetWorktree(wtPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user.email": "me@work.com"}, ws.GitConfig)
+}
@@ -3,6 +3,8 @@ package ops
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/joescharf/wt/pkg/gitops"
 )
@@ -44,13 +46,21 @@ func DeleteAll(git gitops.Client, log Logger, opts DeleteOptions, safetyCheck Sa
 		return 0, err
 	}
 
-	// Filter out main repo
+	// Filter out main repo and excepted worktrees
 	var toDelete []gitops.WorktreeInfo
+	var kept []string
 	for _, wt := range worktrees {
-		if wt.Path != opts.RepoPath {
+		switch {
+		case wt.Path == opts.RepoPath:
+		case slices.Contains(opts.Except, wt.Path):
+			kept = append(kept, filepath.Base(wt.Path))
+		default:
 			toDelete = append(toDelete, wt)
 		}
 	}
+	if len(kept) > 0 {
+		log.Info("Keeping %d worktree(s): %s", len(kept), strings.Join(kept, ", "))
+	}
 
 	if len(toDelete) == 0 {
 		log.Info("No worktrees to delete")
@@ -92,10 +102,72 @@ func DeleteAll(git gitops.Client, log Logger, opts DeleteOptions, safetyCheck Sa
 	}
 
 	// Run git worktree prune after bulk delete
-	if err := git.WorktreePrune(opts.RepoPath); err != nil {
+	if opts.DryRun {
+		log.Verbose("Would run git worktree prune")
+	} else if err := git.WorktreePrune(opts.RepoPath); err != nil {
 		log.Warning("Failed to run git worktree prune: %v", err)
 	}
 
 	log.Success("Deleted %d worktrees", deleted)
 	return deleted, nil
 }
+
+// PlanDeleteAll runs the same safety checks as a delete --all without changing
+// anything, and reports each worktree's status and the action DeleteAll would take.
+func PlanDeleteAll(git gitops.Client, log Logger, opts DeleteOptions) ([]DeletePlan, error) {
+	worktrees, err := git.WorktreeList(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []DeletePlan
+	for _, wt := range worktrees {
+		if wt.Path == opts.RepoPath {
+			continue
+		}
+		dirname := filepath.Base(wt.Path)
+		p := DeletePlan{WtPath: wt.Path, Branch: wt.Branch}
+
+		if slices.Contains(opts.Except, wt.Path) {
+			p.Action = DeleteActionKeep
+			plans = append(plans, p)
+			continue
+		}
+
+		if !isDirectory(wt.Path) {
+			p.Missing = true
+			p.Action = DeleteActionSkip
+			plans = append(plans, p)
+			continue
+		}
+
+		if p.Dirty, err = git.IsWorktreeDirty(wt.Path); err != nil {
+			log.Verbose("Could not check status of '%s': %v", dirname, err)
+		}
+		base := opts.BaseBranch
+		if opts.BaseFor != nil {
+			if b := opts.BaseFor(wt.Path); b != "" {
+				base = b
+			}
+		}
+		if p.Unpushed, err = git.HasUnpushedCommits(wt.Path, base); err != nil {
+			log.Verbose("Could not check unpushed commits of '%s': %v", dirname, err)
+		}
+		if opts.DeleteBranch {
+			if p.Unmerged, err = git.CommitsAhead(wt.Path, base); err != nil {
+				log.Verbose("Could not check unmerged commits of '%s': %v", dirname, err)
+			}
+		}
+
+		switch {
+		case opts.Force:
+			p.Action = DeleteActionDelete
+		case p.Dirty || p.Unpushed || p.Unmerged > 0:
+			p.Action = DeleteActionConfirm
+		default:
+			p.Action = DeleteActionDelete
+		}
+		plans = append(plans, p)
+	}
+	return plans, nil
+}
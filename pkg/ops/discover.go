@@ -28,6 +28,11 @@ func Discover(git gitops.Client, log Logger, opts DiscoverOptions, stateCheck St
 		if wt.Path == opts.RepoPath {
 			continue
 		}
+		if opts.Ignore.Match(wt.Path) {
+			log.Verbose("Ignoring '%s' (%s)", wt.Path, IgnoreFile)
+			result.Ignored++
+			continue
+		}
 
 		managed, err := stateCheck(wt.Path)
 		if err != nil {
@@ -45,6 +50,10 @@ func Discover(git gitops.Client, log Logger, opts DiscoverOptions, stateCheck St
 		})
 	}
 
+	if result.Ignored > 0 {
+		log.Info("Skipped %d worktrees matched by %s", result.Ignored, IgnoreFile)
+	}
+
 	if len(result.Unmanaged) == 0 {
 		log.Success("No unmanaged worktrees found")
 		return result, nil
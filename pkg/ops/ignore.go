@@ -0,0 +1,75 @@
+package ops
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFile is the name of the per-repo ignore file, read from the repo root.
+const IgnoreFile = ".wtignore"
+
+// IgnoreList holds glob patterns from a .wtignore file. Patterns are matched
+// against worktree paths relative to the repo root (absolute patterns match
+// absolute paths). A pattern also ignores everything beneath a matching
+// directory. A nil IgnoreList ignores nothing.
+type IgnoreList struct {
+	root     string
+	patterns []string
+}
+
+// LoadIgnore reads <repoPath>/.wtignore. A missing file yields an empty list.
+func LoadIgnore(repoPath string) (*IgnoreList, error) {
+	f, err := os.Open(filepath.Join(repoPath, IgnoreFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &IgnoreList{root: repoPath}, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ParseIgnore(repoPath, f)
+}
+
+// ParseIgnore parses .wtignore content: one glob per line, blank lines and
+// lines starting with # are skipped.
+func ParseIgnore(repoPath string, r io.Reader) (*IgnoreList, error) {
+	l := &IgnoreList{root: repoPath}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		l.patterns = append(l.patterns, strings.TrimSuffix(line, "/"))
+	}
+	return l, scanner.Err()
+}
+
+// Match reports whether path is excluded by any pattern.
+func (l *IgnoreList) Match(path string) bool {
+	if l == nil || len(l.patterns) == 0 {
+		return false
+	}
+
+	rel, err := filepath.Rel(l.root, path)
+	if err != nil {
+		rel = path
+	}
+
+	for _, p := range l.patterns {
+		target := rel
+		if filepath.IsAbs(p) {
+			target = path
+		}
+		// Try the path and each of its parent directories
+		for t := target; t != "." && t != string(filepath.Separator); t = filepath.Dir(t) {
+			if ok, _ := filepath.Match(p, t); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
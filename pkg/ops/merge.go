@@ -2,6 +2,7 @@ package ops
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -16,6 +17,10 @@ func Merge(git gitops.Client, log Logger, opts MergeOptions, cleanup CleanupFunc
 	result := &MergeResult{Branch: opts.Branch}
 	dirname := filepath.Base(opts.WtPath)
 
+	if opts.Continue {
+		return mergeContinueOnly(git, log, opts, result, cleanup)
+	}
+
 	// Safety check: dirty worktree
 	if !opts.Force {
 		dirty, err := git.IsWorktreeDirty(opts.WtPath)
@@ -27,13 +32,13 @@ func Merge(git gitops.Client, log Logger, opts MergeOptions, cleanup CleanupFunc
 		}
 	}
 
-	// Check if there are commits to merge
-	hasCommits, err := git.HasUnpushedCommits(opts.WtPath, opts.BaseBranch)
+	// Nothing to do if base already contains every commit on the branch
+	merged, err := git.IsAncestor(opts.RepoPath, opts.Branch, opts.BaseBranch)
 	if err != nil {
-		log.Verbose("Could not check for commits: %v", err)
+		log.Verbose("Could not check merge status: %v", err)
 	}
-	if !hasCommits {
-		log.Info("No commits to merge for '%s'", opts.Branch)
+	if merged {
+		log.Info("No commits to merge for '%s' — already merged into '%s'", opts.Branch, opts.BaseBranch)
 		result.Success = true
 		return result, nil
 	}
@@ -41,6 +46,9 @@ func Merge(git gitops.Client, log Logger, opts MergeOptions, cleanup CleanupFunc
 	if opts.CreatePR {
 		return mergePR(git, log, opts, result, prCreate)
 	}
+	if opts.Isolated {
+		return mergeIsolated(git, log, opts, result, cleanup)
+	}
 	return mergeLocal(git, log, opts, result, cleanup)
 }
 
@@ -73,27 +81,125 @@ func mergeLocal(git gitops.Client, log Logger, opts MergeOptions, result *MergeR
 		return result, fmt.Errorf("main repo is on '%s', expected '%s' — switch to '%s' first", currentBranch, opts.BaseBranch, opts.BaseBranch)
 	}
 
-	// Pull base branch if remote exists
+	hasRemote := pullBase(git, log, opts, opts.RepoPath)
+	if err := mergeIntoBase(git, log, opts, result, opts.RepoPath, hasRemote); err != nil {
+		return result, err
+	}
+
+	return mergeLocalFinish(git, log, opts, result, cleanup, opts.RepoPath)
+}
+
+// mergeIsolated merges into base inside a temporary worktree checked out on
+// base, then pushes from it, so the main repo's checkout is never touched. The
+// temporary worktree is always removed; a failed merge is discarded with it,
+// leaving base where it was.
+func mergeIsolated(git gitops.Client, log Logger, opts MergeOptions, result *MergeResult, cleanup CleanupFunc) (*MergeResult, error) {
+	dirname := filepath.Base(opts.WtPath)
+
+	// git refuses to check out a branch in two worktrees
+	currentBranch, err := git.CurrentBranch(opts.RepoPath)
+	if err != nil {
+		return result, err
+	}
+	if currentBranch == opts.BaseBranch {
+		return result, fmt.Errorf("'%s' is checked out in the main repo — merge without --isolated", opts.BaseBranch)
+	}
+
+	rebaseInProgress, err := git.IsRebaseInProgress(opts.WtPath)
+	if err != nil {
+		log.Verbose("Could not check rebase status: %v", err)
+	}
+	if rebaseInProgress {
+		return result, fmt.Errorf("worktree '%s' has a rebase in progress — finish it with 'git -C %s rebase --continue' (or --abort), then run merge again", dirname, opts.WtPath)
+	}
+
+	if opts.DryRun {
+		log.Info("Would check out '%s' in a temporary worktree", opts.BaseBranch)
+		hasRemote := pullBase(git, log, opts, opts.RepoPath)
+		if err := mergeIntoBase(git, log, opts, result, opts.RepoPath, hasRemote); err != nil {
+			return result, err
+		}
+		result, err = mergeLocalFinish(git, log, opts, result, cleanup, opts.RepoPath)
+		log.Info("Would remove the temporary worktree")
+		return result, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "wt-merge-")
+	if err != nil {
+		return result, fmt.Errorf("could not create a temporary worktree: %w", err)
+	}
+	log.Info("Checking out '%s' in a temporary worktree", opts.BaseBranch)
+	if err := git.WorktreeAdd(opts.RepoPath, tmpDir, opts.BaseBranch, "", false, false); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return result, fmt.Errorf("could not check out '%s' in a temporary worktree: %w", opts.BaseBranch, err)
+	}
+	defer removeTempWorktree(git, log, opts.RepoPath, tmpDir)
+
+	hasRemote := pullBase(git, log, opts, tmpDir)
+	if err := mergeIntoBase(git, log, opts, result, tmpDir, hasRemote); err != nil {
+		if opts.Strategy != "rebase" {
+			log.Warning("Discarded the failed merge — '%s' is unchanged; merge without --isolated to resolve conflicts", opts.BaseBranch)
+		}
+		return result, err
+	}
+
+	return mergeLocalFinish(git, log, opts, result, cleanup, tmpDir)
+}
+
+// removeTempWorktree force-removes a temporary worktree, discarding any
+// half-finished merge in it, and deletes its directory.
+func removeTempWorktree(git gitops.Client, log Logger, repoPath, tmpDir string) {
+	if err := git.WorktreeRemove(repoPath, tmpDir, true); err != nil {
+		log.Warning("Could not remove temporary worktree %s: %v", tmpDir, err)
+		_ = os.RemoveAll(tmpDir)
+		if err := git.WorktreePrune(repoPath); err != nil {
+			log.Verbose("Could not prune worktrees: %v", err)
+		}
+		return
+	}
+	_ = os.RemoveAll(tmpDir)
+	log.Verbose("Removed temporary worktree %s", tmpDir)
+}
+
+// pullBase pulls base in basePath, a checkout of base, unless there is no
+// remote or opts.NoPull is set. It reports whether the repo has a remote.
+func pullBase(git gitops.Client, log Logger, opts MergeOptions, basePath string) bool {
 	hasRemote, err := git.HasRemote(opts.RepoPath)
 	if err != nil {
 		log.Verbose("Could not check for remote: %v", err)
 	}
 
-	if hasRemote {
+	if hasRemote && opts.NoPull {
+		log.Info("Skipping pull of '%s' — merging against the local branch", opts.BaseBranch)
+	} else if hasRemote {
 		if opts.DryRun {
 			log.Info("Would pull '%s'", opts.BaseBranch)
 		} else {
 			log.Info("Pulling '%s'", opts.BaseBranch)
-			if err := git.Pull(opts.RepoPath); err != nil {
+			if err := runStep(log, "Pulling", func() error { return git.Pull(basePath) }); err != nil {
 				log.Warning("Pull failed: %v (continuing with merge)", err)
 			}
 		}
 	}
+	return hasRemote
+}
+
+// mergeIntoBase merges opts.Branch into base, checked out at basePath, using
+// opts.Strategy. Rebase merges rebase the worktree first, onto origin/<base>
+// when base was just pulled. With opts.AmendBase, a single-commit branch is
+// cherry-picked instead. The worktree's HEAD before a rebase is kept in
+// result.PreRebaseHEAD.
+func mergeIntoBase(git gitops.Client, log Logger, opts MergeOptions, result *MergeResult, basePath string, hasRemote bool) error {
+	if opts.AmendBase {
+		if picked, err := cherryPickSingle(git, log, opts, basePath); picked || err != nil {
+			return err
+		}
+	}
 
 	if opts.Strategy == "rebase" {
 		// Rebase-then-fast-forward flow
 		rebaseTarget := opts.BaseBranch
-		if hasRemote {
+		if hasRemote && !opts.NoPull {
 			rebaseTarget = "origin/" + opts.BaseBranch
 		}
 
@@ -103,34 +209,177 @@ func mergeLocal(git gitops.Client, log Logger, opts MergeOptions, result *MergeR
 			log.Info("Would rebase '%s' onto '%s'", opts.Branch, rebaseTarget)
 			log.Info("Would fast-forward merge '%s' into '%s'", opts.Branch, opts.BaseBranch)
 		} else {
-			if err := git.Rebase(opts.WtPath, rebaseTarget); err != nil {
+			result.PreRebaseHEAD = headBeforeSync(git, log, opts.WtPath)
+			if err := runStep(log, "Rebasing", func() error {
+				return git.Rebase(opts.WtPath, rebaseTarget, gitops.RebaseRunOptions{StrategyOptions: opts.StrategyOptions, Sign: opts.Sign, SignKey: opts.SignKey})
+			}); err != nil {
 				log.Warning("Rebase failed — resolve conflicts, then run merge again (or 'git -C %s rebase --abort' to cancel)", opts.WtPath)
-				return result, fmt.Errorf("rebase conflict: %w", err)
+				return fmt.Errorf("rebase conflict: %w", err)
 			}
 			log.Success("Rebased '%s' onto '%s'", opts.Branch, opts.BaseBranch)
 
 			// Fast-forward merge into base
 			log.Info("Fast-forward merging '%s' into '%s'", opts.Branch, opts.BaseBranch)
-			if err := git.Merge(opts.RepoPath, opts.Branch); err != nil {
-				return result, fmt.Errorf("fast-forward merge failed: %w", err)
+			if err := runStep(log, "Fast-forwarding", func() error { return git.Merge(basePath, opts.Branch, gitops.MergeRunOptions{}) }); err != nil {
+				return fmt.Errorf("fast-forward merge failed: %w", err)
 			}
 			log.Success("Merged '%s' into '%s'", opts.Branch, opts.BaseBranch)
 		}
+	} else if opts.Strategy == "squash" {
+		log.Info("Squash merging '%s' into '%s'", opts.Branch, opts.BaseBranch)
+		message := MergeCommitMessage(git, log, opts)
+
+		if opts.DryRun {
+			log.Info("Would squash merge '%s' into '%s' with message:", opts.Branch, opts.BaseBranch)
+			logCommitMessage(log, message)
+		} else {
+			if err := runStep(log, "Squash merging", func() error {
+				return git.Merge(basePath, opts.Branch, gitops.MergeRunOptions{Squash: true, Message: message, StrategyOptions: opts.StrategyOptions, Sign: opts.Sign, SignKey: opts.SignKey})
+			}); err != nil {
+				if !opts.Isolated {
+					log.Warning("Squash merge failed — resolve conflicts and commit in '%s' (or 'git -C %s reset --merge' to cancel)", opts.RepoPath, opts.RepoPath)
+				}
+				return fmt.Errorf("squash merge failed: %w", err)
+			}
+			log.Success("Squash merged '%s' into '%s'", opts.Branch, opts.BaseBranch)
+		}
 	} else {
 		log.Info("Merging '%s' into '%s'", opts.Branch, opts.BaseBranch)
 
 		if opts.DryRun {
-			log.Info("Would merge '%s' into '%s'", opts.Branch, opts.BaseBranch)
+			log.Info("Would merge '%s' into '%s' with message:", opts.Branch, opts.BaseBranch)
+			logCommitMessage(log, MergeCommitMessage(git, log, opts))
 		} else {
-			if err := git.Merge(opts.RepoPath, opts.Branch); err != nil {
-				log.Warning("Merge failed — resolve conflicts, then run merge again")
-				return result, fmt.Errorf("merge conflict: %w", err)
+			// git writes the message itself, so merge.suppressDest and the
+			// like still apply
+			if err := runStep(log, "Merging", func() error {
+				return git.Merge(basePath, opts.Branch, gitops.MergeRunOptions{FF: mergeFFMode(opts), StrategyOptions: opts.StrategyOptions, Sign: opts.Sign, SignKey: opts.SignKey})
+			}); err != nil {
+				if !opts.Isolated {
+					log.Warning("Merge failed — resolve conflicts, then run merge again")
+				}
+				return fmt.Errorf("merge conflict: %w", err)
 			}
 			log.Success("Merged '%s' into '%s'", opts.Branch, opts.BaseBranch)
 		}
 	}
 
-	return mergeLocalFinish(git, log, opts, result, cleanup)
+	return nil
+}
+
+// cherryPickSingle cherry-picks opts.Branch onto base, checked out at
+// basePath, when the branch is exactly one commit ahead of base. For any other
+// branch it does nothing and reports false, so the caller merges instead.
+func cherryPickSingle(git gitops.Client, log Logger, opts MergeOptions, basePath string) (bool, error) {
+	ahead, err := git.CommitsAhead(opts.WtPath, opts.BaseBranch)
+	if err != nil {
+		log.Warning("Could not count commits ahead of '%s': %v (merging instead)", opts.BaseBranch, err)
+		return false, nil
+	}
+	if ahead != 1 {
+		log.Info("'%s' is %d commits ahead of '%s' — merging instead of cherry-picking", opts.Branch, ahead, opts.BaseBranch)
+		return false, nil
+	}
+
+	sha, err := git.HeadSHA(opts.WtPath)
+	if err != nil {
+		return false, err
+	}
+
+	if opts.DryRun {
+		log.Info("Would cherry-pick %s from '%s' onto '%s'", ShortSHA(sha), opts.Branch, opts.BaseBranch)
+		return true, nil
+	}
+
+	log.Info("Cherry-picking %s from '%s' onto '%s'", ShortSHA(sha), opts.Branch, opts.BaseBranch)
+	if err := runStep(log, "Cherry-picking", func() error {
+		return git.CherryPick(basePath, sha, gitops.CherryPickRunOptions{Sign: opts.Sign, SignKey: opts.SignKey})
+	}); err != nil {
+		if !opts.Isolated {
+			log.Warning("Cherry-pick failed — resolve conflicts and run 'git -C %s cherry-pick --continue', then 'wt delete %s' (or 'git -C %s cherry-pick --abort' to cancel)", opts.RepoPath, opts.Branch, opts.RepoPath)
+		}
+		return true, fmt.Errorf("cherry-pick conflict: %w", err)
+	}
+	log.Success("Cherry-picked '%s' onto '%s'", opts.Branch, opts.BaseBranch)
+	return true, nil
+}
+
+// mergeFFMode returns the fast-forward mode for a "merge" strategy merge.
+func mergeFFMode(opts MergeOptions) gitops.FFMode {
+	if opts.NoFF {
+		return gitops.NoFF
+	}
+	return gitops.FFDefault
+}
+
+// MergeCommitMessage returns the commit message a local merge of opts.Branch
+// into opts.BaseBranch records. Merges use git's default message, which this
+// mirrors for previews; squash merges list the subjects of the squashed commits. Rebase merges fast-forward and
+// create no commit, so the message is empty.
+func MergeCommitMessage(git gitops.Client, log Logger, opts MergeOptions) string {
+	switch opts.Strategy {
+	case "rebase":
+		return ""
+	case "squash":
+		msg := fmt.Sprintf("Squash merge branch '%s'", opts.Branch)
+		subjects, err := git.CommitSubjects(opts.RepoPath, opts.BaseBranch, opts.Branch)
+		if err != nil {
+			log.Verbose("Could not list commits: %v", err)
+		}
+		if len(subjects) == 0 {
+			return msg
+		}
+		var b strings.Builder
+		b.WriteString(msg + "\n")
+		for _, s := range subjects {
+			b.WriteString("\n* " + s)
+		}
+		return b.String()
+	default:
+		// Mirror git fmt-merge-msg: the target is omitted when merging into main/master
+		msg := fmt.Sprintf("Merge branch '%s'", opts.Branch)
+		if opts.BaseBranch != "main" && opts.BaseBranch != "master" {
+			msg += fmt.Sprintf(" into %s", opts.BaseBranch)
+		}
+		return msg
+	}
+}
+
+// logCommitMessage prints a commit message indented, one log line per message line.
+func logCommitMessage(log Logger, message string) {
+	for _, line := range strings.Split(message, "\n") {
+		log.Info("    %s", line)
+	}
+}
+
+// mergeContinueOnly resumes an in-progress local merge (or rebase-then-ff) and
+// errors if there is nothing to continue. Safety checks are skipped because
+// resolved conflicts are staged by design.
+func mergeContinueOnly(git gitops.Client, log Logger, opts MergeOptions, result *MergeResult, cleanup CleanupFunc) (*MergeResult, error) {
+	if opts.CreatePR {
+		return result, fmt.Errorf("--continue only applies to local merges, not --pr")
+	}
+	if opts.Isolated {
+		return result, fmt.Errorf("--continue can't be used with --isolated — an isolated merge that fails leaves nothing to continue")
+	}
+
+	mergeInProgress, err := git.IsMergeInProgress(opts.RepoPath)
+	if err != nil {
+		log.Verbose("Could not check merge status: %v", err)
+	}
+	if mergeInProgress {
+		return mergeLocalContinue(git, log, opts, result, cleanup)
+	}
+
+	rebaseInProgress, err := git.IsRebaseInProgress(opts.WtPath)
+	if err != nil {
+		log.Verbose("Could not check rebase status: %v", err)
+	}
+	if rebaseInProgress {
+		return mergeLocalContinueRebase(git, log, opts, result, cleanup)
+	}
+
+	return result, fmt.Errorf("no merge or rebase in progress for '%s' — nothing to continue", opts.Branch)
 }
 
 // mergeLocalContinue resumes a merge that was started but had conflicts.
@@ -154,7 +403,7 @@ func mergeLocalContinue(git gitops.Client, log Logger, opts MergeOptions, result
 		log.Success("Merge continued — '%s' merged into '%s'", opts.Branch, opts.BaseBranch)
 	}
 
-	return mergeLocalFinish(git, log, opts, result, cleanup)
+	return mergeLocalFinish(git, log, opts, result, cleanup, opts.RepoPath)
 }
 
 // mergeLocalContinueRebase resumes a rebase-then-ff merge when the rebase had conflicts.
@@ -181,17 +430,18 @@ func mergeLocalContinueRebase(git gitops.Client, log Logger, opts MergeOptions,
 
 		// Fast-forward merge into base
 		log.Info("Fast-forward merging '%s' into '%s'", opts.Branch, opts.BaseBranch)
-		if err := git.Merge(opts.RepoPath, opts.Branch); err != nil {
+		if err := runStep(log, "Fast-forwarding", func() error { return git.Merge(opts.RepoPath, opts.Branch, gitops.MergeRunOptions{}) }); err != nil {
 			return result, fmt.Errorf("fast-forward merge failed: %w", err)
 		}
 		log.Success("Merged '%s' into '%s'", opts.Branch, opts.BaseBranch)
 	}
 
-	return mergeLocalFinish(git, log, opts, result, cleanup)
+	return mergeLocalFinish(git, log, opts, result, cleanup, opts.RepoPath)
 }
 
-// mergeLocalFinish handles push + cleanup after a successful local merge.
-func mergeLocalFinish(git gitops.Client, log Logger, opts MergeOptions, result *MergeResult, cleanup CleanupFunc) (*MergeResult, error) {
+// mergeLocalFinish handles push + cleanup after a successful local merge into
+// base, checked out at basePath.
+func mergeLocalFinish(git gitops.Client, log Logger, opts MergeOptions, result *MergeResult, cleanup CleanupFunc, basePath string) (*MergeResult, error) {
 	// Push base branch if remote exists
 	hasRemote, err := git.HasRemote(opts.RepoPath)
 	if err != nil {
@@ -203,31 +453,91 @@ func mergeLocalFinish(git gitops.Client, log Logger, opts MergeOptions, result *
 			log.Info("Would push '%s'", opts.BaseBranch)
 		} else {
 			log.Info("Pushing '%s'", opts.BaseBranch)
-			if err := git.Push(opts.RepoPath, opts.BaseBranch, false); err != nil {
+			if err := runStep(log, "Pushing", func() error { return git.Push(basePath, opts.BaseBranch, false) }); err != nil {
 				log.Warning("Push failed: %v (merge succeeded locally)", err)
+				result.PushFailed = true
 			} else {
 				log.Success("Pushed '%s'", opts.BaseBranch)
 			}
 		}
 	}
 
-	// Cleanup unless --no-cleanup
-	if !opts.NoCleanup && cleanup != nil {
+	// A failed push keeps the worktree so nothing is lost before the merge reaches the remote
+	if result.PushFailed {
+		dirname := filepath.Base(opts.WtPath)
+		if opts.ConfirmResetBase != nil && opts.ConfirmResetBase(opts.BaseBranch) {
+			result.BaseReset = resetBaseAfterFailedPush(git, log, opts, basePath)
+		}
+		log.Warning("Keeping worktree '%s' because the push failed", dirname)
+		if result.BaseReset {
+			log.Info("Once pushing works, run 'wt merge %s' again", opts.Branch)
+		} else if opts.Isolated {
+			log.Info("Push '%s' yourself (git -C %s push origin %s), then run 'wt delete %s'", opts.BaseBranch, opts.RepoPath, opts.BaseBranch, opts.Branch)
+		} else {
+			log.Info("Push '%s' yourself (git -C %s push), then run 'wt delete %s'", opts.BaseBranch, opts.RepoPath, opts.Branch)
+		}
+	} else if opts.NoCleanup {
+		// --no-cleanup/--keep leaves the worktree, branch, and window untouched
+		log.Info("Keeping worktree '%s' and its iTerm2 window", filepath.Base(opts.WtPath))
+	} else if cleanup != nil {
 		log.Info("Cleaning up worktree")
 		if err := cleanup(opts.WtPath, opts.Branch); err != nil {
 			log.Warning("Cleanup failed: %v (merge succeeded)", err)
 		}
 	}
 
+	if result.BaseReset {
+		return result, fmt.Errorf("pushing '%s' failed, so the merge was undone", opts.BaseBranch)
+	}
 	result.Success = true
-	log.Success("Merge complete")
+	if result.PushFailed {
+		log.Warning("Merge complete locally, but '%s' isn't pushed", opts.BaseBranch)
+	} else {
+		log.Success("Merge complete")
+	}
 	return result, nil
 }
 
+// resetBaseAfterFailedPush undoes a local merge whose push failed by resetting
+// base, checked out at basePath, to ORIG_HEAD, which git merge (including
+// --squash and fast-forwards) sets to base's pre-merge commit. It refuses when
+// the checkout is dirty.
+func resetBaseAfterFailedPush(git gitops.Client, log Logger, opts MergeOptions, basePath string) bool {
+	dirty, err := git.IsWorktreeDirty(basePath)
+	if err != nil || dirty {
+		log.Warning("Not resetting '%s' — the main repo has uncommitted changes or its status is unknown", opts.BaseBranch)
+		return false
+	}
+	if err := git.ResetHard(basePath, "ORIG_HEAD"); err != nil {
+		log.Warning("Could not reset '%s': %v", opts.BaseBranch, err)
+		return false
+	}
+	log.Success("Reset '%s' to its pre-merge commit — the merge is undone locally", opts.BaseBranch)
+	return true
+}
+
+// verifyPushed confirms origin/<branch> matches the worktree's HEAD after a
+// push, so a rejected or partial push fails here with a clear message rather
+// than later inside gh pr create.
+func verifyPushed(git gitops.Client, wtPath, branch string) error {
+	local, err := git.HeadSHA(wtPath)
+	if err != nil {
+		return fmt.Errorf("could not verify push of '%s': %w", branch, err)
+	}
+	remote, err := git.RemoteBranchSHA(wtPath, "origin", branch)
+	if err != nil {
+		return fmt.Errorf("could not verify push of '%s': %w", branch, err)
+	}
+	if remote != local {
+		return fmt.Errorf("push of '%s' didn't land: origin/%s is at %s but HEAD is at %s — push again before creating the PR", branch, branch, ShortSHA(remote), ShortSHA(local))
+	}
+	return nil
+}
+
 // mergePR creates a pull request for the feature branch.
 func mergePR(git gitops.Client, log Logger, opts MergeOptions, result *MergeResult, prCreate PRCreateFunc) (*MergeResult, error) {
-	if opts.Strategy == "rebase" {
-		log.Warning("--rebase is ignored for PR mode (merge strategy is configured on GitHub)")
+	if opts.Strategy == "rebase" || opts.Strategy == "squash" {
+		log.Warning("--%s is ignored for PR mode (merge strategy is configured on GitHub)", opts.Strategy)
 	}
 
 	log.Info("Creating PR for '%s' → '%s'", opts.Branch, opts.BaseBranch)
@@ -237,9 +547,12 @@ func mergePR(git gitops.Client, log Logger, opts MergeOptions, result *MergeResu
 		log.Info("Would push branch '%s'", opts.Branch)
 	} else {
 		log.Info("Pushing branch '%s'", opts.Branch)
-		if err := git.Push(opts.WtPath, opts.Branch, true); err != nil {
+		if err := runStep(log, "Pushing", func() error { return git.Push(opts.WtPath, opts.Branch, true) }); err != nil {
 			return result, fmt.Errorf("push failed: %w", err)
 		}
+		if err := verifyPushed(git, opts.WtPath, opts.Branch); err != nil {
+			return result, err
+		}
 		log.Success("Pushed '%s'", opts.Branch)
 	}
 
@@ -250,8 +563,11 @@ func mergePR(git gitops.Client, log Logger, opts MergeOptions, result *MergeResu
 	}
 	if opts.PRBody != "" {
 		args = append(args, "--body", opts.PRBody)
+	} else if opts.PRBodyFile != "" {
+		args = append(args, "--body-file", opts.PRBodyFile)
 	}
-	if opts.PRTitle == "" && opts.PRBody == "" {
+	// gh fills whatever isn't given from the commits; explicit values win
+	if opts.PRTitle == "" {
 		args = append(args, "--fill")
 	}
 	if opts.PRDraft {
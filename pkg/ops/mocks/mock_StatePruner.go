@@ -17,27 +17,29 @@ func (_m *MockStatePruner) EXPECT() *MockStatePruner_Expecter {
 	return &MockStatePruner_Expecter{mock: &_m.Mock}
 }
 
-// Execute provides a mock function with no fields
-func (_m *MockStatePruner) Execute() (int, error) {
-	ret := _m.Called()
+// Execute provides a mock function with given fields: skip, dryRun
+func (_m *MockStatePruner) Execute(skip func(string) bool, dryRun bool) ([]string, error) {
+	ret := _m.Called(skip, dryRun)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Execute")
 	}
 
-	var r0 int
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (int, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(func(string) bool, bool) ([]string, error)); ok {
+		return rf(skip, dryRun)
 	}
-	if rf, ok := ret.Get(0).(func() int); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(func(string) bool, bool) []string); ok {
+		r0 = rf(skip, dryRun)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(func(string) bool, bool) error); ok {
+		r1 = rf(skip, dryRun)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,23 +53,25 @@ type MockStatePruner_Execute_Call struct {
 }
 
 // Execute is a helper method to define mock.On call
-func (_e *MockStatePruner_Expecter) Execute() *MockStatePruner_Execute_Call {
-	return &MockStatePruner_Execute_Call{Call: _e.mock.On("Execute")}
+//   - skip func(string) bool
+//   - dryRun bool
+func (_e *MockStatePruner_Expecter) Execute(skip interface{}, dryRun interface{}) *MockStatePruner_Execute_Call {
+	return &MockStatePruner_Execute_Call{Call: _e.mock.On("Execute", skip, dryRun)}
 }
 
-func (_c *MockStatePruner_Execute_Call) Run(run func()) *MockStatePruner_Execute_Call {
+func (_c *MockStatePruner_Execute_Call) Run(run func(skip func(string) bool, dryRun bool)) *MockStatePruner_Execute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(func(string) bool), args[1].(bool))
 	})
 	return _c
 }
 
-func (_c *MockStatePruner_Execute_Call) Return(_a0 int, _a1 error) *MockStatePruner_Execute_Call {
+func (_c *MockStatePruner_Execute_Call) Return(_a0 []string, _a1 error) *MockStatePruner_Execute_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStatePruner_Execute_Call) RunAndReturn(run func() (int, error)) *MockStatePruner_Execute_Call {
+func (_c *MockStatePruner_Execute_Call) RunAndReturn(run func(func(string) bool, bool) ([]string, error)) *MockStatePruner_Execute_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,80 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockStepLogger is an autogenerated mock type for the StepLogger type
+type MockStepLogger struct {
+	mock.Mock
+}
+
+type MockStepLogger_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStepLogger) EXPECT() *MockStepLogger_Expecter {
+	return &MockStepLogger_Expecter{mock: &_m.Mock}
+}
+
+// Begin provides a mock function with given fields: step
+func (_m *MockStepLogger) Begin(step string) func() {
+	ret := _m.Called(step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(string) func()); ok {
+		r0 = rf(step)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// MockStepLogger_Begin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Begin'
+type MockStepLogger_Begin_Call struct {
+	*mock.Call
+}
+
+// Begin is a helper method to define mock.On call
+//   - step string
+func (_e *MockStepLogger_Expecter) Begin(step interface{}) *MockStepLogger_Begin_Call {
+	return &MockStepLogger_Begin_Call{Call: _e.mock.On("Begin", step)}
+}
+
+func (_c *MockStepLogger_Begin_Call) Run(run func(step string)) *MockStepLogger_Begin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockStepLogger_Begin_Call) Return(end func()) *MockStepLogger_Begin_Call {
+	_c.Call.Return(end)
+	return _c
+}
+
+func (_c *MockStepLogger_Begin_Call) RunAndReturn(run func(string) func()) *MockStepLogger_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStepLogger creates a new instance of MockStepLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStepLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStepLogger {
+	mock := &MockStepLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
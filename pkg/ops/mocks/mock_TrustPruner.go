@@ -17,27 +17,29 @@ func (_m *MockTrustPruner) EXPECT() *MockTrustPruner_Expecter {
 	return &MockTrustPruner_Expecter{mock: &_m.Mock}
 }
 
-// Execute provides a mock function with given fields: dir
-func (_m *MockTrustPruner) Execute(dir string) (int, error) {
-	ret := _m.Called(dir)
+// Execute provides a mock function with given fields: dir, dryRun
+func (_m *MockTrustPruner) Execute(dir string, dryRun bool) ([]string, error) {
+	ret := _m.Called(dir, dryRun)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Execute")
 	}
 
-	var r0 int
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
-		return rf(dir)
+	if rf, ok := ret.Get(0).(func(string, bool) ([]string, error)); ok {
+		return rf(dir, dryRun)
 	}
-	if rf, ok := ret.Get(0).(func(string) int); ok {
-		r0 = rf(dir)
+	if rf, ok := ret.Get(0).(func(string, bool) []string); ok {
+		r0 = rf(dir, dryRun)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(dir)
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(dir, dryRun)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,23 +54,24 @@ type MockTrustPruner_Execute_Call struct {
 
 // Execute is a helper method to define mock.On call
 //   - dir string
-func (_e *MockTrustPruner_Expecter) Execute(dir interface{}) *MockTrustPruner_Execute_Call {
-	return &MockTrustPruner_Execute_Call{Call: _e.mock.On("Execute", dir)}
+//   - dryRun bool
+func (_e *MockTrustPruner_Expecter) Execute(dir interface{}, dryRun interface{}) *MockTrustPruner_Execute_Call {
+	return &MockTrustPruner_Execute_Call{Call: _e.mock.On("Execute", dir, dryRun)}
 }
 
-func (_c *MockTrustPruner_Execute_Call) Run(run func(dir string)) *MockTrustPruner_Execute_Call {
+func (_c *MockTrustPruner_Execute_Call) Run(run func(dir string, dryRun bool)) *MockTrustPruner_Execute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(bool))
 	})
 	return _c
 }
 
-func (_c *MockTrustPruner_Execute_Call) Return(_a0 int, _a1 error) *MockTrustPruner_Execute_Call {
+func (_c *MockTrustPruner_Execute_Call) Return(_a0 []string, _a1 error) *MockTrustPruner_Execute_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockTrustPruner_Execute_Call) RunAndReturn(run func(string) (int, error)) *MockTrustPruner_Execute_Call {
+func (_c *MockTrustPruner_Execute_Call) RunAndReturn(run func(string, bool) ([]string, error)) *MockTrustPruner_Execute_Call {
 	_c.Call.Return(run)
 	return _c
 }
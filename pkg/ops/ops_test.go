@@ -2,9 +2,14 @@ package ops
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/joescharf/wt/pkg/gitops"
@@ -68,6 +73,33 @@ func TestSync_AlreadyInSync(t *testing.T) {
 	assert.Equal(t, 0, result.Behind)
 }
 
+func TestSync_AheadOnlyReportsAhead(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(2, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(0, nil)
+
+	result, err := Sync(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.AlreadySynced)
+	assert.Equal(t, 2, result.Ahead)
+	require.Len(t, log.successes, 1)
+	assert.Contains(t, log.successes[0], "up to date with 'main' and 2 commit(s) ahead")
+	assert.NotContains(t, log.successes[0], "already in sync")
+}
+
 func TestSync_MergeBehind(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
@@ -78,7 +110,8 @@ func TestSync_MergeBehind(t *testing.T) {
 	mg.EXPECT().HasRemote("/repo").Return(false, nil)
 	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(1, nil)
 	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(3, nil)
-	mg.EXPECT().Merge("/wt/auth", "main").Return(nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/auth", "main", gitops.MergeRunOptions{}).Return(nil)
 
 	result, err := Sync(mg, log, SyncOptions{
 		RepoPath:   "/repo",
@@ -93,6 +126,32 @@ func TestSync_MergeBehind(t *testing.T) {
 	assert.False(t, result.AlreadySynced)
 	assert.Equal(t, 3, result.Behind)
 	assert.Equal(t, 1, result.Ahead)
+	assert.Equal(t, "pre123", result.PreSyncHEAD, "HEAD before the merge is kept for undo")
+}
+
+func TestSync_MergeDivergedNotesMergeCommit(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(2, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(3, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/auth", "main", gitops.MergeRunOptions{}).Return(nil)
+
+	_, err := Sync(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, strings.Join(log.infos, "\n"), "'feature/auth' has diverged from 'main' (2 ahead, 3 behind)")
 }
 
 func TestSync_RebaseBehind(t *testing.T) {
@@ -105,7 +164,8 @@ func TestSync_RebaseBehind(t *testing.T) {
 	mg.EXPECT().HasRemote("/repo").Return(false, nil)
 	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(2, nil)
 	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(1, nil)
-	mg.EXPECT().Rebase("/wt/auth", "main").Return(nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Rebase("/wt/auth", "main", gitops.RebaseRunOptions{}).Return(nil)
 
 	result, err := Sync(mg, log, SyncOptions{
 		RepoPath:   "/repo",
@@ -120,6 +180,67 @@ func TestSync_RebaseBehind(t *testing.T) {
 	assert.Equal(t, "rebase", result.Strategy)
 }
 
+func TestSync_StrategyOptions(t *testing.T) {
+	for _, strategy := range []string{"merge", "rebase"} {
+		t.Run(strategy, func(t *testing.T) {
+			mg := mocks.NewMockClient(t)
+			log := &testLogger{}
+
+			mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+			mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+			mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+			mg.EXPECT().HasRemote("/repo").Return(false, nil)
+			mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(2, nil)
+			mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(1, nil)
+			mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+			xopts := []string{"theirs", "patience"}
+			if strategy == "rebase" {
+				mg.EXPECT().Rebase("/wt/auth", "main", gitops.RebaseRunOptions{StrategyOptions: xopts}).Return(nil)
+			} else {
+				mg.EXPECT().Merge("/wt/auth", "main", gitops.MergeRunOptions{StrategyOptions: xopts}).Return(nil)
+			}
+
+			result, err := Sync(mg, log, SyncOptions{
+				RepoPath:        "/repo",
+				BaseBranch:      "main",
+				Branch:          "feature/auth",
+				WtPath:          "/wt/auth",
+				Strategy:        strategy,
+				StrategyOptions: xopts,
+			})
+
+			require.NoError(t, err)
+			assert.True(t, result.Success)
+		})
+	}
+}
+
+func TestSync_RebaseMerges(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(3, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(1, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Rebase("/wt/auth", "main", gitops.RebaseRunOptions{RebaseMerges: true}).Return(nil)
+
+	result, err := Sync(mg, log, SyncOptions{
+		RepoPath:     "/repo",
+		BaseBranch:   "main",
+		Branch:       "feature/auth",
+		WtPath:       "/wt/auth",
+		Strategy:     "rebase",
+		RebaseMerges: true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
 func TestSync_DirtyWorktreeBlocked(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
@@ -172,7 +293,8 @@ func TestSync_MergeConflict(t *testing.T) {
 	mg.EXPECT().HasRemote("/repo").Return(false, nil)
 	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(0, nil)
 	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(2, nil)
-	mg.EXPECT().Merge("/wt/auth", "main").Return(fmt.Errorf("conflict"))
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/auth", "main", gitops.MergeRunOptions{}).Return(fmt.Errorf("conflict"))
 
 	result, err := Sync(mg, log, SyncOptions{
 		RepoPath:   "/repo",
@@ -188,6 +310,130 @@ func TestSync_MergeConflict(t *testing.T) {
 	assert.False(t, result.Success)
 }
 
+func TestSync_FFOnly_FastForwards(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(0, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(4, nil)
+	mg.EXPECT().IsAncestor("/wt/auth", "HEAD", "main").Return(true, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/auth", "main", gitops.MergeRunOptions{FF: gitops.FFOnly}).Return(nil)
+
+	result, err := Sync(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		FFOnly:     true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Contains(t, log.successes, "Fast-forwarded 'feature/auth' to 'main'")
+}
+
+func TestSync_FFOnly_Diverged(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(2, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(3, nil)
+	mg.EXPECT().IsAncestor("/wt/auth", "HEAD", "main").Return(false, nil)
+	// No Merge expected — diverged branches are rejected before touching the worktree
+
+	result, err := Sync(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		FFOnly:     true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot fast-forward")
+	assert.Contains(t, err.Error(), "--rebase")
+	assert.False(t, result.Success)
+}
+
+func TestSync_FFOnly_AncestryUnknownFallsBackToAhead(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(1, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(3, nil)
+	mg.EXPECT().IsAncestor("/wt/auth", "HEAD", "main").Return(false, fmt.Errorf("bad object"))
+
+	_, err := Sync(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		FFOnly:     true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot fast-forward")
+}
+
+// stepLogger records Begin/end pairs in addition to log lines.
+type stepLogger struct {
+	testLogger
+	steps []string
+}
+
+func (l *stepLogger) Begin(step string) func() {
+	l.steps = append(l.steps, "begin "+step)
+	return func() { l.steps = append(l.steps, "end "+step) }
+}
+
+func TestSync_ReportsSteps(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &stepLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().CommitsBehind("/repo", "origin/main").Return(0, nil)
+	mg.EXPECT().Fetch("/repo").Return(nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "origin/main").Return(0, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "origin/main").Return(2, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(0, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/auth", "origin/main", gitops.MergeRunOptions{}).
+		Run(func(string, string, gitops.MergeRunOptions) {
+			assert.Equal(t, "begin Merging", log.steps[len(log.steps)-1], "merge should run inside its step")
+		}).Return(nil)
+
+	_, err := Sync(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"begin Fetching", "end Fetching", "begin Merging", "end Merging"}, log.steps)
+}
+
 func TestSync_ContinueMerge(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
@@ -209,6 +455,50 @@ func TestSync_ContinueMerge(t *testing.T) {
 	assert.True(t, result.Success)
 }
 
+func TestSync_ContinueFlag_RebaseInProgress(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	// No dirty check — resolved conflicts are staged when continuing
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(true, nil)
+	mg.EXPECT().HasConflicts("/wt/auth").Return(false, nil)
+	mg.EXPECT().RebaseContinue("/wt/auth").Return(nil)
+
+	result, err := Sync(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "rebase",
+		Continue:   true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestSync_ContinueFlag_NothingInProgress(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	// Must not fetch or start a new merge
+
+	_, err := Sync(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		Continue:   true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to continue")
+}
+
 func TestSync_ContinueRebase(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
@@ -239,13 +529,16 @@ func TestSync_WithRemoteFetches(t *testing.T) {
 	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
 	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
 	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().CommitsBehind("/repo", "origin/main").Return(0, nil)
 	mg.EXPECT().Fetch("/repo").Return(nil)
 	// With remote, merge source becomes "origin/main"
 	mg.EXPECT().CommitsAhead("/wt/auth", "origin/main").Return(0, nil)
 	mg.EXPECT().CommitsBehind("/wt/auth", "origin/main").Return(2, nil)
 	// Also check local base branch
 	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(1, nil)
-	mg.EXPECT().Merge("/wt/auth", "origin/main").Return(nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/auth", "origin/main", gitops.MergeRunOptions{}).Return(nil)
 
 	result, err := Sync(mg, log, SyncOptions{
 		RepoPath:   "/repo",
@@ -260,6 +553,53 @@ func TestSync_WithRemoteFetches(t *testing.T) {
 	assert.Equal(t, 2, result.Behind)
 }
 
+func TestSync_WarnsWhenBaseBehindOrigin(t *testing.T) {
+	tests := []struct {
+		name     string
+		checkout string // branch checked out in the main repo
+		behind   int    // local main behind origin/main
+		warning  string
+	}{
+		{name: "stale base", checkout: "main", behind: 3, warning: "Base 'main' is 3 commit(s) behind origin/main — run 'git -C /repo pull' first"},
+		{name: "fresh base", checkout: "main"},
+		{name: "main repo on another branch", checkout: "hotfix"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := mocks.NewMockClient(t)
+			log := &testLogger{}
+
+			mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+			mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+			mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+			mg.EXPECT().HasRemote("/repo").Return(true, nil)
+			mg.EXPECT().Fetch("/repo").Return(nil)
+			mg.EXPECT().CurrentBranch("/repo").Return(tt.checkout, nil)
+			if tt.checkout == "main" {
+				mg.EXPECT().CommitsBehind("/repo", "origin/main").Return(tt.behind, nil)
+			}
+			mg.EXPECT().CommitsAhead("/wt/auth", "origin/main").Return(0, nil)
+			mg.EXPECT().CommitsBehind("/wt/auth", "origin/main").Return(0, nil)
+			mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(0, nil)
+
+			_, err := Sync(mg, log, SyncOptions{
+				RepoPath:   "/repo",
+				BaseBranch: "main",
+				Branch:     "feature/auth",
+				WtPath:     "/wt/auth",
+				Strategy:   "merge",
+			})
+
+			require.NoError(t, err)
+			if tt.warning != "" {
+				assert.Equal(t, []string{tt.warning}, log.warnings)
+			} else {
+				assert.Empty(t, log.warnings)
+			}
+		})
+	}
+}
+
 func TestSync_DryRun(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
@@ -352,7 +692,8 @@ func TestSyncAll_MultipleMixed(t *testing.T) {
 	mg.EXPECT().IsRebaseInProgress("/wt/fix").Return(false, nil)
 	mg.EXPECT().CommitsAhead("/wt/fix", "main").Return(0, nil)
 	mg.EXPECT().CommitsBehind("/wt/fix", "main").Return(2, nil)
-	mg.EXPECT().Merge("/wt/fix", "main").Return(nil)
+	mg.EXPECT().HeadSHA("/wt/fix").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/fix", "main", gitops.MergeRunOptions{}).Return(nil)
 
 	results, err := SyncAll(mg, log, SyncOptions{
 		RepoPath:   "/repo",
@@ -366,67 +707,856 @@ func TestSyncAll_MultipleMixed(t *testing.T) {
 	assert.True(t, results[1].Success)
 }
 
-// --- Merge Tests ---
-
-func TestMerge_LocalMerge(t *testing.T) {
+func TestSyncAll_FFOnlyFailureIsFailed(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
-	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
-	mg.EXPECT().HasUnpushedCommits("/wt/auth", "main").Return(true, nil)
-	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
-	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
-	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/fix", Branch: "bugfix/login"},
+	}, nil)
 	mg.EXPECT().HasRemote("/repo").Return(false, nil)
-	mg.EXPECT().Merge("/repo", "feature/auth").Return(nil)
-
-	cleanupCalled := false
-	cleanup := func(wtPath, branch string) error {
-		cleanupCalled = true
-		return nil
-	}
+	mg.EXPECT().IsWorktreeDirty("/wt/fix").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/fix").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/fix").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/fix", "main").Return(0, nil)
+	mg.EXPECT().CommitsBehind("/wt/fix", "main").Return(2, nil)
+	mg.EXPECT().IsAncestor("/wt/fix", "HEAD", "main").Return(true, nil)
+	mg.EXPECT().HeadSHA("/wt/fix").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/fix", "main", gitops.MergeRunOptions{FF: gitops.FFOnly}).Return(assert.AnError)
 
-	result, err := Merge(mg, log, MergeOptions{
+	results, err := SyncAll(mg, log, SyncOptions{
 		RepoPath:   "/repo",
 		BaseBranch: "main",
-		Branch:     "feature/auth",
-		WtPath:     "/wt/auth",
 		Strategy:   "merge",
-	}, cleanup, nil)
+		FFOnly:     true,
+	})
 
 	require.NoError(t, err)
-	assert.True(t, result.Success)
-	assert.True(t, cleanupCalled)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Failed)
+	assert.False(t, results[0].Success)
+	assert.Contains(t, results[0].FailReason, "fast-forward failed")
+	assert.Contains(t, log.infos, "Sync complete: 0 synced, 0 up-to-date, 0 skipped, 0 conflicts, 1 failed")
 }
 
-func TestMerge_NoCommits(t *testing.T) {
+func TestSyncAll_OnlyBehind(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
-	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
-	mg.EXPECT().HasUnpushedCommits("/wt/auth", "main").Return(false, nil)
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+		{Path: "/wt/docs", Branch: "feature/docs"},
+		{Path: "/wt/fix", Branch: "bugfix/login"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
 
-	result, err := Merge(mg, log, MergeOptions{
+	// auth and docs: up to date — one call each, nothing else
+	mg.EXPECT().AheadBehind("/wt/auth", "main").Return(1, 0, nil)
+	mg.EXPECT().AheadBehind("/wt/docs", "main").Return(0, 0, nil)
+
+	// fix: behind, counts reused for the merge
+	mg.EXPECT().AheadBehind("/wt/fix", "main").Return(0, 2, nil)
+	mg.EXPECT().IsWorktreeDirty("/wt/fix").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/fix").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/fix").Return(false, nil)
+	mg.EXPECT().HeadSHA("/wt/fix").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/fix", "main", gitops.MergeRunOptions{}).Return(nil)
+
+	results, err := SyncAll(mg, log, SyncOptions{
 		RepoPath:   "/repo",
 		BaseBranch: "main",
-		Branch:     "feature/auth",
-		WtPath:     "/wt/auth",
 		Strategy:   "merge",
-	}, nil, nil)
+		OnlyBehind: true,
+	})
 
 	require.NoError(t, err)
-	assert.True(t, result.Success)
+	require.Len(t, results, 3)
+	assert.True(t, results[0].AlreadySynced)
+	assert.True(t, results[1].AlreadySynced)
+	assert.True(t, results[2].Success)
+	assert.Equal(t, 2, results[2].Behind)
+	assert.Contains(t, log.infos, "Skipping 2 up-to-date worktree(s): auth, docs")
+	assert.Contains(t, log.infos, "Sync complete: 1 synced, 2 up-to-date, 0 skipped, 0 conflicts, 0 failed")
 }
 
-func TestMerge_DirtyBlocked(t *testing.T) {
+func TestSyncAll_OnlyBehind_UsesLocalBaseWhenFurtherBehind(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
-	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(true, nil)
-
-	_, err := Merge(mg, log, MergeOptions{
-		RepoPath:   "/repo",
-		BaseBranch: "main",
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().CommitsBehind("/repo", "origin/main").Return(0, nil)
+	mg.EXPECT().Fetch("/repo").Return(nil)
+
+	mg.EXPECT().AheadBehind("/wt/auth", "origin/main").Return(0, 0, nil)
+	mg.EXPECT().AheadBehind("/wt/auth", "main").Return(0, 1, nil)
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Rebase("/wt/auth", "main", gitops.RebaseRunOptions{}).Return(nil)
+
+	results, err := SyncAll(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Strategy:   "rebase",
+		OnlyBehind: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Success)
+}
+
+func TestSyncAll_OnlyBehind_BehindRemoteChecksOnce(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().CommitsBehind("/repo", "origin/main").Return(0, nil)
+	mg.EXPECT().Fetch("/repo").Return(nil)
+
+	// Behind the remote: no AheadBehind against local main just for the filter
+	mg.EXPECT().AheadBehind("/wt/auth", "origin/main").Return(0, 2, nil).Once()
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "origin/main").Return(0, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "origin/main").Return(2, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(2, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/auth", "origin/main", gitops.MergeRunOptions{}).Return(nil)
+
+	results, err := SyncAll(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Strategy:   "merge",
+		OnlyBehind: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, 2, results[0].Behind)
+}
+
+func TestSyncAll_AbortOnConflict(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+		{Path: "/wt/fix", Branch: "bugfix/login"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+
+	// auth: conflicts, merge aborted
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(1, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(2, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre111", nil)
+	mg.EXPECT().Merge("/wt/auth", "main", gitops.MergeRunOptions{}).Return(fmt.Errorf("conflict"))
+	mg.EXPECT().MergeAbort("/wt/auth").Return(nil)
+
+	// fix: merges cleanly after the conflict
+	mg.EXPECT().IsWorktreeDirty("/wt/fix").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/fix").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/fix").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/fix", "main").Return(0, nil)
+	mg.EXPECT().CommitsBehind("/wt/fix", "main").Return(2, nil)
+	mg.EXPECT().HeadSHA("/wt/fix").Return("pre222", nil)
+	mg.EXPECT().Merge("/wt/fix", "main", gitops.MergeRunOptions{}).Return(nil)
+
+	results, err := SyncAll(mg, log, SyncOptions{
+		RepoPath:        "/repo",
+		BaseBranch:      "main",
+		Strategy:        "merge",
+		AbortOnConflict: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Conflict)
+	assert.True(t, results[0].Aborted)
+	assert.False(t, results[0].Success)
+	assert.True(t, results[1].Success)
+	assert.Contains(t, strings.Join(log.warnings, "\n"), "Skipped for conflicts (aborted, left unchanged): feature/auth")
+}
+
+func TestSyncAll_AbortOnConflict_Rebase(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(1, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(2, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre111", nil)
+	mg.EXPECT().Rebase("/wt/auth", "main", gitops.RebaseRunOptions{}).Return(fmt.Errorf("conflict"))
+	mg.EXPECT().RebaseAbort("/wt/auth").Return(nil)
+
+	results, err := SyncAll(mg, log, SyncOptions{
+		RepoPath:        "/repo",
+		BaseBranch:      "main",
+		Strategy:        "rebase",
+		AbortOnConflict: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Conflict)
+	assert.True(t, results[0].Aborted)
+}
+
+func TestSyncAll_AbortOnConflict_AbortFails(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(1, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(2, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre111", nil)
+	mg.EXPECT().Merge("/wt/auth", "main", gitops.MergeRunOptions{}).Return(fmt.Errorf("conflict"))
+	mg.EXPECT().MergeAbort("/wt/auth").Return(fmt.Errorf("no merge to abort"))
+
+	results, err := SyncAll(mg, log, SyncOptions{
+		RepoPath:        "/repo",
+		BaseBranch:      "main",
+		Strategy:        "merge",
+		AbortOnConflict: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Conflict)
+	assert.False(t, results[0].Aborted)
+	assert.Contains(t, strings.Join(log.warnings, "\n"), "could not be aborted")
+}
+
+
+func TestSyncAll_FailFast(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+		{Path: "/wt/fix", Branch: "bugfix/login"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+
+	// auth conflicts; fix must not be touched (the mock fails on any call for it)
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(1, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(2, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre111", nil)
+	mg.EXPECT().Merge("/wt/auth", "main", gitops.MergeRunOptions{}).Return(fmt.Errorf("conflict"))
+
+	results, err := SyncAll(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Strategy:   "merge",
+		FailFast:   true,
+	})
+
+	require.Error(t, err)
+	var stopped *SyncStoppedError
+	require.ErrorAs(t, err, &stopped)
+	assert.Equal(t, "feature/auth", stopped.Branch)
+	assert.Equal(t, "merge conflict", stopped.Reason)
+	assert.Equal(t, 1, stopped.Remaining)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Conflict)
+	assert.Contains(t, strings.Join(log.warnings, "\n"), "Stopped at 'feature/auth' (--fail-fast) — 1 worktree(s) left untouched")
+}
+
+func TestSyncAll_FailFast_StatusCheckError(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+		{Path: "/wt/fix", Branch: "bugfix/login"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, fmt.Errorf("not a git repository"))
+
+	_, err := SyncAll(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Strategy:   "merge",
+		FailFast:   true,
+	})
+
+	var stopped *SyncStoppedError
+	require.ErrorAs(t, err, &stopped)
+	assert.Equal(t, "status check failed", stopped.Reason)
+}
+func TestSyncAll_BaseFor(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+		{Path: "/wt/fix", Branch: "hotfix/login"},
+	}, nil)
+	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().CommitsBehind("/repo", "origin/main").Return(2, nil) // stale local main
+	mg.EXPECT().Fetch("/repo").Return(nil)
+
+	// auth: configured base
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "origin/main").Return(0, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "origin/main").Return(0, nil)
+	mg.EXPECT().CommitsBehind("/wt/auth", "main").Return(0, nil)
+
+	// fix: recorded base, merged from its remote-tracking branch
+	mg.EXPECT().IsWorktreeDirty("/wt/fix").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/wt/fix").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/fix").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/fix", "origin/release").Return(0, nil)
+	mg.EXPECT().CommitsBehind("/wt/fix", "origin/release").Return(3, nil)
+	mg.EXPECT().CommitsBehind("/wt/fix", "release").Return(1, nil)
+	mg.EXPECT().HeadSHA("/wt/fix").Return("pre123", nil)
+	mg.EXPECT().Merge("/wt/fix", "origin/release", gitops.MergeRunOptions{}).Return(nil)
+
+	results, err := SyncAll(mg, log, SyncOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Strategy:   "merge",
+		BaseFor: func(wtPath string) string {
+			if wtPath == "/wt/fix" {
+				return "release"
+			}
+			return ""
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].AlreadySynced)
+	assert.True(t, results[1].Success)
+	assert.Contains(t, log.warnings, "Base 'main' is 2 commit(s) behind origin/main — run 'git -C /repo pull' first")
+}
+
+// --- Merge Tests ---
+
+func TestMerge_LocalMerge(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+
+	cleanupCalled := false
+	cleanup := func(wtPath, branch string) error {
+		cleanupCalled = true
+		return nil
+	}
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+	}, cleanup, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.True(t, cleanupCalled)
+}
+
+// expectMergeThenPushFails sets up a local merge of feature/auth into main
+// whose push of main fails.
+func expectMergeThenPushFails(mg *mocks.MockClient) {
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	mg.EXPECT().Pull("/repo").Return(nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	mg.EXPECT().Push("/repo", "main", false).Return(fmt.Errorf("rejected"))
+}
+
+func TestMerge_PushFailureSkipsCleanup(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	expectMergeThenPushFails(mg)
+
+	cleanupCalled := false
+	cleanup := func(wtPath, branch string) error {
+		cleanupCalled = true
+		return nil
+	}
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+	}, cleanup, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.True(t, result.PushFailed)
+	assert.False(t, cleanupCalled, "cleanup must not run when the push failed")
+	assert.Contains(t, strings.Join(log.warnings, "\n"), "Keeping worktree 'auth' because the push failed")
+}
+
+func TestMerge_PushFailureResetsBase(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	expectMergeThenPushFails(mg)
+	mg.EXPECT().IsWorktreeDirty("/repo").Return(false, nil)
+	mg.EXPECT().ResetHard("/repo", "ORIG_HEAD").Return(nil)
+
+	cleanupCalled := false
+	asked := ""
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:         "/repo",
+		BaseBranch:       "main",
+		Branch:           "feature/auth",
+		WtPath:           "/wt/auth",
+		Strategy:         "merge",
+		ConfirmResetBase: func(base string) bool { asked = base; return true },
+	}, func(wtPath, branch string) error { cleanupCalled = true; return nil }, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the merge was undone")
+	assert.Equal(t, "main", asked)
+	assert.True(t, result.BaseReset)
+	assert.False(t, result.Success)
+	assert.False(t, cleanupCalled)
+}
+
+func TestMerge_PushFailureResetDeclined(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	expectMergeThenPushFails(mg)
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:         "/repo",
+		BaseBranch:       "main",
+		Branch:           "feature/auth",
+		WtPath:           "/wt/auth",
+		Strategy:         "merge",
+		ConfirmResetBase: func(string) bool { return false },
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.PushFailed)
+	assert.False(t, result.BaseReset)
+}
+
+func TestMerge_PushFailureResetRefusedWhenDirty(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	expectMergeThenPushFails(mg)
+	mg.EXPECT().IsWorktreeDirty("/repo").Return(true, nil)
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:         "/repo",
+		BaseBranch:       "main",
+		Branch:           "feature/auth",
+		WtPath:           "/wt/auth",
+		Strategy:         "merge",
+		ConfirmResetBase: func(string) bool { return true },
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.PushFailed)
+	assert.False(t, result.BaseReset)
+}
+
+func TestMerge_NoFF(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{FF: gitops.NoFF}).Return(nil)
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		NoFF:       true,
+	}, func(wtPath, branch string) error { return nil }, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestMerge_Sign(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{FF: gitops.NoFF, Sign: true, SignKey: "ABCD1234"}).Return(nil)
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		NoFF:       true,
+		Sign:       true,
+		SignKey:    "ABCD1234",
+	}, func(wtPath, branch string) error { return nil }, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestMerge_NoPullRebasesOntoLocalBase(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	// No Pull expectation; the rebase targets local main, not origin/main
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Rebase("/wt/auth", "main", gitops.RebaseRunOptions{}).Return(nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	mg.EXPECT().Push("/repo", "main", false).Return(nil)
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "rebase",
+		NoPull:     true,
+		NoCleanup:  true,
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestMerge_Squash(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitSubjects("/repo", "main", "feature/auth").Return([]string{"Add login", "Fix typo"}, nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{
+		Squash:  true,
+		Message: "Squash merge branch 'feature/auth'\n\n* Add login\n* Fix typo",
+	}).Return(nil)
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "squash",
+		NoCleanup:  true,
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestMerge_AmendBaseCherryPicksSingleCommit(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(1, nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("abc1234def5678", nil)
+	mg.EXPECT().CherryPick("/repo", "abc1234def5678", gitops.CherryPickRunOptions{}).Return(nil)
+	// No Merge expected
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		AmendBase:  true,
+		NoCleanup:  true,
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Contains(t, log.successes, "Cherry-picked 'feature/auth' onto 'main'")
+}
+
+func TestMerge_AmendBaseMergesMultipleCommits(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitsAhead("/wt/auth", "main").Return(3, nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	// No CherryPick expected
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		AmendBase:  true,
+		NoCleanup:  true,
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Contains(t, log.infos, "'feature/auth' is 3 commits ahead of 'main' — merging instead of cherry-picking")
+}
+
+func TestMerge_DryRunPreviewsMessage(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "develop").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("develop", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	// No Merge expected in dry-run
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "develop",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		NoCleanup:  true,
+		DryRun:     true,
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Contains(t, log.infos, "Would merge 'feature/auth' into 'develop' with message:")
+	assert.Contains(t, log.infos, "    Merge branch 'feature/auth' into develop")
+}
+
+func TestMerge_DryRunPreviewsSquashMessage(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().CommitSubjects("/repo", "main", "feature/auth").Return([]string{"Add login", "Fix typo"}, nil)
+
+	_, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "squash",
+		NoCleanup:  true,
+		DryRun:     true,
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.Contains(t, log.infos, "Would squash merge 'feature/auth' into 'main' with message:")
+	assert.Contains(t, log.infos, "    Squash merge branch 'feature/auth'")
+	assert.Contains(t, log.infos, "    * Add login")
+	assert.Contains(t, log.infos, "    * Fix typo")
+}
+
+func TestMergeCommitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		base     string
+		subjects []string
+		want     string
+	}{
+		{"merge into main", "merge", "main", nil, "Merge branch 'feature/auth'"},
+		{"merge into master", "merge", "master", nil, "Merge branch 'feature/auth'"},
+		{"merge into other base", "merge", "develop", nil, "Merge branch 'feature/auth' into develop"},
+		{"rebase has no commit", "rebase", "main", nil, ""},
+		{"squash lists subjects", "squash", "main", []string{"one", "two"}, "Squash merge branch 'feature/auth'\n\n* one\n* two"},
+		{"squash without subjects", "squash", "main", nil, "Squash merge branch 'feature/auth'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := mocks.NewMockClient(t)
+			if tt.strategy == "squash" {
+				mg.EXPECT().CommitSubjects("/repo", tt.base, "feature/auth").Return(tt.subjects, nil)
+			}
+			got := MergeCommitMessage(mg, &testLogger{}, MergeOptions{
+				RepoPath:   "/repo",
+				BaseBranch: tt.base,
+				Branch:     "feature/auth",
+				Strategy:   tt.strategy,
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMerge_ContinueFlag_MergeInProgress(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	// No dirty or commit checks when continuing
+	mg.EXPECT().IsMergeInProgress("/repo").Return(true, nil)
+	mg.EXPECT().HasConflicts("/repo").Return(false, nil)
+	mg.EXPECT().MergeContinue("/repo").Return(nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		Continue:   true,
+		NoCleanup:  true,
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestMerge_ContinueFlag_NothingInProgress(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+
+	_, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		Continue:   true,
+	}, nil, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to continue")
+}
+
+func TestMerge_ContinueFlag_PR(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+
+	_, err := Merge(mg, &testLogger{}, MergeOptions{
+		RepoPath: "/repo",
+		Branch:   "feature/auth",
+		WtPath:   "/wt/auth",
+		CreatePR: true,
+		Continue: true,
+	}, nil, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "local merges")
+}
+
+func TestMerge_NoCommits(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(true, nil)
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestMerge_DirtyBlocked(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(true, nil)
+
+	_, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
 		Branch:     "feature/auth",
 		WtPath:     "/wt/auth",
 		Strategy:   "merge",
@@ -441,7 +1571,7 @@ func TestMerge_WrongBranch(t *testing.T) {
 	log := &testLogger{}
 
 	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
-	mg.EXPECT().HasUnpushedCommits("/wt/auth", "main").Return(true, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
 	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
 	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
 	mg.EXPECT().CurrentBranch("/repo").Return("develop", nil)
@@ -463,13 +1593,14 @@ func TestMerge_RebaseThenFF(t *testing.T) {
 	log := &testLogger{}
 
 	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
-	mg.EXPECT().HasUnpushedCommits("/wt/auth", "main").Return(true, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
 	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
 	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
 	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
 	mg.EXPECT().HasRemote("/repo").Return(false, nil)
-	mg.EXPECT().Rebase("/wt/auth", "main").Return(nil)
-	mg.EXPECT().Merge("/repo", "feature/auth").Return(nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("pre123", nil)
+	mg.EXPECT().Rebase("/wt/auth", "main", gitops.RebaseRunOptions{}).Return(nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{}).Return(nil)
 
 	result, err := Merge(mg, log, MergeOptions{
 		RepoPath:   "/repo",
@@ -481,98 +1612,334 @@ func TestMerge_RebaseThenFF(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.True(t, result.Success)
+	assert.Equal(t, "pre123", result.PreRebaseHEAD)
+}
+
+func TestMerge_PR_PushNotOnRemote(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().Push("/wt/auth", "feature/auth", true).Return(nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("abc1234def", nil)
+	mg.EXPECT().RemoteBranchSHA("/wt/auth", "origin", "feature/auth").Return("0ld5ha0000", nil)
+
+	prCalled := false
+	prCreate := func(args []string) (string, error) {
+		prCalled = true
+		return "", nil
+	}
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		CreatePR:   true,
+	}, nil, prCreate)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "origin/feature/auth is at 0ld5ha0 but HEAD is at abc1234")
+	assert.False(t, prCalled, "gh pr create must not run when the push didn't land")
+	assert.False(t, result.Success)
+}
+
+func TestMerge_PR(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().Push("/wt/auth", "feature/auth", true).Return(nil)
+	mg.EXPECT().HeadSHA("/wt/auth").Return("abc1234def", nil)
+	mg.EXPECT().RemoteBranchSHA("/wt/auth", "origin", "feature/auth").Return("abc1234def", nil)
+
+	var capturedArgs []string
+	prCreate := func(args []string) (string, error) {
+		capturedArgs = args
+		return "https://github.com/repo/pull/42", nil
+	}
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		CreatePR:   true,
+		PRTitle:    "Add auth",
+	}, nil, prCreate)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.True(t, result.PRCreated)
+	assert.Equal(t, "https://github.com/repo/pull/42", result.PRURL)
+	assert.Contains(t, capturedArgs, "--title")
+	assert.Contains(t, capturedArgs, "Add auth")
+}
+
+func TestMerge_PR_BodyArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts MergeOptions
+		want []string
+	}{
+		{"fill", MergeOptions{}, []string{"--fill"}},
+		{"body", MergeOptions{PRBody: "text"}, []string{"--body", "text", "--fill"}},
+		{"body file", MergeOptions{PRBodyFile: "/tmp/body.md"}, []string{"--body-file", "/tmp/body.md", "--fill"}},
+		{"body wins over file", MergeOptions{PRBody: "text", PRBodyFile: "/tmp/body.md"}, []string{"--body", "text", "--fill"}},
+		{"title and body file", MergeOptions{PRTitle: "T", PRBodyFile: "/tmp/body.md"}, []string{"--title", "T", "--body-file", "/tmp/body.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := mocks.NewMockClient(t)
+			mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+			mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+			mg.EXPECT().Push("/wt/auth", "feature/auth", true).Return(nil)
+			mg.EXPECT().HeadSHA("/wt/auth").Return("abc1234def", nil)
+			mg.EXPECT().RemoteBranchSHA("/wt/auth", "origin", "feature/auth").Return("abc1234def", nil)
+
+			var capturedArgs []string
+			opts := tt.opts
+			opts.RepoPath, opts.BaseBranch, opts.Branch, opts.WtPath, opts.CreatePR = "/repo", "main", "feature/auth", "/wt/auth", true
+			_, err := Merge(mg, &testLogger{}, opts, nil, func(args []string) (string, error) {
+				capturedArgs = args
+				return "https://github.com/repo/pull/42", nil
+			})
+
+			require.NoError(t, err)
+			want := append([]string{"pr", "create", "--base", "main", "--head", "feature/auth"}, tt.want...)
+			assert.Equal(t, want, capturedArgs)
+		})
+	}
+}
+
+func TestMerge_PRDryRun(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	// Should NOT call Push or PRCreate in dry-run
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		CreatePR:   true,
+		DryRun:     true,
+	}, nil, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestMerge_NoCleanup(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().Merge("/repo", "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+
+	cleanupCalled := false
+	cleanup := func(wtPath, branch string) error {
+		cleanupCalled = true
+		return nil
+	}
+
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		NoCleanup:  true,
+	}, cleanup, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.False(t, cleanupCalled)
+}
+
+
+// expectIsolatedStart sets up an isolated merge of feature/auth into main, with
+// the main repo on develop, and returns a func reporting the temp worktree path.
+func expectIsolatedStart(mg *mocks.MockClient) func() string {
+	var tmp string
+	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
+	mg.EXPECT().CurrentBranch("/repo").Return("develop", nil)
+	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().WorktreeAdd("/repo", mock.Anything, "main", "", false, false).
+		RunAndReturn(func(_, wtPath, _, _ string, _, _ bool) error { tmp = wtPath; return nil })
+	mg.EXPECT().WorktreeRemove("/repo", mock.Anything, true).
+		RunAndReturn(func(_, wtPath string, _ bool) error {
+			if wtPath != tmp {
+				return fmt.Errorf("removed %s, not the temp worktree %s", wtPath, tmp)
+			}
+			return nil
+		})
+	return func() string { return tmp }
+}
+
+// atTemp matches the temporary worktree path once it's known.
+func atTemp(tmp func() string) interface{} {
+	return mock.MatchedBy(func(p string) bool { return p != "" && p == tmp() })
+}
+
+func TestMerge_Isolated(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	tmp := expectIsolatedStart(mg)
+	// Everything that changes base happens in the temp worktree; the mock
+	// fails on any Checkout, Pull, Merge or Push in /repo
+	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	mg.EXPECT().Pull(atTemp(tmp)).Return(nil)
+	mg.EXPECT().Merge(atTemp(tmp), "feature/auth", gitops.MergeRunOptions{}).Return(nil)
+	mg.EXPECT().Push(atTemp(tmp), "main", false).Return(nil)
+
+	cleanupCalled := false
+	result, err := Merge(mg, log, MergeOptions{
+		RepoPath:   "/repo",
+		BaseBranch: "main",
+		Branch:     "feature/auth",
+		WtPath:     "/wt/auth",
+		Strategy:   "merge",
+		Isolated:   true,
+	}, func(wtPath, branch string) error { cleanupCalled = true; return nil }, nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.True(t, cleanupCalled)
+	assert.NoDirExists(t, tmp(), "temp worktree dir should be removed")
 }
 
-func TestMerge_PR(t *testing.T) {
+func TestMerge_IsolatedConflictDiscardsTempWorktree(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
+	tmp := expectIsolatedStart(mg)
+	mg.EXPECT().HasRemote("/repo").Return(false, nil)
+	mg.EXPECT().Merge(atTemp(tmp), "feature/auth", mock.Anything).Return(fmt.Errorf("CONFLICT"))
 
-	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
-	mg.EXPECT().HasUnpushedCommits("/wt/auth", "main").Return(true, nil)
-	mg.EXPECT().Push("/wt/auth", "feature/auth", true).Return(nil)
-
-	var capturedArgs []string
-	prCreate := func(args []string) (string, error) {
-		capturedArgs = args
-		return "https://github.com/repo/pull/42", nil
-	}
-
+	cleanupCalled := false
 	result, err := Merge(mg, log, MergeOptions{
 		RepoPath:   "/repo",
 		BaseBranch: "main",
 		Branch:     "feature/auth",
 		WtPath:     "/wt/auth",
-		CreatePR:   true,
-		PRTitle:    "Add auth",
-	}, nil, prCreate)
+		Strategy:   "merge",
+		Isolated:   true,
+	}, func(wtPath, branch string) error { cleanupCalled = true; return nil }, nil)
 
-	require.NoError(t, err)
-	assert.True(t, result.Success)
-	assert.True(t, result.PRCreated)
-	assert.Equal(t, "https://github.com/repo/pull/42", result.PRURL)
-	assert.Contains(t, capturedArgs, "--title")
-	assert.Contains(t, capturedArgs, "Add auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "merge conflict")
+	assert.False(t, result.Success)
+	assert.False(t, cleanupCalled)
+	assert.NoDirExists(t, tmp())
+	assert.Contains(t, strings.Join(log.warnings, "\n"), "'main' is unchanged")
 }
 
-func TestMerge_PRDryRun(t *testing.T) {
+func TestMerge_IsolatedPushFailureResetsInTempWorktree(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
-
-	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
-	mg.EXPECT().HasUnpushedCommits("/wt/auth", "main").Return(true, nil)
-	// Should NOT call Push or PRCreate in dry-run
+	tmp := expectIsolatedStart(mg)
+	mg.EXPECT().HasRemote("/repo").Return(true, nil)
+	mg.EXPECT().Pull(atTemp(tmp)).Return(nil)
+	mg.EXPECT().Merge(atTemp(tmp), "feature/auth", mock.Anything).Return(nil)
+	mg.EXPECT().Push(atTemp(tmp), "main", false).Return(fmt.Errorf("rejected"))
+	mg.EXPECT().IsWorktreeDirty(atTemp(tmp)).Return(false, nil)
+	mg.EXPECT().ResetHard(atTemp(tmp), "ORIG_HEAD").Return(nil)
 
 	result, err := Merge(mg, log, MergeOptions{
-		RepoPath:   "/repo",
-		BaseBranch: "main",
-		Branch:     "feature/auth",
-		WtPath:     "/wt/auth",
-		CreatePR:   true,
-		DryRun:     true,
+		RepoPath:         "/repo",
+		BaseBranch:       "main",
+		Branch:           "feature/auth",
+		WtPath:           "/wt/auth",
+		Strategy:         "merge",
+		Isolated:         true,
+		ConfirmResetBase: func(string) bool { return true },
 	}, nil, nil)
 
-	require.NoError(t, err)
-	assert.True(t, result.Success)
+	require.Error(t, err)
+	assert.True(t, result.BaseReset)
+	assert.NoDirExists(t, tmp())
 }
 
-func TestMerge_NoCleanup(t *testing.T) {
+func TestMerge_IsolatedRefusesWhenBaseCheckedOut(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
 	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
-	mg.EXPECT().HasUnpushedCommits("/wt/auth", "main").Return(true, nil)
-	mg.EXPECT().IsMergeInProgress("/repo").Return(false, nil)
-	mg.EXPECT().IsRebaseInProgress("/wt/auth").Return(false, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
 	mg.EXPECT().CurrentBranch("/repo").Return("main", nil)
-	mg.EXPECT().HasRemote("/repo").Return(false, nil)
-	mg.EXPECT().Merge("/repo", "feature/auth").Return(nil)
-
-	cleanupCalled := false
-	cleanup := func(wtPath, branch string) error {
-		cleanupCalled = true
-		return nil
-	}
 
-	result, err := Merge(mg, log, MergeOptions{
+	_, err := Merge(mg, log, MergeOptions{
 		RepoPath:   "/repo",
 		BaseBranch: "main",
 		Branch:     "feature/auth",
 		WtPath:     "/wt/auth",
 		Strategy:   "merge",
-		NoCleanup:  true,
-	}, cleanup, nil)
+		Isolated:   true,
+	}, nil, nil)
 
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "merge without --isolated")
+}
+
+// TestMerge_Isolated_Integration runs an isolated merge against real git and
+// checks the main repo stays on its branch with its checkout untouched.
+func TestMerge_Isolated_Integration(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	repo := filepath.Join(dir, "repo")
+	wt := filepath.Join(dir, "auth")
+	runGit := func(args ...string) string {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, "git %v: %s", args, out)
+		return strings.TrimSpace(string(out))
+	}
+	runGit("init", repo)
+	runGit("-C", repo, "config", "user.email", "test@test.com")
+	runGit("-C", repo, "config", "user.name", "Test")
+	runGit("-C", repo, "commit", "--allow-empty", "-m", "init")
+	base := runGit("-C", repo, "branch", "--show-current")
+	runGit("-C", repo, "checkout", "-b", "develop")
+	runGit("-C", repo, "worktree", "add", "-b", "feature/auth", wt, base)
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "auth.go"), []byte("package auth\n"), 0o644))
+	runGit("-C", wt, "add", ".")
+	runGit("-C", wt, "commit", "-m", "Add auth")
+	developHead := runGit("-C", repo, "rev-parse", "HEAD")
+
+	git := gitops.NewClient()
+	result, err := Merge(git, &testLogger{}, MergeOptions{
+		RepoPath:   repo,
+		BaseBranch: base,
+		Branch:     "feature/auth",
+		WtPath:     wt,
+		Strategy:   "merge",
+		NoCleanup:  true,
+		Isolated:   true,
+	}, nil, nil)
 	require.NoError(t, err)
 	assert.True(t, result.Success)
-	assert.False(t, cleanupCalled)
-}
 
+	assert.Equal(t, "develop", runGit("-C", repo, "branch", "--show-current"), "main repo's branch must not change")
+	assert.Equal(t, developHead, runGit("-C", repo, "rev-parse", "HEAD"))
+	assert.Equal(t, runGit("-C", repo, "rev-parse", "feature/auth"), runGit("-C", repo, "rev-parse", base), "base should fast-forward to the branch")
+	assert.Len(t, strings.Split(runGit("-C", repo, "worktree", "list", "--porcelain"), "\n\n"), 2, "temp worktree should be removed")
+}
 func TestMerge_ContinueMerge(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
 	mg.EXPECT().IsWorktreeDirty("/wt/auth").Return(false, nil)
-	mg.EXPECT().HasUnpushedCommits("/wt/auth", "main").Return(true, nil)
+	mg.EXPECT().IsAncestor("/repo", "feature/auth", "main").Return(false, nil)
 	mg.EXPECT().IsMergeInProgress("/repo").Return(true, nil)
 	mg.EXPECT().HasConflicts("/repo").Return(false, nil)
 	mg.EXPECT().MergeContinue("/repo").Return(nil)
@@ -671,15 +2038,160 @@ func TestDeleteAll_NoWorktrees(t *testing.T) {
 	assert.Equal(t, 0, count)
 }
 
+func TestDeleteAll_Except(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	dir := t.TempDir()
+
+	auth := filepath.Join(dir, "auth")
+	api := filepath.Join(dir, "api")
+	for _, p := range []string{auth, api} {
+		require.NoError(t, os.MkdirAll(p, 0755))
+	}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: auth, Branch: "feature/auth"},
+		{Path: api, Branch: "feature/api"},
+	}, nil)
+	mg.EXPECT().WorktreePrune("/repo").Return(nil)
+
+	var cleaned []string
+	cleanup := func(wtPath, branch string) error {
+		cleaned = append(cleaned, branch)
+		return nil
+	}
+
+	count, err := DeleteAll(mg, log, DeleteOptions{
+		RepoPath: "/repo",
+		Force:    true,
+		Except:   []string{auth},
+	}, nil, cleanup)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"feature/api"}, cleaned)
+}
+
+func TestPlanDeleteAll(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	dir := t.TempDir()
+
+	clean := filepath.Join(dir, "clean")
+	dirty := filepath.Join(dir, "dirty")
+	unmerged := filepath.Join(dir, "unmerged")
+	for _, p := range []string{clean, dirty, unmerged} {
+		require.NoError(t, os.MkdirAll(p, 0755))
+	}
+	missing := filepath.Join(dir, "missing")
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: clean, Branch: "feature/clean"},
+		{Path: dirty, Branch: "feature/dirty"},
+		{Path: unmerged, Branch: "feature/unmerged"},
+		{Path: missing, Branch: "feature/missing"},
+	}, nil)
+	mg.EXPECT().IsWorktreeDirty(clean).Return(false, nil)
+	mg.EXPECT().HasUnpushedCommits(clean, "main").Return(false, nil)
+	mg.EXPECT().CommitsAhead(clean, "main").Return(0, nil)
+	mg.EXPECT().IsWorktreeDirty(dirty).Return(true, nil)
+	mg.EXPECT().HasUnpushedCommits(dirty, "main").Return(false, nil)
+	mg.EXPECT().CommitsAhead(dirty, "main").Return(0, nil)
+	mg.EXPECT().IsWorktreeDirty(unmerged).Return(false, nil)
+	mg.EXPECT().HasUnpushedCommits(unmerged, "main").Return(false, nil)
+	mg.EXPECT().CommitsAhead(unmerged, "main").Return(2, nil)
+	// No WorktreeRemove or WorktreePrune
+
+	plans, err := PlanDeleteAll(mg, log, DeleteOptions{
+		RepoPath:     "/repo",
+		BaseBranch:   "main",
+		DeleteBranch: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, plans, 4)
+	assert.Equal(t, DeleteActionDelete, plans[0].Action)
+	assert.True(t, plans[1].Dirty)
+	assert.Equal(t, DeleteActionConfirm, plans[1].Action)
+	assert.Equal(t, 2, plans[2].Unmerged)
+	assert.Equal(t, DeleteActionConfirm, plans[2].Action)
+	assert.True(t, plans[3].Missing)
+	assert.Equal(t, DeleteActionSkip, plans[3].Action)
+}
+
+func TestPlanDeleteAll_ForceDeletesUnsafe(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	wtPath := t.TempDir()
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().IsWorktreeDirty(wtPath).Return(true, nil)
+	mg.EXPECT().HasUnpushedCommits(wtPath, "main").Return(true, nil)
+
+	plans, err := PlanDeleteAll(mg, log, DeleteOptions{RepoPath: "/repo", BaseBranch: "main", Force: true})
+
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	assert.Equal(t, DeleteActionDelete, plans[0].Action)
+}
+
+func TestPlanDeleteAll_ExceptIsKept(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	wtPath := t.TempDir()
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth"},
+	}, nil)
+	// No safety checks for kept worktrees
+
+	plans, err := PlanDeleteAll(mg, log, DeleteOptions{RepoPath: "/repo", BaseBranch: "main", Except: []string{wtPath}})
+
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	assert.Equal(t, DeleteActionKeep, plans[0].Action)
+}
+
+func TestPlanDeleteAll_UsesRecordedBase(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	wtPath := t.TempDir()
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().IsWorktreeDirty(wtPath).Return(false, nil)
+	mg.EXPECT().HasUnpushedCommits(wtPath, "develop").Return(false, nil)
+	mg.EXPECT().CommitsAhead(wtPath, "develop").Return(0, nil)
+
+	plans, err := PlanDeleteAll(mg, log, DeleteOptions{
+		RepoPath:     "/repo",
+		BaseBranch:   "main",
+		BaseFor:      func(string) string { return "develop" },
+		DeleteBranch: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	assert.Equal(t, DeleteActionDelete, plans[0].Action)
+}
+
 // --- Prune Tests ---
 
 func TestPrune_Clean(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
-	statePrune := func() (int, error) { return 0, nil }
+	statePrune := func(skip func(string) bool, dryRun bool) ([]string, error) { return nil, nil }
 	mg.EXPECT().WorktreesDir("/repo").Return("/repo.worktrees", nil)
-	trustPrune := func(dir string) (int, error) { return 0, nil }
+	trustPrune := func(dir string, dryRun bool) ([]string, error) { return nil, nil }
 	mg.EXPECT().WorktreePrune("/repo").Return(nil)
 
 	result, err := Prune(mg, log, PruneOptions{RepoPath: "/repo"}, statePrune, trustPrune)
@@ -694,11 +2206,13 @@ func TestPrune_WithStaleEntries(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
-	statePrune := func() (int, error) { return 3, nil }
+	statePrune := func(skip func(string) bool, dryRun bool) ([]string, error) {
+		return []string{"/repo.worktrees/a", "/repo.worktrees/b", "/repo.worktrees/c"}, nil
+	}
 	mg.EXPECT().WorktreesDir("/repo").Return("/repo.worktrees", nil)
-	trustPrune := func(dir string) (int, error) {
+	trustPrune := func(dir string, dryRun bool) ([]string, error) {
 		assert.Equal(t, "/repo.worktrees", dir)
-		return 1, nil
+		return []string{"/repo.worktrees/a"}, nil
 	}
 	mg.EXPECT().WorktreePrune("/repo").Return(nil)
 
@@ -707,13 +2221,15 @@ func TestPrune_WithStaleEntries(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 3, result.StatePruned)
 	assert.Equal(t, 1, result.TrustPruned)
+	assert.Equal(t, []string{"/repo.worktrees/a", "/repo.worktrees/b", "/repo.worktrees/c"}, result.StatePaths)
+	assert.Equal(t, []string{"/repo.worktrees/a"}, result.TrustPaths)
 }
 
 func TestPrune_NilTrustPruner(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
-	statePrune := func() (int, error) { return 0, nil }
+	statePrune := func(skip func(string) bool, dryRun bool) ([]string, error) { return nil, nil }
 	mg.EXPECT().WorktreePrune("/repo").Return(nil)
 
 	result, err := Prune(mg, log, PruneOptions{RepoPath: "/repo"}, statePrune, nil)
@@ -722,19 +2238,44 @@ func TestPrune_NilTrustPruner(t *testing.T) {
 	assert.Equal(t, 0, result.TrustPruned)
 }
 
+func TestPrune_NoGit(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	statePrune := func(skip func(string) bool, dryRun bool) ([]string, error) { return []string{"/repo.worktrees/gone"}, nil }
+	// No WorktreePrune expectation: NoGit must skip it
+
+	result, err := Prune(mg, log, PruneOptions{RepoPath: "/repo", NoGit: true}, statePrune, nil)
+
+	require.NoError(t, err)
+	assert.False(t, result.GitPruned)
+	assert.Equal(t, 1, result.StatePruned)
+}
+
 func TestPrune_DryRun(t *testing.T) {
 	mg := mocks.NewMockClient(t)
 	log := &testLogger{}
 
-	statePrune := func() (int, error) { return 0, nil }
+	// The pruners are told it's a dry run and report what they would prune
+	statePrune := func(skip func(string) bool, dryRun bool) ([]string, error) {
+		assert.True(t, dryRun)
+		return []string{"/repo.worktrees/gone"}, nil
+	}
 	mg.EXPECT().WorktreesDir("/repo").Return("/repo.worktrees", nil)
-	trustPrune := func(dir string) (int, error) { return 0, nil }
+	trustPrune := func(dir string, dryRun bool) ([]string, error) {
+		assert.True(t, dryRun)
+		return []string{"/repo.worktrees/gone"}, nil
+	}
 	// Should NOT call WorktreePrune in dry-run
 
 	result, err := Prune(mg, log, PruneOptions{RepoPath: "/repo", DryRun: true}, statePrune, trustPrune)
 
 	require.NoError(t, err)
 	assert.False(t, result.GitPruned)
+	assert.Equal(t, []string{"/repo.worktrees/gone"}, result.StatePaths)
+	assert.Equal(t, []string{"/repo.worktrees/gone"}, result.TrustPaths)
+	assert.Contains(t, log.infos, "Would prune 1 stale state entries")
+	assert.NotContains(t, log.successes, "Prune complete")
 }
 
 // --- Discover Tests ---
@@ -838,6 +2379,203 @@ func TestDiscover_AdoptDryRun(t *testing.T) {
 	assert.Equal(t, 0, result.Adopted)
 }
 
+func TestDiscover_IgnoresWtignorePatterns(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().RepoName("/repo").Return("myrepo", nil)
+	mg.EXPECT().WorktreesDir("/repo").Return("/repo.worktrees", nil)
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/repo/.claude/worktrees/glittery-pebble", Branch: "worktree-glittery-pebble"},
+		{Path: "/external/fix", Branch: "bugfix/login"},
+	}, nil)
+
+	stateCheck := func(path string) (bool, error) {
+		assert.NotContains(t, path, ".claude", "ignored paths should not reach the state check")
+		return false, nil
+	}
+
+	ignore, err := ParseIgnore("/repo", strings.NewReader("# agent scratch dirs\n.claude/worktrees/*\n"))
+	require.NoError(t, err)
+
+	result, err := Discover(mg, log, DiscoverOptions{RepoPath: "/repo", Ignore: ignore}, stateCheck, nil)
+
+	require.NoError(t, err)
+	require.Len(t, result.Unmanaged, 1)
+	assert.Equal(t, "bugfix/login", result.Unmanaged[0].Branch)
+	assert.Equal(t, 1, result.Ignored)
+	assert.Contains(t, log.infos, "Skipped 1 worktrees matched by .wtignore")
+}
+
+// writeGitFile makes dir look like a linked worktree whose .git file points at gitdir.
+func writeGitFile(t *testing.T, dir, gitdir string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: "+gitdir+"\n"), 0644))
+}
+
+func TestRepair_AllOK(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	dir := t.TempDir()
+
+	adminDir := filepath.Join(dir, "repo", ".git", "worktrees", "auth")
+	require.NoError(t, os.MkdirAll(adminDir, 0755))
+	writeGitFile(t, filepath.Join(dir, "wt", "auth"), adminDir)
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: filepath.Join(dir, "wt", "auth"), Branch: "feature/auth"},
+	}, nil)
+
+	result, err := Repair(mg, log, RepairOptions{RepoPath: "/repo"})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Broken)
+	assert.Contains(t, log.successes, "All worktree links OK")
+}
+
+func TestRepair_ReportsDuplicateBranches(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/auth", Branch: "feature/auth"},
+		{Path: "/wt/auth-2", Branch: "feature/auth"},
+	}, nil)
+
+	result, err := Repair(mg, log, RepairOptions{RepoPath: "/repo"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []gitops.BranchDuplicate{{Branch: "feature/auth", Paths: []string{"/wt/auth", "/wt/auth-2"}}}, result.Duplicates)
+	assert.Contains(t, log.warnings, "Branch 'feature/auth' is checked out in 2 worktrees: /wt/auth, /wt/auth-2")
+}
+
+func TestRepair_FixesBrokenLinks(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	dir := t.TempDir()
+
+	wtPath := filepath.Join(dir, "wt", "auth")
+	writeGitFile(t, wtPath, filepath.Join(dir, "old-repo", ".git", "worktrees", "auth"))
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().WorktreeRepair("/repo").Run(func(repoPath string) {
+		adminDir := filepath.Join(dir, "repo", ".git", "worktrees", "auth")
+		require.NoError(t, os.MkdirAll(adminDir, 0755))
+		writeGitFile(t, wtPath, adminDir)
+	}).Return(nil)
+
+	result, err := Repair(mg, log, RepairOptions{RepoPath: "/repo"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{wtPath}, result.Broken)
+	assert.Equal(t, 1, result.Repaired)
+	assert.Contains(t, log.successes, "Repaired 1 worktree(s)")
+}
+
+func TestRepair_DryRun(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	dir := t.TempDir()
+
+	wtPath := filepath.Join(dir, "wt", "auth")
+	writeGitFile(t, wtPath, filepath.Join(dir, "old-repo", ".git", "worktrees", "auth"))
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth"},
+	}, nil)
+	// Should NOT call WorktreeRepair
+
+	result, err := Repair(mg, log, RepairOptions{RepoPath: "/repo", DryRun: true})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Broken, 1)
+	assert.Contains(t, log.infos, "Would run git worktree repair")
+}
+
+func TestRepair_StillBroken(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+	dir := t.TempDir()
+
+	wtPath := filepath.Join(dir, "wt", "auth")
+	writeGitFile(t, wtPath, filepath.Join(dir, "old-repo", ".git", "worktrees", "auth"))
+
+	mg.EXPECT().WorktreeList("/repo").Return([]gitops.WorktreeInfo{
+		{Path: "/repo", Branch: "main"},
+		{Path: wtPath, Branch: "feature/auth"},
+	}, nil)
+	mg.EXPECT().WorktreeRepair("/repo").Return(nil)
+
+	result, err := Repair(mg, log, RepairOptions{RepoPath: "/repo"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not be repaired")
+	assert.Equal(t, []string{wtPath}, result.StillBroken)
+}
+
+func TestPrune_SkipsIgnoredState(t *testing.T) {
+	mg := mocks.NewMockClient(t)
+	log := &testLogger{}
+
+	ignore, err := ParseIgnore("/repo", strings.NewReader(".claude/worktrees/*\n"))
+	require.NoError(t, err)
+
+	statePrune := func(skip func(string) bool, dryRun bool) ([]string, error) {
+		assert.True(t, skip("/repo/.claude/worktrees/glittery-pebble"))
+		assert.False(t, skip("/repo.worktrees/auth"))
+		return nil, nil
+	}
+	mg.EXPECT().WorktreePrune("/repo").Return(nil)
+
+	_, err = Prune(mg, log, PruneOptions{RepoPath: "/repo", Ignore: ignore}, statePrune, nil)
+	require.NoError(t, err)
+}
+
+// --- IgnoreList ---
+
+func TestIgnoreList_Match(t *testing.T) {
+	ignore, err := ParseIgnore("/repo", strings.NewReader(`
+# comment
+.claude/worktrees/*
+scratch/
+/tmp/agents/*
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/repo/.claude/worktrees/glittery-pebble", true},
+		{"/repo/.claude/worktrees/glittery-pebble/sub", true}, // beneath a match
+		{"/repo/.claude/other", false},
+		{"/repo/scratch", true},
+		{"/tmp/agents/a1", true}, // absolute pattern
+		{"/repo.worktrees/auth", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ignore.Match(tt.path), tt.path)
+	}
+
+	// nil and empty lists ignore nothing
+	var none *IgnoreList
+	assert.False(t, none.Match("/repo/.claude/worktrees/x"))
+}
+
+func TestLoadIgnore_MissingFile(t *testing.T) {
+	ignore, err := LoadIgnore(t.TempDir())
+	require.NoError(t, err)
+	assert.False(t, ignore.Match("/anything"))
+}
+
 // --- classifySource ---
 
 func TestClassifySource(t *testing.T) {
@@ -4,38 +4,45 @@ import "github.com/joescharf/wt/pkg/gitops"
 
 // Prune cleans up stale state, trust entries, and git worktree tracking.
 // statePrune and trustPrune are callback functions for pruning state and trust;
-// trustPrune may be nil if trust management is not configured.
+// either may be nil to skip that cleanup (trustPrune is nil when trust
+// management is not configured), and opts.NoGit skips git worktree prune.
+// State entries for paths matched by opts.Ignore are never pruned. With
+// opts.DryRun nothing is changed, and the result lists what would be pruned.
 func Prune(git gitops.Client, log Logger, opts PruneOptions, statePrune StatePruner, trustPrune TrustPruner) (*PruneResult, error) {
 	result := &PruneResult{}
 
 	// Prune stale state entries
 	if statePrune != nil {
-		pruned, err := statePrune()
+		paths, err := statePrune(opts.Ignore.Match, opts.DryRun)
 		if err != nil {
 			log.Warning("Failed to prune state: %v", err)
 		}
-		if pruned > 0 {
-			log.Info("Pruned %d stale state entries", pruned)
+		if len(paths) > 0 {
+			log.Info("%s %d stale state entries", prunedVerb(opts.DryRun), len(paths))
 		}
-		result.StatePruned = pruned
+		result.StatePruned = len(paths)
+		result.StatePaths = paths
 	}
 
 	// Prune stale trust entries
 	if trustPrune != nil {
 		wtDir, err := git.WorktreesDir(opts.RepoPath)
 		if err == nil {
-			pruned, err := trustPrune(wtDir)
+			paths, err := trustPrune(wtDir, opts.DryRun)
 			if err != nil {
 				log.Warning("Failed to prune trust entries: %v", err)
-			} else if pruned > 0 {
-				log.Info("Pruned %d stale trust entries", pruned)
+			} else if len(paths) > 0 {
+				log.Info("%s %d stale trust entries", prunedVerb(opts.DryRun), len(paths))
 			}
-			result.TrustPruned = pruned
+			result.TrustPruned = len(paths)
+			result.TrustPaths = paths
 		}
 	}
 
 	// Run git worktree prune
-	if opts.DryRun {
+	if opts.NoGit {
+		log.Verbose("Skipping git worktree prune")
+	} else if opts.DryRun {
 		log.Info("Would run git worktree prune")
 	} else {
 		if err := git.WorktreePrune(opts.RepoPath); err != nil {
@@ -47,11 +54,20 @@ func Prune(git gitops.Client, log Logger, opts PruneOptions, statePrune StatePru
 	}
 
 	totalPruned := result.StatePruned + result.TrustPruned
-	if totalPruned == 0 {
+	switch {
+	case totalPruned == 0:
 		log.Success("Everything clean, nothing to prune")
-	} else {
+	case !opts.DryRun:
 		log.Success("Prune complete")
 	}
 
 	return result, nil
 }
+
+// prunedVerb is how prune's log lines start, depending on whether it's a dry run.
+func prunedVerb(dryRun bool) string {
+	if dryRun {
+		return "Would prune"
+	}
+	return "Pruned"
+}
@@ -0,0 +1,73 @@
+package ops
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/joescharf/wt/pkg/gitops"
+)
+
+// Repair finds worktrees whose .git file points at a gitdir that no longer
+// exists (typically because the main repo was moved) and runs git worktree
+// repair to rewrite the links.
+func Repair(git gitops.Client, log Logger, opts RepairOptions) (*RepairResult, error) {
+	worktrees, err := git.WorktreeList(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RepairResult{Duplicates: WarnDuplicateBranches(log, worktrees)}
+	for _, wt := range worktrees {
+		if wt.Path == opts.RepoPath {
+			continue
+		}
+		if gitops.GitdirBroken(wt.Path) {
+			log.Warning("Broken gitdir link: '%s' (%s)", filepath.Base(wt.Path), wt.Branch)
+			result.Broken = append(result.Broken, wt.Path)
+		}
+	}
+
+	if len(result.Broken) == 0 {
+		log.Success("All worktree links OK")
+		return result, nil
+	}
+
+	if opts.DryRun {
+		log.Info("Would run git worktree repair")
+		return result, nil
+	}
+
+	if err := runStep(log, "Repairing", func() error { return git.WorktreeRepair(opts.RepoPath) }); err != nil {
+		return result, err
+	}
+
+	for _, path := range result.Broken {
+		if gitops.GitdirBroken(path) {
+			log.Warning("Still broken: %s", path)
+			result.StillBroken = append(result.StillBroken, path)
+		} else {
+			result.Repaired++
+		}
+	}
+
+	if len(result.StillBroken) > 0 {
+		return result, fmt.Errorf("%d worktree(s) could not be repaired — if they were moved too, run 'git worktree repair <path>...' from the main repo", len(result.StillBroken))
+	}
+	log.Success("Repaired %d worktree(s)", result.Repaired)
+	return result, nil
+}
+
+// WarnDuplicateBranches warns about each branch that more than one of
+// worktrees has checked out, listing the paths, and returns them. Commands
+// that resolve a worktree by branch can pick any of those paths.
+func WarnDuplicateBranches(log Logger, worktrees []gitops.WorktreeInfo) []gitops.BranchDuplicate {
+	dups := gitops.DuplicateBranches(worktrees)
+	for _, d := range dups {
+		log.Warning("Branch '%s' is checked out in %d worktrees: %s", d.Branch, len(d.Paths), strings.Join(d.Paths, ", "))
+	}
+	if len(dups) > 0 {
+		log.Info("wt may pick either when given the branch; remove the extra worktree or detach it with 'git -C <path> switch --detach'")
+	}
+	return dups
+}
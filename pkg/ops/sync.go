@@ -3,6 +3,7 @@ package ops
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/joescharf/wt/pkg/gitops"
 )
@@ -12,12 +13,13 @@ import (
 func Sync(git gitops.Client, log Logger, opts SyncOptions) (*SyncResult, error) {
 	result := &SyncResult{
 		Branch:   opts.Branch,
+		WtPath:   opts.WtPath,
 		Strategy: opts.Strategy,
 	}
 	dirname := filepath.Base(opts.WtPath)
 
-	// Safety check: dirty worktree
-	if !opts.Force {
+	// Safety check: dirty worktree (resolved conflicts are expected to be staged when continuing)
+	if !opts.Force && !opts.Continue {
 		dirty, err := git.IsWorktreeDirty(opts.WtPath)
 		if err != nil {
 			log.Warning("Could not check worktree status: %v", err)
@@ -44,6 +46,10 @@ func Sync(git gitops.Client, log Logger, opts SyncOptions) (*SyncResult, error)
 		return syncContinueRebase(git, log, opts, result)
 	}
 
+	if opts.Continue {
+		return result, fmt.Errorf("no merge or rebase in progress in '%s' — nothing to continue", dirname)
+	}
+
 	// Determine merge source based on remote availability
 	mergeSource, _ := resolveMergeSource(git, log, opts.RepoPath, opts.BaseBranch, opts.DryRun)
 
@@ -55,7 +61,11 @@ func Sync(git gitops.Client, log Logger, opts SyncOptions) (*SyncResult, error)
 	log.Info("Status of '%s' vs '%s': %s", opts.Branch, opts.BaseBranch, FormatSyncStatus(ahead, behind))
 
 	if behind == 0 {
-		log.Success("'%s' is already in sync with '%s'", opts.Branch, opts.BaseBranch)
+		if ahead > 0 {
+			log.Success("'%s' is up to date with '%s' and %d commit(s) ahead (not yet merged)", opts.Branch, opts.BaseBranch, ahead)
+		} else {
+			log.Success("'%s' is already in sync with '%s'", opts.Branch, opts.BaseBranch)
+		}
 		result.AlreadySynced = true
 		result.Success = true
 		return result, nil
@@ -68,7 +78,11 @@ func Sync(git gitops.Client, log Logger, opts SyncOptions) (*SyncResult, error)
 			log.Info("Would rebase '%s' onto '%s'", opts.Branch, effectiveSource)
 			result.Success = true
 		} else {
-			if err := git.Rebase(opts.WtPath, effectiveSource); err != nil {
+			result.PreSyncHEAD = headBeforeSync(git, log, opts.WtPath)
+			result.Attempted = true
+			if err := runStep(log, "Rebasing", func() error {
+				return git.Rebase(opts.WtPath, effectiveSource, gitops.RebaseRunOptions{StrategyOptions: opts.StrategyOptions, RebaseMerges: opts.RebaseMerges})
+			}); err != nil {
 				log.Warning("Rebase failed — resolve conflicts, then run sync again (or 'git -C %s rebase --abort' to cancel)", opts.WtPath)
 				result.Conflict = true
 				return result, fmt.Errorf("rebase conflict: %w", err)
@@ -76,14 +90,41 @@ func Sync(git gitops.Client, log Logger, opts SyncOptions) (*SyncResult, error)
 			log.Success("Rebased '%s' onto '%s'", opts.Branch, opts.BaseBranch)
 			result.Success = true
 		}
+	} else if opts.FFOnly {
+		if !canFastForward(git, log, opts.WtPath, effectiveSource, ahead) {
+			return result, fmt.Errorf("cannot fast-forward '%s': it has %d commit(s) not on '%s' — use --rebase or a plain sync instead", opts.Branch, ahead, opts.BaseBranch)
+		}
+		log.Info("Fast-forwarding '%s' to '%s' (%d commit(s) behind)", opts.Branch, opts.BaseBranch, behind)
+
+		if opts.DryRun {
+			log.Info("Would fast-forward '%s' to '%s'", opts.Branch, effectiveSource)
+			result.Success = true
+		} else {
+			result.PreSyncHEAD = headBeforeSync(git, log, opts.WtPath)
+			result.Attempted = true
+			if err := runStep(log, "Fast-forwarding", func() error {
+				return git.Merge(opts.WtPath, effectiveSource, gitops.MergeRunOptions{FF: gitops.FFOnly})
+			}); err != nil {
+				return result, fmt.Errorf("cannot fast-forward '%s' — use --rebase or a plain sync instead: %w", opts.Branch, err)
+			}
+			log.Success("Fast-forwarded '%s' to '%s'", opts.Branch, opts.BaseBranch)
+			result.Success = true
+		}
 	} else {
+		if ahead > 0 {
+			log.Info("'%s' has diverged from '%s' (%d ahead, %d behind) — merging will create a merge commit", opts.Branch, opts.BaseBranch, ahead, behind)
+		}
 		log.Info("Merging %d commit(s) from '%s' into '%s'", behind, opts.BaseBranch, opts.Branch)
 
 		if opts.DryRun {
 			log.Info("Would merge '%s' into '%s'", effectiveSource, opts.Branch)
 			result.Success = true
 		} else {
-			if err := git.Merge(opts.WtPath, effectiveSource); err != nil {
+			result.PreSyncHEAD = headBeforeSync(git, log, opts.WtPath)
+			result.Attempted = true
+			if err := runStep(log, "Merging", func() error {
+				return git.Merge(opts.WtPath, effectiveSource, gitops.MergeRunOptions{StrategyOptions: opts.StrategyOptions})
+			}); err != nil {
 				log.Warning("Merge failed — resolve conflicts, then run sync again")
 				result.Conflict = true
 				return result, fmt.Errorf("merge conflict: %w", err)
@@ -96,6 +137,53 @@ func Sync(git gitops.Client, log Logger, opts SyncOptions) (*SyncResult, error)
 	return result, nil
 }
 
+// headBeforeSync returns the worktree's HEAD so a sync can be undone later, or
+// "" if it can't be read (the sync still goes ahead, just without an undo point).
+func headBeforeSync(git gitops.Client, log Logger, wtPath string) string {
+	sha, err := git.HeadSHA(wtPath)
+	if err != nil {
+		log.Verbose("Could not record HEAD of '%s' before syncing: %v", filepath.Base(wtPath), err)
+		return ""
+	}
+	return sha
+}
+
+// canFastForward reports whether the worktree's HEAD is an ancestor of source,
+// so merging source moves HEAD without creating a merge commit. If ancestry
+// can't be determined it falls back to the ahead count.
+func canFastForward(git gitops.Client, log Logger, wtPath, source string, ahead int) bool {
+	ok, err := git.IsAncestor(wtPath, "HEAD", source)
+	if err != nil {
+		log.Verbose("Could not check ancestry: %v", err)
+		return ahead == 0
+	}
+	return ok
+}
+
+// quickAheadBehind counts the worktree against mergeSource with a single
+// AheadBehind call. Only when that finds it up to date with a remote
+// mergeSource is the local base branch, which may be ahead of the remote,
+// checked too. Otherwise resolved is false when mergeSource isn't the base:
+// the worktree is behind either way, and resolveEffectiveMergeSource picks
+// the source later.
+func quickAheadBehind(git gitops.Client, wtPath, baseBranch, mergeSource string) (source string, ahead, behind int, resolved bool, err error) {
+	ahead, behind, err = git.AheadBehind(wtPath, mergeSource)
+	if err != nil {
+		return "", 0, 0, false, err
+	}
+	if mergeSource == baseBranch {
+		return mergeSource, ahead, behind, true, nil
+	}
+	if behind > 0 {
+		return mergeSource, ahead, behind, false, nil
+	}
+	localAhead, localBehind, err := git.AheadBehind(wtPath, baseBranch)
+	if err == nil && localBehind > 0 {
+		return baseBranch, localAhead, localBehind, true, nil
+	}
+	return mergeSource, ahead, behind, true, nil
+}
+
 // syncContinueMerge resumes a merge that was started but had conflicts.
 func syncContinueMerge(git gitops.Client, log Logger, opts SyncOptions, result *SyncResult) (*SyncResult, error) {
 	dirname := filepath.Base(opts.WtPath)
@@ -162,13 +250,26 @@ func SyncAll(git gitops.Client, log Logger, opts SyncOptions) ([]SyncResult, err
 	type wtEntry struct {
 		path   string
 		branch string
+		base   string
+		source string // base to merge from: origin/<base> when a remote exists
+
+		// Precomputed by the --only-behind filter
+		counted bool
+		ahead   int
+		behind  int
 	}
 	var entries []wtEntry
 	for _, wt := range worktrees {
 		if wt.Path == opts.RepoPath {
 			continue
 		}
-		entries = append(entries, wtEntry{path: wt.Path, branch: wt.Branch})
+		base := opts.BaseBranch
+		if opts.BaseFor != nil {
+			if b := opts.BaseFor(wt.Path); b != "" {
+				base = b
+			}
+		}
+		entries = append(entries, wtEntry{path: wt.Path, branch: wt.Branch, base: base})
 	}
 
 	if len(entries) == 0 {
@@ -177,17 +278,61 @@ func SyncAll(git gitops.Client, log Logger, opts SyncOptions) ([]SyncResult, err
 	}
 
 	// Fetch once if remote exists
-	mergeSource, _ := resolveMergeSource(git, log, opts.RepoPath, opts.BaseBranch, opts.DryRun)
+	mergeSource, hasRemote := resolveMergeSource(git, log, opts.RepoPath, opts.BaseBranch, opts.DryRun)
+	for i := range entries {
+		entries[i].source = mergeSource
+		if entries[i].base != opts.BaseBranch {
+			// Worktree recorded its own base; the fetch above covered it too
+			entries[i].source = entries[i].base
+			if hasRemote {
+				entries[i].source = "origin/" + entries[i].base
+			}
+		}
+	}
 
 	var results []SyncResult
-	for _, entry := range entries {
+
+	// Keep only worktrees that are behind, so up-to-date ones cost a single git call
+	if opts.OnlyBehind {
+		var behindEntries []wtEntry
+		var upToDate []string
+		for _, entry := range entries {
+			source, ahead, behind, resolved, err := quickAheadBehind(git, entry.path, entry.base, entry.source)
+			if err != nil {
+				log.Verbose("Could not check ahead/behind of '%s': %v", filepath.Base(entry.path), err)
+				behindEntries = append(behindEntries, entry)
+				continue
+			}
+			if behind == 0 {
+				upToDate = append(upToDate, filepath.Base(entry.path))
+				results = append(results, SyncResult{Branch: entry.branch, Ahead: ahead, AlreadySynced: true, Success: true})
+				continue
+			}
+			if resolved {
+				entry.counted = true
+				entry.source, entry.ahead, entry.behind = source, ahead, behind
+			}
+			behindEntries = append(behindEntries, entry)
+		}
+		if len(upToDate) > 0 {
+			log.Info("Skipping %d up-to-date worktree(s): %s", len(upToDate), strings.Join(upToDate, ", "))
+		}
+		entries = behindEntries
+	}
+
+	var stopped *SyncStoppedError
+	for i, entry := range entries {
 		dirname := filepath.Base(entry.path)
 
 		// Skip if dirty
 		dirty, err := git.IsWorktreeDirty(entry.path)
 		if err != nil {
 			log.Warning("Could not check status of '%s': %v (skipping)", dirname, err)
-			results = append(results, SyncResult{Branch: entry.branch, Skipped: true, SkipReason: "status check failed"})
+			results = append(results, SyncResult{Branch: entry.branch, Skipped: true, SkipReason: "status check failed", Err: err})
+			if opts.FailFast {
+				stopped = &SyncStoppedError{Branch: entry.branch, Reason: "status check failed", Remaining: len(entries) - i - 1}
+				break
+			}
 			continue
 		}
 		if dirty && !opts.Force {
@@ -218,41 +363,88 @@ func SyncAll(git gitops.Client, log Logger, opts SyncOptions) ([]SyncResult, err
 		}
 
 		// Resolve effective merge source
-		effectiveSource, ahead, behind := resolveEffectiveMergeSource(git, log, entry.path, opts.BaseBranch, mergeSource)
+		effectiveSource, ahead, behind := entry.source, entry.ahead, entry.behind
+		if !entry.counted {
+			effectiveSource, ahead, behind = resolveEffectiveMergeSource(git, log, entry.path, entry.base, entry.source)
+		}
 
 		if behind == 0 {
-			log.Info("'%s' is already in sync (%s)", entry.branch, FormatSyncStatus(ahead, behind))
+			if ahead > 0 {
+				log.Info("'%s' is up to date and %d commit(s) ahead (not yet merged)", entry.branch, ahead)
+			} else {
+				log.Info("'%s' is already in sync (%s)", entry.branch, FormatSyncStatus(ahead, behind))
+			}
 			results = append(results, SyncResult{Branch: entry.branch, Ahead: ahead, Behind: behind, AlreadySynced: true, Success: true})
 			continue
 		}
 
-		r := SyncResult{Branch: entry.branch, Ahead: ahead, Behind: behind, Strategy: opts.Strategy}
+		r := SyncResult{Branch: entry.branch, WtPath: entry.path, Ahead: ahead, Behind: behind, Strategy: opts.Strategy}
+		if !opts.DryRun {
+			r.PreSyncHEAD = headBeforeSync(git, log, entry.path)
+			r.Attempted = true
+		}
 
 		if opts.Strategy == "rebase" {
-			log.Info("'%s' %s — rebasing onto %s", entry.branch, FormatSyncStatus(ahead, behind), opts.BaseBranch)
+			log.Info("'%s' %s — rebasing onto %s", entry.branch, FormatSyncStatus(ahead, behind), entry.base)
 
 			if opts.DryRun {
 				log.Info("Would rebase '%s' onto '%s'", entry.branch, effectiveSource)
 				r.Success = true
 			} else {
-				if err := git.Rebase(entry.path, effectiveSource); err != nil {
-					log.Warning("Conflict rebasing '%s' — resolve and run sync", dirname)
-					r.Conflict = true
+				if err := runStep(log, "Rebasing", func() error {
+					return git.Rebase(entry.path, effectiveSource, gitops.RebaseRunOptions{StrategyOptions: opts.StrategyOptions, RebaseMerges: opts.RebaseMerges})
+				}); err != nil {
+					r.Conflict, r.Err = true, err
+					if opts.AbortOnConflict {
+						r.Aborted = abortSyncConflict(log, dirname, "rebase", func() error { return git.RebaseAbort(entry.path) })
+					} else {
+						log.Warning("Conflict rebasing '%s' — resolve and run sync", dirname)
+					}
 				} else {
 					log.Success("Rebased '%s'", entry.branch)
 					r.Success = true
 				}
 			}
+		} else if opts.FFOnly {
+			if !canFastForward(git, log, entry.path, effectiveSource, ahead) {
+				log.Warning("Skipping '%s' — cannot fast-forward (%s)", dirname, FormatSyncStatus(ahead, behind))
+				results = append(results, SyncResult{Branch: entry.branch, Ahead: ahead, Behind: behind, Skipped: true, SkipReason: "not fast-forwardable"})
+				continue
+			}
+			log.Info("'%s' %s — fast-forwarding", entry.branch, FormatSyncStatus(ahead, behind))
+
+			if opts.DryRun {
+				log.Info("Would fast-forward '%s' to '%s'", entry.branch, effectiveSource)
+				r.Success = true
+			} else {
+				if err := runStep(log, "Fast-forwarding", func() error { return git.Merge(entry.path, effectiveSource, gitops.MergeRunOptions{FF: gitops.FFOnly}) }); err != nil {
+					log.Warning("Could not fast-forward '%s': %v", dirname, err)
+					r.Failed, r.FailReason, r.Err = true, "fast-forward failed", err
+				} else {
+					log.Success("Fast-forwarded '%s'", entry.branch)
+					r.Success = true
+				}
+			}
 		} else {
-			log.Info("'%s' %s — merging %d commit(s)", entry.branch, FormatSyncStatus(ahead, behind), behind)
+			if ahead > 0 {
+				log.Info("'%s' %s — diverged, merging %d commit(s) creates a merge commit", entry.branch, FormatSyncStatus(ahead, behind), behind)
+			} else {
+				log.Info("'%s' %s — merging %d commit(s)", entry.branch, FormatSyncStatus(ahead, behind), behind)
+			}
 
 			if opts.DryRun {
 				log.Info("Would merge '%s' into '%s'", effectiveSource, entry.branch)
 				r.Success = true
 			} else {
-				if err := git.Merge(entry.path, effectiveSource); err != nil {
-					log.Warning("Conflict syncing '%s' — resolve and run sync", dirname)
-					r.Conflict = true
+				if err := runStep(log, "Merging", func() error {
+					return git.Merge(entry.path, effectiveSource, gitops.MergeRunOptions{StrategyOptions: opts.StrategyOptions})
+				}); err != nil {
+					r.Conflict, r.Err = true, err
+					if opts.AbortOnConflict {
+						r.Aborted = abortSyncConflict(log, dirname, "merge", func() error { return git.MergeAbort(entry.path) })
+					} else {
+						log.Warning("Conflict syncing '%s' — resolve and run sync", dirname)
+					}
 				} else {
 					log.Success("Synced '%s'", entry.branch)
 					r.Success = true
@@ -260,10 +452,20 @@ func SyncAll(git gitops.Client, log Logger, opts SyncOptions) ([]SyncResult, err
 			}
 		}
 		results = append(results, r)
+
+		if opts.FailFast && !r.Success {
+			reason := "fast-forward failed"
+			if r.Conflict {
+				reason = opts.Strategy + " conflict"
+			}
+			stopped = &SyncStoppedError{Branch: entry.branch, Reason: reason, Remaining: len(entries) - i - 1}
+			break
+		}
 	}
 
 	// Summary
-	var synced, skipped, upToDate, conflicts int
+	var synced, skipped, upToDate, conflicts, failed int
+	var aborted []string
 	for _, r := range results {
 		switch {
 		case r.Skipped:
@@ -272,11 +474,35 @@ func SyncAll(git gitops.Client, log Logger, opts SyncOptions) ([]SyncResult, err
 			upToDate++
 		case r.Conflict:
 			conflicts++
+			if r.Aborted {
+				aborted = append(aborted, r.Branch)
+			}
 		case r.Success:
 			synced++
+		case r.Failed:
+			failed++
 		}
 	}
-	log.Info("Sync complete: %d synced, %d up-to-date, %d skipped, %d conflicts", synced, upToDate, skipped, conflicts)
+	log.Info("Sync complete: %d synced, %d up-to-date, %d skipped, %d conflicts, %d failed", synced, upToDate, skipped, conflicts, failed)
+	if len(aborted) > 0 {
+		log.Warning("Skipped for conflicts (aborted, left unchanged): %s", strings.Join(aborted, ", "))
+	}
+	if stopped != nil {
+		log.Warning("Stopped at '%s' (--fail-fast) — %d worktree(s) left untouched", stopped.Branch, stopped.Remaining)
+		return results, stopped
+	}
 
 	return results, nil
 }
+
+// abortSyncConflict aborts a conflicting merge or rebase in a SyncAll
+// worktree so it's left as it was before the sync, reporting whether the
+// abort succeeded. A failed abort leaves the conflict for the user to resolve.
+func abortSyncConflict(log Logger, dirname, op string, abort func() error) bool {
+	if err := abort(); err != nil {
+		log.Warning("Conflict syncing '%s' and the %s could not be aborted: %v — resolve and run sync", dirname, op, err)
+		return false
+	}
+	log.Warning("Conflict syncing '%s' — aborted the %s, worktree left unchanged", dirname, op)
+	return true
+}
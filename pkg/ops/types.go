@@ -16,6 +16,22 @@ type Logger interface {
 	Verbose(format string, args ...interface{})
 }
 
+// StepLogger is an optional Logger extension for long-running steps such as
+// fetch, merge, and rebase. Begin is called as the step starts and the returned
+// func when it ends, letting the CLI show progress without ops knowing how.
+type StepLogger interface {
+	Begin(step string) (end func())
+}
+
+// runStep runs fn as a named step, reporting it to log if it implements StepLogger.
+func runStep(log Logger, step string, fn func() error) error {
+	if s, ok := log.(StepLogger); ok {
+		end := s.Begin(step)
+		defer end()
+	}
+	return fn()
+}
+
 // SafetyCheckFunc checks whether a worktree is safe to delete.
 // Returns true if safe (or user confirmed), false to skip.
 type SafetyCheckFunc func(wtPath string) (safe bool, err error)
@@ -33,74 +49,146 @@ type StateChecker func(path string) (managed bool, err error)
 // StateAdopter adopts an unmanaged worktree into state.
 type StateAdopter func(path, repo, branch string) error
 
-// StatePruner prunes stale state entries, returning the count pruned.
-type StatePruner func() (int, error)
+// StatePruner prunes stale state entries, returning the paths pruned. Entries
+// whose path satisfies skip must be left alone; with dryRun nothing is removed
+// and the paths that would be pruned are returned.
+type StatePruner func(skip func(path string) bool, dryRun bool) ([]string, error)
 
-// TrustPruner prunes stale trust entries under a directory, returning the count pruned.
+// TrustPruner prunes stale trust entries under a directory, returning the
+// project paths pruned (or, with dryRun, that would be).
 // May be nil if trust management is not configured.
-type TrustPruner func(dir string) (int, error)
+type TrustPruner func(dir string, dryRun bool) ([]string, error)
 
 // SyncOptions configures a single worktree sync operation.
 type SyncOptions struct {
-	RepoPath   string // root of the main repository
-	BaseBranch string // base branch to sync from (e.g., "main")
-	Branch     string // resolved branch name of the worktree
-	WtPath     string // resolved worktree filesystem path
-	Strategy   string // "merge" or "rebase"
-	Force      bool   // skip dirty worktree safety check
-	DryRun     bool
+	RepoPath        string                     // root of the main repository
+	BaseBranch      string                     // base branch to sync from (e.g., "main")
+	Branch          string                     // resolved branch name of the worktree
+	WtPath          string                     // resolved worktree filesystem path
+	Strategy        string                     // "merge" or "rebase"
+	FFOnly          bool                       // merge strategy only: fast-forward or fail, never create a merge commit
+	Continue        bool                       // only continue an in-progress merge/rebase; error if none
+	OnlyBehind      bool                       // SyncAll only: skip up-to-date worktrees before any other per-worktree checks
+	AbortOnConflict bool                       // SyncAll only: abort a conflicting merge/rebase so the worktree is left clean
+	FailFast        bool                       // SyncAll only: stop at the first worktree that fails to sync
+	BaseFor         func(wtPath string) string // SyncAll only: per-worktree base override; "" falls back to BaseBranch
+	StrategyOptions []string                   // passed to git merge/rebase as -X <opt>
+	RebaseMerges    bool                       // rebase strategy only: keep merge commits on the branch (git rebase --rebase-merges)
+	Force           bool                       // skip dirty worktree safety check
+	DryRun          bool
+}
+
+// SyncStoppedError is returned by SyncAll with FailFast when a worktree fails
+// to sync. The worktrees after it are left untouched.
+type SyncStoppedError struct {
+	Branch    string // branch of the worktree that failed
+	Reason    string // why it failed, e.g. "merge conflict"
+	Remaining int    // worktrees not attempted
+}
+
+func (e *SyncStoppedError) Error() string {
+	return fmt.Sprintf("sync stopped at '%s' (%s); %d worktree(s) not synced", e.Branch, e.Reason, e.Remaining)
 }
 
 // SyncResult describes the outcome of a single sync operation.
 type SyncResult struct {
 	Branch        string
+	WtPath        string
 	Ahead         int
 	Behind        int
 	AlreadySynced bool
 	Strategy      string
 	Conflict      bool
+	Aborted       bool // the conflicting merge/rebase was aborted (SyncOptions.AbortOnConflict)
 	Skipped       bool
 	SkipReason    string
+	Failed        bool // the sync step failed without a conflict, e.g. a refused fast-forward
+	FailReason    string
+	Err           error // the git error behind Conflict, Failed, or a failed status check
 	Success       bool
+	Attempted     bool   // a merge/rebase/fast-forward was started (not a dry run or --continue)
+	PreSyncHEAD   string // HEAD before that attempt; empty if none ran or HEAD couldn't be read
 }
 
 // MergeOptions configures a merge operation.
 type MergeOptions struct {
-	RepoPath   string // root of the main repository
-	BaseBranch string // target branch (e.g., "main")
-	Branch     string // resolved feature branch name
-	WtPath     string // resolved worktree filesystem path
-	Strategy   string // "merge" or "rebase"
-	Force      bool   // skip safety checks
-	DryRun     bool
-	CreatePR   bool   // create PR instead of local merge
-	NoCleanup  bool   // keep worktree after merge
-	PRTitle    string // PR title (--pr only)
-	PRBody     string // PR body (--pr only)
-	PRDraft    bool   // create draft PR
+	RepoPath        string   // root of the main repository
+	BaseBranch      string   // target branch (e.g., "main")
+	Branch          string   // resolved feature branch name
+	WtPath          string   // resolved worktree filesystem path
+	Strategy        string   // "merge", "rebase", or "squash"
+	NoFF            bool     // "merge" strategy only: create a merge commit even when a fast-forward is possible
+	AmendBase       bool     // cherry-pick a branch that is exactly one commit ahead onto base instead of merging it
+	NoPull          bool     // don't pull base before a local merge; merge (or rebase) against the local base
+	StrategyOptions []string // passed to the merge/rebase (not fast-forwards) as -X <opt>
+	Sign            bool     // GPG-sign the commits the merge creates (merge commit, squash commit, or rebased commits)
+	SignKey         string   // key to sign with; empty uses git's user.signingkey
+	Continue        bool     // only continue an in-progress merge/rebase; error if none
+	Force           bool     // skip safety checks
+	DryRun          bool
+	CreatePR        bool   // create PR instead of local merge
+	Isolated        bool   // merge in a temporary worktree checked out on base, never touching the main repo's checkout
+	NoCleanup       bool   // keep worktree, branch, and window after merge
+	PRTitle         string // PR title (--pr only)
+	PRBody          string // PR body (--pr only)
+	PRBodyFile      string // file gh reads the PR body from (--pr only); ignored when PRBody is set
+	PRDraft         bool   // create draft PR
+
+	// ConfirmResetBase is asked, when pushing base fails after a local merge,
+	// whether to reset base to its pre-merge commit; nil never resets.
+	ConfirmResetBase func(baseBranch string) bool
 }
 
 // MergeResult describes the outcome of a merge operation.
 type MergeResult struct {
-	Branch    string
-	Success   bool
-	PRCreated bool
-	PRURL     string
+	Branch     string
+	Success    bool
+	PRCreated  bool
+	PRURL      string
+	PushFailed bool // the local merge succeeded but pushing base failed; the worktree was kept
+	BaseReset  bool // after the failed push, base was reset to its pre-merge commit
+
+	PreRebaseHEAD string // worktree HEAD before a rebase merge rebased it; empty if none ran
 }
 
 // DeleteOptions configures a single worktree delete operation.
 type DeleteOptions struct {
-	RepoPath     string // root of the main repository
-	WtPath       string // resolved worktree filesystem path
-	Branch       string // resolved branch name
-	Force        bool   // force removal, skip safety checks
-	DeleteBranch bool   // also delete the git branch
+	RepoPath     string   // root of the main repository
+	WtPath       string   // resolved worktree filesystem path
+	Branch       string   // resolved branch name
+	Force        bool     // force removal, skip safety checks
+	DeleteBranch bool     // also delete the git branch; false keeps it
+	BaseBranch   string   // base for unpushed/unmerged checks (PlanDeleteAll)
+	Except       []string // DeleteAll/PlanDeleteAll: worktree paths to keep
 	DryRun       bool
+
+	BaseFor func(wtPath string) string // PlanDeleteAll only: per-worktree base override; "" falls back to BaseBranch
+}
+
+// Actions DeleteAll would take for a worktree, as reported by PlanDeleteAll.
+const (
+	DeleteActionDelete  = "delete"  // removed without asking
+	DeleteActionConfirm = "confirm" // has work that could be lost; prompts first
+	DeleteActionSkip    = "skip"    // directory already gone
+	DeleteActionKeep    = "keep"    // excluded with DeleteOptions.Except
+)
+
+// DeletePlan is the safety status of one worktree and what DeleteAll would do with it.
+type DeletePlan struct {
+	WtPath   string
+	Branch   string
+	Missing  bool // worktree directory no longer exists
+	Dirty    bool // uncommitted changes
+	Unpushed bool // commits not pushed (or not on base without an upstream)
+	Unmerged int  // commits not on base; only checked with DeleteBranch
+	Action   string
 }
 
 // PruneOptions configures a prune operation.
 type PruneOptions struct {
-	RepoPath string // root of the main repository
+	RepoPath string      // root of the main repository
+	Ignore   *IgnoreList // paths from .wtignore to leave untouched
+	NoGit    bool        // skip git worktree prune (e.g. 'prune --state-only')
 	DryRun   bool
 }
 
@@ -109,12 +197,29 @@ type PruneResult struct {
 	StatePruned int
 	TrustPruned int
 	GitPruned   bool
+	StatePaths  []string // worktree paths whose stale state entries were (or would be) pruned
+	TrustPaths  []string // project paths whose stale trust entries were (or would be) pruned
+}
+
+// RepairOptions configures a repair operation.
+type RepairOptions struct {
+	RepoPath string // root of the main repository
+	DryRun   bool
+}
+
+// RepairResult describes the outcome of a repair operation.
+type RepairResult struct {
+	Broken      []string // worktree paths whose gitdir link was broken
+	Repaired    int
+	StillBroken []string                 // worktree paths git worktree repair could not fix
+	Duplicates  []gitops.BranchDuplicate // branches checked out in more than one worktree (reported, not fixed)
 }
 
 // DiscoverOptions configures a discover operation.
 type DiscoverOptions struct {
-	RepoPath string // root of the main repository
-	Adopt    bool   // create state entries for discovered worktrees
+	RepoPath string      // root of the main repository
+	Adopt    bool        // create state entries for discovered worktrees
+	Ignore   *IgnoreList // paths from .wtignore to skip
 	DryRun   bool
 }
 
@@ -122,6 +227,7 @@ type DiscoverOptions struct {
 type DiscoverResult struct {
 	RepoName  string
 	Unmanaged []UnmanagedWorktree
+	Ignored   int // worktrees skipped by .wtignore
 	Adopted   int
 }
 
@@ -173,15 +279,35 @@ func resolveMergeSource(git gitops.Client, log Logger, repoPath, baseBranch stri
 			log.Info("Would fetch from remote")
 		} else {
 			log.Info("Fetching latest changes")
-			if err := git.Fetch(repoPath); err != nil {
+			if err := runStep(log, "Fetching", func() error { return git.Fetch(repoPath) }); err != nil {
 				log.Warning("Fetch failed: %v (continuing with local state)", err)
 			}
 		}
 		mergeSource = "origin/" + baseBranch
+		warnStaleBase(git, log, repoPath, baseBranch)
 	}
 	return mergeSource, hasRemote
 }
 
+// warnStaleBase warns when the local base branch is behind origin/<base>, so
+// whatever is later merged into it (or synced from it) isn't built on stale
+// commits. It only checks when the main repo has the base checked out, since
+// the count is taken from its HEAD.
+func warnStaleBase(git gitops.Client, log Logger, repoPath, baseBranch string) {
+	current, err := git.CurrentBranch(repoPath)
+	if err != nil || current != baseBranch {
+		return
+	}
+	behind, err := git.CommitsBehind(repoPath, "origin/"+baseBranch)
+	if err != nil {
+		log.Verbose("Could not check '%s' against 'origin/%s': %v", baseBranch, baseBranch, err)
+		return
+	}
+	if behind > 0 {
+		log.Warning("Base '%s' is %d commit(s) behind origin/%s — run 'git -C %s pull' first", baseBranch, behind, baseBranch, repoPath)
+	}
+}
+
 // resolveEffectiveMergeSource checks both remote and local base branch and picks
 // whichever has more commits behind — catching unpushed commits on base.
 func resolveEffectiveMergeSource(git gitops.Client, log Logger, wtPath, baseBranch, mergeSource string) (effectiveSource string, ahead, behind int) {
@@ -208,3 +334,11 @@ func resolveEffectiveMergeSource(git gitops.Client, log Logger, wtPath, baseBran
 	}
 	return effectiveSource, ahead, behind
 }
+
+// ShortSHA abbreviates a commit hash for messages.
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
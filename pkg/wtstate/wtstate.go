@@ -2,8 +2,12 @@ package wtstate
 
 import (
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -42,15 +46,29 @@ func (ft *FlexTime) UnmarshalJSON(data []byte) error {
 // WorktreeState holds the persisted state for a single worktree.
 type WorktreeState struct {
 	Repo            string   `json:"repo"`
+	RepoPath        string   `json:"repo_path,omitempty"` // main repository root
 	Branch          string   `json:"branch"`
 	ClaudeSessionID string   `json:"claude_session_id"`
 	ShellSessionID  string   `json:"shell_session_id"`
 	CreatedAt       FlexTime `json:"created_at"`
+	LastAccessedAt  FlexTime `json:"last_accessed_at,omitzero"` // last time wt opened or focused the worktree's window
+	Port            int      `json:"port,omitempty"`
+	Title           string   `json:"title,omitempty"`         // custom iTerm2 window title (wt open --name)
+	FromTemplate    string   `json:"from_template,omitempty"` // template branch the worktree's branch was created from
+	BaseBranch      string   `json:"base_branch,omitempty"`   // base sync and merge default to (wt create --base)
+	PreSyncHEAD     string   `json:"pre_sync_head,omitempty"` // HEAD before the last sync changed the branch (wt undo-sync)
+	NoClaude        bool     `json:"no_claude,omitempty"`     // windows open without claude (wt create --no-claude) until 'wt open --claude'
+
+	GitConfig map[string]string `json:"git_config,omitempty"` // per-worktree git config applied on create
 }
 
 // State is the top-level state file structure.
 type State struct {
 	Worktrees map[string]*WorktreeState `json:"worktrees"`
+
+	// LastListed maps a repo root to the worktree paths its last 'wt list'
+	// showed, in row order, so 'wt switch <n>' can pick the nth row.
+	LastListed map[string][]string `json:"last_listed,omitempty"`
 }
 
 // Manager handles reading and writing state to disk.
@@ -136,26 +154,194 @@ func (m *Manager) GetWorktree(path string) (*WorktreeState, error) {
 	return s.Worktrees[path], nil
 }
 
+// RepoPaths returns the distinct main repository paths recorded in state,
+// sorted. Entries saved before repo_path was recorded are attributed by the
+// standard <repo>.worktrees/<dirname> layout; any others are left out.
+func (m *Manager) RepoPaths() ([]string, error) {
+	s, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var repos []string
+	for path, ws := range s.Worktrees {
+		repo := ws.RepoPath
+		if repo == "" {
+			dir := filepath.Dir(path)
+			name, ok := strings.CutSuffix(filepath.Base(dir), ".worktrees")
+			if !ok || name == "" {
+				continue
+			}
+			repo = filepath.Join(filepath.Dir(dir), name)
+		}
+		if !seen[repo] {
+			seen[repo] = true
+			repos = append(repos, repo)
+		}
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
 // Prune removes entries for worktree paths that no longer exist on disk.
 // Returns the number of entries pruned.
 func (m *Manager) Prune() (int, error) {
+	pruned, err := m.PruneExcept(nil, false)
+	return len(pruned), err
+}
+
+// PruneExcept is Prune, but leaves entries whose path satisfies skip, and
+// returns the pruned paths in sorted order. A nil skip prunes every stale
+// entry. With dryRun the stale paths are returned but nothing is removed.
+func (m *Manager) PruneExcept(skip func(path string) bool, dryRun bool) ([]string, error) {
 	s, err := m.Load()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	pruned := 0
+	var pruned []string
 	for path := range s.Worktrees {
+		if skip != nil && skip(path) {
+			continue
+		}
 		if _, err := os.Stat(path); os.IsNotExist(err) {
 			delete(s.Worktrees, path)
-			pruned++
+			pruned = append(pruned, path)
 		}
 	}
+	sort.Strings(pruned)
 
-	if pruned > 0 {
+	if len(pruned) > 0 && !dryRun {
 		if err := m.Save(s); err != nil {
 			return pruned, err
 		}
 	}
 	return pruned, nil
 }
+
+// PortRange is an inclusive range of ports to assign worktrees from.
+// The zero value disables port assignment.
+type PortRange struct {
+	Start int
+	End   int
+}
+
+// Enabled reports whether the range can hand out ports.
+func (r PortRange) Enabled() bool {
+	return r.Start > 0 && r.End >= r.Start
+}
+
+// Contains reports whether port falls inside the range.
+func (r PortRange) Contains(port int) bool {
+	return port >= r.Start && port <= r.End
+}
+
+// PortFor returns a deterministic port for path within r, probing upward
+// (and wrapping) past any port in taken. Returns 0 if the range is exhausted.
+func PortFor(path string, r PortRange, taken map[int]bool) int {
+	if !r.Enabled() {
+		return 0
+	}
+	size := r.End - r.Start + 1
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(path))
+	offset := int(h.Sum32() % uint32(size))
+
+	for i := 0; i < size; i++ {
+		port := r.Start + (offset+i)%size
+		if !taken[port] {
+			return port
+		}
+	}
+	return 0
+}
+
+// AssignPort returns the port recorded for path, assigning and saving a new
+// one from r if none is recorded yet. Ports held by other worktrees are skipped,
+// so every managed worktree keeps a unique, stable port. Untracked paths get
+// the port they would be assigned, without adding an entry for them.
+func (m *Manager) AssignPort(path string, r PortRange) (int, error) {
+	s, err := m.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	ws := s.Worktrees[path]
+	if ws != nil && ws.Port != 0 && r.Contains(ws.Port) {
+		return ws.Port, nil
+	}
+
+	taken := make(map[int]bool)
+	for p, other := range s.Worktrees {
+		if p != path && other.Port != 0 {
+			taken[other.Port] = true
+		}
+	}
+
+	port := PortFor(path, r, taken)
+	if port == 0 {
+		return 0, fmt.Errorf("no free ports in range %d-%d", r.Start, r.End)
+	}
+
+	if ws == nil {
+		return port, nil
+	}
+	ws.Port = port
+	return port, m.Save(s)
+}
+
+// Touch records t as the worktree's last access. Untracked paths are ignored.
+func (m *Manager) Touch(path string, t time.Time) error {
+	s, err := m.Load()
+	if err != nil {
+		return err
+	}
+
+	ws := s.Worktrees[path]
+	if ws == nil {
+		return nil
+	}
+	ws.LastAccessedAt = FlexTime{Time: t.UTC()}
+	return m.Save(s)
+}
+
+// SetLastListed records the worktree paths 'wt list' showed for repoPath, in
+// the order they were listed.
+func (m *Manager) SetLastListed(repoPath string, paths []string) error {
+	s, err := m.Load()
+	if err != nil {
+		return err
+	}
+	if s.LastListed == nil {
+		s.LastListed = make(map[string][]string)
+	}
+	s.LastListed[repoPath] = paths
+	return m.Save(s)
+}
+
+// LastListed returns the worktree paths last listed for repoPath, or nil if
+// it hasn't been listed.
+func (m *Manager) LastListed(repoPath string) ([]string, error) {
+	s, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+	return s.LastListed[repoPath], nil
+}
+
+// SetPreSyncHEAD records the HEAD a worktree had before its last sync so
+// 'wt undo-sync' can return to it; an empty sha clears it.
+func (m *Manager) SetPreSyncHEAD(path, sha string) error {
+	s, err := m.Load()
+	if err != nil {
+		return err
+	}
+
+	ws := s.Worktrees[path]
+	if ws == nil {
+		return nil
+	}
+	ws.PreSyncHEAD = sha
+	return m.Save(s)
+}
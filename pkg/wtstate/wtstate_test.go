@@ -92,6 +92,52 @@ func TestStatePrune(t *testing.T) {
 	assert.NotNil(t, s.Worktrees[realDir])
 }
 
+func TestStatePruneExcept(t *testing.T) {
+	dir := t.TempDir()
+	mgr := NewManager(filepath.Join(dir, "state.json"))
+
+	require.NoError(t, mgr.SetWorktree("/tmp/nonexistent-keep", &WorktreeState{Branch: "keep"}))
+	require.NoError(t, mgr.SetWorktree("/tmp/nonexistent-drop", &WorktreeState{Branch: "drop"}))
+
+	skip := func(path string) bool { return path == "/tmp/nonexistent-keep" }
+
+	// A dry run reports the stale path but keeps it
+	pruned, err := mgr.PruneExcept(skip, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tmp/nonexistent-drop"}, pruned)
+	ws, err := mgr.GetWorktree("/tmp/nonexistent-drop")
+	require.NoError(t, err)
+	assert.NotNil(t, ws)
+
+	pruned, err = mgr.PruneExcept(skip, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tmp/nonexistent-drop"}, pruned)
+
+	ws, err = mgr.GetWorktree("/tmp/nonexistent-keep")
+	require.NoError(t, err)
+	assert.NotNil(t, ws)
+	ws, err = mgr.GetWorktree("/tmp/nonexistent-drop")
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+}
+
+func TestRepoPaths(t *testing.T) {
+	mgr := NewManager(filepath.Join(t.TempDir(), "state.json"))
+
+	require.NoError(t, mgr.SetWorktree("/src/alpha.worktrees/auth", &WorktreeState{RepoPath: "/src/alpha"}))
+	require.NoError(t, mgr.SetWorktree("/src/alpha.worktrees/api", &WorktreeState{RepoPath: "/src/alpha"}))
+	// Recorded repo path wins over the layout
+	require.NoError(t, mgr.SetWorktree("/tmp/scratch/x", &WorktreeState{RepoPath: "/src/gamma"}))
+	// Legacy entries fall back to <repo>.worktrees/<dirname>
+	require.NoError(t, mgr.SetWorktree("/src/beta.worktrees/docs", &WorktreeState{}))
+	// Outside the standard layout with no repo path: unattributable
+	require.NoError(t, mgr.SetWorktree("/src/beta/.claude/worktrees/pebble", &WorktreeState{}))
+
+	repos, err := mgr.RepoPaths()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/src/alpha", "/src/beta", "/src/gamma"}, repos)
+}
+
 func TestLoadEmptyFile(t *testing.T) {
 	dir := t.TempDir()
 	statePath := filepath.Join(dir, "state.json")
@@ -103,3 +149,132 @@ func TestLoadEmptyFile(t *testing.T) {
 	assert.NotNil(t, s.Worktrees)
 	assert.Empty(t, s.Worktrees)
 }
+
+func TestPortFor_Deterministic(t *testing.T) {
+	r := PortRange{Start: 4000, End: 4999}
+
+	a := PortFor("/tmp/repo.worktrees/auth", r, nil)
+	b := PortFor("/tmp/repo.worktrees/auth", r, nil)
+	assert.Equal(t, a, b)
+	assert.True(t, r.Contains(a))
+
+	// Disabled range never assigns
+	assert.Equal(t, 0, PortFor("/tmp/repo.worktrees/auth", PortRange{}, nil))
+}
+
+func TestPortFor_SkipsTaken(t *testing.T) {
+	r := PortRange{Start: 4000, End: 4002}
+	path := "/tmp/repo.worktrees/auth"
+
+	first := PortFor(path, r, nil)
+	second := PortFor(path, r, map[int]bool{first: true})
+	assert.NotEqual(t, first, second)
+	assert.True(t, r.Contains(second))
+
+	// Exhausted range
+	assert.Equal(t, 0, PortFor(path, r, map[int]bool{4000: true, 4001: true, 4002: true}))
+}
+
+func TestAssignPort_StableAndUnique(t *testing.T) {
+	dir := t.TempDir()
+	mgr := NewManager(filepath.Join(dir, "state.json"))
+	r := PortRange{Start: 5000, End: 5001}
+
+	require.NoError(t, mgr.SetWorktree("/tmp/a", &WorktreeState{Repo: "myrepo", Branch: "a"}))
+
+	pa, err := mgr.AssignPort("/tmp/a", r)
+	require.NoError(t, err)
+
+	// Same port on repeat calls, and the existing entry keeps its fields
+	again, err := mgr.AssignPort("/tmp/a", r)
+	require.NoError(t, err)
+	assert.Equal(t, pa, again)
+	ws, err := mgr.GetWorktree("/tmp/a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", ws.Branch)
+	assert.Equal(t, pa, ws.Port)
+
+	// An untracked path is told its port but not added
+	pb, err := mgr.AssignPort("/tmp/b", r)
+	require.NoError(t, err)
+	assert.NotEqual(t, pa, pb)
+	ws, err = mgr.GetWorktree("/tmp/b")
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+
+	// A second worktree gets the other port, even on a hash collision
+	require.NoError(t, mgr.SetWorktree("/tmp/b", &WorktreeState{Repo: "myrepo", Branch: "b"}))
+	again, err = mgr.AssignPort("/tmp/b", r)
+	require.NoError(t, err)
+	assert.Equal(t, pb, again)
+
+	// Range exhausted
+	_, err = mgr.AssignPort("/tmp/c", r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no free ports")
+}
+
+func TestSetPreSyncHEAD(t *testing.T) {
+	dir := t.TempDir()
+	mgr := NewManager(filepath.Join(dir, "state.json"))
+
+	require.NoError(t, mgr.SetWorktree("/tmp/a", &WorktreeState{Repo: "myrepo", Branch: "a"}))
+	require.NoError(t, mgr.SetPreSyncHEAD("/tmp/a", "abc123"))
+
+	ws, err := mgr.GetWorktree("/tmp/a")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", ws.PreSyncHEAD)
+	assert.Equal(t, "a", ws.Branch)
+
+	// Clearing keeps the entry; unknown paths are never added
+	require.NoError(t, mgr.SetPreSyncHEAD("/tmp/a", ""))
+	ws, err = mgr.GetWorktree("/tmp/a")
+	require.NoError(t, err)
+	assert.Empty(t, ws.PreSyncHEAD)
+
+	require.NoError(t, mgr.SetPreSyncHEAD("/tmp/b", "abc123"))
+	ws, err = mgr.GetWorktree("/tmp/b")
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+}
+
+func TestTouch(t *testing.T) {
+	dir := t.TempDir()
+	mgr := NewManager(filepath.Join(dir, "state.json"))
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, mgr.SetWorktree("/tmp/a", &WorktreeState{Repo: "myrepo", Branch: "a"}))
+	require.NoError(t, mgr.Touch("/tmp/a", now))
+
+	ws, err := mgr.GetWorktree("/tmp/a")
+	require.NoError(t, err)
+	assert.True(t, now.Equal(ws.LastAccessedAt.Time))
+
+	// Untracked paths aren't added
+	require.NoError(t, mgr.Touch("/tmp/b", now))
+	ws, err = mgr.GetWorktree("/tmp/b")
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+}
+
+func TestLastListed(t *testing.T) {
+	dir := t.TempDir()
+	mgr := NewManager(filepath.Join(dir, "state.json"))
+
+	paths, err := mgr.LastListed("/tmp/repo")
+	require.NoError(t, err)
+	assert.Nil(t, paths)
+
+	require.NoError(t, mgr.SetWorktree("/tmp/repo.worktrees/b", &WorktreeState{Repo: "repo", Branch: "b"}))
+	require.NoError(t, mgr.SetLastListed("/tmp/repo", []string{"/tmp/repo.worktrees/b", "/tmp/repo.worktrees/a"}))
+	require.NoError(t, mgr.SetLastListed("/tmp/other", []string{"/tmp/other.worktrees/x"}))
+
+	paths, err = mgr.LastListed("/tmp/repo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tmp/repo.worktrees/b", "/tmp/repo.worktrees/a"}, paths)
+
+	// Worktree entries are untouched
+	ws, err := mgr.GetWorktree("/tmp/repo.worktrees/b")
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+}